@@ -72,6 +72,12 @@ func ExecuteActionsByChangeset(ctx context.Context, db application.DB, userID uu
 				Ctx:    ctx,
 				UserID: &userID,
 			}, actionConfig, newContext, contextChanges, &actionChanges)
+		case rules.ActionKeyAreaDefaults:
+			newContext, actionChanges, err = executeAction(rules.ActionAreaDefaults{
+				Db:     db,
+				Ctx:    ctx,
+				UserID: &userID,
+			}, actionConfig, newContext, contextChanges, &actionChanges)
 		/* commented out for now until this rule is added
 		case rules.ActionKeyStateToMetastate:
 			newContext, actionChanges, err = executeAction(rules.ActionStateToMetaState{
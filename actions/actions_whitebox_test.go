@@ -110,6 +110,34 @@ func (s *ActionSuite) TestChangeSet() {
 		require.Equal(t, wiCopy.Fields[workitem.SystemBoardcolumns], changes[1].OldValue)
 	})
 
+	s.T().Run("area changes", func(t *testing.T) {
+		wiCopy := createWICopy(*fxt.WorkItems[0], workitem.SystemStateNew, []interface{}{"bcid0", "bcid1"})
+		wiCopy.Fields[workitem.SystemArea] = "areaid0"
+		fxt.WorkItems[0].Fields[workitem.SystemState] = workitem.SystemStateNew
+		fxt.WorkItems[0].Fields[workitem.SystemBoardcolumns] = []interface{}{"bcid0", "bcid1"}
+		fxt.WorkItems[0].Fields[workitem.SystemArea] = "areaid1"
+		defer delete(fxt.WorkItems[0].Fields, workitem.SystemArea)
+		changes, err := fxt.WorkItems[0].ChangeSet(wiCopy)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, workitem.SystemArea, changes[0].AttributeName)
+		require.Equal(t, "areaid1", changes[0].NewValue)
+		require.Equal(t, "areaid0", changes[0].OldValue)
+	})
+
+	s.T().Run("new instance in area", func(t *testing.T) {
+		fxt.WorkItems[0].Fields[workitem.SystemState] = workitem.SystemStateNew
+		fxt.WorkItems[0].Fields[workitem.SystemBoardcolumns] = []interface{}{}
+		fxt.WorkItems[0].Fields[workitem.SystemArea] = "areaid0"
+		defer delete(fxt.WorkItems[0].Fields, workitem.SystemArea)
+		changes, err := fxt.WorkItems[0].ChangeSet(nil)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		require.Equal(t, workitem.SystemArea, changes[1].AttributeName)
+		require.Equal(t, "areaid0", changes[1].NewValue)
+		require.Nil(t, changes[1].OldValue)
+	})
+
 	s.T().Run("new instance", func(t *testing.T) {
 		fxt.WorkItems[0].Fields[workitem.SystemState] = workitem.SystemStateNew
 		fxt.WorkItems[0].Fields[workitem.SystemBoardcolumns] = []interface{}{}
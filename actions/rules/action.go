@@ -7,6 +7,8 @@ const (
 	ActionKeyNil = "Nil"
 	// ActionKeyFieldSet is the key for the ActionKeyFieldSet action rule.
 	ActionKeyFieldSet = "FieldSet"
+	// ActionKeyAreaDefaults is the key for the ActionKeyAreaDefaults action rule.
+	ActionKeyAreaDefaults = "AreaDefaults"
 	// ActionKeyStateToMetastate is the key for the ActionKeyStateToMetastate action rule.
 	ActionKeyStateToMetastate = "BidirectionalStateToColumn"
 
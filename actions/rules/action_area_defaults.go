@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/fabric8-services/fabric8-wit/actions/change"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+)
+
+// ActionAreaDefaults applies the defaults of an area to a WorkItem that was
+// created in or moved to that area: the owners of the area become the
+// assignees of the WorkItem unless it already has assignees and the default
+// labels of the area are attached to it. It takes no configuration. Note
+// that this only works on WorkItems.
+type ActionAreaDefaults struct {
+	Db     application.DB
+	Ctx    context.Context
+	UserID *uuid.UUID
+}
+
+// make sure the rule is implementing the interface.
+var _ Action = ActionAreaDefaults{}
+
+// OnChange executes the action rule.
+func (act ActionAreaDefaults) OnChange(newContext change.Detector, contextChanges change.Set, configuration string, actionChanges *change.Set) (change.Detector, change.Set, error) {
+	// check if the newContext is a WorkItem, fail otherwise.
+	wiContext, ok := newContext.(workitem.WorkItem)
+	if !ok {
+		return nil, nil, errs.New("given context is not a WorkItem: " + reflect.TypeOf(newContext).String())
+	}
+	// only react on changes of the area.
+	areaChanged := false
+	for _, c := range contextChanges {
+		if c.AttributeName == workitem.SystemArea {
+			areaChanged = true
+			break
+		}
+	}
+	if !areaChanged || wiContext.Fields[workitem.SystemArea] == nil {
+		return newContext, *actionChanges, nil
+	}
+	// load the area.
+	areaID, err := uuid.FromString(fmt.Sprint(wiContext.Fields[workitem.SystemArea]))
+	if err != nil {
+		return nil, nil, errs.Wrap(err, "invalid area of work item")
+	}
+	a, err := act.Db.Areas().Load(act.Ctx, areaID)
+	if err != nil {
+		return nil, nil, errs.Wrap(err, "error loading area: "+areaID.String())
+	}
+	changes := AreaDefaults(wiContext.Fields, *a)
+	if len(changes) == 0 {
+		return newContext, *actionChanges, nil
+	}
+	*actionChanges = append(*actionChanges, changes...)
+	// store the WorkItem.
+	actionResultContext, err := ActionFieldSet{
+		Db:     act.Db,
+		Ctx:    act.Ctx,
+		UserID: act.UserID,
+	}.storeWorkItem(&wiContext)
+	if err != nil {
+		return nil, nil, err
+	}
+	return *actionResultContext, *actionChanges, nil
+}
+
+// AreaDefaults applies the defaults of the given area to the given work item
+// fields: the owners of the area become the assignees unless there are
+// assignees already and the default labels of the area that are missing are
+// attached. It returns the changes that were made to the fields.
+func AreaDefaults(fields map[string]interface{}, a area.Area) change.Set {
+	var changes change.Set
+	assignees := fieldValues(fields[workitem.SystemAssignees])
+	if len(assignees) == 0 && len(a.Owners) > 0 {
+		changes = append(changes, change.Change{
+			AttributeName: workitem.SystemAssignees,
+			NewValue:      a.Owners.Strings(),
+			OldValue:      fields[workitem.SystemAssignees],
+		})
+		fields[workitem.SystemAssignees] = a.Owners.Strings()
+	}
+	labels := fieldValues(fields[workitem.SystemLabels])
+	newLabels := append([]interface{}{}, labels...)
+	for _, id := range a.DefaultLabels {
+		if !containsID(labels, id) {
+			newLabels = append(newLabels, id.String())
+		}
+	}
+	if len(newLabels) != len(labels) {
+		changes = append(changes, change.Change{
+			AttributeName: workitem.SystemLabels,
+			NewValue:      newLabels,
+			OldValue:      fields[workitem.SystemLabels],
+		})
+		fields[workitem.SystemLabels] = newLabels
+	}
+	return changes
+}
+
+// fieldValues returns the values of a list field that is either stored
+// ([]interface{}) or not yet converted ([]string).
+func fieldValues(v interface{}) []interface{} {
+	switch values := v.(type) {
+	case []interface{}:
+		return values
+	case []string:
+		res := make([]interface{}, len(values))
+		for i, s := range values {
+			res[i] = s
+		}
+		return res
+	}
+	return nil
+}
+
+// containsID returns true if the given list of field values contains the
+// given ID.
+func containsID(values []interface{}, id uuid.UUID) bool {
+	for _, v := range values {
+		if fmt.Sprint(v) == id.String() {
+			return true
+		}
+	}
+	return false
+}
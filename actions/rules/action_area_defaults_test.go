@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/fabric8-services/fabric8-wit/actions/change"
+	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+)
+
+func TestSuiteActionAreaDefaults(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &ActionAreaDefaultsSuite{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+type ActionAreaDefaultsSuite struct {
+	gormtestsupport.DBTestSuite
+}
+
+// createFixture creates a work item and an area owned by the second identity
+// with the first label as its default label.
+func (s *ActionAreaDefaultsSuite) createFixture(t *testing.T) *tf.TestFixture {
+	fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.Identities(2), tf.Areas(1), tf.Labels(2), tf.WorkItems(1))
+	a := *fxt.Areas[0]
+	a.Owners = area.IDs{fxt.Identities[1].ID}
+	a.DefaultLabels = area.IDs{fxt.Labels[0].ID}
+	updated, err := area.NewAreaRepository(s.DB).Save(s.Ctx, a)
+	require.NoError(t, err)
+	fxt.Areas[0] = updated
+	return fxt
+}
+
+func (s *ActionAreaDefaultsSuite) TestActionExecution() {
+	s.T().Run("unassigned work item moved to area", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		newVersion := *fxt.WorkItems[0]
+		newVersion.Fields[workitem.SystemArea] = fxt.Areas[0].ID.String()
+		contextChanges := change.Set{{AttributeName: workitem.SystemArea, NewValue: fxt.Areas[0].ID.String()}}
+		action := ActionAreaDefaults{
+			Db:     s.GormDB,
+			Ctx:    s.Ctx,
+			UserID: &fxt.Identities[0].ID,
+		}
+		var convertChanges change.Set
+		afterActionWI, convertChanges, err := action.OnChange(newVersion, contextChanges, "", &convertChanges)
+		require.NoError(t, err)
+		require.Len(t, convertChanges, 2)
+		require.Equal(t, workitem.SystemAssignees, convertChanges[0].AttributeName)
+		require.Equal(t, workitem.SystemLabels, convertChanges[1].AttributeName)
+		wi := afterActionWI.(workitem.WorkItem)
+		require.Equal(t, []interface{}{fxt.Identities[1].ID.String()}, wi.Fields[workitem.SystemAssignees])
+		require.Equal(t, []interface{}{fxt.Labels[0].ID.String()}, wi.Fields[workitem.SystemLabels])
+		require.Equal(t, fxt.WorkItems[0].Version+1, wi.Version)
+	})
+
+	s.T().Run("assigned work item keeps its assignees", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		newVersion := *fxt.WorkItems[0]
+		newVersion.Fields[workitem.SystemArea] = fxt.Areas[0].ID.String()
+		newVersion.Fields[workitem.SystemAssignees] = []interface{}{fxt.Identities[0].ID.String()}
+		newVersion.Fields[workitem.SystemLabels] = []interface{}{fxt.Labels[1].ID.String()}
+		contextChanges := change.Set{{AttributeName: workitem.SystemArea, NewValue: fxt.Areas[0].ID.String()}}
+		action := ActionAreaDefaults{
+			Db:     s.GormDB,
+			Ctx:    s.Ctx,
+			UserID: &fxt.Identities[0].ID,
+		}
+		var convertChanges change.Set
+		afterActionWI, convertChanges, err := action.OnChange(newVersion, contextChanges, "", &convertChanges)
+		require.NoError(t, err)
+		require.Len(t, convertChanges, 1)
+		require.Equal(t, workitem.SystemLabels, convertChanges[0].AttributeName)
+		wi := afterActionWI.(workitem.WorkItem)
+		require.Equal(t, []interface{}{fxt.Identities[0].ID.String()}, wi.Fields[workitem.SystemAssignees])
+		require.Equal(t, []interface{}{fxt.Labels[1].ID.String(), fxt.Labels[0].ID.String()}, wi.Fields[workitem.SystemLabels])
+	})
+
+	s.T().Run("area unchanged", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		newVersion := *fxt.WorkItems[0]
+		newVersion.Fields[workitem.SystemArea] = fxt.Areas[0].ID.String()
+		contextChanges := change.Set{{AttributeName: workitem.SystemState, NewValue: workitem.SystemStateOpen}}
+		action := ActionAreaDefaults{
+			Db:     s.GormDB,
+			Ctx:    s.Ctx,
+			UserID: &fxt.Identities[0].ID,
+		}
+		var convertChanges change.Set
+		afterActionWI, convertChanges, err := action.OnChange(newVersion, contextChanges, "", &convertChanges)
+		require.NoError(t, err)
+		require.Empty(t, convertChanges)
+		require.Nil(t, afterActionWI.(workitem.WorkItem).Fields[workitem.SystemAssignees])
+	})
+}
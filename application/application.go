@@ -3,6 +3,7 @@ package application
 import (
 	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/attachment"
 	"github.com/fabric8-services/fabric8-wit/codebase"
 	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/iteration"
@@ -11,9 +12,13 @@ import (
 	"github.com/fabric8-services/fabric8-wit/remoteworkitem"
 	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/spacetemplate"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/importer"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/templatemigration"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/event"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
+	"github.com/fabric8-services/fabric8-wit/worklog"
 )
 
 //An Application stands for a particular implementation of the business logic of our application
@@ -36,8 +41,15 @@ type Application interface {
 	Queries() query.Repository
 	Events() event.Repository
 	SpaceTemplates() spacetemplate.Repository
+	SpaceTemplateImporter() importer.Repository
+	SpaceTemplateMigrations() templatemigration.Repository
 	WorkItemTypeGroups() workitem.WorkItemTypeGroupRepository
 	Boards() workitem.BoardRepository
+	WorkItemRevisions() workitem.RevisionRepository
+	WorkItemTemplates() template.Repository
+	IterationSchedules() iteration.ScheduleRepository
+	Attachments() attachment.Repository
+	Worklogs() worklog.Repository
 }
 
 // A Transaction abstracts a database transaction. The repositories created for the transaction object make changes inside the the transaction
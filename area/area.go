@@ -2,6 +2,7 @@ package area
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/fabric8-services/fabric8-wit/application/repository"
@@ -29,7 +30,13 @@ type Area struct {
 	SpaceID uuid.UUID `sql:"type:uuid"`
 	Path    path.Path
 	Name    string
-	Version int
+	// Owners are assigned to work items without assignees that are created
+	// in or moved to the area
+	Owners IDs `sql:"type:jsonb"`
+	// DefaultLabels are attached to work items that are created in or moved
+	// to the area
+	DefaultLabels IDs `sql:"type:jsonb"`
+	Version       int
 }
 
 // MakeChildOf does all the path magic to make the current area a child of the
@@ -67,6 +74,8 @@ type Repository interface {
 	ListChildren(ctx context.Context, parentArea *Area) ([]Area, error)
 	Query(funcs ...func(*gorm.DB) *gorm.DB) ([]Area, error)
 	Root(ctx context.Context, spaceID uuid.UUID) (*Area, error)
+	Save(ctx context.Context, a Area) (*Area, error)
+	ReplaceDefaultLabels(ctx context.Context, spaceID uuid.UUID, labelIDs []uuid.UUID, replacementID *uuid.UUID) error
 }
 
 // NewAreaRepository creates a new storage type.
@@ -115,6 +124,86 @@ func (m *GormAreaRepository) Create(ctx context.Context, u *Area) error {
 	return nil
 }
 
+// Save updates the name, owners and default labels of the given area
+func (m *GormAreaRepository) Save(ctx context.Context, a Area) (*Area, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "save"}, time.Now())
+	if strings.TrimSpace(a.Name) == "" {
+		return nil, errors.NewBadParameterError("name", a.Name).Expected("not empty")
+	}
+	existing, err := m.Load(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Version != a.Version {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	tx := m.db.Model(existing).Where("version = ?", a.Version).Updates(map[string]interface{}{
+		"name":           a.Name,
+		"owners":         a.Owners.Unique(),
+		"default_labels": a.DefaultLabels.Unique(),
+		"version":        a.Version + 1,
+	})
+	if err := tx.Error; err != nil {
+		if gormsupport.IsUniqueViolation(err, "areas_name_space_id_path_unique") {
+			return nil, errors.NewDataConflictError(fmt.Sprintf("area already exists with name = %s , space_id = %s , path = %s ", a.Name, existing.SpaceID.String(), existing.Path.ParentPath().String()))
+		}
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to update area %s", a.ID))
+	}
+	if tx.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	log.Debug(ctx, map[string]interface{}{
+		"area_id": a.ID,
+	}, "area updated successfully")
+	return m.Load(ctx, a.ID)
+}
+
+// ReplaceDefaultLabels removes the labels with the given IDs from the default
+// labels of all areas of the given space and adds the given replacement label
+// instead, unless it is nil.
+func (m *GormAreaRepository) ReplaceDefaultLabels(ctx context.Context, spaceID uuid.UUID, labelIDs []uuid.UUID, replacementID *uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "replacedefaultlabels"}, time.Now())
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	replaced := map[uuid.UUID]struct{}{}
+	conditions := make([]string, 0, len(labelIDs))
+	args := make([]interface{}, 0, len(labelIDs))
+	for _, id := range labelIDs {
+		replaced[id] = struct{}{}
+		conditions = append(conditions, "default_labels @> jsonb_build_array(?::text)")
+		args = append(args, id.String())
+	}
+	var objs []Area
+	err := m.db.Where("space_id = ?", spaceID).Where("("+strings.Join(conditions, " OR ")+")", args...).Find(&objs).Error
+	if err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to load the areas with default labels %v", labelIDs))
+	}
+	for _, a := range objs {
+		defaultLabels := make(IDs, 0, len(a.DefaultLabels))
+		for _, id := range a.DefaultLabels {
+			if _, ok := replaced[id]; ok {
+				if replacementID == nil {
+					continue
+				}
+				id = *replacementID
+			}
+			defaultLabels = append(defaultLabels, id)
+		}
+		tx := m.db.Model(&Area{}).Where("id = ? AND version = ?", a.ID, a.Version).Updates(map[string]interface{}{
+			"default_labels": defaultLabels.Unique(),
+			"version":        a.Version + 1,
+		})
+		if err := tx.Error; err != nil {
+			return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to update the default labels of area %s", a.ID))
+		}
+		if tx.RowsAffected == 0 {
+			return errors.NewVersionConflictError("version conflict")
+		}
+	}
+	return nil
+}
+
 // List all Areas related to a single item
 func (m *GormAreaRepository) List(ctx context.Context, spaceID uuid.UUID) ([]Area, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "Area", "query"}, time.Now())
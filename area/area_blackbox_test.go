@@ -284,3 +284,103 @@ func (s *TestAreaRepository) TestLoadMultiple() {
 		require.Empty(t, listLoadedAreas)
 	})
 }
+
+func (s *TestAreaRepository) TestSave() {
+	repo := area.NewAreaRepository(s.DB)
+
+	s.T().Run("owners and default labels", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Areas(1), tf.Identities(2), tf.Labels(1))
+		a := *fxt.Areas[0]
+		a.Name = "renamed"
+		a.Owners = area.IDs{fxt.Identities[0].ID, fxt.Identities[1].ID, fxt.Identities[0].ID}
+		a.DefaultLabels = area.IDs{fxt.Labels[0].ID}
+		// when
+		updated, err := repo.Save(context.Background(), a)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", updated.Name)
+		assert.Equal(t, a.Version+1, updated.Version)
+		loaded, err := repo.Load(context.Background(), a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, area.IDs{fxt.Identities[0].ID, fxt.Identities[1].ID}, loaded.Owners)
+		assert.Equal(t, area.IDs{fxt.Labels[0].ID}, loaded.DefaultLabels)
+
+		t.Run("clear owners", func(t *testing.T) {
+			loaded.Owners = nil
+			updated, err := repo.Save(context.Background(), *loaded)
+			require.NoError(t, err)
+			assert.Empty(t, updated.Owners)
+			assert.Equal(t, area.IDs{fxt.Labels[0].ID}, updated.DefaultLabels)
+		})
+	})
+
+	s.T().Run("version conflict", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Areas(1))
+		a := *fxt.Areas[0]
+		a.Version++
+		// when
+		_, err := repo.Save(context.Background(), a)
+		// then
+		require.Error(t, err)
+		assert.IsType(t, errors.VersionConflictError{}, errs.Cause(err))
+	})
+
+	s.T().Run("not found", func(t *testing.T) {
+		// when
+		_, err := repo.Save(context.Background(), area.Area{ID: uuid.NewV4(), Name: "unknown"})
+		// then
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
+func (s *TestAreaRepository) TestReplaceDefaultLabels() {
+	repo := area.NewAreaRepository(s.DB)
+	setup := func(t *testing.T) (*tf.TestFixture, area.Area) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Areas(1), tf.Labels(3))
+		a := *fxt.Areas[0]
+		a.DefaultLabels = area.IDs{fxt.Labels[0].ID, fxt.Labels[1].ID}
+		updated, err := repo.Save(context.Background(), a)
+		require.NoError(t, err)
+		return fxt, *updated
+	}
+
+	s.T().Run("remove", func(t *testing.T) {
+		// given
+		fxt, a := setup(t)
+		// when
+		err := repo.ReplaceDefaultLabels(context.Background(), a.SpaceID, []uuid.UUID{fxt.Labels[0].ID}, nil)
+		// then
+		require.NoError(t, err)
+		loaded, err := repo.Load(context.Background(), a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, area.IDs{fxt.Labels[1].ID}, loaded.DefaultLabels)
+		assert.Equal(t, a.Version+1, loaded.Version)
+	})
+
+	s.T().Run("replace", func(t *testing.T) {
+		// given
+		fxt, a := setup(t)
+		// when
+		err := repo.ReplaceDefaultLabels(context.Background(), a.SpaceID, []uuid.UUID{fxt.Labels[0].ID, fxt.Labels[1].ID}, &fxt.Labels[2].ID)
+		// then
+		require.NoError(t, err)
+		loaded, err := repo.Load(context.Background(), a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, area.IDs{fxt.Labels[2].ID}, loaded.DefaultLabels)
+	})
+
+	s.T().Run("unused label", func(t *testing.T) {
+		// given
+		fxt, a := setup(t)
+		// when
+		err := repo.ReplaceDefaultLabels(context.Background(), a.SpaceID, []uuid.UUID{fxt.Labels[2].ID}, nil)
+		// then
+		require.NoError(t, err)
+		loaded, err := repo.Load(context.Background(), a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, a.Version, loaded.Version)
+	})
+}
@@ -0,0 +1,65 @@
+package area
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// IDs is a list of identity or label IDs that is stored as a JSON array.
+type IDs []uuid.UUID
+
+// Ensure IDs implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*IDs)(nil)
+var _ driver.Valuer = (*IDs)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (ids IDs) Value() (driver.Value, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(ids)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (ids *IDs) Scan(src interface{}) error {
+	if src == nil {
+		*ids = nil
+		return nil
+	}
+	b, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("scan source was not a byte array but %T", src)
+	}
+	return json.Unmarshal(b, ids)
+}
+
+// Unique returns the IDs without duplicates in their original order.
+func (ids IDs) Unique() IDs {
+	if ids == nil {
+		return nil
+	}
+	res := make(IDs, 0, len(ids))
+	seen := map[uuid.UUID]struct{}{}
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+	return res
+}
+
+// Strings returns the IDs as strings the way they are stored in the fields
+// of a work item.
+func (ids IDs) Strings() []interface{} {
+	res := make([]interface{}, len(ids))
+	for i, id := range ids {
+		res[i] = id.String()
+	}
+	return res
+}
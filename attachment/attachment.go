@@ -0,0 +1,195 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeAttachments is the JSONAPI type of attachments
+const APIStringTypeAttachments = "attachments"
+
+// Attachment describes a file attached to a work item or to one of its
+// comments.
+type Attachment struct {
+	gormsupport.Lifecycle
+	ID      uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	SpaceID uuid.UUID `sql:"type:uuid"`
+	// WorkItemID is set for attachments of comments as well
+	WorkItemID  uuid.UUID   `sql:"type:uuid"`
+	CommentID   id.NullUUID `sql:"type:uuid"`
+	FileName    string
+	ContentType string
+	Size        int64
+	UploaderID  uuid.UUID `sql:"type:uuid"`
+	// StorageKey is the key of the content in the blob storage
+	StorageKey string
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (m Attachment) TableName() string {
+	return "attachments"
+}
+
+// GetETagData returns the field values to use to generate the ETag
+func (m Attachment) GetETagData() []interface{} {
+	// attachments are immutable
+	return []interface{}{m.ID}
+}
+
+// GetLastModified returns the last modification time
+func (m Attachment) GetLastModified() time.Time {
+	return m.CreatedAt.Truncate(time.Second)
+}
+
+// NewStorageKey returns a new unique key under which the content of an
+// attachment of the given space is stored.
+func NewStorageKey(spaceID uuid.UUID) string {
+	return spaceID.String() + "/" + uuid.NewV4().String()
+}
+
+// Repository describes interactions with attachments
+type Repository interface {
+	repository.Exister
+	// Create stores the metadata of a new attachment. A forbidden error is
+	// returned if the attachments of the space would take more than quota
+	// bytes.
+	Create(ctx context.Context, a *Attachment, quota int64) error
+	Load(ctx context.Context, id uuid.UUID) (*Attachment, error)
+	// ListByWorkItem returns the attachments of the work item itself, not of
+	// its comments.
+	ListByWorkItem(ctx context.Context, workItemID uuid.UUID) ([]Attachment, error)
+	ListByComment(ctx context.Context, commentID uuid.UUID) ([]Attachment, error)
+	// SpaceUsage returns the total size of all attachments of the space.
+	SpaceUsage(ctx context.Context, spaceID uuid.UUID) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// NewRepository creates a new attachment repository
+func NewRepository(db *gorm.DB) Repository {
+	return &GormRepository{db: db}
+}
+
+// GormRepository is the implementation of the storage interface for
+// attachments.
+type GormRepository struct {
+	db *gorm.DB
+}
+
+// CheckExists returns nil if the given ID exists otherwise returns an error
+func (r *GormRepository) CheckExists(ctx context.Context, id uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "attachment", "exists"}, time.Now())
+	return repository.CheckExists(ctx, r.db, Attachment{}.TableName(), id)
+}
+
+// Create stores the metadata of a new attachment. A forbidden error is
+// returned if the attachments of the space would take more than quota bytes.
+func (r *GormRepository) Create(ctx context.Context, a *Attachment, quota int64) error {
+	defer goa.MeasureSince([]string{"goa", "db", "attachment", "create"}, time.Now())
+	if strings.TrimSpace(a.FileName) == "" {
+		return errors.NewBadParameterError("file name", a.FileName).Expected("not empty")
+	}
+	if a.Size < 0 {
+		return errors.NewBadParameterError("size", a.Size).Expected("not negative")
+	}
+	// Lock the space so that concurrent uploads cannot exceed the quota
+	var locked struct {
+		ID uuid.UUID `gorm:"column:id" sql:"type:uuid"`
+	}
+	db := r.db.Raw(fmt.Sprintf(`SELECT id FROM "%s" WHERE id = ? AND deleted_at IS NULL FOR UPDATE`, space.Space{}.TableName()), a.SpaceID).Scan(&locked)
+	if db.RecordNotFound() {
+		return errors.NewNotFoundError("space", a.SpaceID.String())
+	}
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to lock space %s", a.SpaceID))
+	}
+	usage, err := r.SpaceUsage(ctx, a.SpaceID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	if usage+a.Size > quota {
+		return errors.NewForbiddenError(fmt.Sprintf("the attachments of space %s must not exceed %d bytes (%d bytes used)", a.SpaceID, quota, usage))
+	}
+	a.ID = uuid.NewV4()
+	if err := r.db.Create(a).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":          err,
+			"work_item_id": a.WorkItemID,
+		}, "failed to create attachment")
+		return errors.NewInternalError(ctx, errs.Wrap(err, "failed to create attachment"))
+	}
+	return nil
+}
+
+// Load returns the attachment with the given ID
+func (r *GormRepository) Load(ctx context.Context, id uuid.UUID) (*Attachment, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "attachment", "load"}, time.Now())
+	var a Attachment
+	db := r.db.Where("id = ?", id).First(&a)
+	if db.RecordNotFound() {
+		return nil, errors.NewNotFoundError("attachment", id.String())
+	}
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to load attachment %s", id))
+	}
+	return &a, nil
+}
+
+// ListByWorkItem returns the attachments of the work item itself, not of its
+// comments.
+func (r *GormRepository) ListByWorkItem(ctx context.Context, workItemID uuid.UUID) ([]Attachment, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "attachment", "list"}, time.Now())
+	var res []Attachment
+	if err := r.db.Where("work_item_id = ? AND comment_id IS NULL", workItemID).Order("created_at").Find(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to list attachments of work item %s", workItemID))
+	}
+	return res, nil
+}
+
+// ListByComment returns the attachments of the given comment.
+func (r *GormRepository) ListByComment(ctx context.Context, commentID uuid.UUID) ([]Attachment, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "attachment", "list"}, time.Now())
+	var res []Attachment
+	if err := r.db.Where("comment_id = ?", commentID).Order("created_at").Find(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to list attachments of comment %s", commentID))
+	}
+	return res, nil
+}
+
+// SpaceUsage returns the total size of all attachments of the space.
+func (r *GormRepository) SpaceUsage(ctx context.Context, spaceID uuid.UUID) (int64, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "attachment", "usage"}, time.Now())
+	var usage int64
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(size), 0) FROM "%s" WHERE space_id = ? AND deleted_at IS NULL`, Attachment{}.TableName())
+	if err := r.db.Raw(query, spaceID).Row().Scan(&usage); err != nil {
+		return 0, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to compute the attachment usage of space %s", spaceID))
+	}
+	return usage, nil
+}
+
+// Delete removes the metadata of the attachment with the given ID. The
+// content has to be removed from the blob storage separately.
+func (r *GormRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "attachment", "delete"}, time.Now())
+	db := r.db.Delete(&Attachment{ID: id})
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to delete attachment %s", id))
+	}
+	if db.RowsAffected == 0 {
+		return errors.NewNotFoundError("attachment", id.String())
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package attachment_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/attachment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type attachmentRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunAttachmentRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &attachmentRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *attachmentRepoBlackBoxTest) newAttachment(fxt *tf.TestFixture, size int64) *attachment.Attachment {
+	return &attachment.Attachment{
+		SpaceID:     fxt.Spaces[0].ID,
+		WorkItemID:  fxt.WorkItems[0].ID,
+		FileName:    "screenshot.png",
+		ContentType: "image/png",
+		Size:        size,
+		UploaderID:  fxt.Identities[0].ID,
+		StorageKey:  attachment.NewStorageKey(fxt.Spaces[0].ID),
+	}
+}
+
+func (s *attachmentRepoBlackBoxTest) TestCreate() {
+	repo := attachment.NewRepository(s.DB)
+
+	s.T().Run("ok", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		a := s.newAttachment(fxt, 42)
+		err := repo.Create(s.Ctx, a, 100)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, a.ID)
+		loaded, err := repo.Load(s.Ctx, a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, a.FileName, loaded.FileName)
+		assert.Equal(t, a.ContentType, loaded.ContentType)
+		assert.Equal(t, a.Size, loaded.Size)
+		assert.Equal(t, a.StorageKey, loaded.StorageKey)
+		assert.False(t, loaded.CommentID.Valid)
+	})
+
+	s.T().Run("quota exceeded", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		require.NoError(t, repo.Create(s.Ctx, s.newAttachment(fxt, 60), 100))
+		err := repo.Create(s.Ctx, s.newAttachment(fxt, 41), 100)
+		require.Error(t, err)
+		assert.IsType(t, errors.ForbiddenError{}, err)
+		// exactly reaching the quota is fine
+		require.NoError(t, repo.Create(s.Ctx, s.newAttachment(fxt, 40), 100))
+		usage, err := repo.SpaceUsage(s.Ctx, fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), usage)
+	})
+
+	s.T().Run("empty file name", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		a := s.newAttachment(fxt, 1)
+		a.FileName = " "
+		err := repo.Create(s.Ctx, a, 100)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	s.T().Run("unknown space", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		a := s.newAttachment(fxt, 1)
+		a.SpaceID = uuid.NewV4()
+		err := repo.Create(s.Ctx, a, 100)
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
+func (s *attachmentRepoBlackBoxTest) TestList() {
+	repo := attachment.NewRepository(s.DB)
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Comments(1))
+	onWorkItem := s.newAttachment(fxt, 1)
+	require.NoError(s.T(), repo.Create(s.Ctx, onWorkItem, 100))
+	onComment := s.newAttachment(fxt, 1)
+	onComment.WorkItemID = fxt.Comments[0].ParentID
+	onComment.CommentID = id.NullUUID{UUID: fxt.Comments[0].ID, Valid: true}
+	require.NoError(s.T(), repo.Create(s.Ctx, onComment, 100))
+
+	s.T().Run("by work item", func(t *testing.T) {
+		list, err := repo.ListByWorkItem(s.Ctx, fxt.WorkItems[0].ID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, onWorkItem.ID, list[0].ID)
+	})
+
+	s.T().Run("by comment", func(t *testing.T) {
+		list, err := repo.ListByComment(s.Ctx, fxt.Comments[0].ID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, onComment.ID, list[0].ID)
+	})
+}
+
+func (s *attachmentRepoBlackBoxTest) TestDelete() {
+	repo := attachment.NewRepository(s.DB)
+
+	s.T().Run("ok", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		a := s.newAttachment(fxt, 100)
+		require.NoError(t, repo.Create(s.Ctx, a, 100))
+		require.NoError(t, repo.Delete(s.Ctx, a.ID))
+		_, err := repo.Load(s.Ctx, a.ID)
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, err)
+		// the deleted attachment doesn't count against the quota anymore
+		usage, err := repo.SpaceUsage(s.Ctx, fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), usage)
+	})
+
+	s.T().Run("not found", func(t *testing.T) {
+		err := repo.Delete(s.Ctx, uuid.NewV4())
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, err)
+	})
+}
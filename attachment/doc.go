@@ -0,0 +1,3 @@
+// Package attachment provides the metadata of files attached to work items and
+// comments as well as the blob storages that hold their content.
+package attachment
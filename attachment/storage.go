@@ -0,0 +1,157 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	errs "github.com/pkg/errors"
+)
+
+// BlobStorage stores the content of attachments by their storage key.
+type BlobStorage interface {
+	// Put stores size bytes read from content under the given key.
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get returns the content stored under the given key or a not found
+	// error. The caller has to close the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under the given key. Deleting a key
+	// that doesn't exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// BlobStorageConfiguration describes the configuration of the blob storage
+type BlobStorageConfiguration interface {
+	GetAttachmentsStorage() string
+	GetAttachmentsFileSystemPath() string
+	GetAttachmentsS3Endpoint() string
+	GetAttachmentsS3Bucket() string
+	GetAttachmentsS3Region() string
+	GetAttachmentsS3AccessKey() string
+	GetAttachmentsS3SecretKey() string
+}
+
+// NewBlobStorage creates the blob storage that is configured: either "s3" for
+// an S3-compatible object storage or "filesystem" for the local filesystem.
+func NewBlobStorage(config BlobStorageConfiguration) (BlobStorage, error) {
+	switch config.GetAttachmentsStorage() {
+	case "s3":
+		return NewS3Storage(S3Config{
+			Endpoint:  config.GetAttachmentsS3Endpoint(),
+			Bucket:    config.GetAttachmentsS3Bucket(),
+			Region:    config.GetAttachmentsS3Region(),
+			AccessKey: config.GetAttachmentsS3AccessKey(),
+			SecretKey: config.GetAttachmentsS3SecretKey(),
+		})
+	case "filesystem", "":
+		return NewFileSystemStorage(config.GetAttachmentsFileSystemPath())
+	default:
+		return nil, errs.Errorf("unknown attachment storage %q", config.GetAttachmentsStorage())
+	}
+}
+
+// checkKey makes sure that a storage key cannot escape the storage.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return errors.NewBadParameterError("key", key).Expected("relative path")
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.NewBadParameterError("key", key).Expected("relative path without empty, '.' or '..' segments")
+		}
+	}
+	return nil
+}
+
+// FileSystemStorage is a BlobStorage that keeps the content in files below a
+// directory.
+type FileSystemStorage struct {
+	dir string
+}
+
+// Ensure FileSystemStorage implements the BlobStorage interface
+var _ BlobStorage = (*FileSystemStorage)(nil)
+
+// NewFileSystemStorage creates a blob storage in the given directory, which is
+// created if it doesn't exist yet.
+func NewFileSystemStorage(dir string) (*FileSystemStorage, error) {
+	if dir == "" {
+		return nil, errs.New("no attachment directory configured (attachments.filesystem.path)")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errs.Wrapf(err, "failed to create attachment directory %s", dir)
+	}
+	return &FileSystemStorage{dir: dir}, nil
+}
+
+func (s *FileSystemStorage) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put implements BlobStorage
+func (s *FileSystemStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return errs.Wrapf(err, "failed to create directory for blob %s", key)
+	}
+	// Write to a temporary file first so that readers never see partial
+	// content.
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".upload-")
+	if err != nil {
+		return errs.Wrapf(err, "failed to create blob %s", key)
+	}
+	n, err := io.Copy(tmp, io.LimitReader(content, size))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n != size {
+		err = errs.Errorf("expected %d bytes but got %d", size, n)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return errs.Wrapf(err, "failed to write blob %s", key)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		os.Remove(tmp.Name())
+		return errs.Wrapf(err, "failed to store blob %s", key)
+	}
+	return nil
+}
+
+// Get implements BlobStorage
+func (s *FileSystemStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, errors.NewNotFoundError("blob", key)
+	}
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to open blob %s", key)
+	}
+	return f, nil
+}
+
+// Delete implements BlobStorage
+func (s *FileSystemStorage) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return errs.Wrapf(err, "failed to delete blob %s", key)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package attachment_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/attachment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlobStorage runs the tests that every blob storage has to pass.
+func testBlobStorage(t *testing.T, storage attachment.BlobStorage) {
+	ctx := context.Background()
+
+	t.Run("put get delete", func(t *testing.T) {
+		key := attachment.NewStorageKey(uuid.NewV4())
+		content := "hello attachment"
+		err := storage.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain")
+		require.NoError(t, err)
+
+		r, err := storage.Get(ctx, key)
+		require.NoError(t, err)
+		actual, err := ioutil.ReadAll(r)
+		require.NoError(t, r.Close())
+		require.NoError(t, err)
+		assert.Equal(t, content, string(actual))
+
+		require.NoError(t, storage.Delete(ctx, key))
+		_, err = storage.Get(ctx, key)
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, err)
+	})
+
+	t.Run("get unknown key", func(t *testing.T) {
+		_, err := storage.Get(ctx, attachment.NewStorageKey(uuid.NewV4()))
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, err)
+	})
+
+	t.Run("delete unknown key", func(t *testing.T) {
+		require.NoError(t, storage.Delete(ctx, attachment.NewStorageKey(uuid.NewV4())))
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b"} {
+			t.Run(key, func(t *testing.T) {
+				err := storage.Put(ctx, key, strings.NewReader("x"), 1, "text/plain")
+				require.Error(t, err)
+				assert.IsType(t, errors.BadParameterError{}, err)
+			})
+		}
+	})
+}
+
+func TestFileSystemStorage(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	dir, err := ioutil.TempDir("", "attachments")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	storage, err := attachment.NewFileSystemStorage(dir)
+	require.NoError(t, err)
+	testBlobStorage(t, storage)
+
+	t.Run("no directory", func(t *testing.T) {
+		_, err := attachment.NewFileSystemStorage("")
+		require.Error(t, err)
+	})
+}
+
+// s3StandIn is a minimal in-memory S3-compatible object storage.
+type s3StandIn struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func (s *s3StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") ||
+		r.Header.Get("x-amz-date") == "" ||
+		r.Header.Get("x-amz-content-sha256") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	prefix := "/" + s.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		content, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = content
+	case http.MethodGet:
+		content, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	server := httptest.NewServer(&s3StandIn{bucket: "attachments", objects: map[string][]byte{}})
+	defer server.Close()
+
+	t.Run("compatible storage", func(t *testing.T) {
+		storage, err := attachment.NewS3Storage(attachment.S3Config{
+			Endpoint:  server.URL,
+			Bucket:    "attachments",
+			AccessKey: "access",
+			SecretKey: "secret",
+		})
+		require.NoError(t, err)
+		testBlobStorage(t, storage)
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		storage, err := attachment.NewS3Storage(attachment.S3Config{
+			Endpoint:  server.URL,
+			Bucket:    "attachments",
+			AccessKey: "unknown",
+			SecretKey: "secret",
+		})
+		require.NoError(t, err)
+		err = storage.Put(context.Background(), "a/b", strings.NewReader("x"), 1, "text/plain")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := attachment.NewS3Storage(attachment.S3Config{Endpoint: "not a url", Bucket: "attachments"})
+		require.Error(t, err)
+		_, err = attachment.NewS3Storage(attachment.S3Config{Endpoint: server.URL})
+		require.Error(t, err)
+	})
+}
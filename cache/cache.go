@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// DefaultTTL is the time after which cached values expire unless another TTL
+// has been configured.
+const DefaultTTL = 5 * time.Minute
+
+// Cache stores values by their ID. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Name returns the name under which the cache is registered and
+	// invalidated.
+	Name() string
+	// Get returns the value with the given ID. The second value (ok) is true
+	// if the value was found in the cache and has not expired yet.
+	Get(id uuid.UUID) (value interface{}, ok bool)
+	// Put stores the given value under the given ID.
+	Put(id uuid.UUID, value interface{})
+	// Delete removes the value with the given ID.
+	Delete(id uuid.UUID)
+	// Clear removes all values.
+	Clear()
+	// SetTTL changes the time after which newly stored values expire.
+	SetTTL(ttl time.Duration)
+}
+
+type memoryEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// MemoryCache is a Cache that holds its values in the memory of the process.
+type MemoryCache struct {
+	name    string
+	ttl     time.Duration
+	entries map[uuid.UUID]memoryEntry
+	mapLock sync.RWMutex
+}
+
+// NewMemoryCache creates a new in-memory cache whose values expire after the
+// given TTL.
+func NewMemoryCache(name string, ttl time.Duration) *MemoryCache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &MemoryCache{
+		name:    name,
+		ttl:     ttl,
+		entries: map[uuid.UUID]memoryEntry{},
+	}
+}
+
+// Ensure MemoryCache implements the Cache interface
+var _ Cache = (*MemoryCache)(nil)
+
+// Name implements Cache
+func (c *MemoryCache) Name() string {
+	return c.name
+}
+
+// Get implements Cache
+func (c *MemoryCache) Get(id uuid.UUID) (interface{}, bool) {
+	c.mapLock.RLock()
+	e, ok := c.entries[id]
+	c.mapLock.RUnlock()
+	if !ok || time.Now().After(e.expires) {
+		reportMiss(c.name)
+		return nil, false
+	}
+	reportHit(c.name)
+	return e.value, true
+}
+
+// Put implements Cache
+func (c *MemoryCache) Put(id uuid.UUID, value interface{}) {
+	c.mapLock.Lock()
+	defer c.mapLock.Unlock()
+	c.entries[id] = memoryEntry{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+// Delete implements Cache
+func (c *MemoryCache) Delete(id uuid.UUID) {
+	c.mapLock.Lock()
+	defer c.mapLock.Unlock()
+	delete(c.entries, id)
+}
+
+// Clear implements Cache
+func (c *MemoryCache) Clear() {
+	c.mapLock.Lock()
+	defer c.mapLock.Unlock()
+	c.entries = map[uuid.UUID]memoryEntry{}
+}
+
+// SetTTL implements Cache
+func (c *MemoryCache) SetTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mapLock.Lock()
+	defer c.mapLock.Unlock()
+	c.ttl = ttl
+}
+
+var (
+	registry     = map[string]Cache{}
+	registryLock sync.RWMutex
+)
+
+// Register makes the given cache known under its name so that it can be
+// invalidated by other instances of the service. Registering a cache with a
+// name that is already taken replaces the previous cache. The given cache is
+// returned to allow for one-line declarations.
+func Register(c Cache) Cache {
+	registerMetrics()
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[c.Name()] = c
+	return c
+}
+
+// Lookup returns the registered cache with the given name.
+func Lookup(name string) (Cache, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	c, ok := registry[name]
+	return c, ok
+}
+
+// SetTTL changes the TTL of all registered caches.
+func SetTTL(ttl time.Duration) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	for _, c := range registry {
+		c.SetTTL(ttl)
+	}
+}
+
+// ClearAll clears all registered caches of this instance.
+func ClearAll() {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	for _, c := range registry {
+		c.Clear()
+	}
+}
@@ -0,0 +1,105 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/cache"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	t.Run("get put delete clear", func(t *testing.T) {
+		c := cache.NewMemoryCache("test", time.Minute)
+		id := uuid.NewV4()
+		_, ok := c.Get(id)
+		assert.False(t, ok)
+		c.Put(id, "foo")
+		v, ok := c.Get(id)
+		require.True(t, ok)
+		assert.Equal(t, "foo", v)
+		c.Delete(id)
+		_, ok = c.Get(id)
+		assert.False(t, ok)
+		c.Put(id, "foo")
+		c.Clear()
+		_, ok = c.Get(id)
+		assert.False(t, ok)
+	})
+	t.Run("values expire", func(t *testing.T) {
+		c := cache.NewMemoryCache("test", time.Millisecond)
+		id := uuid.NewV4()
+		c.Put(id, "foo")
+		time.Sleep(5 * time.Millisecond)
+		_, ok := c.Get(id)
+		assert.False(t, ok)
+	})
+	t.Run("registry", func(t *testing.T) {
+		name := "test-" + uuid.NewV4().String()
+		c := cache.Register(cache.NewMemoryCache(name, time.Minute))
+		found, ok := cache.Lookup(name)
+		require.True(t, ok)
+		assert.Equal(t, c, found)
+		id := uuid.NewV4()
+		c.Put(id, "foo")
+		cache.ClearAll()
+		_, ok = c.Get(id)
+		assert.False(t, ok)
+	})
+}
+
+type TestInvalidation struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunInvalidation(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestInvalidation{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *TestInvalidation) TestInvalidate() {
+	name := "test-" + uuid.NewV4().String()
+	c := cache.Register(cache.NewMemoryCache(name, time.Minute))
+	id1 := uuid.NewV4()
+	id2 := uuid.NewV4()
+	c.Put(id1, "foo")
+	c.Put(id2, "bar")
+
+	s.T().Run("single value", func(t *testing.T) {
+		require.NoError(t, cache.Invalidate(s.Ctx, s.DB, name, id1))
+		_, ok := c.Get(id1)
+		assert.False(t, ok)
+		_, ok = c.Get(id2)
+		assert.True(t, ok)
+	})
+	s.T().Run("whole cache", func(t *testing.T) {
+		require.NoError(t, cache.Invalidate(s.Ctx, s.DB, name, uuid.Nil))
+		_, ok := c.Get(id2)
+		assert.False(t, ok)
+	})
+	s.T().Run("within a transaction", func(t *testing.T) {
+		id := uuid.NewV4()
+		c.Put(id, "foo")
+		tx := s.DB.Begin()
+		require.NoError(t, cache.Invalidate(s.Ctx, tx, name, id))
+		_, ok := c.Get(id)
+		assert.False(t, ok)
+		// a value loaded within the transaction is cached again
+		c.Put(id, "uncommitted")
+		require.NoError(t, tx.Rollback().Error)
+		cache.TransactionEnded(tx)
+		_, ok = c.Get(id)
+		assert.False(t, ok)
+	})
+	s.T().Run("unknown cache", func(t *testing.T) {
+		require.NoError(t, cache.Invalidate(s.Ctx, s.DB, "unknown", id1))
+	})
+}
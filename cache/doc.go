@@ -0,0 +1,4 @@
+// Package cache provides caches for rarely changing entities like work item
+// types, work item link types and space templates. The caches of all instances
+// of the service are kept in sync via Postgres notifications.
+package cache
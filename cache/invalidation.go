@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// notificationChannel is the Postgres channel on which cache invalidations are
+// broadcasted to all instances of the service.
+const notificationChannel = "cache_invalidation"
+
+// invalidation names a value (or a whole cache for uuid.Nil) that was
+// invalidated.
+type invalidation struct {
+	name string
+	id   uuid.UUID
+}
+
+// pending holds the invalidations of the open transactions.
+var (
+	pending     = map[*sql.Tx][]invalidation{}
+	pendingLock sync.Mutex
+)
+
+// Invalidate removes the value with the given ID from the named cache of this
+// instance and notifies all other instances to do the same. If the ID is
+// uuid.Nil the whole cache is cleared. When called within a transaction, the
+// other instances are notified once the transaction is committed and the value
+// is evicted from this instance again when TransactionEnded is called, because
+// it might have been cached with uncommitted data in the meantime.
+func Invalidate(ctx context.Context, db *gorm.DB, name string, id uuid.UUID) error {
+	evict(name, id)
+	if tx, ok := db.CommonDB().(*sql.Tx); ok {
+		pendingLock.Lock()
+		pending[tx] = append(pending[tx], invalidation{name: name, id: id})
+		pendingLock.Unlock()
+	}
+	payload := name
+	if !uuid.Equal(id, uuid.Nil) {
+		payload += ":" + id.String()
+	}
+	if err := db.Exec("SELECT pg_notify(?, ?)", notificationChannel, payload).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":   err,
+			"cache": name,
+			"id":    id,
+		}, "failed to notify other instances about the cache invalidation")
+		return errs.Wrapf(err, "failed to notify other instances about the invalidation of cache %s", name)
+	}
+	return nil
+}
+
+// TransactionEnded evicts the values that were invalidated within the given
+// transaction once more. It must be called after the transaction was committed
+// or rolled back.
+func TransactionEnded(db *gorm.DB) {
+	tx, ok := db.CommonDB().(*sql.Tx)
+	if !ok {
+		return
+	}
+	pendingLock.Lock()
+	invalidations := pending[tx]
+	delete(pending, tx)
+	pendingLock.Unlock()
+	for _, i := range invalidations {
+		evict(i.name, i.id)
+	}
+}
+
+// evict removes the value with the given ID (or all values for uuid.Nil) from
+// the named cache.
+func evict(name string, id uuid.UUID) {
+	c, ok := Lookup(name)
+	if !ok {
+		return
+	}
+	reportInvalidation(name)
+	if uuid.Equal(id, uuid.Nil) {
+		c.Clear()
+		return
+	}
+	c.Delete(id)
+}
+
+// handleNotification evicts the cached value named by the payload of a
+// notification that was sent by Invalidate.
+func handleNotification(payload string) {
+	parts := strings.SplitN(payload, ":", 2)
+	if len(parts) == 1 {
+		evict(parts[0], uuid.Nil)
+		return
+	}
+	id, err := uuid.FromString(parts[1])
+	if err != nil {
+		log.Error(nil, map[string]interface{}{
+			"err":     err,
+			"payload": payload,
+		}, "invalid cache invalidation, clearing the whole cache")
+		evict(parts[0], uuid.Nil)
+		return
+	}
+	evict(parts[0], id)
+}
+
+// Listener receives the cache invalidations of all instances of the service
+// and applies them to the registered caches.
+type Listener struct {
+	listener *pq.Listener
+	done     chan struct{}
+}
+
+// NewListener creates a listener that connects to the database with the given
+// connection string.
+func NewListener(connStr string) *Listener {
+	l := &Listener{done: make(chan struct{})}
+	l.listener = pq.NewListener(connStr, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Error(nil, map[string]interface{}{
+				"err":   err,
+				"event": event,
+			}, "cache invalidation listener failed")
+		}
+		if event == pq.ListenerEventReconnected {
+			// we might have missed invalidations while we were disconnected
+			ClearAll()
+		}
+	})
+	return l
+}
+
+// Start listens for cache invalidations until Stop is called.
+func (l *Listener) Start(ctx context.Context) error {
+	if err := l.listener.Listen(notificationChannel); err != nil {
+		return errs.Wrapf(err, "failed to listen on channel %s", notificationChannel)
+	}
+	go func() {
+		for {
+			select {
+			case n := <-l.listener.Notify:
+				// n is nil after a reconnect, which is handled by the event
+				// callback
+				if n != nil {
+					handleNotification(n.Extra)
+				}
+			case <-time.After(90 * time.Second):
+				if err := l.listener.Ping(); err != nil {
+					log.Error(ctx, map[string]interface{}{"err": err}, "cache invalidation listener lost its connection")
+				}
+			case <-l.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops listening for cache invalidations.
+// This should be called only from main
+func (l *Listener) Stop() {
+	close(l.done)
+	if err := l.listener.Close(); err != nil {
+		log.Error(nil, map[string]interface{}{"err": err}, "failed to close the cache invalidation listener")
+	}
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	namespace = ""
+	subsystem = "cache"
+)
+
+var (
+	cacheLabels = []string{"cache"}
+
+	hitCnt = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "hits_total",
+		Help:      "Counter of values found in the cache.",
+	}, cacheLabels)
+
+	missCnt = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "misses_total",
+		Help:      "Counter of values not found in the cache.",
+	}, cacheLabels)
+
+	invalidationCnt = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "invalidations_total",
+		Help:      "Counter of invalidations received from any instance of the service.",
+	}, cacheLabels)
+
+	registerOnce sync.Once
+)
+
+func registerMetrics() {
+	registerOnce.Do(func() {
+		hitCnt = register(hitCnt, "hits_total").(*prometheus.CounterVec)
+		missCnt = register(missCnt, "misses_total").(*prometheus.CounterVec)
+		invalidationCnt = register(invalidationCnt, "invalidations_total").(*prometheus.CounterVec)
+	})
+}
+
+func register(c prometheus.Collector, name string) prometheus.Collector {
+	err := prometheus.Register(c)
+	if err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector
+		}
+		log.Panic(nil, map[string]interface{}{
+			"metric_name": prometheus.BuildFQName(namespace, subsystem, name),
+			"err":         err,
+		}, "failed to register the prometheus metric")
+	}
+	return c
+}
+
+func reportHit(name string) {
+	hitCnt.WithLabelValues(name).Inc()
+}
+
+func reportMiss(name string) {
+	missCnt.WithLabelValues(name).Inc()
+}
+
+func reportInvalidation(name string) {
+	invalidationCnt.WithLabelValues(name).Inc()
+}
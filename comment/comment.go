@@ -19,6 +19,15 @@ type Comment struct {
 	Creator         uuid.UUID   `sql:"type:uuid"` // Belongs To Identity
 	Body            string
 	Markup          string
+	// ResolvedAt is set when the thread started by this (top-level) comment
+	// was resolved
+	ResolvedAt *time.Time
+	ResolvedBy id.NullUUID `sql:"type:uuid"`
+}
+
+// IsResolved returns true if the thread started by this comment was resolved
+func (m Comment) IsResolved() bool {
+	return m.ResolvedAt != nil
 }
 
 // TableName overrides the table name settings in Gorm to force a specific table name
@@ -2,8 +2,10 @@ package comment
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/fabric8-services/fabric8-common/id"
 	"github.com/fabric8-services/fabric8-wit/closeable"
 
 	"github.com/fabric8-services/fabric8-wit/application/repository"
@@ -24,8 +26,27 @@ type Repository interface {
 	Save(ctx context.Context, comment *Comment, modifier uuid.UUID) error
 	Delete(ctx context.Context, commentID uuid.UUID, suppressor uuid.UUID) error
 	List(ctx context.Context, parent uuid.UUID, start *int, limit *int) ([]Comment, uint64, error)
+	// ListByResolution lists the comments of the given parent that belong to
+	// resolved (or unresolved) threads. Replies belong to the thread of their
+	// parent comment.
+	ListByResolution(ctx context.Context, parent uuid.UUID, resolved bool, start *int, limit *int) ([]Comment, uint64, error)
 	Load(ctx context.Context, id uuid.UUID) (*Comment, error)
 	Count(ctx context.Context, parentID uuid.UUID) (int, error)
+	// Resolve marks the thread started by the given top-level comment as
+	// resolved by the given identity.
+	Resolve(ctx context.Context, commentID uuid.UUID, resolver uuid.UUID) (*Comment, error)
+	// Reopen marks the thread started by the given top-level comment as
+	// unresolved again.
+	Reopen(ctx context.Context, commentID uuid.UUID, modifier uuid.UUID) (*Comment, error)
+	// AddReaction adds a reaction of the given identity with the given emoji
+	// to the comment. Adding the same reaction twice has no effect.
+	AddReaction(ctx context.Context, commentID uuid.UUID, identityID uuid.UUID, emoji string) error
+	// RemoveReaction removes the reaction of the given identity with the
+	// given emoji from the comment.
+	RemoveReaction(ctx context.Context, commentID uuid.UUID, identityID uuid.UUID, emoji string) error
+	// ListReactions returns the reactions on the given comments by comment
+	// ID, each in the order in which they were added.
+	ListReactions(ctx context.Context, commentIDs ...uuid.UUID) (map[uuid.UUID][]Reaction, error)
 }
 
 // NewRepository creates a new storage type.
@@ -133,8 +154,30 @@ func (m *GormCommentRepository) Delete(ctx context.Context, commentID uuid.UUID,
 // List all comments related to a single item
 func (m *GormCommentRepository) List(ctx context.Context, parentID uuid.UUID, start *int, limit *int) ([]Comment, uint64, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "comment", "query"}, time.Now())
+	return m.list(ctx, m.db.Model(&Comment{}).Where("parent_id = ?", parentID), start, limit)
+}
+
+// threadResolvedCondition matches the comments whose thread is resolved: the
+// top-level comments that are resolved themselves and the replies to them.
+const threadResolvedCondition = `COALESCE((SELECT t.resolved_at FROM comments t WHERE t.id = comments.parent_comment_id), comments.resolved_at) IS NOT NULL`
 
+// ListByResolution lists the comments of the given parent that belong to
+// resolved (or unresolved) threads. Replies belong to the thread of their
+// parent comment.
+func (m *GormCommentRepository) ListByResolution(ctx context.Context, parentID uuid.UUID, resolved bool, start *int, limit *int) ([]Comment, uint64, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "query"}, time.Now())
 	db := m.db.Model(&Comment{}).Where("parent_id = ?", parentID)
+	if resolved {
+		db = db.Where(threadResolvedCondition)
+	} else {
+		db = db.Where("NOT (" + threadResolvedCondition + ")")
+	}
+	return m.list(ctx, db, start, limit)
+}
+
+// list returns the page of comments matched by the given query together with
+// the total number of matching comments
+func (m *GormCommentRepository) list(ctx context.Context, db *gorm.DB, start *int, limit *int) ([]Comment, uint64, error) {
 	orgDB := db
 	if start != nil {
 		if *start < 0 {
@@ -238,3 +281,120 @@ func (m *GormCommentRepository) CheckExists(ctx context.Context, id uuid.UUID) e
 	defer goa.MeasureSince([]string{"goa", "db", "comment", "exists"}, time.Now())
 	return repository.CheckExists(ctx, m.db, Comment{}.TableName(), id)
 }
+
+// Resolve marks the thread started by the given top-level comment as resolved
+// by the given identity. Resolving a resolved thread has no effect.
+func (m *GormCommentRepository) Resolve(ctx context.Context, commentID uuid.UUID, resolverID uuid.UUID) (*Comment, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "resolve"}, time.Now())
+	c, err := m.Load(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c.ParentCommentID.Valid {
+		return nil, errors.NewBadParameterError("comment", commentID).Expected("top-level comment")
+	}
+	if c.IsResolved() {
+		return c, nil
+	}
+	now := time.Now()
+	c.ResolvedAt = &now
+	c.ResolvedBy = id.NullUUID{UUID: resolverID, Valid: true}
+	if err := m.db.Save(c).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to resolve comment %s", commentID))
+	}
+	if err := m.revisionRepository.Create(ctx, resolverID, RevisionTypeResolve, *c); err != nil {
+		return nil, errs.Wrapf(err, "error while resolving comment")
+	}
+	return c, nil
+}
+
+// Reopen marks the thread started by the given top-level comment as
+// unresolved again. Reopening an unresolved thread has no effect.
+func (m *GormCommentRepository) Reopen(ctx context.Context, commentID uuid.UUID, modifierID uuid.UUID) (*Comment, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "reopen"}, time.Now())
+	c, err := m.Load(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsResolved() {
+		return c, nil
+	}
+	c.ResolvedAt = nil
+	c.ResolvedBy = id.NullUUID{}
+	if err := m.db.Save(c).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to reopen comment %s", commentID))
+	}
+	if err := m.revisionRepository.Create(ctx, modifierID, RevisionTypeReopen, *c); err != nil {
+		return nil, errs.Wrapf(err, "error while reopening comment")
+	}
+	return c, nil
+}
+
+// AddReaction adds a reaction of the given identity with the given emoji to
+// the comment. Adding the same reaction twice has no effect.
+func (m *GormCommentRepository) AddReaction(ctx context.Context, commentID uuid.UUID, identityID uuid.UUID, emoji string) error {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "reaction", "add"}, time.Now())
+	if err := ValidateEmoji(emoji); err != nil {
+		return err
+	}
+	c, err := m.Load(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	db := m.db.Exec(fmt.Sprintf(`INSERT INTO "%s" (comment_id, identity_id, emoji) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`, Reaction{}.TableName()), commentID, identityID, emoji)
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to add reaction to comment %s", commentID))
+	}
+	if db.RowsAffected == 0 {
+		return nil
+	}
+	return m.reactionChanged(ctx, c, identityID, RevisionTypeReactionAdd, emoji)
+}
+
+// RemoveReaction removes the reaction of the given identity with the given
+// emoji from the comment.
+func (m *GormCommentRepository) RemoveReaction(ctx context.Context, commentID uuid.UUID, identityID uuid.UUID, emoji string) error {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "reaction", "remove"}, time.Now())
+	c, err := m.Load(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	db := m.db.Where("comment_id = ? AND identity_id = ? AND emoji = ?", commentID, identityID, emoji).Delete(&Reaction{})
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to remove reaction from comment %s", commentID))
+	}
+	if db.RowsAffected == 0 {
+		return errors.NewNotFoundError("reaction", emoji)
+	}
+	return m.reactionChanged(ctx, c, identityID, RevisionTypeReactionRemove, emoji)
+}
+
+// reactionChanged touches the comment so that cached representations of it
+// are invalidated and stores a revision for the added or removed reaction.
+func (m *GormCommentRepository) reactionChanged(ctx context.Context, c *Comment, identityID uuid.UUID, revisionType RevisionType, emoji string) error {
+	if err := m.db.Model(c).UpdateColumn("updated_at", time.Now()).Error; err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to update comment %s", c.ID))
+	}
+	if err := m.revisionRepository.CreateReaction(ctx, identityID, revisionType, *c, emoji); err != nil {
+		return errs.Wrapf(err, "error while storing the reaction on comment %s", c.ID)
+	}
+	return nil
+}
+
+// ListReactions returns the reactions on the given comments by comment ID,
+// each in the order in which they were added.
+func (m *GormCommentRepository) ListReactions(ctx context.Context, commentIDs ...uuid.UUID) (map[uuid.UUID][]Reaction, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "reaction", "list"}, time.Now())
+	res := map[uuid.UUID][]Reaction{}
+	if len(commentIDs) == 0 {
+		return res, nil
+	}
+	var reactions []Reaction
+	if err := m.db.Where("comment_id IN (?)", commentIDs).Order("created_at, emoji").Find(&reactions).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to list reactions"))
+	}
+	for _, r := range reactions {
+		res[r.CommentID] = append(res[r.CommentID], r)
+	}
+	return res, nil
+}
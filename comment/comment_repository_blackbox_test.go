@@ -258,3 +258,113 @@ func (s *TestCommentRepository) TestExistsComment() {
 		require.IsType(t, errors.NotFoundError{}, err)
 	})
 }
+
+func (s *TestCommentRepository) TestResolveAndReopen() {
+	// given a thread with a top-level comment and a reply
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Identities(2), tf.Comments(2, func(fxt *tf.TestFixture, idx int) error {
+		if idx == 1 {
+			fxt.Comments[idx].ParentCommentID = id.NullUUID{UUID: fxt.Comments[0].ID, Valid: true}
+		}
+		return nil
+	}))
+	thread := fxt.Comments[0]
+	reply := fxt.Comments[1]
+
+	s.T().Run("resolve", func(t *testing.T) {
+		// when
+		resolved, err := s.repo.Resolve(s.Ctx, thread.ID, fxt.Identities[1].ID)
+		// then
+		require.NoError(t, err)
+		assert.True(t, resolved.IsResolved())
+		loaded, err := s.repo.Load(s.Ctx, thread.ID)
+		require.NoError(t, err)
+		require.NotNil(t, loaded.ResolvedAt)
+		assert.Equal(t, id.NullUUID{UUID: fxt.Identities[1].ID, Valid: true}, loaded.ResolvedBy)
+		// resolving again has no effect
+		again, err := s.repo.Resolve(s.Ctx, thread.ID, fxt.Identities[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.Identities[1].ID, again.ResolvedBy.UUID)
+	})
+
+	s.T().Run("list by resolution", func(t *testing.T) {
+		// when
+		resolved, _, err := s.repo.ListByResolution(s.Ctx, thread.ParentID, true, nil, nil)
+		require.NoError(t, err)
+		unresolved, _, err := s.repo.ListByResolution(s.Ctx, thread.ParentID, false, nil, nil)
+		require.NoError(t, err)
+		// then the reply belongs to the resolved thread
+		require.Len(t, resolved, 2)
+		assert.Empty(t, unresolved)
+	})
+
+	s.T().Run("reply cannot be resolved", func(t *testing.T) {
+		_, err := s.repo.Resolve(s.Ctx, reply.ID, fxt.Identities[0].ID)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	s.T().Run("reopen", func(t *testing.T) {
+		// when
+		reopened, err := s.repo.Reopen(s.Ctx, thread.ID, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		assert.False(t, reopened.IsResolved())
+		assert.False(t, reopened.ResolvedBy.Valid)
+		unresolved, _, err := s.repo.ListByResolution(s.Ctx, thread.ParentID, false, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, unresolved, 2)
+	})
+
+	s.T().Run("unknown comment", func(t *testing.T) {
+		_, err := s.repo.Resolve(s.Ctx, uuid.NewV4(), fxt.Identities[0].ID)
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
+func (s *TestCommentRepository) TestReactions() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Identities(2), tf.Comments(2))
+	c := fxt.Comments[0]
+
+	s.T().Run("add", func(t *testing.T) {
+		require.NoError(t, s.repo.AddReaction(s.Ctx, c.ID, fxt.Identities[0].ID, "thumbsup"))
+		require.NoError(t, s.repo.AddReaction(s.Ctx, c.ID, fxt.Identities[1].ID, "thumbsup"))
+		require.NoError(t, s.repo.AddReaction(s.Ctx, c.ID, fxt.Identities[1].ID, "tada"))
+		// adding the same reaction twice has no effect
+		require.NoError(t, s.repo.AddReaction(s.Ctx, c.ID, fxt.Identities[1].ID, "tada"))
+		// when
+		reactions, err := s.repo.ListReactions(s.Ctx, c.ID, fxt.Comments[1].ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, reactions[c.ID], 3)
+		assert.Empty(t, reactions[fxt.Comments[1].ID])
+		summary := comment.Summarize(reactions[c.ID])
+		require.Len(t, summary, 2)
+		assert.Equal(t, "thumbsup", summary[0].Emoji)
+		assert.Equal(t, []uuid.UUID{fxt.Identities[0].ID, fxt.Identities[1].ID}, summary[0].IdentityIDs)
+		assert.Equal(t, "tada", summary[1].Emoji)
+	})
+
+	s.T().Run("invalid emoji", func(t *testing.T) {
+		for _, emoji := range []string{"", "thumbs up", "this-is-way-too-long-to-be-an-emoji"} {
+			err := s.repo.AddReaction(s.Ctx, c.ID, fxt.Identities[0].ID, emoji)
+			require.Error(t, err, emoji)
+			assert.IsType(t, errors.BadParameterError{}, err, emoji)
+		}
+	})
+
+	s.T().Run("remove", func(t *testing.T) {
+		require.NoError(t, s.repo.RemoveReaction(s.Ctx, c.ID, fxt.Identities[1].ID, "tada"))
+		reactions, err := s.repo.ListReactions(s.Ctx, c.ID)
+		require.NoError(t, err)
+		assert.Len(t, reactions[c.ID], 2)
+		// removing it again fails
+		err = s.repo.RemoveReaction(s.Ctx, c.ID, fxt.Identities[1].ID, "tada")
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+
+	s.T().Run("unknown comment", func(t *testing.T) {
+		err := s.repo.AddReaction(s.Ctx, uuid.NewV4(), fxt.Identities[0].ID, "thumbsup")
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
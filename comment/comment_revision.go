@@ -20,6 +20,14 @@ const (
 	_                  // ignore 3rd value
 	// RevisionTypeUpdate a comment update
 	RevisionTypeUpdate // 4
+	// RevisionTypeResolve a comment thread resolution
+	RevisionTypeResolve // 5
+	// RevisionTypeReopen a comment thread reopening
+	RevisionTypeReopen // 6
+	// RevisionTypeReactionAdd a reaction added to a comment
+	RevisionTypeReactionAdd // 7
+	// RevisionTypeReactionRemove a reaction removed from a comment
+	RevisionTypeReactionRemove // 8
 )
 
 // Revision represents a version of a comment
@@ -41,6 +49,9 @@ type Revision struct {
 	CommentBody *string `gorm:"column:comment_body"`
 	// the markup used to input the comment body (nil when comment was deleted)
 	CommentMarkup *string `gorm:"column:comment_markup"`
+	// the emoji of the reaction that was added or removed (nil for all other
+	// types of revisions)
+	CommentReaction *string `gorm:"column:comment_reaction"`
 }
 
 const (
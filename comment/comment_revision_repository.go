@@ -18,8 +18,14 @@ import (
 type RevisionRepository interface {
 	// Create stores a new revision for the given comment.
 	Create(ctx context.Context, modifierID uuid.UUID, revisionType RevisionType, comment Comment) error
+	// CreateReaction stores a new revision for a reaction that was added to or
+	// removed from the given comment.
+	CreateReaction(ctx context.Context, modifierID uuid.UUID, revisionType RevisionType, comment Comment, emoji string) error
 	// List retrieves all revisions for a given comment
 	List(ctx context.Context, workitemID uuid.UUID) ([]Revision, error)
+	// ListByParent retrieves the revisions of the given types for all
+	// comments of the given parent
+	ListByParent(ctx context.Context, parentID uuid.UUID, revisionTypes ...RevisionType) ([]Revision, error)
 }
 
 // NewRevisionRepository creates a GormCommentRevisionRepository
@@ -35,6 +41,16 @@ type GormCommentRevisionRepository struct {
 
 // Create stores a new revision for the given comment.
 func (r *GormCommentRevisionRepository) Create(ctx context.Context, modifierID uuid.UUID, revisionType RevisionType, c Comment) error {
+	return r.create(ctx, modifierID, revisionType, c, nil)
+}
+
+// CreateReaction stores a new revision for a reaction that was added to or
+// removed from the given comment.
+func (r *GormCommentRevisionRepository) CreateReaction(ctx context.Context, modifierID uuid.UUID, revisionType RevisionType, c Comment, emoji string) error {
+	return r.create(ctx, modifierID, revisionType, c, &emoji)
+}
+
+func (r *GormCommentRevisionRepository) create(ctx context.Context, modifierID uuid.UUID, revisionType RevisionType, c Comment, reaction *string) error {
 	log.Debug(nil, map[string]interface{}{
 		"modifier_id":   modifierID,
 		"revision_type": revisionType,
@@ -48,6 +64,7 @@ func (r *GormCommentRevisionRepository) Create(ctx context.Context, modifierID u
 		CommentParentID:  c.ParentID,
 		CommentBody:      &c.Body,
 		CommentMarkup:    &c.Markup,
+		CommentReaction:  reaction,
 	}
 	// if there is a valid parent comment id, add it the the stuct
 	if c.ParentCommentID.Valid == true {
@@ -77,3 +94,17 @@ func (r *GormCommentRevisionRepository) List(ctx context.Context, commentID uuid
 	}
 	return revisions, nil
 }
+
+// ListByParent retrieves the revisions of the given types for all comments of
+// the given parent
+func (r *GormCommentRevisionRepository) ListByParent(ctx context.Context, parentID uuid.UUID, revisionTypes ...RevisionType) ([]Revision, error) {
+	var revisions []Revision
+	db := r.db.Where("comment_parent_id = ?", parentID)
+	if len(revisionTypes) > 0 {
+		db = db.Where("revision_type IN (?)", revisionTypes)
+	}
+	if err := db.Order("revision_time asc").Find(&revisions).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to retrieve the comment revisions of %s", parentID))
+	}
+	return revisions, nil
+}
@@ -0,0 +1,64 @@
+package comment
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// maxEmojiLength is the maximum length of an emoji in characters, which allows
+// for short codes like "thumbsup" as well as for composed unicode emojis.
+const maxEmojiLength = 32
+
+// Reaction describes the reaction of an identity with an emoji on a comment
+type Reaction struct {
+	CreatedAt  time.Time
+	CommentID  uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	IdentityID uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	Emoji      string    `gorm:"primary_key"`
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (r Reaction) TableName() string {
+	return "comment_reactions"
+}
+
+// ValidateEmoji returns an error if the given emoji is empty, too long or
+// contains whitespace.
+func ValidateEmoji(emoji string) error {
+	if emoji == "" || utf8.RuneCountInString(emoji) > maxEmojiLength {
+		return errors.NewBadParameterError("emoji", emoji).Expected("between 1 and 32 characters")
+	}
+	if strings.IndexFunc(emoji, unicode.IsSpace) >= 0 {
+		return errors.NewBadParameterError("emoji", emoji).Expected("no whitespace")
+	}
+	return nil
+}
+
+// ReactionSummary sums up the reactions with one emoji on a comment
+type ReactionSummary struct {
+	Emoji       string
+	IdentityIDs []uuid.UUID
+}
+
+// Summarize groups the given reactions by their emoji in the order in which
+// each emoji was used first.
+func Summarize(reactions []Reaction) []ReactionSummary {
+	res := []ReactionSummary{}
+	idx := map[string]int{}
+	for _, r := range reactions {
+		i, ok := idx[r.Emoji]
+		if !ok {
+			i = len(res)
+			idx[r.Emoji] = i
+			res = append(res, ReactionSummary{Emoji: r.Emoji})
+		}
+		res[i].IdentityIDs = append(res[i].IdentityIDs, r.IdentityID)
+	}
+	return res
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -45,6 +46,19 @@ const (
 	varPostgresConnectionMaxIdle    = "postgres.connection.maxidle"
 	varPostgresConnectionMaxOpen    = "postgres.connection.maxopen"
 	varFeatureWorkitemRemote        = "feature.workitem.remote"
+	varIterationScheduleInterval    = "iteration.schedule.interval"
+	varTemplateMigrationInterval    = "template.migration.interval"
+	varTemplateMigrationBatchSize   = "template.migration.batchsize"
+	varCacheTTL                     = "cache.ttl"
+	varAttachmentsStorage           = "attachments.storage"
+	varAttachmentsFileSystemPath    = "attachments.filesystem.path"
+	varAttachmentsS3Endpoint        = "attachments.s3.endpoint"
+	varAttachmentsS3Bucket          = "attachments.s3.bucket"
+	varAttachmentsS3Region          = "attachments.s3.region"
+	varAttachmentsS3AccessKey       = "attachments.s3.accesskey"
+	varAttachmentsS3SecretKey       = "attachments.s3.secretkey"
+	varAttachmentsMaxSize           = "attachments.maxsize"
+	varAttachmentsSpaceQuota        = "attachments.space.quota"
 	varPopulateCommonTypes          = "populate.commontypes"
 	varHTTPAddress                  = "http.address"
 	varMetricsHTTPAddress           = "metrics.http.address"
@@ -85,6 +99,7 @@ const (
 	varCacheControlUsers             = "cachecontrol.users"
 	varCacheControlCollaborators     = "cachecontrol.collaborators"
 	varCacheControlSpaceTemplates    = "cachecontrol.spacetemplates"
+	varCacheControlAttachments       = "cachecontrol.attachments"
 
 	// cache control settings for a single resource
 	varCacheControlUser             = "cachecontrol.user"
@@ -98,6 +113,8 @@ const (
 	varCacheControlLabel            = "cachecontrol.label"
 	varCacheControlQuery            = "cachecontrol.query"
 	varCacheControlComment          = "cachecontrol.comment"
+	varCacheControlAttachment       = "cachecontrol.attachment"
+	varCacheControlWorklog          = "cachecontrol.worklog"
 	varCacheControlTrackerQueries   = "cachecontrol.trackerqueries"
 
 	defaultConfigFile           = "config.yaml"
@@ -229,6 +246,7 @@ func (c *Registry) setConfigDefaults() {
 	c.v.SetDefault(varCacheControlIterations, "max-age=2")
 	c.v.SetDefault(varCacheControlAreas, "max-age=2")
 	c.v.SetDefault(varCacheControlComments, "max-age=2")
+	c.v.SetDefault(varCacheControlAttachments, "max-age=2")
 	c.v.SetDefault(varCacheControlFilters, "max-age=86400")
 	c.v.SetDefault(varCacheControlUsers, "max-age=2")
 	c.v.SetDefault(varCacheControlCollaborators, "max-age=2")
@@ -243,6 +261,8 @@ func (c *Registry) setConfigDefaults() {
 	c.v.SetDefault(varCacheControlIteration, "private,max-age=2")
 	c.v.SetDefault(varCacheControlArea, "private,max-age=120")
 	c.v.SetDefault(varCacheControlComment, "private,max-age=120")
+	c.v.SetDefault(varCacheControlAttachment, "private,max-age=120")
+	c.v.SetDefault(varCacheControlWorklog, "private,max-age=120")
 	// data returned from '/api/user' must not be cached by intermediate proxies,
 	// but can only be kept in the client's local cache.
 	c.v.SetDefault(varCacheControlUser, "private,max-age=120")
@@ -250,6 +270,26 @@ func (c *Registry) setConfigDefaults() {
 	// Features
 	c.v.SetDefault(varFeatureWorkitemRemote, true)
 
+	// Interval in which the iterations of the iteration schedules are generated
+	c.v.SetDefault(varIterationScheduleInterval, time.Duration(time.Hour))
+
+	// Interval in which unfinished space template migrations are resumed and
+	// the number of work items or links they convert per transaction
+	c.v.SetDefault(varTemplateMigrationInterval, time.Duration(time.Minute))
+	c.v.SetDefault(varTemplateMigrationBatchSize, 100)
+
+	// Time after which cached work item types, work item link types and space
+	// templates expire
+	c.v.SetDefault(varCacheTTL, time.Duration(5*time.Minute))
+
+	// Where the content of attachments is stored: "filesystem" or "s3"
+	c.v.SetDefault(varAttachmentsStorage, "filesystem")
+	c.v.SetDefault(varAttachmentsS3Region, "us-east-1")
+	// Maximum size of a single attachment (10 MiB) and of all attachments of
+	// a space (1 GiB) in bytes
+	c.v.SetDefault(varAttachmentsMaxSize, 10*1024*1024)
+	c.v.SetDefault(varAttachmentsSpaceQuota, 1024*1024*1024)
+
 	c.v.SetDefault(varKeycloakTesUser2Name, defaultKeycloakTesUser2Name)
 	c.v.SetDefault(varOpenshiftTenantMasterURL, defaultOpenshiftTenantMasterURL)
 	c.v.SetDefault(varCheStarterURL, defaultCheStarterURL)
@@ -274,6 +314,88 @@ func (c *Registry) GetFeatureWorkitemRemote() bool {
 	return c.v.GetBool(varFeatureWorkitemRemote)
 }
 
+// GetIterationScheduleInterval returns the interval in which the iterations
+// of all iteration schedules are generated
+func (c *Registry) GetIterationScheduleInterval() time.Duration {
+	return c.v.GetDuration(varIterationScheduleInterval)
+}
+
+// GetCacheTTL returns the time after which cached work item types, work item
+// link types and space templates expire
+func (c *Registry) GetCacheTTL() time.Duration {
+	return c.v.GetDuration(varCacheTTL)
+}
+
+// GetAttachmentsStorage returns the kind of storage for the content of
+// attachments: "filesystem" or "s3"
+func (c *Registry) GetAttachmentsStorage() string {
+	return c.v.GetString(varAttachmentsStorage)
+}
+
+// GetAttachmentsFileSystemPath returns the directory in which the content of
+// attachments is stored when using the "filesystem" storage. Outside of the
+// developer mode there is no default because attachments must not end up in a
+// temporary directory, so an empty string is returned if it is not set.
+func (c *Registry) GetAttachmentsFileSystemPath() string {
+	if c.v.IsSet(varAttachmentsFileSystemPath) {
+		return c.v.GetString(varAttachmentsFileSystemPath)
+	}
+	if c.IsPostgresDeveloperModeEnabled() {
+		return filepath.Join(os.TempDir(), "fabric8-wit-attachments")
+	}
+	return ""
+}
+
+// GetAttachmentsS3Endpoint returns the URL of the S3-compatible object storage
+func (c *Registry) GetAttachmentsS3Endpoint() string {
+	return c.v.GetString(varAttachmentsS3Endpoint)
+}
+
+// GetAttachmentsS3Bucket returns the bucket in which the content of
+// attachments is stored when using the "s3" storage
+func (c *Registry) GetAttachmentsS3Bucket() string {
+	return c.v.GetString(varAttachmentsS3Bucket)
+}
+
+// GetAttachmentsS3Region returns the region of the S3 bucket
+func (c *Registry) GetAttachmentsS3Region() string {
+	return c.v.GetString(varAttachmentsS3Region)
+}
+
+// GetAttachmentsS3AccessKey returns the access key for the S3 bucket
+func (c *Registry) GetAttachmentsS3AccessKey() string {
+	return c.v.GetString(varAttachmentsS3AccessKey)
+}
+
+// GetAttachmentsS3SecretKey returns the secret key for the S3 bucket
+func (c *Registry) GetAttachmentsS3SecretKey() string {
+	return c.v.GetString(varAttachmentsS3SecretKey)
+}
+
+// GetAttachmentsMaxSize returns the maximum size of a single attachment in
+// bytes
+func (c *Registry) GetAttachmentsMaxSize() int64 {
+	return c.v.GetInt64(varAttachmentsMaxSize)
+}
+
+// GetAttachmentsSpaceQuota returns the maximum total size of the attachments
+// of a space in bytes
+func (c *Registry) GetAttachmentsSpaceQuota() int64 {
+	return c.v.GetInt64(varAttachmentsSpaceQuota)
+}
+
+// GetTemplateMigrationInterval returns the interval in which unfinished space
+// template migrations are resumed
+func (c *Registry) GetTemplateMigrationInterval() time.Duration {
+	return c.v.GetDuration(varTemplateMigrationInterval)
+}
+
+// GetTemplateMigrationBatchSize returns the number of work items or links a
+// space template migration converts per transaction
+func (c *Registry) GetTemplateMigrationBatchSize() int {
+	return c.v.GetInt(varTemplateMigrationBatchSize)
+}
+
 // GetPostgresUser returns the postgres user as set via default, config file, or environment variable
 func (c *Registry) GetPostgresUser() string {
 	return c.v.GetString(varPostgresUser)
@@ -534,6 +656,24 @@ func (c *Registry) GetCacheControlComment() string {
 	return c.v.GetString(varCacheControlComment)
 }
 
+// GetCacheControlAttachments returns the value to set in the "Cache-Control" HTTP response header
+// when returning a list of attachments.
+func (c *Registry) GetCacheControlAttachments() string {
+	return c.v.GetString(varCacheControlAttachments)
+}
+
+// GetCacheControlAttachment returns the value to set in the "Cache-Control" HTTP response header
+// when returning the metadata of an attachment.
+func (c *Registry) GetCacheControlAttachment() string {
+	return c.v.GetString(varCacheControlAttachment)
+}
+
+// GetCacheControlWorklog returns the value to set in the "Cache-Control" HTTP response header
+// when returning a single worklog.
+func (c *Registry) GetCacheControlWorklog() string {
+	return c.v.GetString(varCacheControlWorklog)
+}
+
 // GetCacheControlTrackerQueries returns the value to set in the "Cache-Control" HTTP response header
 // when returning a comment.
 func (c *Registry) GetCacheControlTrackerQueries() string {
@@ -215,3 +215,33 @@ func TestGetDeploymentsTimeoutDefault(t *testing.T) {
 	expectedTimeSeconds := time.Duration(30) * time.Second
 	assert.Equal(t, expectedTimeSeconds, viperValue)
 }
+
+func TestGetAttachmentsFileSystemPath(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+	envName := "F8_ATTACHMENTS_FILESYSTEM_PATH"
+	env, isSet := os.LookupEnv(envName)
+	defer func() {
+		if isSet {
+			os.Setenv(envName, env)
+		} else {
+			os.Unsetenv(envName)
+		}
+		resetConfiguration(defaultValuesConfigFilePath)
+	}()
+
+	t.Run("set", func(t *testing.T) {
+		os.Setenv(envName, "/var/lib/fabric8-wit/attachments")
+		resetConfiguration(defaultValuesConfigFilePath)
+		assert.Equal(t, "/var/lib/fabric8-wit/attachments", config.GetAttachmentsFileSystemPath())
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		os.Unsetenv(envName)
+		resetConfiguration(defaultValuesConfigFilePath)
+		if config.IsPostgresDeveloperModeEnabled() {
+			assert.NotEmpty(t, config.GetAttachmentsFileSystemPath())
+		} else {
+			assert.Empty(t, config.GetAttachmentsFileSystemPath())
+		}
+	})
+}
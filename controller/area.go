@@ -109,6 +109,9 @@ func (c *AreaController) CreateChild(ctx *app.CreateChildAreaContext) error {
 			Name:    *reqArea.Attributes.Name,
 		}
 		a.MakeChildOf(*parent)
+		if err := setAreaDefaults(ctx, appl, reqArea.Relationships, a); err != nil {
+			return err
+		}
 		return appl.Areas().Create(ctx, a)
 	})
 	if err != nil {
@@ -121,6 +124,110 @@ func (c *AreaController) CreateChild(ctx *app.CreateChildAreaContext) error {
 	return ctx.Created(result)
 }
 
+// Update runs the update action.
+func (c *AreaController) Update(ctx *app.UpdateAreaContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	id, err := uuid.FromString(ctx.ID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	reqArea := ctx.Payload.Data
+	if reqArea.Attributes.Version == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.version", nil).Expected("not nil"))
+	}
+	var a *area.Area
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		a, err = appl.Areas().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		s, err := appl.Spaces().Load(ctx, a.SpaceID)
+		if err != nil {
+			return err
+		}
+		if !uuid.Equal(*currentUser, s.OwnerID) {
+			log.Warn(ctx, map[string]interface{}{
+				"space_id":     s.ID,
+				"space_owner":  s.OwnerID,
+				"current_user": *currentUser,
+			}, "user is not the space owner")
+			return errors.NewForbiddenError("user is not the space owner")
+		}
+		a.Version = *reqArea.Attributes.Version
+		if reqArea.Attributes.Name != nil {
+			a.Name = *reqArea.Attributes.Name
+		}
+		if err := setAreaDefaults(ctx, appl, reqArea.Relationships, a); err != nil {
+			return err
+		}
+		a, err = appl.Areas().Save(ctx, *a)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.AreaSingle{
+		Data: ConvertArea(c.db, ctx.Request, *a, addResolvedPath),
+	})
+}
+
+// setAreaDefaults sets the owners and default labels of the given area from
+// the given relationships. A relationship that is not part of the request
+// leaves the area untouched while an empty one clears it. Owners have to be
+// existing identities and default labels have to belong to the area's space.
+func setAreaDefaults(ctx context.Context, appl application.Application, rel *app.AreaRelations, a *area.Area) error {
+	if rel == nil {
+		return nil
+	}
+	if rel.Owners != nil {
+		owners, err := areaRelationIDs("data.relationships.owners", rel.Owners)
+		if err != nil {
+			return err
+		}
+		for _, id := range owners {
+			if err := appl.Identities().CheckExists(ctx, id); err != nil {
+				return errors.NewBadParameterError("data.relationships.owners", id.String()).Expected("existing identity")
+			}
+		}
+		a.Owners = owners
+	}
+	if rel.DefaultLabels != nil {
+		labels, err := areaRelationIDs("data.relationships.default-labels", rel.DefaultLabels)
+		if err != nil {
+			return err
+		}
+		for _, id := range labels {
+			lbl, err := appl.Labels().Load(ctx, id)
+			if err != nil || !uuid.Equal(lbl.SpaceID, a.SpaceID) {
+				return errors.NewBadParameterError("data.relationships.default-labels", id.String()).Expected("label of the area's space")
+			}
+		}
+		a.DefaultLabels = labels
+	}
+	return nil
+}
+
+// areaRelationIDs returns the IDs of the given relationship without
+// duplicates.
+func areaRelationIDs(name string, rel *app.RelationGenericList) (area.IDs, error) {
+	ids := area.IDs{}
+	for _, d := range rel.Data {
+		if d == nil || d.ID == nil {
+			return nil, errors.NewBadParameterError(name+".data.id", nil).Expected("not nil")
+		}
+		id, err := uuid.FromString(*d.ID)
+		if err != nil {
+			return nil, errors.NewBadParameterError(name+".data.id", *d.ID).Expected("valid UUID")
+		}
+		ids = append(ids, id)
+	}
+	return ids.Unique(), nil
+}
+
 // Show runs the show action.
 func (c *AreaController) Show(ctx *app.ShowAreaContext) error {
 	id, err := uuid.FromString(ctx.ID)
@@ -231,6 +338,12 @@ func ConvertArea(db application.DB, request *http.Request, ar area.Area, options
 					Related: &childURL,
 				},
 			},
+			Owners: &app.RelationGenericList{
+				Data: ConvertUsersSimple(request, ar.Owners.Strings()),
+			},
+			DefaultLabels: &app.RelationGenericList{
+				Data: ConvertLabelsSimple(request, ar.DefaultLabels.Strings()),
+			},
 		},
 		Links: &app.GenericLinks{
 			Self:    &relatedURL,
@@ -307,6 +307,78 @@ func (rest *TestAreaREST) TestShowChildrenArea() {
 	})
 }
 
+func (rest *TestAreaREST) TestUpdateArea() {
+	rest.T().Run("OK", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, rest.DB, tf.Identities(2), tf.Areas(1), tf.Labels(1))
+		svc, ctrl := rest.SecuredControllerWithIdentity(fxt.Identities[0])
+		payload := newUpdateAreaPayload(*fxt.Areas[0], fxt.Identities[1].ID.String())
+		payload.Data.Attributes.Name = ptr.String("renamed")
+		payload.Data.Relationships.DefaultLabels = &app.RelationGenericList{
+			Data: []*app.GenericData{{ID: ptr.String(fxt.Labels[0].ID.String())}},
+		}
+		// when
+		_, updated := test.UpdateAreaOK(t, svc.Context, svc, ctrl, fxt.Areas[0].ID.String(), payload)
+		// then
+		assert.Equal(t, "renamed", *updated.Data.Attributes.Name)
+		assert.Equal(t, fxt.Areas[0].Version+1, *updated.Data.Attributes.Version)
+		require.Len(t, updated.Data.Relationships.Owners.Data, 1)
+		assert.Equal(t, fxt.Identities[1].ID.String(), *updated.Data.Relationships.Owners.Data[0].ID)
+		require.Len(t, updated.Data.Relationships.DefaultLabels.Data, 1)
+		assert.Equal(t, fxt.Labels[0].ID.String(), *updated.Data.Relationships.DefaultLabels.Data[0].ID)
+
+		t.Run("relationships not given are kept", func(t *testing.T) {
+			a, err := area.NewAreaRepository(rest.DB).Load(rest.Ctx, fxt.Areas[0].ID)
+			require.NoError(t, err)
+			payload := newUpdateAreaPayload(*a)
+			payload.Data.Relationships.Owners = nil
+			_, updated := test.UpdateAreaOK(t, svc.Context, svc, ctrl, a.ID.String(), payload)
+			assert.Equal(t, "renamed", *updated.Data.Attributes.Name)
+			require.Len(t, updated.Data.Relationships.Owners.Data, 1)
+			require.Len(t, updated.Data.Relationships.DefaultLabels.Data, 1)
+		})
+	})
+
+	rest.T().Run("Failure", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, rest.DB, tf.Identities(2), tf.Areas(1))
+		svc, ctrl := rest.SecuredControllerWithIdentity(fxt.Identities[0])
+
+		t.Run("not the space owner", func(t *testing.T) {
+			svc, ctrl := rest.SecuredControllerWithIdentity(fxt.Identities[1])
+			test.UpdateAreaForbidden(t, svc.Context, svc, ctrl, fxt.Areas[0].ID.String(), newUpdateAreaPayload(*fxt.Areas[0]))
+		})
+		t.Run("unknown owner", func(t *testing.T) {
+			test.UpdateAreaBadRequest(t, svc.Context, svc, ctrl, fxt.Areas[0].ID.String(), newUpdateAreaPayload(*fxt.Areas[0], uuid.NewV4().String()))
+		})
+		t.Run("label of another space", func(t *testing.T) {
+			other := tf.NewTestFixture(t, rest.DB, tf.Labels(1))
+			payload := newUpdateAreaPayload(*fxt.Areas[0])
+			payload.Data.Relationships.DefaultLabels = &app.RelationGenericList{
+				Data: []*app.GenericData{{ID: ptr.String(other.Labels[0].ID.String())}},
+			}
+			test.UpdateAreaBadRequest(t, svc.Context, svc, ctrl, fxt.Areas[0].ID.String(), payload)
+		})
+		t.Run("missing version", func(t *testing.T) {
+			payload := newUpdateAreaPayload(*fxt.Areas[0])
+			payload.Data.Attributes.Version = nil
+			test.UpdateAreaBadRequest(t, svc.Context, svc, ctrl, fxt.Areas[0].ID.String(), payload)
+		})
+		t.Run("version conflict", func(t *testing.T) {
+			payload := newUpdateAreaPayload(*fxt.Areas[0])
+			payload.Data.Attributes.Version = ptr.Int(fxt.Areas[0].Version + 1)
+			test.UpdateAreaConflict(t, svc.Context, svc, ctrl, fxt.Areas[0].ID.String(), payload)
+		})
+		t.Run("unknown area", func(t *testing.T) {
+			test.UpdateAreaNotFound(t, svc.Context, svc, ctrl, uuid.NewV4().String(), newUpdateAreaPayload(*fxt.Areas[0]))
+		})
+		t.Run("unauthorized", func(t *testing.T) {
+			svc, ctrl := rest.UnSecuredController()
+			test.UpdateAreaUnauthorized(t, svc.Context, svc, ctrl, fxt.Areas[0].ID.String(), newUpdateAreaPayload(*fxt.Areas[0]))
+		})
+	})
+}
+
 func ConvertAreaToModel(appArea app.AreaSingle) area.Area {
 	return area.Area{
 		ID:      *appArea.Data.ID,
@@ -328,3 +400,22 @@ func newCreateChildAreaPayload(name string) *app.CreateChildAreaPayload {
 		},
 	}
 }
+
+func newUpdateAreaPayload(a area.Area, ownerIDs ...string) *app.UpdateAreaPayload {
+	owners := make([]*app.GenericData, len(ownerIDs))
+	for i := range ownerIDs {
+		owners[i] = &app.GenericData{ID: &ownerIDs[i]}
+	}
+	return &app.UpdateAreaPayload{
+		Data: &app.Area{
+			Type: area.APIStringTypeAreas,
+			ID:   &a.ID,
+			Attributes: &app.AreaAttributes{
+				Version: &a.Version,
+			},
+			Relationships: &app.AreaRelations{
+				Owners: &app.RelationGenericList{Data: owners},
+			},
+		},
+	}
+}
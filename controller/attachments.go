@@ -0,0 +1,360 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/attachment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AttachmentsController implements the attachments resource.
+type AttachmentsController struct {
+	*goa.Controller
+	db      application.DB
+	storage attachment.BlobStorage
+	config  AttachmentsControllerConfiguration
+}
+
+// AttachmentsControllerConfiguration the configuration for the AttachmentsController
+type AttachmentsControllerConfiguration interface {
+	GetCacheControlAttachment() string
+}
+
+// NewAttachmentsController creates an attachments controller.
+func NewAttachmentsController(service *goa.Service, db application.DB, storage attachment.BlobStorage, config AttachmentsControllerConfiguration) *AttachmentsController {
+	return &AttachmentsController{
+		Controller: service.NewController("AttachmentsController"),
+		db:         db,
+		storage:    storage,
+		config:     config,
+	}
+}
+
+// Show runs the show action.
+func (c *AttachmentsController) Show(ctx *app.ShowAttachmentsContext) error {
+	var a *attachment.Attachment
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		a, err = appl.Attachments().Load(ctx, ctx.AttachmentID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.ConditionalRequest(*a, c.config.GetCacheControlAttachment, func() error {
+		return ctx.OK(&app.AttachmentSingle{
+			Data: ConvertAttachment(ctx.Request, *a),
+		})
+	})
+}
+
+// Download runs the download action.
+func (c *AttachmentsController) Download(ctx *app.DownloadAttachmentsContext) error {
+	var a *attachment.Attachment
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		a, err = appl.Attachments().Load(ctx, ctx.AttachmentID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	content, err := c.storage.Get(ctx, a.StorageKey)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":           err,
+			"attachment_id": a.ID,
+		}, "failed to load the content of the attachment")
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	defer content.Close()
+	disposition := "attachment"
+	if isInlineContentType(a.ContentType) {
+		disposition = "inline"
+	}
+	h := ctx.ResponseData.Header()
+	h.Set("Content-Type", a.ContentType)
+	h.Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	h.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.FileName}))
+	// never let browsers guess a different (e.g. executable) content type
+	h.Set("X-Content-Type-Options", "nosniff")
+	ctx.ResponseData.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(ctx.ResponseData, content); err != nil {
+		// the status has already been sent, so all we can do is to log
+		log.Error(ctx, map[string]interface{}{
+			"err":           err,
+			"attachment_id": a.ID,
+		}, "failed to send the content of the attachment")
+	}
+	return nil
+}
+
+// Delete runs the delete action.
+func (c *AttachmentsController) Delete(ctx *app.DeleteAttachmentsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	var a *attachment.Attachment
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		a, err = appl.Attachments().Load(ctx, ctx.AttachmentID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	// User is allowed to delete if user is the uploader of the attachment OR
+	// user is a space collaborator
+	if !uuid.Equal(a.UploaderID, *identityID) {
+		authorized, err := authz.Authorize(ctx, a.SpaceID.String())
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+		}
+		if !authorized {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is neither the uploader of the attachment nor a space collaborator"))
+		}
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		return appl.Attachments().Delete(ctx, a.ID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if err := c.storage.Delete(ctx, a.StorageKey); err != nil {
+		// the metadata is gone, so the orphaned content is not reachable
+		// anymore and doesn't count against the quota
+		log.Error(ctx, map[string]interface{}{
+			"err":           err,
+			"attachment_id": a.ID,
+			"storage_key":   a.StorageKey,
+		}, "failed to delete the content of the attachment")
+	}
+	return ctx.OK([]byte{})
+}
+
+// AttachmentUploadConfiguration the configuration for uploading attachments
+type AttachmentUploadConfiguration interface {
+	GetAttachmentsMaxSize() int64
+	GetAttachmentsSpaceQuota() int64
+}
+
+// uploadAttachment stores the body of the given request as a new attachment
+// of the given work item and (optional) comment. Only space collaborators can
+// attach files to work items. The content is put into the blob storage before
+// the metadata is created, and removed again if the metadata cannot be
+// created.
+func uploadAttachment(ctx context.Context, db application.DB, storage attachment.BlobStorage, config AttachmentUploadConfiguration, req *http.Request, fileName string, workItemID uuid.UUID, commentID id.NullUUID) (*attachment.Attachment, error) {
+	uploaderID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return nil, goa.ErrUnauthorized(err.Error())
+	}
+	// only keep the base name of files uploaded with their full path
+	fileName = strings.TrimSpace(path.Base(strings.Replace(fileName, "\\", "/", -1)))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, errors.NewBadParameterError("filename", fileName).Expected("name of a file")
+	}
+	contentType := "application/octet-stream"
+	if mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil {
+		contentType = mime.FormatMediaType(mediaType, params)
+	}
+	maxSize := config.GetAttachmentsMaxSize()
+	if req.ContentLength > maxSize {
+		return nil, errors.NewBadParameterError("size", req.ContentLength).Expected(fmt.Sprintf("at most %d bytes", maxSize))
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(req.Body, maxSize+1))
+	if err != nil {
+		return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("failed to read the uploaded file: %s", err))
+	}
+	if int64(len(content)) > maxSize {
+		return nil, errors.NewBadParameterError("size", len(content)).Expected(fmt.Sprintf("at most %d bytes", maxSize))
+	}
+
+	a := &attachment.Attachment{
+		WorkItemID:  workItemID,
+		CommentID:   commentID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		UploaderID:  *uploaderID,
+	}
+	quota := config.GetAttachmentsSpaceQuota()
+	// fail early instead of uploading content that will be rejected anyway
+	err = application.Transactional(db, func(appl application.Application) error {
+		wi, err := appl.WorkItems().LoadByID(ctx, workItemID)
+		if err != nil {
+			return err
+		}
+		a.SpaceID = wi.SpaceID
+		usage, err := appl.Attachments().SpaceUsage(ctx, wi.SpaceID)
+		if err != nil {
+			return err
+		}
+		if usage+a.Size > quota {
+			return errors.NewForbiddenError(fmt.Sprintf("the attachments of space %s must not exceed %d bytes (%d bytes used)", wi.SpaceID, quota, usage))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// attachments of comments can be uploaded by everybody who can comment
+	if !commentID.Valid {
+		authorized, err := authz.Authorize(ctx, a.SpaceID.String())
+		if err != nil {
+			return nil, errors.NewUnauthorizedError(err.Error())
+		}
+		if !authorized {
+			return nil, errors.NewForbiddenError("user is not a space collaborator")
+		}
+	}
+	a.StorageKey = attachment.NewStorageKey(a.SpaceID)
+	if err := storage.Put(ctx, a.StorageKey, bytes.NewReader(content), a.Size, a.ContentType); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":          err,
+			"work_item_id": workItemID,
+		}, "failed to store the content of the attachment")
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to store the uploaded file"))
+	}
+	err = application.Transactional(db, func(appl application.Application) error {
+		return appl.Attachments().Create(ctx, a, quota)
+	})
+	if err != nil {
+		if delErr := storage.Delete(ctx, a.StorageKey); delErr != nil {
+			log.Error(ctx, map[string]interface{}{
+				"err":         delErr,
+				"storage_key": a.StorageKey,
+			}, "failed to delete the content of the rejected attachment")
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// isInlineContentType returns true for the content types that browsers may
+// display inline, i.e. images except for SVG which may contain scripts.
+func isInlineContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "image/") && mediaType != "image/svg+xml"
+}
+
+// attachmentContentHref returns the relative URL of the content of the
+// attachment with the given ID.
+func attachmentContentHref(attachmentID uuid.UUID) string {
+	return app.AttachmentsHref(attachmentID) + "/content"
+}
+
+// attachmentMarkdown returns a Markdown snippet that references the given
+// attachment: images are embedded, all other files are linked. The URL is
+// relative so that it works on all hosts serving the API.
+func attachmentMarkdown(a attachment.Attachment) string {
+	name := strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(a.FileName)
+	link := fmt.Sprintf("[%s](%s)", name, attachmentContentHref(a.ID))
+	if isInlineContentType(a.ContentType) {
+		return "!" + link
+	}
+	return link
+}
+
+// ConvertAttachments converts between internal and external REST representation
+func ConvertAttachments(request *http.Request, attachments []attachment.Attachment) []*app.Attachment {
+	res := make([]*app.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		res = append(res, ConvertAttachment(request, a))
+	}
+	return res
+}
+
+// ConvertAttachment converts between internal and external REST representation
+func ConvertAttachment(request *http.Request, a attachment.Attachment) *app.Attachment {
+	selfURL := rest.AbsoluteURL(request, app.AttachmentsHref(a.ID))
+	contentURL := rest.AbsoluteURL(request, attachmentContentHref(a.ID))
+	workItemURL := rest.AbsoluteURL(request, app.WorkitemHref(a.WorkItemID))
+	spaceURL := rest.AbsoluteURL(request, app.SpaceHref(a.SpaceID))
+	uploaderURL := rest.AbsoluteURL(request, fmt.Sprintf("%s/%s", usersEndpoint, a.UploaderID))
+	res := &app.Attachment{
+		Type: attachment.APIStringTypeAttachments,
+		ID:   a.ID,
+		Attributes: &app.AttachmentAttributes{
+			Filename:    ptr.String(a.FileName),
+			ContentType: ptr.String(a.ContentType),
+			Size:        ptr.Int(int(a.Size)),
+			Markdown:    ptr.String(attachmentMarkdown(a)),
+			CreatedAt:   ptr.Time(a.CreatedAt.UTC()),
+		},
+		Relationships: &app.AttachmentRelations{
+			Workitem: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APIStringTypeWorkItem),
+					ID:   ptr.String(a.WorkItemID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &workItemURL,
+					Related: &workItemURL,
+				},
+			},
+			Space: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(space.SpaceType),
+					ID:   ptr.String(a.SpaceID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &spaceURL,
+					Related: &spaceURL,
+				},
+			},
+			Uploader: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APIStringTypeUser),
+					ID:   ptr.String(a.UploaderID.String()),
+				},
+				Links: &app.GenericLinks{
+					Related: &uploaderURL,
+				},
+			},
+		},
+		Links: &app.AttachmentLinks{
+			Self:    &selfURL,
+			Related: &contentURL,
+		},
+	}
+	if a.CommentID.Valid {
+		commentURL := rest.AbsoluteURL(request, app.CommentsHref(a.CommentID.UUID))
+		res.Relationships.Comment = &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: ptr.String(APIStringTypeComments),
+				ID:   ptr.String(a.CommentID.UUID.String()),
+			},
+			Links: &app.GenericLinks{
+				Self:    &commentURL,
+				Related: &commentURL,
+			},
+		}
+	}
+	return res
+}
@@ -0,0 +1,252 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	"github.com/fabric8-services/fabric8-wit/attachment"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type attachmentsTestConfig struct {
+	maxSize int64
+	quota   int64
+}
+
+func (c attachmentsTestConfig) GetAttachmentsMaxSize() int64       { return c.maxSize }
+func (c attachmentsTestConfig) GetAttachmentsSpaceQuota() int64    { return c.quota }
+func (c attachmentsTestConfig) GetCacheControlAttachments() string { return "max-age=2" }
+func (c attachmentsTestConfig) GetCacheControlAttachment() string  { return "max-age=2" }
+
+type TestAttachmentsREST struct {
+	gormtestsupport.DBTestSuite
+	dir     string
+	storage attachment.BlobStorage
+	config  attachmentsTestConfig
+}
+
+func TestRunAttachmentsREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestAttachmentsREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *TestAttachmentsREST) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	dir, err := ioutil.TempDir("", "attachments")
+	require.NoError(s.T(), err)
+	s.dir = dir
+	s.storage, err = attachment.NewFileSystemStorage(dir)
+	require.NoError(s.T(), err)
+	s.config = attachmentsTestConfig{maxSize: 1024, quota: 2048}
+}
+
+func (s *TestAttachmentsREST) TearDownTest() {
+	os.RemoveAll(s.dir)
+	s.DBTestSuite.TearDownTest()
+}
+
+// upload sends the given content to the upload action of the work item
+// attachments (or comment attachments if commentID is not nil) and returns the
+// recorded response.
+func (s *TestAttachmentsREST) upload(t *testing.T, svc *goa.Service, wiID uuid.UUID, commentID *uuid.UUID, fileName, contentType string, content []byte) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	prms := url.Values{"filename": {fileName}}
+	var path string
+	if commentID != nil {
+		path = fmt.Sprintf("/api/comments/%s/attachments", *commentID)
+		prms["commentId"] = []string{commentID.String()}
+	} else {
+		path = fmt.Sprintf("/api/workitems/%s/attachments", wiID)
+		prms["wiID"] = []string{wiID.String()}
+	}
+	req, err := http.NewRequest("POST", path+"?"+url.Values{"filename": {fileName}}.Encode(), bytes.NewReader(content))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	goaCtx := goa.NewContext(goa.WithAction(svc.Context, "AttachmentsTest"), rw, req, prms)
+	if commentID != nil {
+		ctx, err := app.NewUploadCommentAttachmentsContext(goaCtx, req, svc)
+		require.NoError(t, err)
+		require.NoError(t, NewCommentAttachmentsController(svc, s.GormDB, s.storage, s.config).Upload(ctx))
+	} else {
+		ctx, err := app.NewUploadWorkItemAttachmentsContext(goaCtx, req, svc)
+		require.NoError(t, err)
+		require.NoError(t, NewWorkItemAttachmentsController(svc, s.GormDB, s.storage, s.config).Upload(ctx))
+	}
+	return rw
+}
+
+func (s *TestAttachmentsREST) download(t *testing.T, svc *goa.Service, attachmentID uuid.UUID) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/api/attachments/%s/content", attachmentID), nil)
+	require.NoError(t, err)
+	prms := url.Values{"attachmentID": {attachmentID.String()}}
+	goaCtx := goa.NewContext(goa.WithAction(svc.Context, "AttachmentsTest"), rw, req, prms)
+	ctx, err := app.NewDownloadAttachmentsContext(goaCtx, req, svc)
+	require.NoError(t, err)
+	require.NoError(t, NewAttachmentsController(svc, s.GormDB, s.storage, s.config).Download(ctx))
+	return rw
+}
+
+func decodeAttachment(t *testing.T, rw *httptest.ResponseRecorder) app.AttachmentSingle {
+	var res app.AttachmentSingle
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &res))
+	require.NotNil(t, res.Data)
+	return res
+}
+
+func (s *TestAttachmentsREST) TestUploadToWorkItem() {
+	s.T().Run("ok", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[0], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		content := []byte("not really a PNG")
+		rw := s.upload(t, svc, fxt.WorkItems[0].ID, nil, "C:\\Users\\me\\screen shot.png", "image/png", content)
+		require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		res := decodeAttachment(t, rw)
+		assert.Equal(t, "screen shot.png", *res.Data.Attributes.Filename)
+		assert.Equal(t, "image/png", *res.Data.Attributes.ContentType)
+		assert.Equal(t, len(content), *res.Data.Attributes.Size)
+		href := fmt.Sprintf("/api/attachments/%s/content", res.Data.ID)
+		assert.Equal(t, fmt.Sprintf("![screen shot.png](%s)", href), *res.Data.Attributes.Markdown)
+		assert.True(t, strings.HasSuffix(*res.Data.Links.Related, href))
+		assert.Equal(t, fxt.WorkItems[0].ID.String(), *res.Data.Relationships.Workitem.Data.ID)
+		assert.Equal(t, fxt.Spaces[0].ID.String(), *res.Data.Relationships.Space.Data.ID)
+		assert.Equal(t, fxt.Identities[0].ID.String(), *res.Data.Relationships.Uploader.Data.ID)
+		assert.Nil(t, res.Data.Relationships.Comment)
+		assert.True(t, strings.HasSuffix(rw.Header().Get("Location"), fmt.Sprintf("/api/attachments/%s", res.Data.ID)))
+
+		_, list := test.ListWorkItemAttachmentsOK(t, svc.Context, svc, NewWorkItemAttachmentsController(svc, s.GormDB, s.storage, s.config), fxt.WorkItems[0].ID, nil, nil)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, res.Data.ID, list.Data[0].ID)
+
+		_, shown := test.ShowAttachmentsOK(t, svc.Context, svc, NewAttachmentsController(svc, s.GormDB, s.storage, s.config), res.Data.ID, nil, nil)
+		assert.Equal(t, res.Data.ID, shown.Data.ID)
+
+		dl := s.download(t, svc, res.Data.ID)
+		require.Equal(t, http.StatusOK, dl.Code)
+		assert.Equal(t, content, dl.Body.Bytes())
+		assert.Equal(t, "image/png", dl.Header().Get("Content-Type"))
+		assert.Equal(t, "nosniff", dl.Header().Get("X-Content-Type-Options"))
+		assert.True(t, strings.HasPrefix(dl.Header().Get("Content-Disposition"), "inline"))
+	})
+
+	s.T().Run("other files are downloaded as attachment", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[0], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		rw := s.upload(t, svc, fxt.WorkItems[0].ID, nil, "drawing.svg", "image/svg+xml", []byte("<svg/>"))
+		require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		res := decodeAttachment(t, rw)
+		assert.Equal(t, fmt.Sprintf("[drawing.svg](/api/attachments/%s/content)", res.Data.ID), *res.Data.Attributes.Markdown)
+		dl := s.download(t, svc, res.Data.ID)
+		require.Equal(t, http.StatusOK, dl.Code)
+		assert.Equal(t, `attachment; filename=drawing.svg`, dl.Header().Get("Content-Disposition"))
+	})
+
+	s.T().Run("file too large", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[0], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		rw := s.upload(t, svc, fxt.WorkItems[0].ID, nil, "big.bin", "application/octet-stream", make([]byte, s.config.maxSize+1))
+		assert.Equal(t, http.StatusBadRequest, rw.Code, rw.Body.String())
+	})
+
+	s.T().Run("quota exceeded", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[0], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		for i := 0; i < 2; i++ {
+			rw := s.upload(t, svc, fxt.WorkItems[0].ID, nil, "file.bin", "application/octet-stream", make([]byte, s.config.maxSize))
+			require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		}
+		rw := s.upload(t, svc, fxt.WorkItems[0].ID, nil, "file.bin", "application/octet-stream", []byte("x"))
+		assert.Equal(t, http.StatusForbidden, rw.Code, rw.Body.String())
+	})
+
+	s.T().Run("unknown work item", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(1))
+		svc := testsupport.ServiceAsUser("Attachments-Service", *fxt.Identities[0])
+		rw := s.upload(t, svc, uuid.NewV4(), nil, "file.txt", "text/plain", []byte("x"))
+		assert.Equal(t, http.StatusNotFound, rw.Code, rw.Body.String())
+	})
+
+	s.T().Run("unauthorized", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := goa.New("Attachments-Service")
+		rw := s.upload(t, svc, fxt.WorkItems[0].ID, nil, "file.txt", "text/plain", []byte("x"))
+		assert.Equal(t, http.StatusUnauthorized, rw.Code, rw.Body.String())
+	})
+
+	s.T().Run("not a space collaborator", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		svc := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[1], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		rw := s.upload(t, svc, fxt.WorkItems[0].ID, nil, "file.txt", "text/plain", []byte("x"))
+		assert.Equal(t, http.StatusForbidden, rw.Code)
+		_, list := test.ListWorkItemAttachmentsOK(t, svc.Context, svc, NewWorkItemAttachmentsController(svc, s.GormDB, s.storage, s.config), fxt.WorkItems[0].ID, nil, nil)
+		assert.Empty(t, list.Data)
+	})
+}
+
+func (s *TestAttachmentsREST) TestUploadToComment() {
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Comments(1))
+	svc := testsupport.ServiceAsUser("Attachments-Service", *fxt.Identities[0])
+	rw := s.upload(s.T(), svc, uuid.Nil, &fxt.Comments[0].ID, "log.txt", "text/plain; charset=utf-8", []byte("some log"))
+	require.Equal(s.T(), http.StatusCreated, rw.Code, rw.Body.String())
+	res := decodeAttachment(s.T(), rw)
+	require.NotNil(s.T(), res.Data.Relationships.Comment)
+	assert.Equal(s.T(), fxt.Comments[0].ID.String(), *res.Data.Relationships.Comment.Data.ID)
+	assert.Equal(s.T(), fxt.Comments[0].ParentID.String(), *res.Data.Relationships.Workitem.Data.ID)
+	assert.Equal(s.T(), "text/plain; charset=utf-8", *res.Data.Attributes.ContentType)
+
+	_, list := test.ListCommentAttachmentsOK(s.T(), svc.Context, svc, NewCommentAttachmentsController(svc, s.GormDB, s.storage, s.config), fxt.Comments[0].ID, nil, nil)
+	require.Len(s.T(), list.Data, 1)
+	assert.Equal(s.T(), res.Data.ID, list.Data[0].ID)
+	// attachments of comments are not listed for the work item itself
+	_, list = test.ListWorkItemAttachmentsOK(s.T(), svc.Context, svc, NewWorkItemAttachmentsController(svc, s.GormDB, s.storage, s.config), fxt.Comments[0].ParentID, nil, nil)
+	assert.Empty(s.T(), list.Data)
+}
+
+func (s *TestAttachmentsREST) TestDelete() {
+	s.T().Run("by uploader", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[0], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		res := decodeAttachment(t, s.upload(t, svc, fxt.WorkItems[0].ID, nil, "file.txt", "text/plain", []byte("x")))
+		ctrl := NewAttachmentsController(svc, s.GormDB, s.storage, s.config)
+		test.DeleteAttachmentsOK(t, svc.Context, svc, ctrl, res.Data.ID)
+		test.ShowAttachmentsNotFound(t, svc.Context, svc, ctrl, res.Data.ID, nil, nil)
+		// the content cannot be downloaded anymore
+		dl := s.download(t, svc, res.Data.ID)
+		assert.Equal(t, http.StatusNotFound, dl.Code)
+	})
+
+	s.T().Run("by other user", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		uploader := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[0], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		res := decodeAttachment(t, s.upload(t, uploader, fxt.WorkItems[0].ID, nil, "file.txt", "text/plain", []byte("x")))
+		other := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[1], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		test.DeleteAttachmentsForbidden(t, other.Context, other, NewAttachmentsController(other, s.GormDB, s.storage, s.config), res.Data.ID)
+	})
+
+	s.T().Run("by space collaborator", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		uploader := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[0], &TestSpaceAuthzService{owner: *fxt.Identities[0]})
+		res := decodeAttachment(t, s.upload(t, uploader, fxt.WorkItems[0].ID, nil, "file.txt", "text/plain", []byte("x")))
+		collaborator := testsupport.ServiceAsSpaceUser("Attachments-Service", *fxt.Identities[1], &TestSpaceAuthzService{owner: *fxt.Identities[0], userIDs: fxt.Identities[1].ID.String()})
+		test.DeleteAttachmentsOK(t, collaborator.Context, collaborator, NewAttachmentsController(collaborator, s.GormDB, s.storage, s.config), res.Data.ID)
+	})
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeBurndowns is the JSONAPI type of a burndown
+const APIStringTypeBurndowns = "burndowns"
+
+// BurndownController implements the burndown resource.
+type BurndownController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewBurndownController creates a burndown controller.
+func NewBurndownController(service *goa.Service, db application.DB) *BurndownController {
+	return &BurndownController{
+		Controller: service.NewController("BurndownController"),
+		db:         db,
+	}
+}
+
+// Show returns the burndown of an iteration and its child iterations.
+func (c *BurndownController) Show(ctx *app.ShowBurndownContext) error {
+	id, err := uuid.FromString(ctx.IterationID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	var field string
+	if ctx.Field != nil {
+		field = *ctx.Field
+	}
+	var b *workitem.Burndown
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		itr, err := appl.Iterations().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		if itr.StartAt == nil || itr.EndAt == nil {
+			return errors.NewBadParameterError("iteration", id).Expected("an iteration with a start and an end date")
+		}
+		now := time.Now()
+		if now.Before(*itr.StartAt) {
+			// nothing happened in an iteration that hasn't started yet
+			b = &workitem.Burndown{Start: itr.StartAt.UTC(), End: itr.EndAt.UTC(), Field: field, Days: []workitem.BurndownDay{}}
+			return nil
+		}
+		end := *itr.EndAt
+		if now.Before(end) {
+			end = now
+		}
+		children, err := appl.Iterations().LoadChildren(ctx, id)
+		if err != nil {
+			return err
+		}
+		iterationIDs := []uuid.UUID{id}
+		for _, child := range children {
+			iterationIDs = append(iterationIDs, child.ID)
+		}
+		b, err = appl.WorkItemRevisions().Burndown(ctx, iterationIDs, field, *itr.StartAt, end)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.BurndownSingle{
+		Data: ConvertBurndown(ctx.Request, id, *b),
+	})
+}
+
+// ConvertBurndown converts a burndown from model to app representation.
+func ConvertBurndown(request *http.Request, iterationID uuid.UUID, b workitem.Burndown) *app.Burndown {
+	selfURL := rest.AbsoluteURL(request, app.BurndownHref(iterationID))
+	res := &app.Burndown{
+		Type: APIStringTypeBurndowns,
+		ID:   iterationID,
+		Attributes: &app.BurndownAttributes{
+			Start: b.Start,
+			End:   b.End,
+			Days:  make([]*app.BurndownDay, len(b.Days)),
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+	if b.Field != "" {
+		res.Attributes.Field = ptr.String(b.Field)
+	}
+	for i, d := range b.Days {
+		res.Attributes.Days[i] = &app.BurndownDay{
+			Date:        d.Date,
+			Open:        d.Open,
+			Closed:      d.Closed,
+			OpenValue:   d.OpenValue,
+			ClosedValue: d.ClosedValue,
+			Added:       d.Added,
+			Removed:     d.Removed,
+		}
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestBurndownREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunBurndownREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestBurndownREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (rest *TestBurndownREST) TestShow() {
+	start := time.Now().Add(-48 * time.Hour)
+	end := time.Now().Add(48 * time.Hour)
+	futureStart := time.Now().Add(24 * time.Hour)
+	futureEnd := time.Now().Add(72 * time.Hour)
+	fxt := tf.NewTestFixture(rest.T(), rest.DB,
+		tf.Iterations(3, func(fxt *tf.TestFixture, idx int) error {
+			switch idx {
+			case 0:
+				fxt.Iterations[idx].StartAt = &start
+				fxt.Iterations[idx].EndAt = &end
+			case 2:
+				fxt.Iterations[idx].StartAt = &futureStart
+				fxt.Iterations[idx].EndAt = &futureEnd
+			}
+			return nil
+		}),
+		tf.WorkItems(2, tf.SetWorkItemTitles("A", "B"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.Iterations[0].ID.String()
+			return nil
+		}),
+	)
+	// close work item "A"
+	wi := *fxt.WorkItemByTitle("A")
+	wi.Fields[workitem.SystemState] = workitem.SystemStateClosed
+	_, _, err := workitem.NewWorkItemRepository(rest.DB).Save(rest.Ctx, wi.SpaceID, wi, fxt.Identities[0].ID)
+	require.NoError(rest.T(), err)
+
+	svc := testsupport.ServiceAsUser("Burndown-Service", *fxt.Identities[0])
+	ctrl := NewBurndownController(svc, rest.GormDB)
+
+	rest.T().Run("ok", func(t *testing.T) {
+		_, b := test.ShowBurndownOK(t, svc.Context, svc, ctrl, fxt.Iterations[0].ID.String(), nil)
+		require.NotNil(t, b.Data.Attributes)
+		assert.Equal(t, fxt.Iterations[0].ID, b.Data.ID)
+		require.NotEmpty(t, b.Data.Attributes.Days)
+		today := b.Data.Attributes.Days[len(b.Data.Attributes.Days)-1]
+		assert.Equal(t, 1, today.Open)
+		assert.Equal(t, 1, today.Closed)
+		assert.Equal(t, 2, today.Added)
+		assert.Equal(t, 0, b.Data.Attributes.Days[0].Open)
+	})
+	rest.T().Run("iteration without dates", func(t *testing.T) {
+		test.ShowBurndownBadRequest(t, svc.Context, svc, ctrl, fxt.Iterations[1].ID.String(), nil)
+	})
+	rest.T().Run("iteration not started yet", func(t *testing.T) {
+		_, b := test.ShowBurndownOK(t, svc.Context, svc, ctrl, fxt.Iterations[2].ID.String(), nil)
+		require.NotNil(t, b.Data.Attributes)
+		assert.Empty(t, b.Data.Attributes.Days)
+	})
+	rest.T().Run("not found", func(t *testing.T) {
+		test.ShowBurndownNotFound(t, svc.Context, svc, ctrl, uuid.NewV4().String(), nil)
+	})
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/attachment"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CommentAttachmentsController implements the comment_attachments resource.
+type CommentAttachmentsController struct {
+	*goa.Controller
+	db      application.DB
+	storage attachment.BlobStorage
+	config  CommentAttachmentsControllerConfiguration
+}
+
+// CommentAttachmentsControllerConfiguration configuration for the CommentAttachmentsController
+type CommentAttachmentsControllerConfiguration interface {
+	AttachmentUploadConfiguration
+	GetCacheControlAttachments() string
+}
+
+// NewCommentAttachmentsController creates a comment_attachments controller.
+func NewCommentAttachmentsController(service *goa.Service, db application.DB, storage attachment.BlobStorage, config CommentAttachmentsControllerConfiguration) *CommentAttachmentsController {
+	return &CommentAttachmentsController{
+		Controller: service.NewController("CommentAttachmentsController"),
+		db:         db,
+		storage:    storage,
+		config:     config,
+	}
+}
+
+// List runs the list action.
+func (c *CommentAttachmentsController) List(ctx *app.ListCommentAttachmentsContext) error {
+	var attachments []attachment.Attachment
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Comments().CheckExists(ctx, ctx.CommentID); err != nil {
+			return err
+		}
+		var err error
+		attachments, err = appl.Attachments().ListByComment(ctx, ctx.CommentID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.ConditionalEntities(attachments, c.config.GetCacheControlAttachments, func() error {
+		return ctx.OK(&app.AttachmentList{
+			Data: ConvertAttachments(ctx.Request, attachments),
+		})
+	})
+}
+
+// Upload runs the upload action.
+func (c *CommentAttachmentsController) Upload(ctx *app.UploadCommentAttachmentsContext) error {
+	var workItemID uuid.UUID
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		cmt, err := appl.Comments().Load(ctx, ctx.CommentID)
+		if err != nil {
+			return err
+		}
+		// comments can only be attached to work items for now
+		workItemID = cmt.ParentID
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	a, err := uploadAttachment(ctx, c.db, c.storage, c.config, ctx.Request, ctx.Filename, workItemID, id.NullUUID{UUID: ctx.CommentID, Valid: true})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.AttachmentsHref(a.ID)))
+	return ctx.Created(&app.AttachmentSingle{
+		Data: ConvertAttachment(ctx.Request, *a),
+	})
+}
@@ -71,13 +71,10 @@ func (c *CommentsController) Show(ctx *app.ShowCommentsContext) error {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
 	return ctx.ConditionalRequest(*cmt, c.config.GetCacheControlComment, func() error {
-		res := &app.CommentSingle{}
-		// This code should change if others type of parents than WI are allowed
-		includeParentWorkItem := CommentIncludeParentWorkItem(ctx, cmt)
-		res.Data = ConvertComment(
-			ctx.Request,
-			*cmt,
-			includeParentWorkItem)
+		res, err := c.convertCommentWithReactions(ctx, ctx.Request, cmt)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
 		return ctx.OK(res)
 	})
 }
@@ -172,6 +169,145 @@ func (c *CommentsController) Delete(ctx *app.DeleteCommentsContext) error {
 	return ctx.OK([]byte{})
 }
 
+// Resolve marks a top-level comment thread as resolved
+func (c *CommentsController) Resolve(ctx *app.ResolveCommentsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	// User is allowed to resolve if user is creator of the comment OR user is a space collaborator
+	if err := c.authorizeThreadModification(ctx, ctx.CommentID, *identityID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var cm *comment.Comment
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		cm, err = appl.Comments().Resolve(ctx, ctx.CommentID, *identityID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res, err := c.convertCommentWithReactions(ctx, ctx.Request, cm)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	c.notification.Send(ctx, notification.NewCommentUpdated(cm.ID.String()))
+	return ctx.OK(res)
+}
+
+// Reopen reopens a resolved top-level comment thread
+func (c *CommentsController) Reopen(ctx *app.ReopenCommentsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	// User is allowed to reopen if user is creator of the comment OR user is a space collaborator
+	if err := c.authorizeThreadModification(ctx, ctx.CommentID, *identityID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var cm *comment.Comment
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		cm, err = appl.Comments().Reopen(ctx, ctx.CommentID, *identityID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res, err := c.convertCommentWithReactions(ctx, ctx.Request, cm)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	c.notification.Send(ctx, notification.NewCommentUpdated(cm.ID.String()))
+	return ctx.OK(res)
+}
+
+// AddReaction adds a reaction of the current user to a comment
+func (c *CommentsController) AddReaction(ctx *app.AddReactionCommentsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	var cm *comment.Comment
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Comments().AddReaction(ctx, ctx.CommentID, *identityID, ctx.Emoji); err != nil {
+			return err
+		}
+		cm, err = appl.Comments().Load(ctx, ctx.CommentID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res, err := c.convertCommentWithReactions(ctx, ctx.Request, cm)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(res)
+}
+
+// RemoveReaction removes a reaction of the current user from a comment
+func (c *CommentsController) RemoveReaction(ctx *app.RemoveReactionCommentsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	var cm *comment.Comment
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Comments().RemoveReaction(ctx, ctx.CommentID, *identityID, ctx.Emoji); err != nil {
+			return err
+		}
+		cm, err = appl.Comments().Load(ctx, ctx.CommentID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res, err := c.convertCommentWithReactions(ctx, ctx.Request, cm)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(res)
+}
+
+// authorizeThreadModification returns an error if the given identity is
+// neither the creator of the comment nor a collaborator of the space of the
+// commented work item.
+func (c *CommentsController) authorizeThreadModification(ctx context.Context, commentID, identityID uuid.UUID) error {
+	_, wi, userIsCreator, err := c.loadComment(ctx, commentID, identityID)
+	if err != nil {
+		return err
+	}
+	if userIsCreator {
+		return nil
+	}
+	authorized, err := authz.Authorize(ctx, wi.SpaceID.String())
+	if err != nil {
+		return errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return errors.NewForbiddenError("user is not a space collaborator")
+	}
+	return nil
+}
+
+// convertCommentWithReactions loads the reactions on the given comment and
+// converts it including its parent work item and reactions.
+func (c *CommentsController) convertCommentWithReactions(ctx context.Context, request *http.Request, cm *comment.Comment) (*app.CommentSingle, error) {
+	var reactions map[uuid.UUID][]comment.Reaction
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		reactions, err = appl.Comments().ListReactions(ctx, cm.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// This code should change if others type of parents than WI are allowed
+	return &app.CommentSingle{
+		Data: ConvertComment(request, *cm, CommentIncludeParentWorkItem(ctx, cm), CommentIncludeReactions(reactions)),
+	}, nil
+}
+
 // CommentConvertFunc is a open ended function to add additional links/data/relations to a Comment during
 // conversion from internal to API
 type CommentConvertFunc func(*http.Request, *comment.Comment, *app.Comment)
@@ -252,6 +388,21 @@ func ConvertComment(request *http.Request, comment comment.Comment, additional .
 				ID:   ptr.String(comment.ParentCommentID.UUID.String()),
 			},
 		}
+	} else {
+		c.Attributes.Resolved = ptr.Bool(comment.IsResolved())
+		c.Attributes.ResolvedAt = comment.ResolvedAt
+	}
+	if comment.ResolvedBy.Valid {
+		relatedResolverLink := rest.AbsoluteURL(request, fmt.Sprintf("%s/%s", usersEndpoint, comment.ResolvedBy.UUID))
+		c.Relationships.ResolvedBy = &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: ptr.String(APIStringTypeUser),
+				ID:   ptr.String(comment.ResolvedBy.UUID.String()),
+				Links: &app.GenericLinks{
+					Related: &relatedResolverLink,
+				},
+			},
+		}
 	}
 	for _, add := range additional {
 		add(request, &comment, c)
@@ -272,6 +423,21 @@ func CommentIncludeParentWorkItem(ctx context.Context, c *comment.Comment) Comme
 	}
 }
 
+// CommentIncludeReactions adds the summarized reactions from the given map
+// of reactions by comment ID to the comment
+func CommentIncludeReactions(reactions map[uuid.UUID][]comment.Reaction) CommentConvertFunc {
+	return func(request *http.Request, cm *comment.Comment, data *app.Comment) {
+		data.Attributes.Reactions = []*app.CommentReaction{}
+		for _, summary := range comment.Summarize(reactions[cm.ID]) {
+			data.Attributes.Reactions = append(data.Attributes.Reactions, &app.CommentReaction{
+				Emoji:      summary.Emoji,
+				Count:      len(summary.IdentityIDs),
+				Identities: summary.IdentityIDs,
+			})
+		}
+	}
+}
+
 // CommentIncludeParent adds the "parent" relationship to this Comment
 func CommentIncludeParent(request *http.Request, comment *comment.Comment, data *app.Comment, href HrefFunc, parentType string) {
 	data.Relationships.Parent = &app.RelationGeneric{
@@ -410,6 +410,81 @@ func (s *CommentsSuite) TestOtherCollaboratorCanDelete() {
 	test.DeleteCommentsOK(s.T(), svcWithCollaborator1.Context, svcWithCollaborator1, commentCtrl, fxt.Comments[0].ID)
 }
 
+func (s *CommentsSuite) TestCreatorCanResolveAndReopen() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(1))
+	wiID := fxt.WorkItems[0].ID
+	c := s.createWorkItemComment(s.testIdentity, wiID, "body", &plaintextMarkup, nil)
+	userSvc, _, _, workitemCommentsCtrl, commentsCtrl := s.securedControllers(s.testIdentity)
+	// when
+	_, resolved := test.ResolveCommentsOK(s.T(), userSvc.Context, userSvc, commentsCtrl, *c.Data.ID)
+	// then
+	require.NotNil(s.T(), resolved.Data.Attributes.Resolved)
+	assert.True(s.T(), *resolved.Data.Attributes.Resolved)
+	require.NotNil(s.T(), resolved.Data.Attributes.ResolvedAt)
+	require.NotNil(s.T(), resolved.Data.Relationships.ResolvedBy)
+	assert.Equal(s.T(), s.testIdentity.ID.String(), *resolved.Data.Relationships.ResolvedBy.Data.ID)
+	resolvedFilter := true
+	_, list := test.ListWorkItemCommentsOK(s.T(), userSvc.Context, userSvc, workitemCommentsCtrl, wiID, &resolvedFilter, nil, nil, nil, nil)
+	require.Len(s.T(), list.Data, 1)
+	assert.Equal(s.T(), *c.Data.ID, *list.Data[0].ID)
+	// when
+	_, reopened := test.ReopenCommentsOK(s.T(), userSvc.Context, userSvc, commentsCtrl, *c.Data.ID)
+	// then
+	assert.False(s.T(), *reopened.Data.Attributes.Resolved)
+	assert.Nil(s.T(), reopened.Data.Relationships.ResolvedBy)
+	_, list = test.ListWorkItemCommentsOK(s.T(), userSvc.Context, userSvc, workitemCommentsCtrl, wiID, &resolvedFilter, nil, nil, nil, nil)
+	assert.Empty(s.T(), list.Data)
+}
+
+func (s *CommentsSuite) TestNonCollaboratorCanNotResolve() {
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Identities(2), tf.Spaces(1), tf.WorkItems(1), tf.Comments(1))
+	svcNotAuthorized := testsupport.ServiceAsSpaceUser("Collaborators-Service", *fxt.Identities[1], &TestSpaceAuthzService{*fxt.Identities[0], ""})
+	commentsCtrlNotAuthorized := NewCommentsController(svcNotAuthorized, s.GormDB, s.Configuration)
+	test.ResolveCommentsForbidden(s.T(), svcNotAuthorized.Context, svcNotAuthorized, commentsCtrlNotAuthorized, fxt.Comments[0].ID)
+}
+
+func (s *CommentsSuite) TestCanNotResolveReply() {
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(1))
+	wiID := fxt.WorkItems[0].ID
+	c := s.createWorkItemComment(s.testIdentity, wiID, "body", &plaintextMarkup, nil)
+	reply := s.createWorkItemComment(s.testIdentity, wiID, "reply", &plaintextMarkup, c.Data.ID)
+	userSvc, _, _, _, commentsCtrl := s.securedControllers(s.testIdentity)
+	test.ResolveCommentsBadRequest(s.T(), userSvc.Context, userSvc, commentsCtrl, *reply.Data.ID)
+}
+
+func (s *CommentsSuite) TestReactions() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(1))
+	c := s.createWorkItemComment(s.testIdentity, fxt.WorkItems[0].ID, "body", &plaintextMarkup, nil)
+	userSvc, _, _, _, commentsCtrl := s.securedControllers(s.testIdentity)
+
+	s.T().Run("add", func(t *testing.T) {
+		_, result := test.AddReactionCommentsOK(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, "thumbsup")
+		require.Len(t, result.Data.Attributes.Reactions, 1)
+		assert.Equal(t, "thumbsup", result.Data.Attributes.Reactions[0].Emoji)
+		assert.Equal(t, 1, result.Data.Attributes.Reactions[0].Count)
+		assert.Equal(t, []uuid.UUID{s.testIdentity.ID}, result.Data.Attributes.Reactions[0].Identities)
+		_, shown := test.ShowCommentsOK(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, nil, nil)
+		assert.Len(t, shown.Data.Attributes.Reactions, 1)
+	})
+
+	s.T().Run("invalid emoji", func(t *testing.T) {
+		test.AddReactionCommentsBadRequest(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, "thumbs up")
+	})
+
+	s.T().Run("remove", func(t *testing.T) {
+		_, result := test.RemoveReactionCommentsOK(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, "thumbsup")
+		assert.Empty(t, result.Data.Attributes.Reactions)
+		test.RemoveReactionCommentsNotFound(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, "thumbsup")
+	})
+
+	s.T().Run("unauthorized", func(t *testing.T) {
+		svc, ctrl := s.unsecuredController()
+		test.AddReactionCommentsUnauthorized(t, svc.Context, svc, ctrl, *c.Data.ID, "thumbsup")
+	})
+}
+
 // Following test creates a space and 2 identities.
 // Identity 1 creates is the space owner and creates a comment on a workitem.
 // Test if identity 2 ( which is not a space collaborator) can edit/update the comment
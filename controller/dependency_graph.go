@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeDependencyGraphs is the JSONAPI type of a dependency graph
+const APIStringTypeDependencyGraphs = "dependencygraphs"
+
+// DependencyGraphController implements the dependency_graph resource.
+type DependencyGraphController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewDependencyGraphController creates a dependency_graph controller.
+func NewDependencyGraphController(service *goa.Service, db application.DB) *DependencyGraphController {
+	return &DependencyGraphController{
+		Controller: service.NewController("DependencyGraphController"),
+		db:         db,
+	}
+}
+
+// Show returns the dependency graph of a space or of one of its iterations.
+func (c *DependencyGraphController) Show(ctx *app.ShowDependencyGraphContext) error {
+	var weightField string
+	if ctx.Weight != nil {
+		weightField = *ctx.Weight
+	}
+	var g *link.DependencyGraph
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return err
+		}
+		if ctx.Iteration != nil {
+			itr, err := appl.Iterations().Load(ctx, *ctx.Iteration)
+			if err != nil {
+				return err
+			}
+			if itr.SpaceID != ctx.SpaceID {
+				return errors.NewNotFoundError("iteration", ctx.Iteration.String())
+			}
+		}
+		var err error
+		g, err = appl.WorkItemLinks().DependencyGraph(ctx, ctx.SpaceID, ctx.Iteration, weightField)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	graphID := ctx.SpaceID
+	if ctx.Iteration != nil {
+		graphID = *ctx.Iteration
+	}
+	return ctx.OK(&app.DependencyGraphSingle{
+		Data: ConvertDependencyGraph(ctx.Request, ctx.SpaceID, graphID, *g),
+	})
+}
+
+// ConvertDependencyGraph converts a dependency graph from model to app
+// representation.
+func ConvertDependencyGraph(request *http.Request, spaceID, graphID uuid.UUID, g link.DependencyGraph) *app.DependencyGraph {
+	selfURL := rest.AbsoluteURL(request, app.DependencyGraphHref(spaceID))
+	res := &app.DependencyGraph{
+		Type: APIStringTypeDependencyGraphs,
+		ID:   graphID,
+		Attributes: &app.DependencyGraphAttributes{
+			Nodes:              make([]*app.DependencyGraphNode, len(g.Nodes)),
+			Edges:              make([]*app.DependencyGraphEdge, len(g.Edges)),
+			CriticalPath:       g.CriticalPath,
+			CriticalPathWeight: g.CriticalPathWeight,
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+	for i, n := range g.Nodes {
+		blockedBy := n.BlockedBy
+		if blockedBy == nil {
+			blockedBy = []uuid.UUID{}
+		}
+		res.Attributes.Nodes[i] = &app.DependencyGraphNode{
+			ID:        n.ID,
+			Number:    n.Number,
+			Title:     n.Title,
+			State:     n.State,
+			Weight:    n.Weight,
+			Blocked:   n.Blocked,
+			BlockedBy: blockedBy,
+		}
+	}
+	for i, e := range g.Edges {
+		res.Attributes.Edges[i] = &app.DependencyGraphEdge{
+			LinkID:     e.LinkID,
+			LinkTypeID: e.LinkTypeID,
+			Source:     e.SourceID,
+			Target:     e.TargetID,
+		}
+	}
+	return res
+}
@@ -0,0 +1,68 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestDependencyGraphREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunDependencyGraphREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestDependencyGraphREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (rest *TestDependencyGraphREST) TestShow() {
+	fxt := tf.NewTestFixture(rest.T(), rest.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.Spaces(2),
+		tf.Iterations(1),
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyDependency)),
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.LinkChain("A", "B", "C")...)),
+	)
+	svc := testsupport.ServiceAsUser("DependencyGraph-Service", *fxt.Identities[0])
+	ctrl := NewDependencyGraphController(svc, rest.GormDB)
+
+	rest.T().Run("ok", func(t *testing.T) {
+		_, g := test.ShowDependencyGraphOK(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, nil, nil)
+		require.NotNil(t, g.Data.Attributes)
+		assert.Equal(t, fxt.Spaces[0].ID, g.Data.ID)
+		require.Len(t, g.Data.Attributes.Nodes, 3)
+		require.Len(t, g.Data.Attributes.Edges, 2)
+		for _, n := range g.Data.Attributes.Nodes {
+			assert.Equal(t, n.Title != "A", n.Blocked, "blocked state of %s", n.Title)
+		}
+		assert.Equal(t, []uuid.UUID{
+			fxt.WorkItemByTitle("A").ID,
+			fxt.WorkItemByTitle("B").ID,
+			fxt.WorkItemByTitle("C").ID,
+		}, g.Data.Attributes.CriticalPath)
+		assert.Equal(t, 3.0, g.Data.Attributes.CriticalPathWeight)
+	})
+	rest.T().Run("not found", func(t *testing.T) {
+		t.Run("space", func(t *testing.T) {
+			test.ShowDependencyGraphNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), nil, nil)
+		})
+		t.Run("iteration", func(t *testing.T) {
+			iterationID := uuid.NewV4()
+			test.ShowDependencyGraphNotFound(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, &iterationID, nil)
+		})
+		t.Run("iteration of another space", func(t *testing.T) {
+			test.ShowDependencyGraphNotFound(t, svc.Context, svc, ctrl, fxt.Spaces[1].ID, &fxt.Iterations[0].ID, nil)
+		})
+	})
+}
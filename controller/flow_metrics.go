@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeFlowMetrics is the JSONAPI type of flow metrics
+const APIStringTypeFlowMetrics = "flowmetrics"
+
+// defaultFlowMetricsRange is the date range for which flow metrics are
+// computed if neither the request nor the iteration specify one.
+const defaultFlowMetricsRange = 30 * 24 * time.Hour
+
+// FlowMetricsController implements the flow_metrics resource.
+type FlowMetricsController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewFlowMetricsController creates a flow_metrics controller.
+func NewFlowMetricsController(service *goa.Service, db application.DB) *FlowMetricsController {
+	return &FlowMetricsController{
+		Controller: service.NewController("FlowMetricsController"),
+		db:         db,
+	}
+}
+
+// Show returns the flow metrics of a space or of one of its iterations.
+func (c *FlowMetricsController) Show(ctx *app.ShowFlowMetricsContext) error {
+	var m *workitem.FlowMetrics
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		end := time.Now()
+		var start time.Time
+		if ctx.Iteration != nil {
+			itr, err := appl.Iterations().Load(ctx, *ctx.Iteration)
+			if err != nil {
+				return err
+			}
+			if itr.SpaceID != ctx.SpaceID {
+				return errors.NewNotFoundError("iteration", ctx.Iteration.String())
+			}
+			if itr.StartAt != nil && end.Before(*itr.StartAt) && ctx.Start == nil && ctx.End == nil {
+				// nothing happened in an iteration that hasn't started yet
+				m = &workitem.FlowMetrics{Start: itr.StartAt.UTC(), End: itr.StartAt.UTC()}
+				if itr.EndAt != nil {
+					m.End = itr.EndAt.UTC()
+				}
+				return nil
+			}
+			if itr.EndAt != nil && itr.EndAt.Before(end) {
+				end = *itr.EndAt
+			}
+			if itr.StartAt != nil {
+				start = *itr.StartAt
+			}
+		}
+		if ctx.End != nil {
+			end = *ctx.End
+		}
+		if ctx.Start != nil {
+			start = *ctx.Start
+		}
+		if start.IsZero() {
+			start = end.Add(-defaultFlowMetricsRange)
+		}
+		var columns []workitem.BoardColumn
+		if ctx.Board != nil {
+			b, err := appl.Boards().Load(ctx, *ctx.Board)
+			if err != nil {
+				return err
+			}
+			if b.SpaceTemplateID != s.SpaceTemplateID || (b.SpaceID != nil && *b.SpaceID != s.ID) {
+				return errors.NewNotFoundError("work item board", ctx.Board.String())
+			}
+			columns = b.Columns
+		}
+		m, err = appl.WorkItemRevisions().FlowMetrics(ctx, ctx.SpaceID, ctx.Iteration, columns, start, end)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	metricsID := ctx.SpaceID
+	if ctx.Iteration != nil {
+		metricsID = *ctx.Iteration
+	}
+	return ctx.OK(&app.FlowMetricsSingle{
+		Data: ConvertFlowMetrics(ctx.Request, ctx.SpaceID, metricsID, *m),
+	})
+}
+
+// ConvertFlowMetrics converts flow metrics from model to app representation.
+func ConvertFlowMetrics(request *http.Request, spaceID, metricsID uuid.UUID, m workitem.FlowMetrics) *app.FlowMetrics {
+	selfURL := rest.AbsoluteURL(request, app.FlowMetricsHref(spaceID))
+	res := &app.FlowMetrics{
+		Type: APIStringTypeFlowMetrics,
+		ID:   metricsID,
+		Attributes: &app.FlowMetricsAttributes{
+			Start:          m.Start,
+			End:            m.End,
+			Dates:          m.Dates,
+			CumulativeFlow: make([]*app.FlowSeries, len(m.CumulativeFlow)),
+			LeadTimes:      convertFlowTimeStats(m.LeadTimes),
+			CycleTimes:     convertFlowTimeStats(m.CycleTimes),
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+	if res.Attributes.Dates == nil {
+		res.Attributes.Dates = []time.Time{}
+	}
+	for i, s := range m.CumulativeFlow {
+		res.Attributes.CumulativeFlow[i] = &app.FlowSeries{
+			Key:    s.Key,
+			Name:   s.Name,
+			Counts: s.Counts,
+		}
+	}
+	return res
+}
+
+func convertFlowTimeStats(stats []workitem.FlowTimeStats) []*app.FlowTimeStats {
+	res := make([]*app.FlowTimeStats, len(stats))
+	for i, s := range stats {
+		res[i] = &app.FlowTimeStats{
+			WorkItemType: s.WorkItemTypeID,
+			Count:        s.Count,
+			Mean:         s.Mean,
+			P50:          s.P50,
+			P75:          s.P75,
+			P85:          s.P85,
+			P95:          s.P95,
+		}
+	}
+	return res
+}
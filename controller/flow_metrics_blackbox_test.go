@@ -0,0 +1,99 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestFlowMetricsREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunFlowMetricsREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestFlowMetricsREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (rest *TestFlowMetricsREST) TestShow() {
+	fxt := tf.NewTestFixture(rest.T(), rest.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+		tf.WorkItemBoards(1),
+	)
+	// close work item "A"
+	wi := *fxt.WorkItemByTitle("A")
+	wi.Fields[workitem.SystemState] = workitem.SystemStateClosed
+	_, _, err := workitem.NewWorkItemRepository(rest.DB).Save(rest.Ctx, wi.SpaceID, wi, fxt.Identities[0].ID)
+	require.NoError(rest.T(), err)
+
+	svc := testsupport.ServiceAsUser("FlowMetrics-Service", *fxt.Identities[0])
+	ctrl := NewFlowMetricsController(svc, rest.GormDB)
+
+	rest.T().Run("per state", func(t *testing.T) {
+		_, m := test.ShowFlowMetricsOK(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, nil, nil, nil, nil)
+		require.NotNil(t, m.Data.Attributes)
+		assert.Equal(t, fxt.Spaces[0].ID, m.Data.ID)
+		require.NotEmpty(t, m.Data.Attributes.Dates)
+		states := map[string]int{}
+		for _, s := range m.Data.Attributes.CumulativeFlow {
+			states[s.Key] = s.Counts[len(s.Counts)-1]
+		}
+		assert.Equal(t, 1, states[workitem.SystemStateClosed])
+		require.Len(t, m.Data.Attributes.LeadTimes, 1)
+		assert.Equal(t, fxt.WorkItemTypes[0].ID, m.Data.Attributes.LeadTimes[0].WorkItemType)
+		assert.Equal(t, 1, m.Data.Attributes.LeadTimes[0].Count)
+		require.Len(t, m.Data.Attributes.CycleTimes, 1)
+	})
+	rest.T().Run("per board column", func(t *testing.T) {
+		_, m := test.ShowFlowMetricsOK(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, &fxt.WorkItemBoards[0].ID, nil, nil, nil)
+		require.Len(t, m.Data.Attributes.CumulativeFlow, len(fxt.WorkItemBoards[0].Columns))
+		for i, s := range m.Data.Attributes.CumulativeFlow {
+			assert.Equal(t, fxt.WorkItemBoards[0].Columns[i].ID.String(), s.Key)
+		}
+	})
+	rest.T().Run("bad date range", func(t *testing.T) {
+		end := time.Now()
+		start := end.Add(400 * 24 * time.Hour)
+		test.ShowFlowMetricsBadRequest(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, nil, &end, nil, &start)
+		start = end.Add(-400 * 24 * time.Hour)
+		test.ShowFlowMetricsBadRequest(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, nil, &end, nil, &start)
+	})
+	rest.T().Run("iteration not started yet", func(t *testing.T) {
+		future := tf.NewTestFixture(t, rest.DB, tf.Iterations(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Iterations[idx].StartAt = ptr.Time(time.Now().Add(7 * 24 * time.Hour))
+			fxt.Iterations[idx].EndAt = ptr.Time(time.Now().Add(21 * 24 * time.Hour))
+			return nil
+		}))
+		_, m := test.ShowFlowMetricsOK(t, svc.Context, svc, ctrl, future.Spaces[0].ID, nil, nil, &future.Iterations[0].ID, nil)
+		assert.Equal(t, future.Iterations[0].ID, m.Data.ID)
+		assert.Empty(t, m.Data.Attributes.Dates)
+		assert.Empty(t, m.Data.Attributes.CumulativeFlow)
+		assert.Empty(t, m.Data.Attributes.LeadTimes)
+	})
+	rest.T().Run("not found", func(t *testing.T) {
+		t.Run("space", func(t *testing.T) {
+			test.ShowFlowMetricsNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), nil, nil, nil, nil)
+		})
+		t.Run("iteration", func(t *testing.T) {
+			iterationID := uuid.NewV4()
+			test.ShowFlowMetricsNotFound(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, nil, nil, &iterationID, nil)
+		})
+		t.Run("board", func(t *testing.T) {
+			boardID := uuid.NewV4()
+			test.ShowFlowMetricsNotFound(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, &boardID, nil, nil, nil)
+		})
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/space/authz"
@@ -119,6 +120,9 @@ func (c *IterationController) CreateChild(ctx *app.CreateChildIterationContext)
 		EndAt:       reqItr.Attributes.EndAt,
 		UserActive:  *reqItr.Attributes.UserActive,
 	}
+	if err := applyIterationCapacity(reqItr.Attributes, itr); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
 	if reqItr.ID != nil {
 		itr.ID = *reqItr.ID
 	} else {
@@ -272,6 +276,9 @@ func (c *IterationController) Update(ctx *app.UpdateIterationContext) error {
 		if ctx.Payload.Data.Attributes.UserActive != nil {
 			itr.UserActive = *ctx.Payload.Data.Attributes.UserActive
 		}
+		if err := applyIterationCapacity(ctx.Payload.Data.Attributes, itr); err != nil {
+			return err
+		}
 		var oldSubtree []iteration.Iteration
 		if ctx.Payload.Data.Relationships != nil && ctx.Payload.Data.Relationships.Parent != nil {
 			// update parent of Iteration
@@ -476,6 +483,7 @@ func ConvertIteration(request *http.Request, itr iteration.Iteration, additional
 			UserActive:   &itr.UserActive,
 			ActiveStatus: &activeStatus,
 			Number:       &itr.Number,
+			CapacityUnit: ptr.String(itr.CapacityUnit.String()),
 		},
 		Relationships: &app.IterationRelations{
 			Space: &app.RelationGeneric{
@@ -513,12 +521,49 @@ func ConvertIteration(request *http.Request, itr iteration.Iteration, additional
 			},
 		}
 	}
+	if len(itr.Capacity) > 0 {
+		i.Attributes.Capacity = make(map[string]float64, len(itr.Capacity))
+		for identityID, capacity := range itr.Capacity {
+			i.Attributes.Capacity[identityID.String()] = capacity
+		}
+	}
 	for _, add := range additional {
 		add(request, &itr, i)
 	}
 	return i
 }
 
+// applyIterationCapacity sets the capacity and its unit on the given
+// iteration if they are part of the given attributes. The given capacity
+// replaces the existing one as a whole.
+func applyIterationCapacity(attrs *app.IterationAttributes, itr *iteration.Iteration) error {
+	if attrs == nil {
+		return nil
+	}
+	if attrs.CapacityUnit != nil {
+		unit := iteration.CapacityUnit(*attrs.CapacityUnit)
+		if err := unit.CheckValid(); err != nil {
+			return err
+		}
+		itr.CapacityUnit = unit
+	}
+	if attrs.Capacity != nil {
+		capacity := make(iteration.Capacity, len(attrs.Capacity))
+		for identityID, v := range attrs.Capacity {
+			id, err := uuid.FromString(identityID)
+			if err != nil {
+				return errors.NewBadParameterError("data.attributes.capacity", identityID).Expected("an identity ID")
+			}
+			capacity[id] = v
+		}
+		if err := capacity.CheckValid(); err != nil {
+			return err
+		}
+		itr.Capacity = capacity
+	}
+	return nil
+}
+
 // ConvertIterationSimple converts a simple Iteration ID into a Generic
 // Relationship data+links element
 func ConvertIterationSimple(request *http.Request, id interface{}) (*app.GenericData, *app.GenericLinks) {
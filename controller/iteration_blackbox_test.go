@@ -598,6 +598,28 @@ func (rest *TestIterationREST) TestSuccessUpdateIteration() {
 			assert.Equal(rest.T(), 0, updated.Data.Relationships.Workitems.Meta[KeyTotalWorkItems])
 			assert.Equal(rest.T(), 0, updated.Data.Relationships.Workitems.Meta[KeyClosedWorkItems])
 		})
+		t.Run("capacity", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(rest.T(), rest.DB, tf.Iterations(1))
+			itr := fxt.Iterations[0]
+			capacity := map[string]float64{fxt.Identities[0].ID.String(): 5}
+			payload := app.UpdateIterationPayload{
+				Data: &app.Iteration{
+					Attributes: &app.IterationAttributes{
+						Capacity:     capacity,
+						CapacityUnit: ptr.String(iteration.CapacityUnitDays.String()),
+					},
+					ID:   &itr.ID,
+					Type: iteration.APIStringTypeIteration,
+				},
+			}
+			svc, ctrl := rest.SecuredControllerWithIdentity(fxt.Identities[0])
+			// when
+			_, updated := test.UpdateIterationOK(rest.T(), svc.Context, svc, ctrl, itr.ID.String(), &payload)
+			// then
+			assert.Equal(rest.T(), capacity, updated.Data.Attributes.Capacity)
+			assert.Equal(rest.T(), iteration.CapacityUnitDays.String(), *updated.Data.Attributes.CapacityUnit)
+		})
 	})
 }
 
@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeIterationSchedules is the JSONAPI type of an iteration schedule
+const APIStringTypeIterationSchedules = "iterationschedules"
+
+// IterationScheduleController implements the iteration_schedule resource.
+type IterationScheduleController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewIterationScheduleController creates an iteration_schedule controller.
+func NewIterationScheduleController(service *goa.Service, db application.DB) *IterationScheduleController {
+	return &IterationScheduleController{
+		Controller: service.NewController("IterationScheduleController"),
+		db:         db,
+	}
+}
+
+// Show runs the show action.
+func (c *IterationScheduleController) Show(ctx *app.ShowIterationScheduleContext) error {
+	var s *iteration.Schedule
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		s, err = appl.IterationSchedules().Load(ctx, ctx.SpaceID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.IterationScheduleSingle{
+		Data: ConvertIterationSchedule(ctx.Request, *s),
+	})
+}
+
+// Update runs the update action.
+func (c *IterationScheduleController) Update(ctx *app.UpdateIterationScheduleContext) error {
+	if err := c.authorizeScheduleChange(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil || ctx.Payload.Data.Attributes == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes", nil).Expected("not nil"))
+	}
+	var saved *iteration.Schedule
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		// attributes that are not given are kept
+		s := iteration.Schedule{SpaceID: ctx.SpaceID}
+		existing, err := appl.IterationSchedules().Load(ctx, ctx.SpaceID)
+		if err == nil {
+			s = *existing
+		} else if notFound, _ := errors.IsNotFoundError(err); !notFound {
+			return err
+		}
+		if err := updateScheduleFromPayload(&s, ctx.Payload.Data); err != nil {
+			return err
+		}
+		saved, err = appl.IterationSchedules().Save(ctx, s)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.IterationScheduleSingle{
+		Data: ConvertIterationSchedule(ctx.Request, *saved),
+	})
+}
+
+// updateScheduleFromPayload sets the attributes and the parent given in the
+// payload on the schedule.
+func updateScheduleFromPayload(s *iteration.Schedule, data *app.IterationSchedule) error {
+	attrs := data.Attributes
+	if attrs.StartAt != nil {
+		s.StartAt = *attrs.StartAt
+	}
+	if attrs.Cadence != nil {
+		s.Cadence = *attrs.Cadence
+	}
+	if attrs.Length != nil {
+		s.Length = *attrs.Length
+	}
+	if attrs.NamePattern != nil {
+		s.NamePattern = *attrs.NamePattern
+	}
+	if attrs.Counter != nil {
+		s.Counter = *attrs.Counter
+	}
+	if attrs.Ahead != nil {
+		s.Ahead = *attrs.Ahead
+	}
+	if attrs.Version != nil {
+		s.Version = *attrs.Version
+	}
+	if data.Relationships != nil && data.Relationships.Parent != nil {
+		s.ParentID = nil
+		if rel := data.Relationships.Parent.Data; rel != nil && rel.ID != nil {
+			parentID, err := uuid.FromString(*rel.ID)
+			if err != nil {
+				return errors.NewBadParameterError("data.relationships.parent.data.id", *rel.ID).Expected("a UUID")
+			}
+			s.ParentID = &parentID
+		}
+	}
+	return nil
+}
+
+// Delete runs the delete action.
+func (c *IterationScheduleController) Delete(ctx *app.DeleteIterationScheduleContext) error {
+	if err := c.authorizeScheduleChange(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		return appl.IterationSchedules().Delete(ctx, ctx.SpaceID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK([]byte{})
+}
+
+// Materialize runs the materialize action.
+func (c *IterationScheduleController) Materialize(ctx *app.MaterializeIterationScheduleContext) error {
+	if err := c.authorizeScheduleChange(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var created []iteration.Iteration
+	itrMap := make(iterationIDMap)
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		created, err = appl.IterationSchedules().Materialize(ctx, ctx.SpaceID, time.Now())
+		if err != nil {
+			return err
+		}
+		iterations, err := appl.Iterations().List(ctx, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		for _, itr := range iterations {
+			itrMap[itr.ID] = itr
+		}
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.IterationList{
+		Data: ConvertIterations(ctx.Request, created, parentPathResolver(itrMap)),
+	})
+}
+
+// authorizeScheduleChange returns an error if the current user is not the
+// owner of the given space.
+func (c *IterationScheduleController) authorizeScheduleChange(ctx context.Context, spaceID uuid.UUID) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return goa.ErrUnauthorized(err.Error())
+	}
+	var s *space.Space
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		s, err = appl.Spaces().Load(ctx, spaceID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !uuid.Equal(*currentUser, s.OwnerID) {
+		log.Warn(ctx, map[string]interface{}{
+			"space_id":     spaceID,
+			"space_owner":  s.OwnerID,
+			"current_user": *currentUser,
+		}, "user is not the space owner")
+		return errors.NewForbiddenError("user is not the space owner")
+	}
+	return nil
+}
+
+// ConvertIterationSchedule converts an iteration schedule from model to app
+// representation.
+func ConvertIterationSchedule(request *http.Request, s iteration.Schedule) *app.IterationSchedule {
+	selfURL := rest.AbsoluteURL(request, app.IterationScheduleHref(s.SpaceID))
+	spaceURL := rest.AbsoluteURL(request, app.SpaceHref(s.SpaceID))
+	res := &app.IterationSchedule{
+		Type: APIStringTypeIterationSchedules,
+		ID:   &s.ID,
+		Attributes: &app.IterationScheduleAttributes{
+			StartAt:     ptr.Time(s.StartAt),
+			Cadence:     ptr.Int(s.Cadence),
+			Length:      ptr.Int(s.Length),
+			NamePattern: ptr.String(s.NamePattern),
+			Counter:     ptr.Int(s.Counter),
+			Ahead:       ptr.Int(s.Ahead),
+			Version:     ptr.Int(s.Version),
+			CreatedAt:   ptr.Time(s.CreatedAt.UTC()),
+			UpdatedAt:   ptr.Time(s.UpdatedAt.UTC()),
+		},
+		Relationships: &app.IterationScheduleRelations{
+			Space: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: &space.SpaceType,
+					ID:   ptr.String(s.SpaceID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &spaceURL,
+					Related: &spaceURL,
+				},
+			},
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+	if s.ParentID != nil {
+		parentURL := rest.AbsoluteURL(request, app.IterationHref(*s.ParentID))
+		res.Relationships.Parent = &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: ptr.String(iteration.APIStringTypeIteration),
+				ID:   ptr.String(s.ParentID.String()),
+			},
+			Links: &app.GenericLinks{
+				Self:    &parentURL,
+				Related: &parentURL,
+			},
+		}
+	}
+	return res
+}
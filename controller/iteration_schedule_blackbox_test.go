@@ -0,0 +1,109 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestIterationScheduleREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunIterationScheduleREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestIterationScheduleREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func newIterationSchedulePayload(startAt time.Time) *app.UpdateIterationSchedulePayload {
+	return &app.UpdateIterationSchedulePayload{
+		Data: &app.IterationSchedule{
+			Type: APIStringTypeIterationSchedules,
+			Attributes: &app.IterationScheduleAttributes{
+				StartAt:     &startAt,
+				Cadence:     ptr.Int(14),
+				Length:      ptr.Int(14),
+				NamePattern: ptr.String("Sprint {n}"),
+				Ahead:       ptr.Int(3),
+			},
+		},
+	}
+}
+
+func (rest *TestIterationScheduleREST) TestSchedule() {
+	fxt := tf.NewTestFixture(rest.T(), rest.DB,
+		tf.Identities(2, tf.SetIdentityUsernames("space owner", "other")),
+		tf.Iterations(1),
+	)
+	spaceID := fxt.Spaces[0].ID
+	svc := testsupport.ServiceAsUser("IterationSchedule-Service", *fxt.IdentityByUsername("space owner"))
+	ctrl := NewIterationScheduleController(svc, rest.GormDB)
+
+	rest.T().Run("not found before creation", func(t *testing.T) {
+		test.ShowIterationScheduleNotFound(t, svc.Context, svc, ctrl, spaceID)
+	})
+	rest.T().Run("forbidden for other users", func(t *testing.T) {
+		otherSvc := testsupport.ServiceAsUser("IterationSchedule-Service", *fxt.IdentityByUsername("other"))
+		otherCtrl := NewIterationScheduleController(otherSvc, rest.GormDB)
+		test.UpdateIterationScheduleForbidden(t, otherSvc.Context, otherSvc, otherCtrl, spaceID, newIterationSchedulePayload(time.Now()))
+		test.MaterializeIterationScheduleForbidden(t, otherSvc.Context, otherSvc, otherCtrl, spaceID)
+	})
+	rest.T().Run("unauthorized", func(t *testing.T) {
+		anonSvc := goa.New("IterationSchedule-Service")
+		test.UpdateIterationScheduleUnauthorized(t, anonSvc.Context, anonSvc, NewIterationScheduleController(anonSvc, rest.GormDB), spaceID, newIterationSchedulePayload(time.Now()))
+	})
+	rest.T().Run("bad request", func(t *testing.T) {
+		payload := newIterationSchedulePayload(time.Now())
+		payload.Data.Attributes.NamePattern = ptr.String("Sprint")
+		test.UpdateIterationScheduleBadRequest(t, svc.Context, svc, ctrl, spaceID, payload)
+	})
+	rest.T().Run("create, show, materialize and delete", func(t *testing.T) {
+		// create
+		_, created := test.UpdateIterationScheduleOK(t, svc.Context, svc, ctrl, spaceID, newIterationSchedulePayload(time.Now().Add(-24*time.Hour)))
+		require.NotNil(t, created.Data.Attributes)
+		assert.Equal(t, 1, *created.Data.Attributes.Counter)
+		assert.Equal(t, 0, *created.Data.Attributes.Version)
+		// update only the number of iterations ahead
+		_, updated := test.UpdateIterationScheduleOK(t, svc.Context, svc, ctrl, spaceID, &app.UpdateIterationSchedulePayload{
+			Data: &app.IterationSchedule{
+				Type: APIStringTypeIterationSchedules,
+				Attributes: &app.IterationScheduleAttributes{
+					Ahead:   ptr.Int(2),
+					Version: created.Data.Attributes.Version,
+				},
+			},
+		})
+		assert.Equal(t, 2, *updated.Data.Attributes.Ahead)
+		assert.Equal(t, "Sprint {n}", *updated.Data.Attributes.NamePattern)
+		assert.Equal(t, 1, *updated.Data.Attributes.Version)
+		// show
+		_, shown := test.ShowIterationScheduleOK(t, svc.Context, svc, ctrl, spaceID)
+		assert.Equal(t, *updated.Data.ID, *shown.Data.ID)
+		// materialize
+		_, iterations := test.MaterializeIterationScheduleOK(t, svc.Context, svc, ctrl, spaceID)
+		require.Len(t, iterations.Data, 2)
+		assert.Equal(t, "Sprint 1", *iterations.Data[0].Attributes.Name)
+		assert.Equal(t, "Sprint 2", *iterations.Data[1].Attributes.Name)
+		_, iterations = test.MaterializeIterationScheduleOK(t, svc.Context, svc, ctrl, spaceID)
+		assert.Empty(t, iterations.Data)
+		// delete
+		test.DeleteIterationScheduleOK(t, svc.Context, svc, ctrl, spaceID)
+		test.ShowIterationScheduleNotFound(t, svc.Context, svc, ctrl, spaceID)
+	})
+	rest.T().Run("unknown space", func(t *testing.T) {
+		test.UpdateIterationScheduleNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), newIterationSchedulePayload(time.Now()))
+	})
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,17 +12,22 @@ import (
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
 	"github.com/fabric8-services/fabric8-wit/label"
 	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/notification"
 	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
 )
 
 // LabelController implements the label resource.
 type LabelController struct {
 	*goa.Controller
-	db     application.DB
-	config LabelControllerConfiguration
+	db           application.DB
+	notification notification.Channel
+	config       LabelControllerConfiguration
 }
 
 // LabelControllerConfiguration the configuration for the LabelController
@@ -32,10 +38,21 @@ type LabelControllerConfiguration interface {
 
 // NewLabelController creates a label controller.
 func NewLabelController(service *goa.Service, db application.DB, config LabelControllerConfiguration) *LabelController {
+	return NewNotifyingLabelController(service, db, &notification.DevNullChannel{}, config)
+}
+
+// NewNotifyingLabelController creates a label controller that notifies about
+// the work items updated when labels are deleted or merged.
+func NewNotifyingLabelController(service *goa.Service, db application.DB, notificationChannel notification.Channel, config LabelControllerConfiguration) *LabelController {
+	n := notificationChannel
+	if n == nil {
+		n = &notification.DevNullChannel{}
+	}
 	return &LabelController{
-		Controller: service.NewController("LabelController"),
-		db:         db,
-		config:     config}
+		Controller:   service.NewController("LabelController"),
+		db:           db,
+		notification: n,
+		config:       config}
 }
 
 // Show retrieve a single label
@@ -76,6 +93,12 @@ func (c *LabelController) Create(ctx *app.CreateLabelContext) error {
 	if ctx.Payload.Data.Attributes.BorderColor != nil {
 		lbl.BorderColor = *ctx.Payload.Data.Attributes.BorderColor
 	}
+	if ctx.Payload.Data.Relationships != nil && ctx.Payload.Data.Relationships.Parent != nil {
+		lbl.ParentID, err = labelParentID(ctx.Payload.Data.Relationships.Parent)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+	}
 	err = application.Transactional(c.db, func(appl application.Application) error {
 		return appl.Labels().Create(ctx, lbl)
 	})
@@ -125,9 +148,39 @@ func ConvertLabel(request *http.Request, lbl label.Label) *app.Label {
 			Related: &relatedURL,
 		},
 	}
+	if lbl.ParentID != nil {
+		parentID := lbl.ParentID.String()
+		parentRelatedURL := rest.AbsoluteURL(request, app.LabelHref(spaceID, parentID))
+		l.Relationships.Parent = &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: &labelType,
+				ID:   &parentID,
+			},
+			Links: &app.GenericLinks{
+				Self:    &parentRelatedURL,
+				Related: &parentRelatedURL,
+			},
+		}
+	}
 	return l
 }
 
+// labelParentID returns the ID of the parent label in the given relationship
+// or nil if the relationship data is null.
+func labelParentID(parent *app.RelationGeneric) (*uuid.UUID, error) {
+	if parent.Data == nil {
+		return nil, nil
+	}
+	if parent.Data.ID == nil {
+		return nil, errors.NewBadParameterError("data.relationships.parent.data.id", nil).Expected("not nil")
+	}
+	parentID, err := uuid.FromString(*parent.Data.ID)
+	if err != nil {
+		return nil, errors.NewBadParameterError("data.relationships.parent.data.id", *parent.Data.ID).Expected("valid UUID")
+	}
+	return &parentID, nil
+}
+
 // List runs the list action.
 func (c *LabelController) List(ctx *app.ListLabelContext) error {
 	err := application.Transactional(c.db, func(appl application.Application) error {
@@ -203,6 +256,12 @@ func (c *LabelController) Update(ctx *app.UpdateLabelContext) error {
 		if ctx.Payload.Data.Attributes.BorderColor != nil {
 			lbl.BorderColor = *ctx.Payload.Data.Attributes.BorderColor
 		}
+		if ctx.Payload.Data.Relationships != nil && ctx.Payload.Data.Relationships.Parent != nil {
+			lbl.ParentID, err = labelParentID(ctx.Payload.Data.Relationships.Parent)
+			if err != nil {
+				return err
+			}
+		}
 		lbl, err = appl.Labels().Save(ctx, *lbl)
 		return err
 	})
@@ -215,3 +274,161 @@ func (c *LabelController) Update(ctx *app.UpdateLabelContext) error {
 	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.LabelHref(ctx.SpaceID, result.Data.ID)))
 	return ctx.OK(result)
 }
+
+// Delete runs the delete action.
+func (c *LabelController) Delete(ctx *app.DeleteLabelContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if err := c.authorizeLabelModification(ctx, ctx.SpaceID, ctx.LabelID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var revisions []workitem.Revision
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		revisions, err = appl.WorkItems().ReplaceLabels(ctx, ctx.SpaceID, []uuid.UUID{ctx.LabelID}, nil, *currentUser)
+		if err != nil {
+			return err
+		}
+		if err := appl.Areas().ReplaceDefaultLabels(ctx, ctx.SpaceID, []uuid.UUID{ctx.LabelID}, nil); err != nil {
+			return err
+		}
+		return appl.Labels().Delete(ctx, ctx.LabelID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	c.notifyWorkItemsUpdated(ctx, revisions)
+	return ctx.OK([]byte{})
+}
+
+// Merge runs the merge action.
+func (c *LabelController) Merge(ctx *app.MergeLabelContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if ctx.Payload == nil || len(ctx.Payload.Data) == 0 {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data", nil).Expected("at least one label to merge"))
+	}
+	sourceIDs := make([]uuid.UUID, 0, len(ctx.Payload.Data))
+	for _, d := range ctx.Payload.Data {
+		if d == nil || d.ID == nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.id", nil).Expected("not nil"))
+		}
+		id, err := uuid.FromString(*d.ID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.id", *d.ID).Expected("valid UUID"))
+		}
+		sourceIDs = append(sourceIDs, id)
+	}
+	if err := c.authorizeLabelModification(ctx, ctx.SpaceID, ctx.LabelID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var lbl *label.Label
+	var revisions []workitem.Revision
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		lbl, err = appl.Labels().Merge(ctx, ctx.LabelID, sourceIDs...)
+		if err != nil {
+			return err
+		}
+		revisions, err = appl.WorkItems().ReplaceLabels(ctx, ctx.SpaceID, sourceIDs, &ctx.LabelID, *currentUser)
+		if err != nil {
+			return err
+		}
+		return appl.Areas().ReplaceDefaultLabels(ctx, ctx.SpaceID, sourceIDs, &ctx.LabelID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	c.notifyWorkItemsUpdated(ctx, revisions)
+	return ctx.OK(&app.LabelSingle{
+		Data: ConvertLabel(ctx.Request, *lbl),
+	})
+}
+
+// notifyWorkItemsUpdated sends a work item update notification for each of
+// the given revisions.
+func (c *LabelController) notifyWorkItemsUpdated(ctx context.Context, revisions []workitem.Revision) {
+	for _, rev := range revisions {
+		c.notification.Send(ctx, notification.NewWorkItemUpdated(rev.WorkItemID.String(), rev.ID))
+	}
+}
+
+// authorizeLabelModification verifies that the label with the given ID
+// belongs to the given space and that the current user is a collaborator of
+// the space.
+func (c *LabelController) authorizeLabelModification(ctx context.Context, spaceID, labelID uuid.UUID) error {
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		lbl, err := appl.Labels().Load(ctx, labelID)
+		if err != nil {
+			return err
+		}
+		if !uuid.Equal(lbl.SpaceID, spaceID) {
+			return errors.NewNotFoundError("label", labelID.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	authorized, err := authz.Authorize(ctx, spaceID.String())
+	if err != nil {
+		return errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return errors.NewForbiddenError("user is not a space collaborator")
+	}
+	return nil
+}
+
+// Usage runs the usage action.
+func (c *LabelController) Usage(ctx *app.UsageLabelContext) error {
+	var labels []label.Label
+	var usage map[uuid.UUID]workitem.LabelUsage
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return err
+		}
+		var err error
+		labels, err = appl.Labels().List(ctx, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		usage, err = appl.WorkItems().LabelUsage(ctx, ctx.SpaceID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.LabelUsageList{
+		Data: make([]*app.LabelUsage, 0, len(labels)),
+	}
+	for _, lbl := range labels {
+		labelID := lbl.ID.String()
+		labelURL := rest.AbsoluteURL(ctx.Request, app.LabelHref(ctx.SpaceID, labelID))
+		res.Data = append(res.Data, &app.LabelUsage{
+			Type: "label-usages",
+			ID:   lbl.ID,
+			Attributes: &app.LabelUsageAttributes{
+				Count:      usage[lbl.ID].Count,
+				TotalCount: usage[lbl.ID].TotalCount,
+			},
+			Relationships: &app.LabelUsageRelations{
+				Label: &app.RelationGeneric{
+					Data: &app.GenericData{
+						Type: ptr.String(label.APIStringTypeLabels),
+						ID:   &labelID,
+					},
+					Links: &app.GenericLinks{
+						Self:    &labelURL,
+						Related: &labelURL,
+					},
+				},
+			},
+		})
+	}
+	return ctx.OK(res)
+}
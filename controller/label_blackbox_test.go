@@ -11,9 +11,12 @@ import (
 	. "github.com/fabric8-services/fabric8-wit/controller"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/label"
+	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	notificationsupport "github.com/fabric8-services/fabric8-wit/test/notification"
 	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/goadesign/goa"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
@@ -278,3 +281,144 @@ func assertLabelLinking(t *testing.T, target *app.Label) {
 	require.NotNil(t, target.Relationships.Space.Links.Self)
 	assert.True(t, strings.Contains(*target.Relationships.Space.Links.Self, "/api/spaces/"))
 }
+
+// createLabeledWorkItems creates the labels "bug", "Bug" and "bugs" with "bug"
+// being the parent of "Bug" and two work items labeled with "bug" and "Bug" and
+// with "bugs" respectively.
+func (rest *TestLabelREST) createLabeledWorkItems(t *testing.T) *tf.TestFixture {
+	return tf.NewTestFixture(t, rest.DB,
+		tf.Labels(3, tf.SetLabelNames("bug", "Bug", "bugs"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 1 {
+				fxt.Labels[idx].ParentID = &fxt.Labels[0].ID
+			}
+			return nil
+		}),
+		tf.WorkItems(2, tf.SetWorkItemTitles("first", "second"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 0 {
+				fxt.WorkItems[idx].Fields[workitem.SystemLabels] = []string{fxt.Labels[0].ID.String(), fxt.Labels[1].ID.String()}
+			} else {
+				fxt.WorkItems[idx].Fields[workitem.SystemLabels] = []string{fxt.Labels[2].ID.String()}
+			}
+			return nil
+		}),
+	)
+}
+
+func (rest *TestLabelREST) TestDeleteLabel() {
+	rest.T().Run("ok", func(t *testing.T) {
+		testFxt := rest.createLabeledWorkItems(t)
+		svc := testsupport.ServiceAsSpaceUser("Label-Service", *testFxt.Identities[0], &TestSpaceAuthzService{owner: *testFxt.Identities[0]})
+		ctrl := NewLabelController(svc, rest.GormDB, rest.Configuration)
+		test.DeleteLabelOK(t, svc.Context, svc, ctrl, testFxt.Spaces[0].ID, testFxt.LabelByName("bug").ID)
+		test.ShowLabelNotFound(t, svc.Context, svc, ctrl, testFxt.Spaces[0].ID, testFxt.LabelByName("bug").ID, nil, nil)
+		// the child is moved up
+		_, child := test.ShowLabelOK(t, svc.Context, svc, ctrl, testFxt.Spaces[0].ID, testFxt.LabelByName("Bug").ID, nil, nil)
+		assert.Nil(t, child.Data.Relationships.Parent)
+		// the label is removed from the work item
+		wi, err := workitem.NewWorkItemRepository(rest.DB).LoadByID(rest.Ctx, testFxt.WorkItemByTitle("first").ID)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{testFxt.LabelByName("Bug").ID.String()}, wi.Fields[workitem.SystemLabels])
+	})
+
+	rest.T().Run("not a collaborator", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, rest.DB, tf.Identities(2), tf.Labels(1))
+		svc := testsupport.ServiceAsSpaceUser("Label-Service", *testFxt.Identities[1], &TestSpaceAuthzService{owner: *testFxt.Identities[0]})
+		test.DeleteLabelForbidden(t, svc.Context, svc, NewLabelController(svc, rest.GormDB, rest.Configuration), testFxt.Spaces[0].ID, testFxt.Labels[0].ID)
+	})
+
+	rest.T().Run("label of other space", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, rest.DB, tf.Labels(1))
+		other := tf.NewTestFixture(t, rest.DB, tf.Spaces(1))
+		svc := testsupport.ServiceAsSpaceUser("Label-Service", *testFxt.Identities[0], &TestSpaceAuthzService{owner: *testFxt.Identities[0]})
+		test.DeleteLabelNotFound(t, svc.Context, svc, NewLabelController(svc, rest.GormDB, rest.Configuration), other.Spaces[0].ID, testFxt.Labels[0].ID)
+	})
+
+	rest.T().Run("unauthorized", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, rest.DB, tf.Labels(1))
+		svc := goa.New("Label-Service")
+		test.DeleteLabelUnauthorized(t, svc.Context, svc, NewLabelController(svc, rest.GormDB, rest.Configuration), testFxt.Spaces[0].ID, testFxt.Labels[0].ID)
+	})
+}
+
+func (rest *TestLabelREST) TestMergeLabels() {
+	newMergePayload := func(labels ...*label.Label) *app.MergeLabelPayload {
+		payload := &app.MergeLabelPayload{}
+		for _, l := range labels {
+			payload.Data = append(payload.Data, &app.GenericData{
+				Type: ptr.String(label.APIStringTypeLabels),
+				ID:   ptr.String(l.ID.String()),
+			})
+		}
+		return payload
+	}
+
+	rest.T().Run("ok", func(t *testing.T) {
+		testFxt := rest.createLabeledWorkItems(t)
+		svc := testsupport.ServiceAsSpaceUser("Label-Service", *testFxt.Identities[0], &TestSpaceAuthzService{owner: *testFxt.Identities[0]})
+		notifications := notificationsupport.FakeNotificationChannel{}
+		ctrl := NewNotifyingLabelController(svc, rest.GormDB, &notifications, rest.Configuration)
+		// when merging "Bug" and "bugs" into "bug"
+		target := testFxt.LabelByName("bug")
+		_, merged := test.MergeLabelOK(t, svc.Context, svc, ctrl, testFxt.Spaces[0].ID, target.ID, newMergePayload(testFxt.LabelByName("Bug"), testFxt.LabelByName("bugs")))
+		// then
+		assert.Equal(t, target.ID, *merged.Data.ID)
+		_, labels := test.ListLabelOK(t, svc.Context, svc, ctrl, testFxt.Spaces[0].ID, nil, nil)
+		require.Len(t, labels.Data, 1)
+		repo := workitem.NewWorkItemRepository(rest.DB)
+		for _, title := range []string{"first", "second"} {
+			wi, err := repo.LoadByID(rest.Ctx, testFxt.WorkItemByTitle(title).ID)
+			require.NoError(t, err)
+			assert.Equal(t, []interface{}{target.ID.String()}, wi.Fields[workitem.SystemLabels], title)
+		}
+		// and the updated work items are notified about
+		require.Len(t, notifications.Messages, 2)
+		for _, msg := range notifications.Messages {
+			assert.Equal(t, "workitem.update", msg.MessageType)
+		}
+		assert.ElementsMatch(t, []string{testFxt.WorkItemByTitle("first").ID.String(), testFxt.WorkItemByTitle("second").ID.String()},
+			[]string{notifications.Messages[0].TargetID, notifications.Messages[1].TargetID})
+	})
+
+	rest.T().Run("no labels to merge", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, rest.DB, tf.Labels(1))
+		svc := testsupport.ServiceAsSpaceUser("Label-Service", *testFxt.Identities[0], &TestSpaceAuthzService{owner: *testFxt.Identities[0]})
+		test.MergeLabelBadRequest(t, svc.Context, svc, NewLabelController(svc, rest.GormDB, rest.Configuration), testFxt.Spaces[0].ID, testFxt.Labels[0].ID, newMergePayload())
+	})
+
+	rest.T().Run("label of other space", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, rest.DB, tf.Labels(1))
+		other := tf.NewTestFixture(t, rest.DB, tf.Labels(1))
+		svc := testsupport.ServiceAsSpaceUser("Label-Service", *testFxt.Identities[0], &TestSpaceAuthzService{owner: *testFxt.Identities[0]})
+		test.MergeLabelBadRequest(t, svc.Context, svc, NewLabelController(svc, rest.GormDB, rest.Configuration), testFxt.Spaces[0].ID, testFxt.Labels[0].ID, newMergePayload(other.Labels[0]))
+	})
+
+	rest.T().Run("not a collaborator", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, rest.DB, tf.Identities(2), tf.Labels(2))
+		svc := testsupport.ServiceAsSpaceUser("Label-Service", *testFxt.Identities[1], &TestSpaceAuthzService{owner: *testFxt.Identities[0]})
+		test.MergeLabelForbidden(t, svc.Context, svc, NewLabelController(svc, rest.GormDB, rest.Configuration), testFxt.Spaces[0].ID, testFxt.Labels[0].ID, newMergePayload(testFxt.Labels[1]))
+	})
+}
+
+func (rest *TestLabelREST) TestLabelUsage() {
+	testFxt := rest.createLabeledWorkItems(rest.T())
+	svc := goa.New("Label-Service")
+	ctrl := NewLabelController(svc, rest.GormDB, rest.Configuration)
+
+	rest.T().Run("ok", func(t *testing.T) {
+		_, usage := test.UsageLabelOK(t, svc.Context, svc, ctrl, testFxt.Spaces[0].ID)
+		require.Len(t, usage.Data, 3)
+		counts := map[uuid.UUID]app.LabelUsageAttributes{}
+		for _, u := range usage.Data {
+			require.NotNil(t, u.Relationships.Label)
+			assert.Equal(t, u.ID.String(), *u.Relationships.Label.Data.ID)
+			counts[u.ID] = *u.Attributes
+		}
+		assert.Equal(t, app.LabelUsageAttributes{Count: 1, TotalCount: 1}, counts[testFxt.LabelByName("bug").ID])
+		assert.Equal(t, app.LabelUsageAttributes{Count: 1, TotalCount: 1}, counts[testFxt.LabelByName("Bug").ID])
+		assert.Equal(t, app.LabelUsageAttributes{Count: 1, TotalCount: 1}, counts[testFxt.LabelByName("bugs").ID])
+	})
+
+	rest.T().Run("unknown space", func(t *testing.T) {
+		test.UsageLabelNotFound(t, svc.Context, svc, ctrl, uuid.NewV4())
+	})
+}
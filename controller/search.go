@@ -340,8 +340,12 @@ func (c *SearchController) Show(ctx *app.ShowSearchContext) error {
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "failed to load work item types"))
 		}
+		options := []WorkItemConvertFunc{hasChildren, includeParent}
+		if ctx.Computed != nil && *ctx.Computed {
+			options = append(options, workItemIncludeComputedFields(ctx, c.db, wits, result))
+		}
 
-		wis, err := ConvertWorkItems(ctx.Request, wits, result, hasChildren, includeParent)
+		wis, err := ConvertWorkItems(ctx.Request, wits, result, options...)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, err)
 		}
@@ -356,7 +360,11 @@ func (c *SearchController) Show(ctx *app.ShowSearchContext) error {
 		if err != nil {
 			return errs.Wrap(err, "failed to enrich work item list")
 		}
-		setPagingLinks(response.Links, buildAbsoluteURL(ctx.Request), len(result), offset, limit, count, "filter[expression]="+*ctx.FilterExpression)
+		additionalQuery := []string{"filter[expression]=" + *ctx.FilterExpression}
+		if ctx.Computed != nil && *ctx.Computed {
+			additionalQuery = append(additionalQuery, "computed=true")
+		}
+		setPagingLinks(response.Links, buildAbsoluteURL(ctx.Request), len(result), offset, limit, count, additionalQuery...)
 
 		// Sort "data" by name or ID if no title given
 		var data WorkItemPtrSlice = response.Data
@@ -525,12 +533,20 @@ func (c *SearchController) enrichWorkItemList(ctx *app.ShowSearchContext, ancest
 		return errs.Wrapf(err, "unable to load work item items in batch: %s", fetchInBatch)
 	}
 
-	for _, ele := range wis {
-		wit, err := c.db.WorkItemTypes().Load(ctx.Context, ele.Type)
-		if err != nil {
-			return errs.Wrapf(err, "failed to load work item type: %s", ele.Type)
-		}
-		convertedWI, err := ConvertWorkItem(ctx.Request, *wit, *ele, hasChildren, includeParentWorkItem(ctx, ancestors, childLinks))
+	included := make([]workitem.WorkItem, len(wis))
+	for i, ele := range wis {
+		included[i] = *ele
+	}
+	wits, err := loadWorkItemTypesFromArr(ctx.Context, c.db, included)
+	if err != nil {
+		return errs.Wrap(err, "failed to load work item types")
+	}
+	options := []WorkItemConvertFunc{hasChildren, includeParentWorkItem(ctx, ancestors, childLinks)}
+	if ctx.Computed != nil && *ctx.Computed {
+		options = append(options, workItemIncludeComputedFields(ctx, c.db, wits, included))
+	}
+	for i, ele := range included {
+		convertedWI, err := ConvertWorkItem(ctx.Request, wits[i], ele, options...)
 		if err != nil {
 			return errs.WithStack(err)
 		}
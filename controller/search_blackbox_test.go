@@ -326,7 +326,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItems() {
 	}))
 	// when
 	spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -347,7 +347,7 @@ func (s *searchControllerTestSuite) TestSearchPagination() {
 	svc := goa.New("TestSearchPagination")
 	svc.Context = goa.NewContext(context.Background(), nil, &http.Request{URL: &url.URL{Scheme: "https", Host: "foo.bar.com"}}, nil)
 	spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-	_, sr := test.ShowSearchOK(s.T(), svc.Context, svc, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), svc.Context, svc, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 	// then
 	// defaults in paging.go is 'pageSizeDefault = 20'
 	assert.Equal(s.T(), "http:///api/search?page[offset]=0&page[limit]=20&q=specialwordforsearch2", *sr.Links.First)
@@ -367,7 +367,7 @@ func (s *searchControllerTestSuite) TestSearchWithEmptyValue() {
 	// when
 	q := ""
 	spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-	_, jerrs := test.ShowSearchBadRequest(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+	_, jerrs := test.ShowSearchBadRequest(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 	// then
 	require.NotNil(s.T(), jerrs)
 	require.Len(s.T(), jerrs.Errors, 1)
@@ -387,7 +387,7 @@ func (s *searchControllerTestSuite) TestSearchWithDomainPortCombination() {
 	// when
 	q := `"http://localhost:8080/detail/154687364529310"`
 	spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -407,7 +407,7 @@ func (s *searchControllerTestSuite) TestSearchURLWithoutPort() {
 	// when
 	q := `"http://localhost/detail/876394"`
 	spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -427,7 +427,7 @@ func (s *searchControllerTestSuite) TestUnregisteredURLWithPort() {
 	// when
 	q := `http://some-other-domain:8080/different-path/`
 	spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -447,7 +447,7 @@ func (s *searchControllerTestSuite) TestUnwantedCharactersRelatedToSearchLogic()
 	// add url: in the query, that is not expected by the code hence need to make sure it gives expected result.
 	q := `http://url:some-random-other-domain:8080/different-path/`
 	spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 	// then
 	require.NotNil(s.T(), sr.Data)
 	assert.Empty(s.T(), sr.Data)
@@ -538,7 +538,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsSpaceContext() {
 	// when
 	q := "common_word"
 	space1IDStr := fxt.Spaces[0].ID.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &space1IDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &space1IDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	assert.Len(s.T(), sr.Data, 3)
@@ -547,7 +547,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsSpaceContext() {
 		assert.Contains(s.T(), item.Attributes[workitem.SystemTitle], "shutter_island common_word")
 	}
 	space2IDStr := fxt.Spaces[1].ID.String()
-	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, &space2IDStr)
+	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &space2IDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	assert.Len(s.T(), sr.Data, 5)
@@ -557,7 +557,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsSpaceContext() {
 	}
 
 	// when searched without spaceID then it should get all related WI
-	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, nil)
+	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, nil)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	assert.Len(s.T(), sr.Data, 8)
@@ -575,7 +575,7 @@ func (s *searchControllerTestSuite) TestFullTextSearch() {
 		// when
 		q := "with 'single"
 		spaceIDStr := fxt.Spaces[0].ID.String()
-		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, &q, &spaceIDStr)
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, nil, &q, &spaceIDStr)
 		// then
 		require.NotNil(t, sr)
 		require.Len(t, sr.Data, 1)
@@ -605,7 +605,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithoutSpaceContext() {
 
 	q := searchByMe
 	// when search without space context
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, &q, nil)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, &q, nil)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	toBeFound := id.Map{}
@@ -627,7 +627,7 @@ func (s *searchControllerTestSuite) TestSearchFilter() {
 		// when
 		filter := fmt.Sprintf(`{"space": "%s"}`, fxt.WorkItems[0].SpaceID)
 		spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		// then
 		require.NotEmpty(t, sr.Data)
 		r := sr.Data[0]
@@ -641,7 +641,7 @@ func (s *searchControllerTestSuite) TestSearchFilter() {
 		// when
 		filter := `{"number": "foo"}`
 		spaceIDStr := fxt.WorkItems[0].SpaceID.String()
-		_, jerr := test.ShowSearchBadRequest(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, jerr := test.ShowSearchBadRequest(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		// then
 		require.NotEmpty(t, jerr)
 		require.Len(t, jerr.Errors, 1)
@@ -709,7 +709,7 @@ func (s *searchControllerTestSuite) TestSearchByWorkItemTypeGroup() {
 				{"space": "%s"}
 			]}`, fxt.Spaces[0].ID)
 			// when
-			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 			// then
 			toBeFound := map[string]struct{}{
 				"open scenario":      {},
@@ -727,7 +727,7 @@ func (s *searchControllerTestSuite) TestSearchByWorkItemTypeGroup() {
 				{"space": "%s"}
 			]}`, fxt.Spaces[0].ID)
 			// when
-			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 			// then
 			toBeFound := map[string]struct{}{
 				"open experience":   {},
@@ -743,7 +743,7 @@ func (s *searchControllerTestSuite) TestSearchByWorkItemTypeGroup() {
 				{"space": "%s"}
 			]}`, fxt.Spaces[0].ID)
 			// when
-			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 			// then
 			toBeFound := map[string]struct{}{
 				"open feature":   {},
@@ -761,7 +761,7 @@ func (s *searchControllerTestSuite) TestSearchByWorkItemTypeGroup() {
 				{"space": "%s"}
 			]}`, fxt.Spaces[0].ID)
 			// when
-			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 			// then
 			toBeFound := map[string]struct{}{
 				"open task":      {},
@@ -782,7 +782,7 @@ func (s *searchControllerTestSuite) TestSearchByWorkItemTypeGroup() {
 				{"space": "%s"}
 			]}`, "unknown work item type group", fxt.Spaces[0].ID)
 			// when
-			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+			_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 			// then
 			require.Empty(t, sr.Data)
 		})
@@ -838,7 +838,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 		filter := fmt.Sprintf(`
 				{"label": {"$IN": ["%s", "%s"]}}`,
 			fxt.LabelByName("important").ID, fxt.LabelByName("ui").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotNil(t, result)
 		fmt.Println(result.Data)
 		require.NotEmpty(t, result.Data)
@@ -855,7 +855,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, fxt.LabelByName("backend").ID, fxt.IterationByName("sprint2").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1) // 3 items with Backend label & 5+1 items with sprint2
 	})
@@ -867,7 +867,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"label": "%s"}
 				]}`,
 			spaceIDStr, fxt.LabelByName("ui").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 5) // 5 items having UI label
 	})
@@ -879,7 +879,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"label": "%s"}
 				]}`,
 			fxt.LabelByName("ui").ID, fxt.LabelByName("backend").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 8)
 	})
@@ -891,7 +891,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"label": "%s"}
 				]}`,
 			spaceIDStr, fxt.LabelByName("rest").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		assert.Len(t, result.Data, 0) // no items having REST label
 	})
 
@@ -902,7 +902,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"label": "%s", "negate": true}
 				]}`,
 			spaceIDStr, fxt.LabelByName("backend").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 5+1) // 6 items are not having Backend label
 	})
@@ -914,7 +914,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"iteration": "%s"}
 				]}`,
 			workitem.SystemStateResolved, fxt.IterationByName("sprint1").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		require.Len(t, result.Data, 3) // resolved items having sprint1 are 3
 	})
@@ -926,7 +926,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"iteration": {"$EQ": "%s"}}
 				]}`,
 			workitem.SystemStateResolved, fxt.IterationByName("sprint1").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		require.Len(t, result.Data, 3) // resolved items having sprint1 are 3
 	})
@@ -938,7 +938,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"iteration": "%s"}
 				]}`,
 			workitem.SystemStateResolved, fxt.IterationByName("sprint2").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.Len(t, result.Data, 0) // No items having state=resolved && sprint2
 	})
 
@@ -952,7 +952,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"iteration": "%s"}
 				]}`,
 			workitem.SystemStateResolved, fxt.IterationByName("sprint2").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1) // resolved items + items in sprint2
 	})
@@ -964,7 +964,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"title": {"$SUBSTR":"%s"}}
 				]}`,
 			spaceIDStr, "special")
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3)
 	})
@@ -976,7 +976,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 		filter := fmt.Sprintf(`
 				{"state": {"$IN": ["%s", "%s"]}}`,
 			workitem.SystemStateResolved, workitem.SystemStateClosed)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1) // state = resolved or state = closed
 	})
@@ -991,7 +991,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, workitem.SystemStateResolved, fxt.IterationByName("sprint2").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1)
 	})
@@ -1006,7 +1006,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, workitem.SystemStateResolved, fxt.IterationByName("sprint2").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1)
 	})
@@ -1021,7 +1021,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, workitem.SystemStateResolved, fxt.IterationByName("sprint1").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		assert.Len(t, result.Data, 0)
 	})
 
@@ -1036,7 +1036,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, workitem.SystemStateOpen, fakeIterationID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 9) // all items are other than open state & in other thatn fake itr
 	})
@@ -1052,7 +1052,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, workitem.SystemStateOpen, fakeIterationID)
-		test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 	})
 
 	s.T().Run("space=ID AND (state!=open AND iteration!=fake-iterationID) using NE", func(t *testing.T) {
@@ -1066,7 +1066,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, workitem.SystemStateOpen, fakeIterationID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 9) // all items are other than open state & in other thatn fake itr
 	})
@@ -1079,7 +1079,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"state": "%s"}
 				]}`,
 			fakeSpaceID1, workitem.SystemStateOpen)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &fakeSpaceID1)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &fakeSpaceID1)
 		assert.Len(t, result.Data, 0) // we have 5 closed items but they are in different space
 	})
 
@@ -1091,7 +1091,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"state": "%s"}
 				]}`,
 			spaceIDStr, fxt.IdentityByUsername("bob").ID, workitem.SystemStateClosed)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 5) // we have 5 closed items assigned to bob
 	})
@@ -1105,7 +1105,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"iteration": "%s"}
 				]}`,
 			spaceIDStr, fxt.IdentityByUsername("alice").ID, fxt.IterationByName("sprint1").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3) // alice worked on 3 issues in sprint1
 	})
@@ -1117,7 +1117,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"creator":"%s"}
 				]}`,
 			spaceIDStr, fxt.IdentityByUsername("spaceowner").ID.String())
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 9) // we have 9 items created by spaceowner
 	})
@@ -1132,7 +1132,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					{"iteration": "%s"}
 				]}`,
 			spaceIDStr, fxt.IdentityByUsername("alice").ID, workitem.SystemStateClosed, fxt.IterationByName("sprint1").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3)
 	})
@@ -1148,7 +1148,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, workitem.SystemStateClosed, workitem.SystemStateResolved)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1) //resolved + closed
 	})
@@ -1164,7 +1164,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, fxt.WorkItemTypeByName("bug").ID, fxt.WorkItemTypeByName("feature").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1) //bugs + features
 	})
@@ -1180,7 +1180,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, fxt.WorkItemTypeByName("bug").ID, fxt.WorkItemTypeByName("feature").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3+5+1) //bugs + features
 	})
@@ -1196,7 +1196,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, fxt.WorkItemTypeByName("bug").ID, workitem.SystemStateResolved, fxt.IdentityByUsername("bob").ID, fxt.IdentityByUsername("alice").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3) //resolved bugs
 	})
@@ -1212,14 +1212,14 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 					]}
 				]}`,
 			spaceIDStr, fxt.WorkItemTypeByName("bug").ID, workitem.SystemStateResolved, fxt.IdentityByUsername("bob").ID, fxt.IdentityByUsername("alice").ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 3) //resolved bugs
 	})
 
 	s.T().Run("bad expression missing curly brace", func(t *testing.T) {
 		filter := fmt.Sprintf(`{"state": "0fe7b23e-c66e-43a9-ab1b-fbad9924fe7c"`)
-		res, jerrs := test.ShowSearchBadRequest(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		res, jerrs := test.ShowSearchBadRequest(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotNil(t, jerrs)
 		require.Len(t, jerrs.Errors, 1)
 		require.NotNil(t, jerrs.Errors[0].ID)
@@ -1231,7 +1231,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 
 	s.T().Run("non existing key", func(t *testing.T) {
 		filter := fmt.Sprintf(`{"nonexistingkey": "0fe7b23e-c66e-43a9-ab1b-fbad9924fe7c"}`)
-		res, jerrs := test.ShowSearchBadRequest(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		res, jerrs := test.ShowSearchBadRequest(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotNil(t, jerrs)
 		require.Len(t, jerrs.Errors, 1)
 		require.NotNil(t, jerrs.Errors[0].ID)
@@ -1247,7 +1247,7 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 						{"assignee":null}
 					]}`,
 		)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotNil(s.T(), result)
 		require.NotEmpty(t, result.Data)
 	})
@@ -1256,13 +1256,13 @@ func (s *searchControllerTestSuite) TestSearchQueryScenarioDriven() {
 		filter := fmt.Sprintf(`
 					{"assignee":null}`,
 		)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 	})
 
 	s.T().Run("assignee=null with negate", func(t *testing.T) {
 		filter := fmt.Sprintf(`{"$AND": [{"assignee":null, "negate": true}]}`)
-		res, jerrs := test.ShowSearchBadRequest(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+		res, jerrs := test.ShowSearchBadRequest(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 		require.NotNil(t, jerrs)
 		require.Len(t, jerrs.Errors, 1)
 		require.NotNil(t, jerrs.Errors[0].ID)
@@ -1292,7 +1292,7 @@ func (s *searchControllerTestSuite) TestSearchByJoinedData() {
 		// given
 		filter := fmt.Sprintf(`{"iteration.name": "%s"}`, fxt.Iterations[0].Name)
 		// when
-		resWriter, list := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, ptr.String(spaceIDStr))
+		resWriter, list := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, ptr.String(spaceIDStr))
 		// then
 		require.NotNil(t, resWriter)
 		require.NotNil(t, list)
@@ -1342,7 +1342,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 
 		t.Run("without child iteration", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s", "child": true}`, fxt.Iterations[2].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 4)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1360,7 +1360,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 		})
 		t.Run("with one child iteration", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s", "child": true}`, fxt.Iterations[1].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 6)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1380,7 +1380,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 		})
 		t.Run("with one child iteration implicit", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s"}`, fxt.Iterations[1].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 6)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1400,7 +1400,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 		})
 		t.Run("with two child iteration", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s", "child": true}`, fxt.Iterations[0].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 9)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1424,7 +1424,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 
 		t.Run("without child iteration - implicit", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s"}`, fxt.Iterations[2].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 4)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1442,7 +1442,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 		})
 		t.Run("without child iteration - child false", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s", "child": false}`, fxt.Iterations[2].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 4)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1460,7 +1460,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 		})
 		t.Run("with one child iteration - child false", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s", "child": false}`, fxt.Iterations[1].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 2)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1476,7 +1476,7 @@ func (s *searchControllerTestSuite) TestSearchWorkItemsWithChildIterationsOption
 		})
 		t.Run("with two child iteration - child false", func(t *testing.T) {
 			filter := fmt.Sprintf(`{"iteration": "%s", "child": false}`, fxt.Iterations[0].ID)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			require.NotEmpty(t, result.Data)
 			assert.Len(t, result.Data, 3)
 			toBeFound := id.MapFromSlice(id.Slice{
@@ -1559,7 +1559,7 @@ func (s *searchControllerTestSuite) TestIncludedParents() {
 			t.Run(testName, func(t *testing.T) {
 				t.Logf("Running with filter: %s", filter)
 				// when
-				_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+				_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 				// then
 				require.NotEmpty(t, result.Data)
 				assert.Len(t, result.Data, len(searchForTitles))
@@ -1691,7 +1691,7 @@ func (s *searchControllerTestSuite) TestIncludedChildren() {
 		t.Run("B,C with tree-view = true", func(t *testing.T) {
 			// when
 			filter := fmt.Sprintf(`{"$AND":[{"space":"%[1]s"}, {"$OR": [{"title":"B"}, {"title":"C"}]}], "$OPTS":{"%[2]s": true}}`, spaceIDStr, search.OptTreeViewKey)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			// then
 			require.NotEmpty(t, result.Data)
 			// check "data" section
@@ -1751,7 +1751,7 @@ func (s *searchControllerTestSuite) TestIncludedChildren() {
 		t.Run("B,C with tree-view = false", func(t *testing.T) {
 			// when
 			filter := fmt.Sprintf(`{"$AND":[{"space":"%[1]s"}, {"$OR": [{"title":"B"}, {"title":"C"}]}], "$OPTS":{"%[2]s": false}}`, spaceIDStr, search.OptTreeViewKey)
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, &spaceIDStr)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, &spaceIDStr)
 			// then
 			require.NotEmpty(t, result.Data)
 			require.Empty(t, result.Included)
@@ -1793,7 +1793,7 @@ func (s *searchControllerTestSuite) TestUpdateWorkItem() {
 		filter := fmt.Sprintf(`{"$AND":[{"space":"%s"},{"assignee":null}]}`, fxt.Spaces[0].ID.String())
 		t.Run("filter null", func(t *testing.T) {
 			// when
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 			// then
 			require.Len(t, result.Data, 1)
 			require.Equal(t, fxt.WorkItemByTitle("unassigned").ID, *result.Data[0].ID)
@@ -1807,7 +1807,7 @@ func (s *searchControllerTestSuite) TestUpdateWorkItem() {
 				_, updated := test.UpdateWorkitemOK(t, s.svc.Context, s.svc, workitemCtrl, *wi.ID, &payload2)
 				compareWithGoldenAgnostic(t, filepath.Join(s.testDir, "show", "filter_assignee_null_update_work_item.golden.json"), updated)
 
-				_, result = test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+				_, result = test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 				compareWithGoldenAgnostic(t, filepath.Join(s.testDir, "show", "filter_assignee_null_show_after_update_work_item.golden.json"), updated)
 				assert.Nil(s.T(), result.Data[0].Attributes[workitem.SystemAssignees])
 
@@ -1832,7 +1832,7 @@ func (s *searchControllerTestSuite) TestUpdateWorkItem() {
 		filter := fmt.Sprintf(`{"$AND":[{"space":"%s"},{"label":{"$EQ":null}}]}`, fxt.Spaces[0].ID.String())
 		t.Run("filter null", func(t *testing.T) {
 			// when
-			_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+			_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 			// then
 			require.Len(t, result.Data, 1)
 			require.Equal(t, fxt.WorkItemByTitle("unlabelled").ID, *result.Data[0].ID)
@@ -1845,7 +1845,7 @@ func (s *searchControllerTestSuite) TestUpdateWorkItem() {
 				_, updated := test.UpdateWorkitemOK(t, s.svc.Context, s.svc, workitemCtrl, *wi.ID, &payload2)
 				compareWithGoldenAgnostic(t, filepath.Join(s.testDir, "show", "filter_label_null_update_work_item.golden.json"), updated)
 
-				_, result = test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil)
+				_, result = test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter, nil, nil, nil, nil, nil)
 				compareWithGoldenAgnostic(t, filepath.Join(s.testDir, "show", "filter_label_null_show_after_update_work_item.golden.json"), updated)
 				assert.Nil(s.T(), result.Data[0].Attributes[workitem.SystemLabels])
 			})
@@ -2029,7 +2029,7 @@ func (s *searchControllerTestSuite) TestSearchByTrackerQuery() {
                                        {"trackerquery.id": "%s"}
                                ]}`,
 			spaceIDStr, fxt.TrackerQueries[0].ID)
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter1, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter1, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result.Data)
 		assert.Len(t, result.Data, 4)
 
@@ -2040,7 +2040,7 @@ func (s *searchControllerTestSuite) TestSearchByTrackerQuery() {
                                        {"trackerquery.id": "%s"}
                                ]}`,
 			spaceIDStr, fxt.TrackerQueries[1].ID)
-		_, result2 := test.ShowSearchOK(t, nil, nil, s.controller, &filter2, nil, nil, nil, nil, &spaceIDStr)
+		_, result2 := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter2, nil, nil, nil, nil, &spaceIDStr)
 		require.NotEmpty(t, result2.Data)
 		assert.Len(t, result2.Data, 1)
 	})
@@ -2052,7 +2052,7 @@ func (s *searchControllerTestSuite) TestSearchByTrackerQuery() {
                                        {"trackerquery.id": "%s"}
                                ]}`,
 			spaceIDStr, uuid.NewV4())
-		_, result := test.ShowSearchOK(t, nil, nil, s.controller, &filter1, nil, nil, nil, nil, &spaceIDStr)
+		_, result := test.ShowSearchOK(t, nil, nil, s.controller, nil, &filter1, nil, nil, nil, nil, &spaceIDStr)
 		require.Empty(t, result.Data)
 	})
 }
@@ -83,6 +83,9 @@ func (c *SpaceIterationsController) Create(ctx *app.CreateSpaceIterationsContext
 		if reqIter.Attributes.Description != nil {
 			newItr.Description = reqIter.Attributes.Description
 		}
+		if err := applyIterationCapacity(reqIter.Attributes, &newItr); err != nil {
+			return err
+		}
 		err = appl.Iterations().Create(ctx, &newItr)
 		if err != nil {
 			return err
@@ -1,22 +1,33 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/spacetemplate"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/importer"
+	"github.com/ghodss/yaml"
 	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 )
 
 // APISpaceTemplates is the URL a) the URL portion in /api/spacetemplates and b)
 // the "type" string in a JSON API resource object.
 var APISpaceTemplates = "spacetemplates"
 
+// APISpaceTemplateDiffs is the "type" string of a space template diff in a
+// JSON API resource object.
+const APISpaceTemplateDiffs = "spacetemplatediffs"
+
 // SpaceTemplateController implements the space_template resource.
 type SpaceTemplateController struct {
 	*goa.Controller
@@ -79,6 +90,218 @@ func (c *SpaceTemplateController) Show(ctx *app.ShowSpaceTemplateContext) error
 	return ctx.OK(res)
 }
 
+// Create runs the create action.
+func (c *SpaceTemplateController) Create(ctx *app.CreateSpaceTemplateContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if ctx.Payload == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data", nil).Expected("not nil"))
+	}
+	templ, err := parseSpaceTemplate(ctx.Payload.Data)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if ctx.Payload.Data.ID != nil {
+		templ.SetID(*ctx.Payload.Data.ID)
+	}
+	templ.Template.CreatorID = currentUserIdentityID
+	templ.ModifierID = *currentUserIdentityID
+	res := &app.SpaceTemplateSingle{}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		err := appl.SpaceTemplates().CheckExists(ctx, templ.Template.ID)
+		if err == nil {
+			return errors.NewDataConflictError(fmt.Sprintf("space template %s already exists", templ.Template.ID))
+		}
+		if notFound, _ := errors.IsNotFoundError(err); !notFound {
+			return err
+		}
+		imported, err := appl.SpaceTemplateImporter().Import(ctx, *templ)
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"err":               err,
+				"space_template_id": templ.Template.ID,
+			}, "failed to import space template")
+			return err
+		}
+		err = appl.SpaceTemplates().CreateVersion(ctx, spacetemplate.SpaceTemplateVersion{
+			SpaceTemplateID: imported.Template.ID,
+			Version:         imported.Template.Version,
+			Template:        *ctx.Payload.Data.Attributes.Template,
+			ModifierID:      currentUserIdentityID,
+		})
+		if err != nil {
+			return err
+		}
+		res.Data = ConvertSpaceTemplate(appl, ctx.Request, imported.Template)
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.SpaceTemplateHref(res.Data.ID)))
+	return ctx.Created(res)
+}
+
+// Update runs the update action.
+func (c *SpaceTemplateController) Update(ctx *app.UpdateSpaceTemplateContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if isSystemSpaceTemplate(ctx.SpaceTemplateID) {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("system space templates cannot be changed"))
+	}
+	if ctx.Payload == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data", nil).Expected("not nil"))
+	}
+	templ, err := parseSpaceTemplate(ctx.Payload.Data)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if ctx.Payload.Data.Attributes.Version == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.version", nil).Expected("not nil"))
+	}
+	templ.SetID(ctx.SpaceTemplateID)
+	templ.ModifierID = *currentUserIdentityID
+	res := &app.SpaceTemplateSingle{}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		existing, err := appl.SpaceTemplates().Load(ctx, ctx.SpaceTemplateID)
+		if err != nil {
+			return err
+		}
+		if existing.CreatorID == nil || !uuid.Equal(*existing.CreatorID, *currentUserIdentityID) {
+			return errors.NewForbiddenError("only the creator of a space template can update it")
+		}
+		if existing.Version != *ctx.Payload.Data.Attributes.Version {
+			return errors.NewVersionConflictError("version conflict")
+		}
+		imported, err := appl.SpaceTemplateImporter().Import(ctx, *templ)
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"err":               err,
+				"space_template_id": ctx.SpaceTemplateID,
+			}, "failed to import space template")
+			return err
+		}
+		// every update of the template increases its version and the
+		// definition of each version is kept
+		imported.Template.Version = existing.Version
+		saved, err := appl.SpaceTemplates().Save(ctx, imported.Template)
+		if err != nil {
+			return err
+		}
+		err = appl.SpaceTemplates().CreateVersion(ctx, spacetemplate.SpaceTemplateVersion{
+			SpaceTemplateID: saved.ID,
+			Version:         saved.Version,
+			Template:        *ctx.Payload.Data.Attributes.Template,
+			ModifierID:      currentUserIdentityID,
+		})
+		if err != nil {
+			return err
+		}
+		res.Data = ConvertSpaceTemplate(appl, ctx.Request, *saved)
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(res)
+}
+
+// Export runs the export action.
+func (c *SpaceTemplateController) Export(ctx *app.ExportSpaceTemplateContext) error {
+	var templ *importer.ImportHelper
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		templ, err = appl.SpaceTemplateImporter().Export(ctx, ctx.SpaceTemplateID)
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":               err,
+			"space_template_id": ctx.SpaceTemplateID,
+		}, "failed to export space template")
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	bs, err := yaml.Marshal(templ)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewInternalError(ctx, errs.Wrap(err, "failed to marshal space template to YAML")))
+	}
+	return ctx.OK(bs)
+}
+
+// Diff runs the diff action.
+func (c *SpaceTemplateController) Diff(ctx *app.DiffSpaceTemplateContext) error {
+	hasPayload := ctx.Payload != nil && ctx.Payload.Data != nil
+	if hasPayload == (ctx.With != nil) {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("with", ctx.With).Expected("either another space template or a candidate template in the payload"))
+	}
+	var candidate *importer.ImportHelper
+	if hasPayload {
+		var err error
+		candidate, err = parseSpaceTemplate(ctx.Payload.Data)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+	}
+	var diff importer.TemplateDiff
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		templ, err := appl.SpaceTemplateImporter().Export(ctx, ctx.SpaceTemplateID)
+		if err != nil {
+			return err
+		}
+		if candidate == nil {
+			candidate, err = appl.SpaceTemplateImporter().Export(ctx, *ctx.With)
+			if err != nil {
+				return err
+			}
+		}
+		diff = importer.Diff(*templ, *candidate)
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.SpaceTemplateDiffSingle{
+		Data: ConvertSpaceTemplateDiff(ctx.Request, ctx.SpaceTemplateID, diff),
+	})
+}
+
+// parseSpaceTemplate parses and validates the YAML or JSON space template
+// definition found in the given payload data.
+func parseSpaceTemplate(data *app.SpaceTemplate) (*importer.ImportHelper, error) {
+	if data == nil || data.Attributes == nil || data.Attributes.Template == nil {
+		return nil, errors.NewBadParameterError("data.attributes.template", nil).Expected("not nil")
+	}
+	templ, err := importer.FromString(*data.Attributes.Template)
+	if err != nil {
+		if ok, _ := errors.IsBadParameterError(err); ok {
+			return nil, err
+		}
+		return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("failed to parse space template: %s", errs.Cause(err)))
+	}
+	return templ, nil
+}
+
+// isSystemSpaceTemplate returns true if the given ID belongs to one of the
+// space templates that are imported on every startup.
+func isSystemSpaceTemplate(id uuid.UUID) bool {
+	for _, systemID := range []uuid.UUID{
+		spacetemplate.SystemBaseTemplateID,
+		spacetemplate.SystemLegacyTemplateID,
+		spacetemplate.SystemScrumTemplateID,
+		spacetemplate.SystemAgileTemplateID,
+		spacetemplate.SystemIssueTrackingTemplateID,
+	} {
+		if uuid.Equal(id, systemID) {
+			return true
+		}
+	}
+	return false
+}
+
 // SpaceTemplateConvertFunc is a open ended function to add additional links/data/relations to a space template during
 // convertion from internal to API
 type SpaceTemplateConvertFunc func(application.Application, *http.Request, *spacetemplate.SpaceTemplate, *app.SpaceTemplate) error
@@ -140,3 +363,51 @@ func ConvertSpaceTemplate(appl application.Application, request *http.Request, s
 	}
 	return i
 }
+
+// ConvertSpaceTemplateDiff converts the differences between the space
+// template with the given ID and another template to the REST representation.
+func ConvertSpaceTemplateDiff(request *http.Request, spaceTemplateID uuid.UUID, diff importer.TemplateDiff) *app.SpaceTemplateDiff {
+	return &app.SpaceTemplateDiff{
+		Type: APISpaceTemplateDiffs,
+		ID:   &spaceTemplateID,
+		Attributes: &app.SpaceTemplateDiffAttributes{
+			Empty:              ptr.Bool(diff.Empty()),
+			Template:           convertSpaceTemplateChanges(diff.Template),
+			WorkItemTypes:      convertSpaceTemplateArtifactDiff(diff.WorkItemTypes),
+			WorkItemLinkTypes:  convertSpaceTemplateArtifactDiff(diff.WorkItemLinkTypes),
+			WorkItemTypeGroups: convertSpaceTemplateArtifactDiff(diff.WorkItemTypeGroups),
+			WorkItemBoards:     convertSpaceTemplateArtifactDiff(diff.WorkItemBoards),
+		},
+		Links: &app.GenericLinks{
+			Self: ptr.String(rest.AbsoluteURL(request, app.SpaceTemplateHref(spaceTemplateID)+"/diff")),
+		},
+	}
+}
+
+func convertSpaceTemplateArtifactDiff(diff importer.ArtifactDiff) *app.SpaceTemplateArtifactDiff {
+	res := &app.SpaceTemplateArtifactDiff{
+		Added:   diff.Added,
+		Removed: diff.Removed,
+		Changed: []*app.SpaceTemplateArtifactChange{},
+	}
+	for _, changed := range diff.Changed {
+		id := changed.ID
+		res.Changed = append(res.Changed, &app.SpaceTemplateArtifactChange{
+			ID:      &id,
+			Name:    ptr.String(changed.Name),
+			Changes: convertSpaceTemplateChanges(changed.Changes),
+		})
+	}
+	return res
+}
+
+func convertSpaceTemplateChanges(changes []importer.Change) []*app.SpaceTemplateChange {
+	res := []*app.SpaceTemplateChange{}
+	for _, change := range changes {
+		res = append(res, &app.SpaceTemplateChange{
+			Attribute: change.Attribute,
+			Kind:      string(change.Kind),
+		})
+	}
+	return res
+}
@@ -4,21 +4,26 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/app/test"
 	. "github.com/fabric8-services/fabric8-wit/controller"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/spacetemplate"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/importer"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/goadesign/goa"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/require"
@@ -29,6 +34,7 @@ type testSpaceTemplateSuite struct {
 	gormtestsupport.DBTestSuite
 	ctx     context.Context
 	testDir string
+	fxt     *tf.TestFixture
 }
 
 func TestSpaceTemplateSuite(t *testing.T) {
@@ -39,10 +45,15 @@ func TestSpaceTemplateSuite(t *testing.T) {
 func (s *testSpaceTemplateSuite) SetupTest() {
 	s.DBTestSuite.SetupTest()
 	s.testDir = filepath.Join("test-files", "space_templates")
+	s.fxt = tf.NewTestFixture(s.T(), s.DB, tf.Identities(2))
 }
 
 func (s *testSpaceTemplateSuite) SecuredController() (*goa.Service, *SpaceTemplateController) {
-	svc := testsupport.ServiceAsUser("SpaceTemplate-Service", testsupport.TestIdentity)
+	return s.SecuredControllerWithIdentity(*s.fxt.Identities[0])
+}
+
+func (s *testSpaceTemplateSuite) SecuredControllerWithIdentity(idn account.Identity) (*goa.Service, *SpaceTemplateController) {
+	svc := testsupport.ServiceAsUser("SpaceTemplate-Service", idn)
 	return svc, NewSpaceTemplateController(svc, s.GormDB, s.Configuration)
 }
 
@@ -193,6 +204,230 @@ func (s *testSpaceTemplateSuite) TestSpaceTemplate_List() {
 	})
 }
 
+// newSpaceTemplateYAML returns the YAML definition of a space template with
+// the given name and one work item type for every given ID.
+func newSpaceTemplateYAML(name string, witIDs ...uuid.UUID) string {
+	res := "space_template:\n  name: " + name + "\n  can_construct: yes\nwork_item_types:\n"
+	for i, witID := range witIDs {
+		res += fmt.Sprintf(`- id: "%s"
+  name: Type %d
+  icon: fa fa-bug
+  extends: "%s"
+  can_construct: yes
+`, witID, i, workitem.SystemPlannerItem)
+	}
+	return res
+}
+
+func newSpaceTemplatePayload(templ string) *app.SpaceTemplate {
+	return &app.SpaceTemplate{
+		Type: APISpaceTemplates,
+		Attributes: &app.SpaceTemplateAttributes{
+			Template: &templ,
+		},
+	}
+}
+
+func (s *testSpaceTemplateSuite) TestSpaceTemplate_Create() {
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		svc, ctrl := s.SecuredController()
+		witID := uuid.NewV4()
+		name := "custom " + uuid.NewV4().String()
+		payload := &app.CreateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID))}
+		// when
+		res, created := test.CreateSpaceTemplateCreated(t, svc.Context, svc, ctrl, payload)
+		// then
+		require.NotNil(t, created)
+		require.Equal(t, name, *created.Data.Attributes.Name)
+		require.Equal(t, 0, *created.Data.Attributes.Version)
+		require.NotEmpty(t, res.Header().Get("Location"))
+		st, err := spacetemplate.NewRepository(s.DB).Load(s.Ctx, *created.Data.ID)
+		require.NoError(t, err)
+		require.NotNil(t, st.CreatorID)
+		require.Equal(t, s.fxt.Identities[0].ID, *st.CreatorID)
+		version, err := spacetemplate.NewRepository(s.DB).LoadVersion(s.Ctx, *created.Data.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, *payload.Data.Attributes.Template, version.Template)
+		wit, err := workitem.NewWorkItemTypeRepository(s.DB).Load(s.Ctx, witID)
+		require.NoError(t, err)
+		require.Equal(t, *created.Data.ID, wit.SpaceTemplateID)
+
+		t.Run("conflict", func(t *testing.T) {
+			payload := &app.CreateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML("another "+name, uuid.NewV4()))}
+			payload.Data.ID = created.Data.ID
+			test.CreateSpaceTemplateConflict(t, svc.Context, svc, ctrl, payload)
+		})
+	})
+
+	s.T().Run("invalid template", func(t *testing.T) {
+		svc, ctrl := s.SecuredController()
+		for name, templ := range map[string]string{
+			"not yaml":   "{{{",
+			"no name":    newSpaceTemplateYAML(""),
+			"wrong type": "space_template: []",
+		} {
+			t.Run(name, func(t *testing.T) {
+				payload := &app.CreateSpaceTemplatePayload{Data: newSpaceTemplatePayload(templ)}
+				test.CreateSpaceTemplateBadRequest(t, svc.Context, svc, ctrl, payload)
+			})
+		}
+	})
+
+	s.T().Run("unauthorized", func(t *testing.T) {
+		svc := goa.New("SpaceTemplate-Service")
+		ctrl := NewSpaceTemplateController(svc, s.GormDB, s.Configuration)
+		payload := &app.CreateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML("foo", uuid.NewV4()))}
+		test.CreateSpaceTemplateUnauthorized(t, svc.Context, svc, ctrl, payload)
+	})
+}
+
+func (s *testSpaceTemplateSuite) TestSpaceTemplate_Update() {
+	// given
+	svc, ctrl := s.SecuredController()
+	witID := uuid.NewV4()
+	name := "custom " + uuid.NewV4().String()
+	_, created := test.CreateSpaceTemplateCreated(s.T(), svc.Context, svc, ctrl, &app.CreateSpaceTemplatePayload{
+		Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID)),
+	})
+	templateID := *created.Data.ID
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		otherWITID := uuid.NewV4()
+		payload := &app.UpdateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML("renamed "+name, witID, otherWITID))}
+		payload.Data.Attributes.Version = created.Data.Attributes.Version
+		_, updated := test.UpdateSpaceTemplateOK(t, svc.Context, svc, ctrl, templateID, payload)
+		// then
+		require.Equal(t, "renamed "+name, *updated.Data.Attributes.Name)
+		require.Equal(t, *created.Data.Attributes.Version+1, *updated.Data.Attributes.Version)
+		wit, err := workitem.NewWorkItemTypeRepository(s.DB).Load(s.Ctx, otherWITID)
+		require.NoError(t, err)
+		require.Equal(t, templateID, wit.SpaceTemplateID)
+		version, err := spacetemplate.NewRepository(s.DB).LoadVersion(s.Ctx, templateID, *updated.Data.Attributes.Version)
+		require.NoError(t, err)
+		require.Equal(t, *payload.Data.Attributes.Template, version.Template)
+
+		t.Run("version conflict", func(t *testing.T) {
+			test.UpdateSpaceTemplateConflict(t, svc.Context, svc, ctrl, templateID, payload)
+		})
+		t.Run("removed work item type", func(t *testing.T) {
+			payload := &app.UpdateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID))}
+			payload.Data.Attributes.Version = updated.Data.Attributes.Version
+			test.UpdateSpaceTemplateBadRequest(t, svc.Context, svc, ctrl, templateID, payload)
+		})
+	})
+
+	s.T().Run("not the creator", func(t *testing.T) {
+		svc, ctrl := s.SecuredControllerWithIdentity(*s.fxt.Identities[1])
+		payload := &app.UpdateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML("renamed "+name, witID))}
+		payload.Data.Attributes.Version = created.Data.Attributes.Version
+		test.UpdateSpaceTemplateForbidden(t, svc.Context, svc, ctrl, templateID, payload)
+	})
+
+	s.T().Run("missing version", func(t *testing.T) {
+		payload := &app.UpdateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID))}
+		test.UpdateSpaceTemplateBadRequest(t, svc.Context, svc, ctrl, templateID, payload)
+	})
+
+	s.T().Run("system template", func(t *testing.T) {
+		payload := &app.UpdateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID))}
+		payload.Data.Attributes.Version = ptr.Int(0)
+		test.UpdateSpaceTemplateForbidden(t, svc.Context, svc, ctrl, spacetemplate.SystemScrumTemplateID, payload)
+	})
+
+	s.T().Run("not found", func(t *testing.T) {
+		payload := &app.UpdateSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, uuid.NewV4()))}
+		payload.Data.Attributes.Version = ptr.Int(0)
+		test.UpdateSpaceTemplateNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), payload)
+	})
+}
+
+func (s *testSpaceTemplateSuite) TestSpaceTemplate_Export() {
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		svc, ctrl := s.SecuredController()
+		witID := uuid.NewV4()
+		name := "custom " + uuid.NewV4().String()
+		_, created := test.CreateSpaceTemplateCreated(t, svc.Context, svc, ctrl, &app.CreateSpaceTemplatePayload{
+			Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID)),
+		})
+		// when
+		res := test.ExportSpaceTemplateOK(t, svc.Context, svc, ctrl, *created.Data.ID)
+		// then
+		require.Equal(t, "application/x-yaml", res.Header().Get("Content-Type"))
+		rec, ok := res.(*httptest.ResponseRecorder)
+		require.True(t, ok)
+		exported, err := importer.FromString(rec.Body.String())
+		require.NoError(t, err)
+		require.Equal(t, *created.Data.ID, exported.Template.ID)
+		require.Equal(t, name, exported.Template.Name)
+		require.Len(t, exported.WITs, 1)
+		require.Equal(t, witID, exported.WITs[0].ID)
+	})
+
+	s.T().Run("system template", func(t *testing.T) {
+		svc, ctrl := s.SecuredController()
+		test.ExportSpaceTemplateOK(t, svc.Context, svc, ctrl, spacetemplate.SystemAgileTemplateID)
+	})
+
+	s.T().Run("not found", func(t *testing.T) {
+		svc, ctrl := s.SecuredController()
+		test.ExportSpaceTemplateNotFound(t, svc.Context, svc, ctrl, uuid.NewV4())
+	})
+}
+
+func (s *testSpaceTemplateSuite) TestSpaceTemplate_Diff() {
+	// given
+	svc, ctrl := s.SecuredController()
+	witID := uuid.NewV4()
+	name := "custom " + uuid.NewV4().String()
+	_, created := test.CreateSpaceTemplateCreated(s.T(), svc.Context, svc, ctrl, &app.CreateSpaceTemplatePayload{
+		Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID)),
+	})
+	templateID := *created.Data.ID
+
+	s.T().Run("with candidate", func(t *testing.T) {
+		// when
+		payload := &app.DiffSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID, uuid.NewV4()))}
+		_, diff := test.DiffSpaceTemplateOK(t, svc.Context, svc, ctrl, templateID, nil, payload)
+		// then
+		require.False(t, *diff.Data.Attributes.Empty)
+		require.Empty(t, diff.Data.Attributes.Template)
+		require.Equal(t, []string{"Type 1"}, diff.Data.Attributes.WorkItemTypes.Added)
+		require.Empty(t, diff.Data.Attributes.WorkItemTypes.Removed)
+		require.Empty(t, diff.Data.Attributes.WorkItemTypes.Changed)
+	})
+
+	s.T().Run("with itself", func(t *testing.T) {
+		_, diff := test.DiffSpaceTemplateOK(t, svc.Context, svc, ctrl, templateID, &templateID, nil)
+		require.True(t, *diff.Data.Attributes.Empty)
+	})
+
+	s.T().Run("with another template", func(t *testing.T) {
+		_, diff := test.DiffSpaceTemplateOK(t, svc.Context, svc, ctrl, templateID, &spacetemplate.SystemAgileTemplateID, nil)
+		require.False(t, *diff.Data.Attributes.Empty)
+		require.Equal(t, []*app.SpaceTemplateChange{
+			{Attribute: "description", Kind: "changed"},
+			{Attribute: "name", Kind: "changed"},
+		}, diff.Data.Attributes.Template)
+		require.Contains(t, diff.Data.Attributes.WorkItemTypes.Removed, "Type 0")
+	})
+
+	s.T().Run("bad request", func(t *testing.T) {
+		// neither another template nor a candidate
+		test.DiffSpaceTemplateBadRequest(t, svc.Context, svc, ctrl, templateID, nil, nil)
+		// both
+		payload := &app.DiffSpaceTemplatePayload{Data: newSpaceTemplatePayload(newSpaceTemplateYAML(name, witID))}
+		test.DiffSpaceTemplateBadRequest(t, svc.Context, svc, ctrl, templateID, &templateID, payload)
+	})
+
+	s.T().Run("not found", func(t *testing.T) {
+		otherID := uuid.NewV4()
+		test.DiffSpaceTemplateNotFound(t, svc.Context, svc, ctrl, templateID, &otherID, nil)
+	})
+}
+
 func convertSpaceTemplateSingleToModel(t *testing.T, appSpaceTemplate app.SpaceTemplateSingle) spacetemplate.SpaceTemplate {
 	return convertSpaceTemplateToModel(t, *appSpaceTemplate.Data)
 }
@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/templatemigration"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeSpaceTemplateMigrations is the JSONAPI type of a space
+// template migration
+const APIStringTypeSpaceTemplateMigrations = "spacetemplatemigrations"
+
+// SpaceTemplateMigrationController implements the space_template_migration resource.
+type SpaceTemplateMigrationController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewSpaceTemplateMigrationController creates a space_template_migration controller.
+func NewSpaceTemplateMigrationController(service *goa.Service, db application.DB) *SpaceTemplateMigrationController {
+	return &SpaceTemplateMigrationController{
+		Controller: service.NewController("SpaceTemplateMigrationController"),
+		db:         db,
+	}
+}
+
+// Show runs the show action.
+func (c *SpaceTemplateMigrationController) Show(ctx *app.ShowSpaceTemplateMigrationContext) error {
+	var m *templatemigration.Migration
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		m, err = appl.SpaceTemplateMigrations().Load(ctx, ctx.MigrationID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if m.SpaceID != ctx.SpaceID {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("space template migration", ctx.MigrationID.String()))
+	}
+	return ctx.OK(&app.SpaceTemplateMigrationSingle{
+		Data: ConvertSpaceTemplateMigration(ctx.Request, *m),
+	})
+}
+
+// List runs the list action.
+func (c *SpaceTemplateMigrationController) List(ctx *app.ListSpaceTemplateMigrationContext) error {
+	var migrations []templatemigration.Migration
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return err
+		}
+		var err error
+		migrations, err = appl.SpaceTemplateMigrations().List(ctx, ctx.SpaceID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.SpaceTemplateMigrationList{
+		Data: []*app.SpaceTemplateMigration{},
+	}
+	for _, m := range migrations {
+		res.Data = append(res.Data, ConvertSpaceTemplateMigration(ctx.Request, m))
+	}
+	return ctx.OK(res)
+}
+
+// Create runs the create action.
+func (c *SpaceTemplateMigrationController) Create(ctx *app.CreateSpaceTemplateMigrationContext) error {
+	currentUser, err := c.authorizeMigration(ctx, ctx.SpaceID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data", nil).Expected("not nil"))
+	}
+	m, err := convertSpaceTemplateMigrationFromPayload(ctx.Payload.Data)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	m.SpaceID = ctx.SpaceID
+	m.CreatorID = *currentUser
+	var created *templatemigration.Migration
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		created, err = appl.SpaceTemplateMigrations().Create(ctx, *m)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.SpaceTemplateMigrationHref(ctx.SpaceID, created.ID)))
+	return ctx.Created(&app.SpaceTemplateMigrationSingle{
+		Data: ConvertSpaceTemplateMigration(ctx.Request, *created),
+	})
+}
+
+// authorizeMigration returns the current user if it is the owner of the given
+// space and an error otherwise.
+func (c *SpaceTemplateMigrationController) authorizeMigration(ctx context.Context, spaceID uuid.UUID) (*uuid.UUID, error) {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return nil, goa.ErrUnauthorized(err.Error())
+	}
+	var s *space.Space
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		s, err = appl.Spaces().Load(ctx, spaceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !uuid.Equal(*currentUser, s.OwnerID) {
+		log.Warn(ctx, map[string]interface{}{
+			"space_id":     spaceID,
+			"space_owner":  s.OwnerID,
+			"current_user": *currentUser,
+		}, "user is not the space owner")
+		return nil, errors.NewForbiddenError("user is not the space owner")
+	}
+	return currentUser, nil
+}
+
+// convertSpaceTemplateMigrationFromPayload converts the payload of a create
+// request into a migration model.
+func convertSpaceTemplateMigrationFromPayload(data *app.SpaceTemplateMigration) (*templatemigration.Migration, error) {
+	if data.Relationships == nil || data.Relationships.TargetTemplate == nil ||
+		data.Relationships.TargetTemplate.Data == nil || data.Relationships.TargetTemplate.Data.ID == nil {
+		return nil, errors.NewBadParameterError("data.relationships.target_template", nil).Expected("not nil")
+	}
+	targetID, err := uuid.FromString(*data.Relationships.TargetTemplate.Data.ID)
+	if err != nil {
+		return nil, errors.NewBadParameterError("data.relationships.target_template.data.id", *data.Relationships.TargetTemplate.Data.ID).Expected("a UUID")
+	}
+	m := templatemigration.Migration{
+		TargetTemplateID: targetID,
+		TypeMapping:      templatemigration.TypeMappings{},
+		LinkTypeMapping:  templatemigration.LinkTypeMappings{},
+	}
+	attrs := data.Attributes
+	if attrs == nil {
+		return &m, nil
+	}
+	if attrs.DryRun != nil {
+		m.DryRun = *attrs.DryRun
+	}
+	for oldID, mapping := range attrs.TypeMapping {
+		id, err := uuid.FromString(oldID)
+		if err != nil {
+			return nil, errors.NewBadParameterError("data.attributes.type_mapping", oldID).Expected("work item type IDs as keys")
+		}
+		if mapping == nil {
+			return nil, errors.NewBadParameterError("data.attributes.type_mapping."+oldID, nil).Expected("not nil")
+		}
+		m.TypeMapping[id] = templatemigration.TypeMapping{
+			TypeID:     mapping.TypeID,
+			Fields:     mapping.Fields,
+			EnumValues: mapping.EnumValues,
+		}
+	}
+	for oldID, newID := range attrs.LinkTypeMapping {
+		id, err := uuid.FromString(oldID)
+		if err != nil {
+			return nil, errors.NewBadParameterError("data.attributes.link_type_mapping", oldID).Expected("link type IDs as keys")
+		}
+		m.LinkTypeMapping[id] = newID
+	}
+	return &m, nil
+}
+
+// ConvertSpaceTemplateMigration converts a space template migration from model
+// to app representation.
+func ConvertSpaceTemplateMigration(request *http.Request, m templatemigration.Migration) *app.SpaceTemplateMigration {
+	selfURL := rest.AbsoluteURL(request, app.SpaceTemplateMigrationHref(m.SpaceID, m.ID))
+	spaceURL := rest.AbsoluteURL(request, app.SpaceHref(m.SpaceID))
+	sourceURL := rest.AbsoluteURL(request, app.SpaceTemplateHref(m.SourceTemplateID))
+	targetURL := rest.AbsoluteURL(request, app.SpaceTemplateHref(m.TargetTemplateID))
+	creatorURL := rest.AbsoluteURL(request, app.UsersHref(m.CreatorID.String()))
+	res := &app.SpaceTemplateMigration{
+		Type: APIStringTypeSpaceTemplateMigrations,
+		ID:   &m.ID,
+		Attributes: &app.SpaceTemplateMigrationAttributes{
+			TypeMapping:       map[string]*app.SpaceTemplateMigrationTypeMapping{},
+			LinkTypeMapping:   map[string]uuid.UUID{},
+			DryRun:            ptr.Bool(m.DryRun),
+			State:             ptr.String(string(m.State)),
+			MigratedWorkItems: ptr.Int(m.MigratedWorkItems),
+			Error:             m.Error,
+			CreatedAt:         ptr.Time(m.CreatedAt.UTC()),
+			UpdatedAt:         ptr.Time(m.UpdatedAt.UTC()),
+		},
+		Relationships: &app.SpaceTemplateMigrationRelations{
+			Space: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: &space.SpaceType,
+					ID:   ptr.String(m.SpaceID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &spaceURL,
+					Related: &spaceURL,
+				},
+			},
+			SourceTemplate: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APISpaceTemplates),
+					ID:   ptr.String(m.SourceTemplateID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &sourceURL,
+					Related: &sourceURL,
+				},
+			},
+			TargetTemplate: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APISpaceTemplates),
+					ID:   ptr.String(m.TargetTemplateID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &targetURL,
+					Related: &targetURL,
+				},
+			},
+			Creator: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APIStringTypeUser),
+					ID:   ptr.String(m.CreatorID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &creatorURL,
+					Related: &creatorURL,
+				},
+			},
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+	for oldID, mapping := range m.TypeMapping {
+		res.Attributes.TypeMapping[oldID.String()] = &app.SpaceTemplateMigrationTypeMapping{
+			TypeID:     mapping.TypeID,
+			Fields:     mapping.Fields,
+			EnumValues: mapping.EnumValues,
+		}
+	}
+	for oldID, newID := range m.LinkTypeMapping {
+		res.Attributes.LinkTypeMapping[oldID.String()] = newID
+	}
+	if m.State != templatemigration.StatePending {
+		res.Attributes.Report = convertSpaceTemplateMigrationReport(m.Report)
+	}
+	return res
+}
+
+func convertSpaceTemplateMigrationReport(r templatemigration.Report) *app.SpaceTemplateMigrationReport {
+	res := &app.SpaceTemplateMigrationReport{
+		WorkItems:     r.WorkItems,
+		Links:         r.Links,
+		Types:         []*app.SpaceTemplateMigrationTypeReport{},
+		LinkTypes:     []*app.SpaceTemplateMigrationLinkTypeReport{},
+		RemovedBoards: r.RemovedBoards,
+		Problems:      r.Problems,
+	}
+	for _, t := range r.Types {
+		res.Types = append(res.Types, convertSpaceTemplateMigrationTypeReport(t))
+	}
+	for _, t := range r.Unplanned {
+		res.Unplanned = append(res.Unplanned, convertSpaceTemplateMigrationTypeReport(t))
+	}
+	for _, l := range r.LinkTypes {
+		lr := &app.SpaceTemplateMigrationLinkTypeReport{
+			SourceLinkTypeID:   l.SourceLinkTypeID,
+			SourceLinkTypeName: l.SourceLinkTypeName,
+			TargetLinkTypeID:   l.TargetLinkTypeID,
+			Links:              l.Links,
+		}
+		if l.TargetLinkTypeName != "" {
+			lr.TargetLinkTypeName = ptr.String(l.TargetLinkTypeName)
+		}
+		res.LinkTypes = append(res.LinkTypes, lr)
+	}
+	return res
+}
+
+func convertSpaceTemplateMigrationTypeReport(t templatemigration.TypeReport) *app.SpaceTemplateMigrationTypeReport {
+	res := &app.SpaceTemplateMigrationTypeReport{
+		SourceTypeID:   t.SourceTypeID,
+		SourceTypeName: t.SourceTypeName,
+		TargetTypeID:   t.TargetTypeID,
+		WorkItems:      t.WorkItems,
+		MovedFields:    t.MovedFields,
+		UnmappedValues: t.UnmappedValues,
+	}
+	if t.TargetTypeName != "" {
+		res.TargetTypeName = ptr.String(t.TargetTypeName)
+	}
+	return res
+}
@@ -0,0 +1,123 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSpaceTemplateMigrationREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunSpaceTemplateMigrationREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestSpaceTemplateMigrationREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func newSpaceTemplateMigrationPayload(targetTemplateID uuid.UUID, mapping map[string]*app.SpaceTemplateMigrationTypeMapping) *app.CreateSpaceTemplateMigrationPayload {
+	return &app.CreateSpaceTemplateMigrationPayload{
+		Data: &app.SpaceTemplateMigration{
+			Type: APIStringTypeSpaceTemplateMigrations,
+			Attributes: &app.SpaceTemplateMigrationAttributes{
+				TypeMapping: mapping,
+				DryRun:      ptr.Bool(true),
+			},
+			Relationships: &app.SpaceTemplateMigrationRelations{
+				TargetTemplate: &app.RelationGeneric{
+					Data: &app.GenericData{
+						Type: ptr.String(APISpaceTemplates),
+						ID:   ptr.String(targetTemplateID.String()),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (rest *TestSpaceTemplateMigrationREST) TestMigration() {
+	fxt := tf.NewTestFixture(rest.T(), rest.DB,
+		tf.Identities(2, tf.SetIdentityUsernames("space owner", "other")),
+		tf.SpaceTemplates(2),
+		tf.Spaces(2),
+		tf.WorkItemTypes(2, tf.SetWorkItemTypeNames("bug", "task"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemTypes[idx].SpaceTemplateID = fxt.SpaceTemplates[idx].ID
+			return nil
+		}),
+		tf.WorkItems(1),
+	)
+	spaceID := fxt.Spaces[0].ID
+	targetID := fxt.SpaceTemplates[1].ID
+	mapping := map[string]*app.SpaceTemplateMigrationTypeMapping{
+		fxt.WorkItemTypes[0].ID.String(): {TypeID: fxt.WorkItemTypes[1].ID},
+	}
+	svc := testsupport.ServiceAsUser("SpaceTemplateMigration-Service", *fxt.IdentityByUsername("space owner"))
+	ctrl := NewSpaceTemplateMigrationController(svc, rest.GormDB)
+
+	rest.T().Run("forbidden for other users", func(t *testing.T) {
+		otherSvc := testsupport.ServiceAsUser("SpaceTemplateMigration-Service", *fxt.IdentityByUsername("other"))
+		otherCtrl := NewSpaceTemplateMigrationController(otherSvc, rest.GormDB)
+		test.CreateSpaceTemplateMigrationForbidden(t, otherSvc.Context, otherSvc, otherCtrl, spaceID, newSpaceTemplateMigrationPayload(targetID, mapping))
+	})
+	rest.T().Run("unauthorized", func(t *testing.T) {
+		anonSvc := goa.New("SpaceTemplateMigration-Service")
+		test.CreateSpaceTemplateMigrationUnauthorized(t, anonSvc.Context, anonSvc, NewSpaceTemplateMigrationController(anonSvc, rest.GormDB), spaceID, newSpaceTemplateMigrationPayload(targetID, mapping))
+	})
+	rest.T().Run("bad request", func(t *testing.T) {
+		t.Run("no target template", func(t *testing.T) {
+			payload := newSpaceTemplateMigrationPayload(targetID, mapping)
+			payload.Data.Relationships = nil
+			test.CreateSpaceTemplateMigrationBadRequest(t, svc.Context, svc, ctrl, spaceID, payload)
+		})
+		t.Run("same template", func(t *testing.T) {
+			test.CreateSpaceTemplateMigrationBadRequest(t, svc.Context, svc, ctrl, spaceID, newSpaceTemplateMigrationPayload(fxt.SpaceTemplates[0].ID, mapping))
+		})
+		t.Run("invalid type mapping key", func(t *testing.T) {
+			payload := newSpaceTemplateMigrationPayload(targetID, map[string]*app.SpaceTemplateMigrationTypeMapping{
+				"foo": {TypeID: fxt.WorkItemTypes[1].ID},
+			})
+			test.CreateSpaceTemplateMigrationBadRequest(t, svc.Context, svc, ctrl, spaceID, payload)
+		})
+	})
+	rest.T().Run("create, show and list", func(t *testing.T) {
+		// create
+		res, created := test.CreateSpaceTemplateMigrationCreated(t, svc.Context, svc, ctrl, spaceID, newSpaceTemplateMigrationPayload(targetID, mapping))
+		require.NotNil(t, created.Data.ID)
+		assert.NotEmpty(t, res.Header().Get("Location"))
+		assert.Equal(t, "pending", *created.Data.Attributes.State)
+		assert.True(t, *created.Data.Attributes.DryRun)
+		assert.Nil(t, created.Data.Attributes.Report)
+		require.Contains(t, created.Data.Attributes.TypeMapping, fxt.WorkItemTypes[0].ID.String())
+		assert.Equal(t, fxt.WorkItemTypes[1].ID, created.Data.Attributes.TypeMapping[fxt.WorkItemTypes[0].ID.String()].TypeID)
+		assert.Equal(t, fxt.SpaceTemplates[0].ID.String(), *created.Data.Relationships.SourceTemplate.Data.ID)
+		// a second unfinished migration is rejected
+		test.CreateSpaceTemplateMigrationConflict(t, svc.Context, svc, ctrl, spaceID, newSpaceTemplateMigrationPayload(targetID, mapping))
+		// show
+		_, shown := test.ShowSpaceTemplateMigrationOK(t, svc.Context, svc, ctrl, spaceID, *created.Data.ID)
+		assert.Equal(t, *created.Data.ID, *shown.Data.ID)
+		test.ShowSpaceTemplateMigrationNotFound(t, svc.Context, svc, ctrl, fxt.Spaces[1].ID, *created.Data.ID)
+		// list
+		_, list := test.ListSpaceTemplateMigrationOK(t, svc.Context, svc, ctrl, spaceID)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, *created.Data.ID, *list.Data[0].ID)
+		_, list = test.ListSpaceTemplateMigrationOK(t, svc.Context, svc, ctrl, fxt.Spaces[1].ID)
+		assert.Empty(t, list.Data)
+	})
+	rest.T().Run("unknown space", func(t *testing.T) {
+		test.CreateSpaceTemplateMigrationNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), newSpaceTemplateMigrationPayload(targetID, mapping))
+		test.ListSpaceTemplateMigrationNotFound(t, svc.Context, svc, ctrl, uuid.NewV4())
+		test.ShowSpaceTemplateMigrationNotFound(t, svc.Context, svc, ctrl, spaceID, uuid.NewV4())
+	})
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SpaceWorkItemBoardsController implements the space_work_item_boards resource.
+type SpaceWorkItemBoardsController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewSpaceWorkItemBoardsController creates a space_work_item_boards controller.
+func NewSpaceWorkItemBoardsController(service *goa.Service, db application.DB) *SpaceWorkItemBoardsController {
+	return &SpaceWorkItemBoardsController{
+		Controller: service.NewController("SpaceWorkItemBoardsController"),
+		db:         db,
+	}
+}
+
+// List runs the list action.
+func (c *SpaceWorkItemBoardsController) List(ctx *app.ListSpaceWorkItemBoardsContext) error {
+	var boards []*workitem.Board
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return errs.WithStack(err)
+		}
+		var err error
+		boards, err = appl.Boards().ListBySpace(ctx, ctx.SpaceID)
+		return errs.WithStack(err)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.WorkItemBoardList{
+		Data: make([]*app.WorkItemBoardData, len(boards)),
+		Links: &app.WorkItemBoardLinks{
+			Self: rest.AbsoluteURL(ctx.Request, app.SpaceWorkItemBoardsHref(ctx.SpaceID)),
+		},
+	}
+	for i, board := range boards {
+		res.Data[i] = ConvertBoardFromModel(ctx.Request, *board)
+		for _, column := range board.Columns {
+			res.Included = append(res.Included, ConvertColumnsFromModel(ctx.Request, column))
+		}
+	}
+	return ctx.OK(res)
+}
+
+// Create runs the create action.
+func (c *SpaceWorkItemBoardsController) Create(ctx *app.CreateSpaceWorkItemBoardsContext) error {
+	if _, err := login.ContextIdentity(ctx); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if err := authorizeSpaceBoards(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil || ctx.Payload.Data.Attributes == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes", nil).Expected("not nil"))
+	}
+	var board *workitem.Board
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx, ctx.SpaceID)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		b := workitem.Board{
+			SpaceID:         ptr.UUID(s.ID),
+			SpaceTemplateID: s.SpaceTemplateID,
+		}
+		// start with a copy of the template board if one is given
+		rel := ctx.Payload.Data.Relationships
+		if rel != nil && rel.Template != nil && rel.Template.Data != nil && rel.Template.Data.ID != nil {
+			templateID, err := uuid.FromString(*rel.Template.Data.ID)
+			if err != nil {
+				return errors.NewBadParameterError("data.relationships.template.data.id", *rel.Template.Data.ID).Expected("a UUID")
+			}
+			template, err := appl.Boards().Load(ctx, templateID)
+			if err != nil {
+				return errs.WithStack(err)
+			}
+			if template.SpaceID != nil || template.SpaceTemplateID != s.SpaceTemplateID {
+				return errors.NewBadParameterError("data.relationships.template.data.id", templateID).Expected("a board of the space's template")
+			}
+			b.Description = template.Description
+			b.Swimlane = template.Swimlane
+			for _, column := range template.Columns {
+				column.ID = uuid.Nil
+				column.BoardID = uuid.Nil
+				column.Lifecycle = gormsupport.Lifecycle{}
+				b.Columns = append(b.Columns, column)
+			}
+		}
+		updateBoardFromPayload(&b, ctx.Payload.Data.Attributes)
+		board, err = appl.Boards().Create(ctx, b)
+		return errs.WithStack(err)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := convertBoardSingle(ctx.Request, *board, nil)
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.WorkItemBoardHref(board.ID)))
+	return ctx.Created(res)
+}
+
+// authorizeSpaceBoards returns an error if the current user is not allowed to
+// customize the boards of the given space.
+func authorizeSpaceBoards(ctx context.Context, spaceID uuid.UUID) error {
+	authorized, err := authz.Authorize(ctx, spaceID.String())
+	if err != nil {
+		return errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return errors.NewForbiddenError("user is not authorized to access the space")
+	}
+	return nil
+}
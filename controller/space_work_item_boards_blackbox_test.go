@@ -0,0 +1,139 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type spaceWorkItemBoardsSuite struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestSpaceWorkItemBoardsSuite(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &spaceWorkItemBoardsSuite{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *spaceWorkItemBoardsSuite) TestCreateUpdateDelete() {
+	// given a space and a board of its space template
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Spaces(1), tf.WorkItemBoards(1))
+	template := fxt.WorkItemBoards[0]
+	svc := testsupport.ServiceAsSpaceUser("SpaceBoards-Service", *fxt.Identities[0], &TestSpaceAuthzService{*fxt.Identities[0], ""})
+	spaceCtrl := NewSpaceWorkItemBoardsController(svc, gormapplication.NewGormDB(s.DB))
+	boardCtrl := NewWorkItemBoardController(svc, gormapplication.NewGormDB(s.DB))
+
+	var created *app.WorkItemBoardSingle
+	s.T().Run("create from template board", func(t *testing.T) {
+		// when
+		payload := app.CreateSpaceWorkItemBoardsPayload{
+			Data: &app.WorkItemBoardData{
+				Type: APIWorkItemBoards,
+				Attributes: &app.WorkItemBoardAttributes{
+					Name:        "Team Board",
+					Context:     template.Context,
+					ContextType: template.ContextType,
+					Swimlane:    ptr.String(string(workitem.SwimlaneAssignee)),
+				},
+				Relationships: &app.WorkItemBoardRelationships{
+					Template: &app.RelationGeneric{
+						Data: &app.GenericData{
+							ID:   ptr.String(template.ID.String()),
+							Type: ptr.String(APIWorkItemBoards),
+						},
+					},
+				},
+			},
+		}
+		_, created = test.CreateSpaceWorkItemBoardsCreated(t, svc.Context, svc, spaceCtrl, fxt.Spaces[0].ID, &payload)
+		// then
+		require.Equal(t, "Team Board", created.Data.Attributes.Name)
+		require.Equal(t, template.Description, *created.Data.Attributes.Description)
+		require.Equal(t, string(workitem.SwimlaneAssignee), *created.Data.Attributes.Swimlane)
+		require.Equal(t, fxt.Spaces[0].ID.String(), *created.Data.Relationships.Space.Data.ID)
+		require.Len(t, created.Included, len(template.Columns))
+		for i, c := range created.Included {
+			require.NotEqual(t, template.Columns[i].ID, c.ID)
+			require.Equal(t, template.Columns[i].Name, c.Attributes.Name)
+			require.Equal(t, 0, *c.Attributes.WipCount)
+		}
+		t.Run("list", func(t *testing.T) {
+			_, list := test.ListSpaceWorkItemBoardsOK(t, svc.Context, svc, spaceCtrl, fxt.Spaces[0].ID)
+			require.Len(t, list.Data, 1)
+			require.Equal(t, *created.Data.ID, *list.Data[0].ID)
+		})
+	})
+
+	s.T().Run("update columns and WIP limits", func(t *testing.T) {
+		// given
+		require.NotNil(t, created)
+		first := created.Included[0]
+		payload := app.UpdateWorkItemBoardPayload{
+			Data: &app.WorkItemBoardData{
+				Type:       APIWorkItemBoards,
+				Attributes: created.Data.Attributes,
+			},
+		}
+		payload.Data.Attributes.Columns = []*app.WorkItemBoardColumnPayload{
+			{
+				ID: &first.ID,
+				Attributes: &app.WorkItemBoardColumnAttributes{
+					Name:              first.Attributes.Name,
+					TransRuleKey:      first.Attributes.TransRuleKey,
+					TransRuleArgument: first.Attributes.TransRuleArgument,
+					WipLimit:          ptr.Int(2),
+					WipLimitMode:      ptr.String(string(workitem.WIPLimitBlock)),
+				},
+			},
+		}
+		// when
+		_, updated := test.UpdateWorkItemBoardOK(t, svc.Context, svc, boardCtrl, *created.Data.ID, &payload)
+		// then
+		require.Equal(t, *created.Data.Attributes.Version+1, *updated.Data.Attributes.Version)
+		require.Len(t, updated.Included, 1)
+		require.Equal(t, first.ID, updated.Included[0].ID)
+		require.Equal(t, 2, *updated.Included[0].Attributes.WipLimit)
+		require.Equal(t, string(workitem.WIPLimitBlock), *updated.Included[0].Attributes.WipLimitMode)
+		require.False(t, *updated.Included[0].Attributes.WipExceeded)
+		t.Run("version conflict", func(t *testing.T) {
+			test.UpdateWorkItemBoardConflict(t, svc.Context, svc, boardCtrl, *created.Data.ID, &payload)
+		})
+	})
+
+	s.T().Run("template boards cannot be changed", func(t *testing.T) {
+		payload := app.UpdateWorkItemBoardPayload{
+			Data: &app.WorkItemBoardData{
+				Type: APIWorkItemBoards,
+				Attributes: &app.WorkItemBoardAttributes{
+					Name:        "foo",
+					Context:     template.Context,
+					ContextType: template.ContextType,
+					Version:     ptr.Int(template.Version),
+				},
+			},
+		}
+		test.UpdateWorkItemBoardForbidden(t, svc.Context, svc, boardCtrl, template.ID, &payload)
+		test.DeleteWorkItemBoardForbidden(t, svc.Context, svc, boardCtrl, template.ID)
+	})
+
+	s.T().Run("unauthorized user", func(t *testing.T) {
+		other := testsupport.ServiceAsSpaceUser("SpaceBoards-Service", *fxt.Identities[0], &TestSpaceAuthzService{testsupport.TestIdentity, ""})
+		test.DeleteWorkItemBoardForbidden(t, other.Context, other, NewWorkItemBoardController(other, gormapplication.NewGormDB(s.DB)), *created.Data.ID)
+	})
+
+	s.T().Run("delete", func(t *testing.T) {
+		test.DeleteWorkItemBoardNoContent(t, svc.Context, svc, boardCtrl, *created.Data.ID)
+		test.ShowWorkItemBoardNotFound(t, svc.Context, svc, boardCtrl, *created.Data.ID)
+	})
+}
@@ -0,0 +1,274 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/worklog"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SpaceWorklogsController implements the space_worklogs resource.
+type SpaceWorklogsController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewSpaceWorklogsController creates a space_worklogs controller.
+func NewSpaceWorklogsController(service *goa.Service, db application.DB) *SpaceWorklogsController {
+	return &SpaceWorklogsController{
+		Controller: service.NewController("SpaceWorklogsController"),
+		db:         db,
+	}
+}
+
+// List runs the list action.
+func (c *SpaceWorklogsController) List(ctx *app.ListSpaceWorklogsContext) error {
+	filter := worklog.Filter{
+		SpaceID:     ctx.SpaceID,
+		IdentityID:  ctx.FilterIdentity,
+		IterationID: ctx.FilterIteration,
+		From:        ctx.FilterFrom,
+		To:          ctx.FilterTo,
+	}
+	if err := c.checkCollaborator(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var worklogs []worklog.Worklog
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		worklogs, err = appl.Worklogs().List(ctx, filter)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var total int64
+	for _, w := range worklogs {
+		total += w.Duration
+	}
+	return ctx.OK(&app.SpaceWorklogList{
+		Data: ConvertWorklogs(ctx.Request, worklogs),
+		Meta: &app.SpaceWorklogListMeta{
+			TotalCount:     len(worklogs),
+			TotalTimeSpent: int(total),
+		},
+	})
+}
+
+// Timesheet runs the timesheet action.
+func (c *SpaceWorklogsController) Timesheet(ctx *app.TimesheetSpaceWorklogsContext) error {
+	filter := worklog.Filter{
+		SpaceID:     ctx.SpaceID,
+		IdentityID:  ctx.FilterIdentity,
+		IterationID: ctx.FilterIteration,
+		From:        ctx.FilterFrom,
+		To:          ctx.FilterTo,
+	}
+	groupBy := make([]worklog.GroupBy, len(ctx.GroupBy))
+	for i, g := range ctx.GroupBy {
+		groupBy[i] = worklog.GroupBy(g)
+	}
+	if err := c.checkCollaborator(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var grid [][]string
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		if len(groupBy) == 0 {
+			grid, err = timesheetEntries(ctx, appl, filter)
+		} else {
+			grid, err = timesheetSummaries(ctx, appl, filter, groupBy)
+		}
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	for _, line := range grid {
+		for i := range line {
+			line[i] = escapeCSVCell(line[i])
+		}
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(grid); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "failed to write the timesheet"))
+	}
+	timeStr := time.Now().UTC().Format(time.RFC3339)
+	ctx.ResponseData.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "timesheet-" + timeStr + ".csv"}))
+	return ctx.OK(buf.Bytes())
+}
+
+// checkCollaborator returns an error unless the given space exists and the
+// current user is a collaborator of it.
+func (c *SpaceWorklogsController) checkCollaborator(ctx context.Context, spaceID uuid.UUID) error {
+	if _, err := login.ContextIdentity(ctx); err != nil {
+		return goa.ErrUnauthorized(err.Error())
+	}
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		return appl.Spaces().CheckExists(ctx, spaceID)
+	})
+	if err != nil {
+		return err
+	}
+	return authorizeWorklogAccess(ctx, spaceID)
+}
+
+// timesheetEntries returns the header and one line per worklog matching the
+// given filter.
+func timesheetEntries(ctx context.Context, appl application.Application, filter worklog.Filter) ([][]string, error) {
+	worklogs, err := appl.Worklogs().List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	workItemIDs := make([]uuid.UUID, 0, len(worklogs))
+	for _, w := range worklogs {
+		workItemIDs = append(workItemIDs, w.WorkItemID)
+	}
+	workItems := map[uuid.UUID]*workitem.WorkItem{}
+	if len(workItemIDs) > 0 {
+		wis, err := appl.WorkItems().LoadBatchByID(ctx, workItemIDs)
+		if err != nil {
+			return nil, errs.Wrap(err, "failed to load the work items of the worklogs")
+		}
+		for _, wi := range wis {
+			workItems[wi.ID] = wi
+		}
+	}
+	names := timesheetNames{}
+	grid := [][]string{{"Started At", "Identity", "Work Item", "Title", "Iteration", "Hours", "Comment"}}
+	for _, w := range worklogs {
+		identity, err := names.identity(ctx, appl, w.IdentityID)
+		if err != nil {
+			return nil, err
+		}
+		var number, title, iteration string
+		if wi, ok := workItems[w.WorkItemID]; ok {
+			number = fmt.Sprint(wi.Fields[workitem.SystemNumber])
+			if t, ok := wi.Fields[workitem.SystemTitle].(string); ok {
+				title = t
+			}
+			if iterationID, err := uuid.FromString(fmt.Sprint(wi.Fields[workitem.SystemIteration])); err == nil {
+				if iteration, err = names.iteration(ctx, appl, iterationID); err != nil {
+					return nil, err
+				}
+			}
+		}
+		grid = append(grid, []string{
+			w.StartedAt.UTC().Format(time.RFC3339),
+			identity,
+			number,
+			title,
+			iteration,
+			formatHours(w.Duration),
+			w.Comment,
+		})
+	}
+	return grid, nil
+}
+
+// timesheetSummaries returns the header and one line per group of the
+// worklogs matching the given filter.
+func timesheetSummaries(ctx context.Context, appl application.Application, filter worklog.Filter, groupBy []worklog.GroupBy) ([][]string, error) {
+	summaries, err := appl.Worklogs().Summarize(ctx, filter, groupBy...)
+	if err != nil {
+		return nil, err
+	}
+	header := []string{}
+	for _, g := range groupBy {
+		switch g {
+		case worklog.GroupByIdentity:
+			header = append(header, "Identity")
+		case worklog.GroupByIteration:
+			header = append(header, "Iteration")
+		}
+	}
+	names := timesheetNames{}
+	grid := [][]string{append(header, "Worklogs", "Hours")}
+	for _, s := range summaries {
+		line := []string{}
+		for _, g := range groupBy {
+			switch g {
+			case worklog.GroupByIdentity:
+				identity, err := names.identity(ctx, appl, s.IdentityID.UUID)
+				if err != nil {
+					return nil, err
+				}
+				line = append(line, identity)
+			case worklog.GroupByIteration:
+				var iteration string
+				if s.IterationID.Valid {
+					if iteration, err = names.iteration(ctx, appl, s.IterationID.UUID); err != nil {
+						return nil, err
+					}
+				}
+				line = append(line, iteration)
+			}
+		}
+		grid = append(grid, append(line, strconv.Itoa(s.Count), formatHours(s.Duration)))
+	}
+	return grid, nil
+}
+
+// timesheetNames caches the names of the identities and iterations shown in
+// a timesheet.
+type timesheetNames map[uuid.UUID]string
+
+func (n timesheetNames) identity(ctx context.Context, appl application.Application, id uuid.UUID) (string, error) {
+	if name, ok := n[id]; ok {
+		return name, nil
+	}
+	identity, err := appl.Identities().Load(ctx, id)
+	if err != nil {
+		return "", errs.Wrapf(err, "failed to load identity %s", id)
+	}
+	n[id] = identity.Username
+	return identity.Username, nil
+}
+
+func (n timesheetNames) iteration(ctx context.Context, appl application.Application, id uuid.UUID) (string, error) {
+	if name, ok := n[id]; ok {
+		return name, nil
+	}
+	itr, err := appl.Iterations().Load(ctx, id)
+	if ok, _ := errors.IsNotFoundError(err); ok {
+		// the work item still refers to a deleted iteration
+		n[id] = ""
+		return "", nil
+	}
+	if err != nil {
+		return "", errs.Wrapf(err, "failed to load iteration %s", id)
+	}
+	n[id] = itr.Name
+	return itr.Name, nil
+}
+
+// escapeCSVCell prefixes the given cell with a quote if it starts with a
+// character that makes spreadsheet applications evaluate it as a formula. The
+// titles and comments in a timesheet are entered by users.
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// formatHours formats the given number of seconds as hours with two decimals
+func formatHours(seconds int64) string {
+	return strconv.FormatFloat(float64(seconds)/3600, 'f', 2, 64)
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// APIStringTypeVelocities is the JSONAPI type of a velocity
+	APIStringTypeVelocities = "velocities"
+	// APIStringTypeIterationPlans is the JSONAPI type of an iteration plan
+	APIStringTypeIterationPlans = "iterationplans"
+)
+
+// VelocityController implements the velocity resource.
+type VelocityController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewVelocityController creates a velocity controller.
+func NewVelocityController(service *goa.Service, db application.DB) *VelocityController {
+	return &VelocityController{
+		Controller: service.NewController("VelocityController"),
+		db:         db,
+	}
+}
+
+// Show returns the velocity of the latest iterations of a space that have
+// already ended.
+func (c *VelocityController) Show(ctx *app.ShowVelocityContext) error {
+	var field string
+	if ctx.Field != nil {
+		field = *ctx.Field
+	}
+	var v *workitem.Velocity
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		err := appl.Spaces().CheckExists(ctx, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		iterations, err := appl.Iterations().List(ctx, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		v, err = appl.WorkItems().Velocity(ctx, iteration.PastIterations(iterations, time.Now(), ctx.History), field)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.VelocitySingle{
+		Data: ConvertVelocity(ctx.Request, ctx.SpaceID, *v),
+	})
+}
+
+// ConvertVelocity converts a velocity from model to app representation.
+func ConvertVelocity(request *http.Request, spaceID uuid.UUID, v workitem.Velocity) *app.Velocity {
+	selfURL := rest.AbsoluteURL(request, app.VelocityHref(spaceID))
+	res := &app.Velocity{
+		Type: APIStringTypeVelocities,
+		ID:   spaceID,
+		Attributes: &app.VelocityAttributes{
+			Average:            v.Average,
+			AveragePerAssignee: convertAssigneeValues(v.AveragePerAssignee),
+			Iterations:         make([]*app.IterationVelocity, len(v.Iterations)),
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+	if v.Field != "" {
+		res.Attributes.Field = ptr.String(v.Field)
+	}
+	for i, itr := range v.Iterations {
+		res.Attributes.Iterations[i] = &app.IterationVelocity{
+			ID:          itr.IterationID,
+			Name:        itr.Name,
+			StartAt:     itr.StartAt,
+			EndAt:       itr.EndAt,
+			Closed:      itr.Closed,
+			Value:       itr.Value,
+			PerAssignee: convertAssigneeValues(itr.PerAssignee),
+		}
+	}
+	return res
+}
+
+// convertAssigneeValues converts a map of values per assignee to a map keyed
+// by the string representation of the identity IDs.
+func convertAssigneeValues(values map[uuid.UUID]float64) map[string]float64 {
+	res := make(map[string]float64, len(values))
+	for id, v := range values {
+		res[id.String()] = v
+	}
+	return res
+}
+
+// IterationPlanningController implements the iteration_planning resource.
+type IterationPlanningController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewIterationPlanningController creates an iteration_planning controller.
+func NewIterationPlanningController(service *goa.Service, db application.DB) *IterationPlanningController {
+	return &IterationPlanningController{
+		Controller: service.NewController("IterationPlanningController"),
+		db:         db,
+	}
+}
+
+// Show compares the work committed to an iteration with its capacity and the
+// velocity of the iterations that ended before it starts.
+func (c *IterationPlanningController) Show(ctx *app.ShowIterationPlanningContext) error {
+	id, err := uuid.FromString(ctx.IterationID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	var field string
+	if ctx.Field != nil {
+		field = *ctx.Field
+	}
+	var plan *workitem.IterationPlan
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		itr, err := appl.Iterations().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		iterations, err := appl.Iterations().List(ctx, itr.SpaceID)
+		if err != nil {
+			return err
+		}
+		before := time.Now()
+		if itr.StartAt != nil {
+			before = *itr.StartAt
+		}
+		v, err := appl.WorkItems().Velocity(ctx, iteration.PastIterations(iterations, before, ctx.History), field)
+		if err != nil {
+			return err
+		}
+		plan, err = appl.WorkItems().PlanIteration(ctx, *itr, *v)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.IterationPlanSingle{
+		Data: ConvertIterationPlan(ctx.Request, *plan),
+	})
+}
+
+// ConvertIterationPlan converts an iteration plan from model to app
+// representation.
+func ConvertIterationPlan(request *http.Request, p workitem.IterationPlan) *app.IterationPlan {
+	selfURL := rest.AbsoluteURL(request, app.IterationPlanningHref(p.IterationID))
+	res := &app.IterationPlan{
+		Type: APIStringTypeIterationPlans,
+		ID:   p.IterationID,
+		Attributes: &app.IterationPlanAttributes{
+			CapacityUnit: p.CapacityUnit.String(),
+			Capacity:     p.Capacity,
+			Committed:    p.Committed,
+			Unassigned:   p.Unassigned,
+			Velocity:     p.Velocity,
+			Assignees:    make([]*app.AssigneeLoad, len(p.Assignees)),
+			Warnings:     p.Warnings,
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+	if res.Attributes.Warnings == nil {
+		res.Attributes.Warnings = []string{}
+	}
+	if p.Field != "" {
+		res.Attributes.Field = ptr.String(p.Field)
+	}
+	for i, a := range p.Assignees {
+		res.Attributes.Assignees[i] = &app.AssigneeLoad{
+			Assignee:      a.AssigneeID,
+			Capacity:      a.Capacity,
+			Committed:     a.Committed,
+			Velocity:      a.Velocity,
+			Overcommitted: a.Overcommitted,
+		}
+	}
+	return res
+}
@@ -0,0 +1,107 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestVelocityREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunVelocityREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestVelocityREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+// createPlanningFixture creates a past iteration with two closed work items
+// and an upcoming iteration with two open work items, all of them assigned to
+// the first identity of the fixture. The capacity of the identity in the
+// upcoming iteration is 1.
+func (rest *TestVelocityREST) createPlanningFixture() *tf.TestFixture {
+	day := 24 * time.Hour
+	pastStart, pastEnd := time.Now().Add(-20*day), time.Now().Add(-10*day)
+	nextStart, nextEnd := time.Now().Add(day), time.Now().Add(14*day)
+	return tf.NewTestFixture(rest.T(), rest.DB,
+		tf.Iterations(3, tf.SetIterationNames("root", "past", "next"), tf.PlaceIterationUnderRootIteration(), func(fxt *tf.TestFixture, idx int) error {
+			switch idx {
+			case 1:
+				fxt.Iterations[idx].StartAt = &pastStart
+				fxt.Iterations[idx].EndAt = &pastEnd
+			case 2:
+				fxt.Iterations[idx].StartAt = &nextStart
+				fxt.Iterations[idx].EndAt = &nextEnd
+				fxt.Iterations[idx].Capacity = iteration.Capacity{fxt.Identities[0].ID: 1}
+			}
+			return nil
+		}),
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItems[idx].Fields[workitem.SystemAssignees] = []string{fxt.Identities[0].ID.String()}
+			if idx < 2 {
+				fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.IterationByName("past").ID.String()
+				fxt.WorkItems[idx].Fields[workitem.SystemState] = workitem.SystemStateClosed
+			} else {
+				fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.IterationByName("next").ID.String()
+			}
+			return nil
+		}),
+	)
+}
+
+func (rest *TestVelocityREST) TestShowVelocity() {
+	fxt := rest.createPlanningFixture()
+	svc := testsupport.ServiceAsUser("Velocity-Service", *fxt.Identities[0])
+	ctrl := NewVelocityController(svc, rest.GormDB)
+
+	rest.T().Run("ok", func(t *testing.T) {
+		_, v := test.ShowVelocityOK(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, nil, nil)
+		require.NotNil(t, v.Data.Attributes)
+		assert.Equal(t, fxt.Spaces[0].ID, v.Data.ID)
+		require.Len(t, v.Data.Attributes.Iterations, 1)
+		assert.Equal(t, fxt.IterationByName("past").ID, v.Data.Attributes.Iterations[0].ID)
+		assert.Equal(t, 2, v.Data.Attributes.Iterations[0].Closed)
+		assert.Equal(t, 2.0, v.Data.Attributes.Average)
+		assert.Equal(t, map[string]float64{fxt.Identities[0].ID.String(): 2}, v.Data.Attributes.AveragePerAssignee)
+	})
+	rest.T().Run("not found", func(t *testing.T) {
+		test.ShowVelocityNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), nil, nil)
+	})
+}
+
+func (rest *TestVelocityREST) TestShowIterationPlanning() {
+	fxt := rest.createPlanningFixture()
+	svc := testsupport.ServiceAsUser("IterationPlanning-Service", *fxt.Identities[0])
+	ctrl := NewIterationPlanningController(svc, rest.GormDB)
+
+	rest.T().Run("ok", func(t *testing.T) {
+		_, p := test.ShowIterationPlanningOK(t, svc.Context, svc, ctrl, fxt.IterationByName("next").ID.String(), nil, nil)
+		require.NotNil(t, p.Data.Attributes)
+		assert.Equal(t, fxt.IterationByName("next").ID, p.Data.ID)
+		assert.Equal(t, iteration.CapacityUnitPoints.String(), p.Data.Attributes.CapacityUnit)
+		assert.Equal(t, 1.0, p.Data.Attributes.Capacity)
+		assert.Equal(t, 2.0, p.Data.Attributes.Committed)
+		assert.Equal(t, 2.0, p.Data.Attributes.Velocity)
+		require.Len(t, p.Data.Attributes.Assignees, 1)
+		assert.Equal(t, fxt.Identities[0].ID, p.Data.Attributes.Assignees[0].Assignee)
+		assert.Equal(t, ptr.Float64(1), p.Data.Attributes.Assignees[0].Capacity)
+		assert.True(t, p.Data.Attributes.Assignees[0].Overcommitted)
+		assert.NotEmpty(t, p.Data.Attributes.Warnings)
+	})
+	rest.T().Run("not found", func(t *testing.T) {
+		test.ShowIterationPlanningNotFound(t, svc.Context, svc, ctrl, uuid.NewV4().String(), nil, nil)
+	})
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/attachment"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/goadesign/goa"
+)
+
+// WorkItemAttachmentsController implements the work_item_attachments resource.
+type WorkItemAttachmentsController struct {
+	*goa.Controller
+	db      application.DB
+	storage attachment.BlobStorage
+	config  WorkItemAttachmentsControllerConfiguration
+}
+
+// WorkItemAttachmentsControllerConfiguration configuration for the WorkItemAttachmentsController
+type WorkItemAttachmentsControllerConfiguration interface {
+	AttachmentUploadConfiguration
+	GetCacheControlAttachments() string
+}
+
+// NewWorkItemAttachmentsController creates a work_item_attachments controller.
+func NewWorkItemAttachmentsController(service *goa.Service, db application.DB, storage attachment.BlobStorage, config WorkItemAttachmentsControllerConfiguration) *WorkItemAttachmentsController {
+	return &WorkItemAttachmentsController{
+		Controller: service.NewController("WorkItemAttachmentsController"),
+		db:         db,
+		storage:    storage,
+		config:     config,
+	}
+}
+
+// List runs the list action.
+func (c *WorkItemAttachmentsController) List(ctx *app.ListWorkItemAttachmentsContext) error {
+	var attachments []attachment.Attachment
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.WorkItems().CheckExists(ctx, ctx.WiID); err != nil {
+			return err
+		}
+		var err error
+		attachments, err = appl.Attachments().ListByWorkItem(ctx, ctx.WiID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.ConditionalEntities(attachments, c.config.GetCacheControlAttachments, func() error {
+		return ctx.OK(&app.AttachmentList{
+			Data: ConvertAttachments(ctx.Request, attachments),
+		})
+	})
+}
+
+// Upload runs the upload action.
+func (c *WorkItemAttachmentsController) Upload(ctx *app.UploadWorkItemAttachmentsContext) error {
+	a, err := uploadAttachment(ctx, c.db, c.storage, c.config, ctx.Request, ctx.Filename, ctx.WiID, id.NullUUID{})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.AttachmentsHref(a.ID)))
+	return ctx.Created(&app.AttachmentSingle{
+		Data: ConvertAttachment(ctx.Request, *a),
+	})
+}
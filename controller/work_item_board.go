@@ -3,15 +3,19 @@ package controller
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/goadesign/goa"
 	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 )
 
 // WorkItemBoardController implements the work_item_board resource.
@@ -39,37 +43,176 @@ func NewWorkItemBoardController(service *goa.Service, db application.DB) *WorkIt
 // Show runs the show action.
 func (c *WorkItemBoardController) Show(ctx *app.ShowWorkItemBoardContext) error {
 	var board *workitem.Board
+	var counts map[uuid.UUID]int
 	err := application.Transactional(c.db, func(appl application.Application) error {
 		b, err := appl.Boards().Load(ctx, ctx.BoardID)
 		if err != nil {
 			return errs.WithStack(err)
 		}
 		board = b
-		return nil
+		counts, err = appl.Boards().CountColumnItems(ctx, ctx.BoardID)
+		return errs.WithStack(err)
 	})
 	if err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
+	return ctx.OK(convertBoardSingle(ctx.Request, *board, counts))
+}
+
+// Update runs the update action. Only boards of a space can be updated.
+func (c *WorkItemBoardController) Update(ctx *app.UpdateWorkItemBoardContext) error {
+	if _, err := login.ContextIdentity(ctx); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil || ctx.Payload.Data.Attributes == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes", nil).Expected("not nil"))
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.Version == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.version", nil).Expected("not nil"))
+	}
+	var board *workitem.Board
+	var counts map[uuid.UUID]int
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		b, err := appl.Boards().Load(ctx, ctx.BoardID)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		if b.SpaceID == nil {
+			return errors.NewForbiddenError("boards of a space template cannot be updated")
+		}
+		if err := authorizeSpaceBoards(ctx, *b.SpaceID); err != nil {
+			return err
+		}
+		b.Version = *attrs.Version
+		updateBoardFromPayload(b, attrs)
+		board, err = appl.Boards().Save(ctx, *b)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		counts, err = appl.Boards().CountColumnItems(ctx, board.ID)
+		return errs.WithStack(err)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(convertBoardSingle(ctx.Request, *board, counts))
+}
+
+// Delete runs the delete action. Only boards of a space can be deleted.
+func (c *WorkItemBoardController) Delete(ctx *app.DeleteWorkItemBoardContext) error {
+	if _, err := login.ContextIdentity(ctx); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		b, err := appl.Boards().Load(ctx, ctx.BoardID)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		if b.SpaceID == nil {
+			return errors.NewForbiddenError("boards of a space template cannot be deleted")
+		}
+		if err := authorizeSpaceBoards(ctx, *b.SpaceID); err != nil {
+			return err
+		}
+		return appl.Boards().Delete(ctx, ctx.BoardID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.NoContent()
+}
+
+// updateBoardFromPayload overwrites the board's attributes with the ones given
+// in the payload. The columns are only replaced if the payload lists any.
+func updateBoardFromPayload(b *workitem.Board, attrs *app.WorkItemBoardAttributes) {
+	b.Name = strings.TrimSpace(attrs.Name)
+	b.Context = attrs.Context
+	b.ContextType = attrs.ContextType
+	if attrs.Description != nil {
+		b.Description = *attrs.Description
+	}
+	if attrs.Swimlane != nil {
+		b.Swimlane = workitem.BoardSwimlane(*attrs.Swimlane)
+	}
+	if attrs.Columns != nil {
+		b.Columns = ConvertColumnsToModel(attrs.Columns)
+	}
+}
+
+// convertBoardSingle converts the given board and its columns into a single
+// response. The given item counts are reported for the columns of a board of a
+// space.
+func convertBoardSingle(request *http.Request, board workitem.Board, counts map[uuid.UUID]int) *app.WorkItemBoardSingle {
 	res := &app.WorkItemBoardSingle{
-		Data: ConvertBoardFromModel(ctx.Request, *board),
+		Data: ConvertBoardFromModel(request, board),
 	}
 	for _, column := range board.Columns {
-		res.Included = append(res.Included, ConvertColumnsFromModel(ctx.Request, column))
+		converted := ConvertColumnsFromModel(request, column)
+		if board.SpaceID != nil {
+			count := counts[column.ID]
+			converted.Attributes.WipCount = &count
+			converted.Attributes.WipExceeded = ptr.Bool(column.WIPLimit != nil && count > *column.WIPLimit)
+		}
+		res.Included = append(res.Included, converted)
 	}
-	return ctx.OK(res)
+	return res
 }
 
 // ConvertColumnsFromModel converts WorkitemTypeBoard model to a response
 // resource object for jsonapi.org specification
 func ConvertColumnsFromModel(request *http.Request, column workitem.BoardColumn) *app.WorkItemBoardColumnData {
-	return &app.WorkItemBoardColumnData{
+	res := &app.WorkItemBoardColumnData{
 		ID:   column.ID,
 		Type: APIBoardColumns,
 		Attributes: &app.WorkItemBoardColumnAttributes{
-			Name:  column.Name,
-			Order: &column.Order,
+			Name:              column.Name,
+			Order:             &column.Order,
+			TransRuleKey:      ptr.String(column.TransRuleKey),
+			TransRuleArgument: ptr.String(column.TransRuleArgument),
+			WipLimit:          column.WIPLimit,
+			States:            column.States,
 		},
 	}
+	if column.WIPLimit != nil {
+		mode := column.WIPLimitMode
+		if mode == "" {
+			mode = workitem.WIPLimitWarn
+		}
+		res.Attributes.WipLimitMode = ptr.String(string(mode))
+	}
+	return res
+}
+
+// ConvertColumnsToModel converts the columns of a board payload to the model
+// representation. The order of the columns is taken from their position.
+func ConvertColumnsToModel(columns []*app.WorkItemBoardColumnPayload) []workitem.BoardColumn {
+	res := make([]workitem.BoardColumn, 0, len(columns))
+	for i, c := range columns {
+		if c == nil || c.Attributes == nil {
+			continue
+		}
+		column := workitem.BoardColumn{
+			Name:     strings.TrimSpace(c.Attributes.Name),
+			Order:    i,
+			WIPLimit: c.Attributes.WipLimit,
+			States:   c.Attributes.States,
+		}
+		if c.ID != nil {
+			column.ID = *c.ID
+		}
+		if c.Attributes.TransRuleKey != nil {
+			column.TransRuleKey = *c.Attributes.TransRuleKey
+		}
+		if c.Attributes.TransRuleArgument != nil {
+			column.TransRuleArgument = *c.Attributes.TransRuleArgument
+		}
+		if c.Attributes.WipLimitMode != nil {
+			column.WIPLimitMode = workitem.WIPLimitMode(*c.Attributes.WipLimitMode)
+		}
+		res = append(res, column)
+	}
+	return res
 }
 
 // ConvertBoardFromModel converts WorkitemTypeBoard model to a response resource
@@ -85,6 +228,9 @@ func ConvertBoardFromModel(request *http.Request, b workitem.Board) *app.WorkIte
 			Context:     b.Context,
 			ContextType: b.ContextType,
 			Name:        b.Name,
+			Description: ptr.String(b.Description),
+			Swimlane:    ptr.String(string(b.Swimlane)),
+			Version:     ptr.Int(b.Version),
 			CreatedAt:   ptr.Time(b.CreatedAt.UTC()),
 			UpdatedAt:   ptr.Time(b.UpdatedAt.UTC()),
 		},
@@ -100,6 +246,14 @@ func ConvertBoardFromModel(request *http.Request, b workitem.Board) *app.WorkIte
 			},
 		},
 	}
+	if b.SpaceID != nil {
+		res.Relationships.Space = &app.RelationGeneric{
+			Data: &app.GenericData{
+				ID:   ptr.String(b.SpaceID.String()),
+				Type: ptr.String(APIStringTypeSpace),
+			},
+		}
+	}
 
 	// iterate over the columns and attach them as an
 	// included relationship
@@ -148,7 +148,7 @@ func (s *workItemChildSuite) TestChildren() {
 		})
 		t.Run("list", func(t *testing.T) {
 			// when
-			res, workItemList := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil)
+			res, workItemList := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil, nil)
 			// then
 			compareWithGoldenAgnostic(t, filepath.Join(s.testDir, "list_children", "ok.res.payload.golden.json"), workItemList)
 			toBeFound := id.Slice{fxt.WorkItemByTitle("child1").ID, fxt.WorkItemByTitle("child2").ID}.ToMap()
@@ -179,7 +179,7 @@ func (s *workItemChildSuite) TestChildren() {
 			updatedAt, ok := fxt.WorkItemByTitle("parent").Fields[workitem.SystemUpdatedAt].(time.Time)
 			require.True(t, ok)
 			ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-			res, workItemList := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, &ifModifiedSince, nil)
+			res, workItemList := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, &ifModifiedSince, nil)
 			// then
 			toBeFound := id.Slice{fxt.WorkItemByTitle("child1").ID, fxt.WorkItemByTitle("child2").ID}.ToMap()
 			for _, wi := range workItemList.Data {
@@ -193,7 +193,7 @@ func (s *workItemChildSuite) TestChildren() {
 		t.Run("using expired if none match header", func(t *testing.T) {
 			// when
 			ifNoneMatch := "foo"
-			res, workItemList := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, &ifNoneMatch)
+			res, workItemList := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil, &ifNoneMatch)
 			// then
 			toBeFound := id.Slice{fxt.WorkItemByTitle("child1").ID, fxt.WorkItemByTitle("child2").ID}.ToMap()
 			for _, wi := range workItemList.Data {
@@ -206,18 +206,18 @@ func (s *workItemChildSuite) TestChildren() {
 		})
 		t.Run("not modified using if modified since header", func(t *testing.T) {
 			// given
-			res, _ := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil)
+			res, _ := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil, nil)
 			ifModifiedSince := res.Header()[app.LastModified][0]
 			// when
-			res = test.ListChildrenWorkitemNotModified(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, &ifModifiedSince, nil)
+			res = test.ListChildrenWorkitemNotModified(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, &ifModifiedSince, nil)
 			// then
 			assertResponseHeaders(t, res)
 		})
 		t.Run("not modified using if none match header", func(t *testing.T) {
-			res, _ := test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil)
+			res, _ := test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil, nil)
 			// when
 			ifNoneMatch := res.Header()[app.ETag][0]
-			res = test.ListChildrenWorkitemNotModified(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, &ifNoneMatch)
+			res = test.ListChildrenWorkitemNotModified(t, s.svc.Context, s.svc, s.workItemCtrl, fxt.WorkItemByTitle("parent").ID, nil, nil, nil, nil, &ifNoneMatch)
 			// then
 			assertResponseHeaders(t, res)
 		})
@@ -241,7 +241,7 @@ func (s *workItemChildSuite) TestWorkItemListFilterByNoParents() {
 		// given
 		var pe *bool
 		// when
-		_, result := test.ListWorkitemsOK(t, nil, nil, s.workItemsCtrl, fxt.Spaces[0].ID, nil, nil, nil, nil, nil, pe, nil, nil, nil, nil, nil, nil, nil)
+		_, result := test.ListWorkitemsOK(t, nil, nil, s.workItemsCtrl, fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, pe, nil, nil, nil, nil, nil, nil, nil)
 		// then
 		toBeFound := id.Slice{fxt.WorkItemByTitle("parent").ID, fxt.WorkItemByTitle("child1").ID, fxt.WorkItemByTitle("child2").ID}.ToMap()
 		for _, wi := range result.Data {
@@ -256,7 +256,7 @@ func (s *workItemChildSuite) TestWorkItemListFilterByNoParents() {
 		// given
 		pe := false
 		// when
-		_, result := test.ListWorkitemsOK(t, nil, nil, s.workItemsCtrl, fxt.Spaces[0].ID, nil, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil, nil)
+		_, result := test.ListWorkitemsOK(t, nil, nil, s.workItemsCtrl, fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil, nil)
 		// then
 		toBeFound := id.Slice{fxt.WorkItemByTitle("parent").ID}.ToMap()
 		for _, wi := range result.Data {
@@ -272,7 +272,7 @@ func (s *workItemChildSuite) TestWorkItemListFilterByNoParents() {
 		// given
 		pe := true
 		// when
-		_, result := test.ListWorkitemsOK(t, nil, nil, s.workItemsCtrl, fxt.Spaces[0].ID, nil, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil, nil)
+		_, result := test.ListWorkitemsOK(t, nil, nil, s.workItemsCtrl, fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil, nil)
 		// then
 		toBeFound := id.Slice{fxt.WorkItemByTitle("parent").ID, fxt.WorkItemByTitle("child1").ID, fxt.WorkItemByTitle("child2").ID}.ToMap()
 		for _, wi := range result.Data {
@@ -528,7 +528,7 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOK() {
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.T(), "bug1", "bug2")
 	// when
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -542,7 +542,7 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingExpiredIfMod
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -557,7 +557,7 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenOKThenListUsingIfModifiedSi
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -570,7 +570,7 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingExpiredIfNon
 	s.linkWorkItems(s.T(), "bug1", "bug2")
 	// when
 	ifNoneMatch := "foo"
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -584,7 +584,7 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingIfNoneMatchH
 	s.linkWorkItems(s.T(), "bug1", "bug2")
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -598,7 +598,7 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkThenListToChildrenOK() {
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, workitemLink12.ID)
 	// when
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasNoChildren)
@@ -614,7 +614,7 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingExp
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasNoChildren)
@@ -631,7 +631,7 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingIfM
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasNoChildren)
@@ -646,7 +646,7 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingExp
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, workitemLink12.ID)
 	// when
 	ifNoneMatch := "foo"
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasNoChildren)
@@ -662,7 +662,7 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingIfN
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, workitemLink12.ID)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasNoChildren)
@@ -676,7 +676,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOK() {
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	s.linkWorkItems(s.T(), "bug1", "bug3")
 	// when
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -692,7 +692,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingExp
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -709,7 +709,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingIfM
 	// when/then
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -724,7 +724,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingExp
 	s.linkWorkItems(s.T(), "bug1", "bug3")
 	// when
 	ifNoneMatch := "foo"
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -740,7 +740,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingIfN
 	s.linkWorkItems(s.T(), "bug1", "bug3")
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemsOK(s.T(), s.svc.Context, s.svc, s.workItemsCtrl, s.fxt.Spaces[0].ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -796,7 +796,7 @@ func (s *searchParentExistsSuite) TestSearchWorkItemListFilterUsingParentExists(
 		var pe *bool
 		// when
 		sid := space.SystemSpace.String()
-		test.ShowSearchBadRequest(t, nil, nil, s.searchCtrl, nil, pe, nil, nil, nil, &sid)
+		test.ShowSearchBadRequest(t, nil, nil, s.searchCtrl, nil, nil, pe, nil, nil, nil, &sid)
 	})
 	s.T().Run("with parentexists value set to false", func(t *testing.T) {
 		// given
@@ -810,7 +810,7 @@ func (s *searchParentExistsSuite) TestSearchWorkItemListFilterUsingParentExists(
 			s.fxt.Spaces[0].ID.String(),
 			s.fxt.WorkItemByTitle("bug1").Type)
 
-		_, result := test.ShowSearchOK(t, nil, nil, s.searchCtrl, &filter, &pe, nil, nil, nil, nil)
+		_, result := test.ShowSearchOK(t, nil, nil, s.searchCtrl, nil, &filter, &pe, nil, nil, nil, nil)
 		// then
 		assert.Len(t, result.Data, 1)
 		checkChildrenRelationship(t, lookupWorkitemFromSearchList(t, *result, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -829,7 +829,7 @@ func (s *searchParentExistsSuite) TestSearchWorkItemListFilterUsingParentExists(
 			s.fxt.Spaces[0].ID.String(),
 			s.fxt.WorkItemByTitle("bug1").Type)
 
-		_, result := test.ShowSearchOK(t, nil, nil, s.searchCtrl, &filter, &pe, nil, nil, nil, &sid)
+		_, result := test.ShowSearchOK(t, nil, nil, s.searchCtrl, nil, &filter, &pe, nil, nil, nil, &sid)
 		// then
 		assert.Len(t, result.Data, 3)
 		checkChildrenRelationship(t, lookupWorkitemFromSearchList(t, *result, s.fxt.WorkItemByTitle("bug1").ID), hasChildren)
@@ -849,7 +849,7 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListChildren()
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 
 	// check number of children
-	_, childrenList := test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.fxt.WorkItemByTitle("bug1").ID, nil, nil, nil, nil)
+	_, childrenList := test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.fxt.WorkItemByTitle("bug1").ID, nil, nil, nil, nil, nil)
 	require.Equal(s.T(), 2, childrenList.Meta.TotalCount)
 
 	// delete link
@@ -860,7 +860,7 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListChildren()
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 
 	// check number of children
-	_, childrenList = test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.fxt.WorkItemByTitle("bug1").ID, nil, nil, nil, nil)
+	_, childrenList = test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.fxt.WorkItemByTitle("bug1").ID, nil, nil, nil, nil, nil)
 	require.Equal(s.T(), 1, childrenList.Meta.TotalCount)
 
 	// delete link
@@ -871,6 +871,6 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListChildren()
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 
 	// check number of children
-	_, childrenList = test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.fxt.WorkItemByTitle("bug1").ID, nil, nil, nil, nil)
+	_, childrenList = test.ListChildrenWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.fxt.WorkItemByTitle("bug1").ID, nil, nil, nil, nil, nil)
 	require.Equal(s.T(), 0, childrenList.Meta.TotalCount)
 }
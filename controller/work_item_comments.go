@@ -113,16 +113,30 @@ func (c *WorkItemCommentsController) List(ctx *app.ListWorkItemCommentsContext)
 		if err != nil {
 			return goa.ErrNotFound(err.Error())
 		}
-		comments, tc, err := appl.Comments().List(ctx, ctx.WiID, &offset, &limit)
+		var comments []comment.Comment
+		var tc uint64
+		if ctx.FilterResolved != nil {
+			comments, tc, err = appl.Comments().ListByResolution(ctx, ctx.WiID, *ctx.FilterResolved, &offset, &limit)
+		} else {
+			comments, tc, err = appl.Comments().List(ctx, ctx.WiID, &offset, &limit)
+		}
 		count := int(tc)
 		if err != nil {
 			return goa.ErrInternal(err.Error())
 		}
+		commentIDs := make([]uuid.UUID, len(comments))
+		for i, cm := range comments {
+			commentIDs[i] = cm.ID
+		}
+		reactions, err := appl.Comments().ListReactions(ctx, commentIDs...)
+		if err != nil {
+			return goa.ErrInternal(err.Error())
+		}
 		return ctx.ConditionalEntities(comments, c.config.GetCacheControlComments, func() error {
 			res := &app.CommentList{}
 			res.Data = []*app.Comment{}
 			res.Meta = &app.CommentListMeta{TotalCount: count}
-			res.Data = ConvertComments(ctx.Request, comments)
+			res.Data = ConvertComments(ctx.Request, comments, CommentIncludeReactions(reactions))
 			res.Links = &app.PagingLinks{}
 			setPagingLinks(res.Links, buildAbsoluteURL(ctx.Request), len(comments), offset, limit, count)
 			return ctx.OK(res)
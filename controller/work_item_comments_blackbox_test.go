@@ -221,7 +221,7 @@ func (rest *TestCommentREST) TestListCommentsByParentWorkItemOK() {
 	svc, ctrl := rest.UnSecuredController()
 	offset := "0"
 	limit := 3
-	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, &limit, &offset, nil, nil)
+	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, nil, &limit, &offset, nil, nil)
 	// then
 	assertComments(rest.T(), rest.testIdentity, cs)
 	assertResponseHeaders(rest.T(), res)
@@ -234,7 +234,7 @@ func (rest *TestCommentREST) TestListCommentsByParentWorkItemOKWithParentComment
 	svc, ctrl := rest.UnSecuredController()
 	offset := "0"
 	limit := 3
-	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, &limit, &offset, nil, nil)
+	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, nil, &limit, &offset, nil, nil)
 	// note: the comments are returned in reverse order, [2] is the parent
 	parentCommentID := cs.Data[2].ID.String()
 	assert.Equal(rest.T(), parentCommentID, *cs.Data[1].Relationships.ParentComment.Data.ID)
@@ -250,7 +250,7 @@ func (rest *TestCommentREST) TestListCommentsByParentWorkItemOKUsingExpiredIfMod
 	offset := "0"
 	limit := 3
 	ifModifiedSince := app.ToHTTPTime(comments[3].UpdatedAt.Add(-1 * time.Hour))
-	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, &limit, &offset, &ifModifiedSince, nil)
+	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, nil, &limit, &offset, &ifModifiedSince, nil)
 	// then
 	assertComments(rest.T(), rest.testIdentity, cs)
 	assertResponseHeaders(rest.T(), res)
@@ -264,7 +264,7 @@ func (rest *TestCommentREST) TestListCommentsByParentWorkItemOKUsingExpiredIfNon
 	offset := "0"
 	limit := 3
 	ifNoneMatch := "foo"
-	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, &limit, &offset, nil, &ifNoneMatch)
+	res, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, nil, &limit, &offset, nil, &ifNoneMatch)
 	// then
 	assertComments(rest.T(), rest.testIdentity, cs)
 	assertResponseHeaders(rest.T(), res)
@@ -278,7 +278,7 @@ func (rest *TestCommentREST) TestListCommentsByParentWorkItemNotModifiedUsingIfM
 	offset := "0"
 	limit := 3
 	ifModifiedSince := app.ToHTTPTime(comments[3].UpdatedAt)
-	res := test.ListWorkItemCommentsNotModified(rest.T(), svc.Context, svc, ctrl, wi.ID, &limit, &offset, &ifModifiedSince, nil)
+	res := test.ListWorkItemCommentsNotModified(rest.T(), svc.Context, svc, ctrl, wi.ID, nil, &limit, &offset, &ifModifiedSince, nil)
 	// then
 	assertResponseHeaders(rest.T(), res)
 }
@@ -295,7 +295,7 @@ func (rest *TestCommentREST) TestListCommentsByParentWorkItemNotModifiedUsingIfN
 		comments[1],
 		comments[0],
 	})
-	res := test.ListWorkItemCommentsNotModified(rest.T(), svc.Context, svc, ctrl, wi.ID, &limit, &offset, nil, &ifNoneMatch)
+	res := test.ListWorkItemCommentsNotModified(rest.T(), svc.Context, svc, ctrl, wi.ID, nil, &limit, &offset, nil, &ifNoneMatch)
 	// then
 	assertResponseHeaders(rest.T(), res)
 }
@@ -308,7 +308,7 @@ func (rest *TestCommentREST) TestEmptyListCommentsByParentWorkItem() {
 	svc, ctrl := rest.UnSecuredController()
 	offset := "0"
 	limit := 1
-	_, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, &limit, &offset, nil, nil)
+	_, cs := test.ListWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.ID, nil, &limit, &offset, nil, nil)
 	// then
 	assert.Equal(rest.T(), 0, len(cs.Data))
 }
@@ -349,5 +349,5 @@ func (rest *TestCommentREST) TestListCommentsByMissingParentWorkItem() {
 	// when/then
 	offset := "0"
 	limit := 1
-	test.ListWorkItemCommentsNotFound(rest.T(), svc.Context, svc, ctrl, uuid.NewV4(), &limit, &offset, nil, nil)
+	test.ListWorkItemCommentsNotFound(rest.T(), svc.Context, svc, ctrl, uuid.NewV4(), nil, &limit, &offset, nil, nil)
 }
@@ -113,6 +113,25 @@ func ConvertEvent(ctx context.Context, appl application.Application, req *http.R
 		},
 	}
 
+	if wiEvent.IsCommentEvent() {
+		e.Relationships.Comment = &app.RelationGeneric{
+			Links: &app.GenericLinks{
+				Self: ptr.String(rest.AbsoluteURL(req, app.CommentsHref(wiEvent.CommentID))),
+			},
+			Data: &app.GenericData{
+				ID:   ptr.String(wiEvent.CommentID.String()),
+				Type: ptr.String(APIStringTypeComments),
+			},
+		}
+		if wiEvent.Old != nil {
+			e.Attributes.OldValue = &wiEvent.Old
+		}
+		if wiEvent.New != nil {
+			e.Attributes.NewValue = &wiEvent.New
+		}
+		return &e, nil
+	}
+
 	if wiEvent.Name == event.WorkitemTypeChangeEvent {
 		oldTypeUUID, ok := wiEvent.Old.(uuid.UUID)
 		if !ok {
@@ -236,7 +236,7 @@ func (c *WorkItemLinkController) Create(ctx *app.CreateWorkItemLinkContext) erro
 	var createdModelLink *link.WorkItemLink
 	err = application.Transactional(c.db, func(appl application.Application) error {
 		var err error
-		createdModelLink, err = appl.WorkItemLinks().Create(ctx.Context, modelLink.SourceID, modelLink.TargetID, modelLink.LinkTypeID, *currentUserIdentityID)
+		createdModelLink, err = appl.WorkItemLinks().CreateWithAttributes(ctx.Context, modelLink.SourceID, modelLink.TargetID, modelLink.LinkTypeID, modelLink.Attributes, *currentUserIdentityID)
 		return err
 	})
 	if err != nil {
@@ -328,6 +328,46 @@ func (c *WorkItemLinkController) Delete(ctx *app.DeleteWorkItemLinkContext) erro
 	return ctx.OK([]byte{})
 }
 
+// Update runs the update action. Only the attribute values of a link can be
+// changed, its type, source and target remain as they are.
+func (c *WorkItemLinkController) Update(ctx *app.UpdateWorkItemLinkContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	data := ctx.Payload.Data
+	if data.ID != nil && *data.ID != ctx.LinkID {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.id", *data.ID).Expected(ctx.LinkID))
+	}
+	if data.Attributes == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes", nil).Expected("not nil"))
+	}
+	if data.Attributes.Version == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.version", nil).Expected("not nil"))
+	}
+	authorized, err := c.checkIfUserIsSpaceCollaboratorOrWorkItemCreator(ctx, ctx.LinkID, *currentUserIdentityID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if !authorized {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not authorized to update the link"))
+	}
+	var modelLink *link.WorkItemLink
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		modelLink, err = appl.WorkItemLinks().UpdateAttributes(ctx.Context, ctx.LinkID, *data.Attributes.Version, data.Attributes.Fields, *currentUserIdentityID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	appLink := ConvertLinkFromModel(ctx.Request, *modelLink)
+	if err := enrichLinkSingle(ctx.Context, c.db, ctx.Request, &appLink); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&appLink)
+}
+
 // Show runs the show action.
 func (c *WorkItemLinkController) Show(ctx *app.ShowWorkItemLinkContext) error {
 	var modelLink *link.WorkItemLink
@@ -400,12 +440,15 @@ func ConvertLinkFromModel(request *http.Request, t link.WorkItemLink) app.WorkIt
 			},
 		},
 	}
+	if len(t.Attributes) > 0 {
+		converted.Data.Attributes.Fields = map[string]interface{}(t.Attributes)
+	}
 	return converted
 }
 
 // ConvertLinkToModel converts the incoming app representation of a work item link to the model layout.
 // Values are only overwrriten if they are set in "in", otherwise the values in "out" remain.
-// NOTE: Only the LinkTypeID, SourceID, and TargetID fields will be set.
+// NOTE: Only the LinkTypeID, SourceID, TargetID and Attributes fields will be set.
 //       You need to preload the elements after calling this function.
 func ConvertLinkToModel(appLink app.WorkItemLinkSingle) (*link.WorkItemLink, error) {
 	modelLink := link.WorkItemLink{}
@@ -419,6 +462,10 @@ func ConvertLinkToModel(appLink app.WorkItemLinkSingle) (*link.WorkItemLink, err
 		modelLink.Version = *attrs.Version
 	}
 
+	if attrs != nil && attrs.Fields != nil {
+		modelLink.Attributes = attrs.Fields
+	}
+
 	if rel != nil && rel.LinkType != nil && rel.LinkType.Data != nil {
 		modelLink.LinkTypeID = rel.LinkType.Data.ID
 	}
@@ -128,6 +128,24 @@ func (s *workItemLinkSuite) TestCreate() {
 			svc, ctrl := s.SecuredController(*fxt.Identities[0])
 			createOK(t, fxt, svc, ctrl, true)
 		})
+		t.Run("with attributes", func(t *testing.T) {
+			fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(2),
+				tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+					fxt.WorkItemLinkTypes[idx].Fields = workitem.FieldDefinitions{
+						"reason": {Label: "Reason", Type: workitem.SimpleType{Kind: workitem.KindString}},
+					}
+					return nil
+				}),
+			)
+			svc, ctrl := s.SecuredController(*fxt.Identities[0])
+			createPayload := newCreateWorkItemLinkPayload(fxt.WorkItems[0].ID, fxt.WorkItems[1].ID, fxt.WorkItemLinkTypes[0].ID)
+			createPayload.Data.Attributes.Fields = map[string]interface{}{"reason": "waiting for review"}
+			_, workItemLink := test.CreateWorkItemLinkCreated(t, svc.Context, svc, ctrl, createPayload)
+			require.NotNil(t, workItemLink)
+			require.Equal(t, map[string]interface{}{"reason": "waiting for review"}, workItemLink.Data.Attributes.Fields)
+			_, shown := test.ShowWorkItemLinkOK(t, svc.Context, svc, ctrl, *workItemLink.Data.ID, nil, nil)
+			require.Equal(t, map[string]interface{}{"reason": "waiting for review"}, shown.Data.Attributes.Fields)
+		})
 		t.Run("as space collaborator", func(t *testing.T) {
 			fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(4), tf.WorkItemLinkTypes(1), tf.Identities(2, tf.SetIdentityUsernames("owner", "collaborator")))
 			svc := testsupport.ServiceAsSpaceUser("TestWorkItem-Service", *fxt.IdentityByUsername("collaborator"), &TestSpaceAuthzService{*fxt.IdentityByUsername("collaborator"), ""})
@@ -263,6 +281,85 @@ func (s *workItemLinkSuite) TestDelete() {
 	})
 }
 
+func (s *workItemLinkSuite) TestUpdate() {
+	reasonLinkType := tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+		fxt.WorkItemLinkTypes[idx].Fields = workitem.FieldDefinitions{
+			"reason": {Label: "Reason", Type: workitem.SimpleType{Kind: workitem.KindString}},
+		}
+		return nil
+	})
+	newPayload := func(l link.WorkItemLink, version int, fields map[string]interface{}) *app.UpdateWorkItemLinkPayload {
+		payload := newUpdateWorkItemLinkPayload(l.ID, l.SourceID, l.TargetID, l.LinkTypeID)
+		payload.Data.Attributes.Version = &version
+		payload.Data.Attributes.Fields = fields
+		return payload
+	}
+
+	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItemLinks(1), reasonLinkType)
+		svc, ctrl := s.SecuredController(*fxt.Identities[0])
+		l := *fxt.WorkItemLinks[0]
+		// when
+		_, updated := test.UpdateWorkItemLinkOK(t, svc.Context, svc, ctrl, l.ID, newPayload(l, l.Version, map[string]interface{}{"reason": "waiting for review"}))
+		// then
+		require.NotNil(t, updated.Data.Attributes)
+		require.Equal(t, map[string]interface{}{"reason": "waiting for review"}, updated.Data.Attributes.Fields)
+		require.Equal(t, l.Version+1, *updated.Data.Attributes.Version)
+		_, shown := test.ShowWorkItemLinkOK(t, svc.Context, svc, ctrl, l.ID, nil, nil)
+		require.Equal(t, map[string]interface{}{"reason": "waiting for review"}, shown.Data.Attributes.Fields)
+	})
+	s.T().Run(http.StatusText(http.StatusBadRequest), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItemLinks(1), reasonLinkType)
+		svc, ctrl := s.SecuredController(*fxt.Identities[0])
+		l := *fxt.WorkItemLinks[0]
+		t.Run("unknown attribute", func(t *testing.T) {
+			_, _ = test.UpdateWorkItemLinkBadRequest(t, svc.Context, svc, ctrl, l.ID, newPayload(l, l.Version, map[string]interface{}{"foo": "bar"}))
+		})
+		t.Run("missing version", func(t *testing.T) {
+			payload := newPayload(l, l.Version, map[string]interface{}{"reason": "foo"})
+			payload.Data.Attributes.Version = nil
+			_, _ = test.UpdateWorkItemLinkBadRequest(t, svc.Context, svc, ctrl, l.ID, payload)
+		})
+	})
+	s.T().Run(http.StatusText(http.StatusConflict), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItemLinks(1), reasonLinkType)
+		svc, ctrl := s.SecuredController(*fxt.Identities[0])
+		l := *fxt.WorkItemLinks[0]
+		// when/then
+		_, _ = test.UpdateWorkItemLinkConflict(t, svc.Context, svc, ctrl, l.ID, newPayload(l, l.Version+1, map[string]interface{}{"reason": "foo"}))
+	})
+	s.T().Run(http.StatusText(http.StatusForbidden), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1), reasonLinkType, tf.Identities(2, tf.SetIdentityUsernames("owner", "collaborator")))
+		svc := testsupport.ServiceAsSpaceUser("svc", *fxt.IdentityByUsername("collaborator"), &TestSpaceAuthzService{*fxt.IdentityByUsername("owner"), ""})
+		ctrl := NewWorkItemLinkController(svc, s.GormDB, s.Configuration)
+		l := *fxt.WorkItemLinks[0]
+		// when/then
+		_, _ = test.UpdateWorkItemLinkForbidden(t, svc.Context, svc, ctrl, l.ID, newPayload(l, l.Version, map[string]interface{}{"reason": "foo"}))
+	})
+	s.T().Run(http.StatusText(http.StatusUnauthorized), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1))
+		svc := goa.New("TestUnauthorizedUpdateWorkItemLink-Service")
+		ctrl := NewWorkItemLinkController(svc, s.GormDB, s.Configuration)
+		l := *fxt.WorkItemLinks[0]
+		// when/then
+		_, _ = test.UpdateWorkItemLinkUnauthorized(t, svc.Context, svc, ctrl, l.ID, newPayload(l, l.Version, nil))
+	})
+	s.T().Run(http.StatusText(http.StatusNotFound), func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItemLinks(1))
+		svc, ctrl := s.SecuredController(*fxt.Identities[0])
+		l := *fxt.WorkItemLinks[0]
+		l.ID = uuid.NewV4()
+		// when/then
+		_, _ = test.UpdateWorkItemLinkNotFound(t, svc.Context, svc, ctrl, l.ID, newPayload(l, 0, nil))
+	})
+}
+
 func (s *workItemLinkSuite) TestShow() {
 	s.T().Run(http.StatusText(http.StatusOK), func(t *testing.T) {
 		t.Run("normal", func(t *testing.T) {
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// linkGraphMaxStartItems is the maximum number of work items that a filter
+// expression may match when exporting a link graph.
+const linkGraphMaxStartItems = 100
+
+// WorkItemLinkGraphController implements the work_item_link_graph resource.
+type WorkItemLinkGraphController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewWorkItemLinkGraphController creates a work_item_link_graph controller.
+func NewWorkItemLinkGraphController(service *goa.Service, db application.DB) *WorkItemLinkGraphController {
+	return &WorkItemLinkGraphController{
+		Controller: service.NewController("WorkItemLinkGraphController"),
+		db:         db,
+	}
+}
+
+// Export walks the links starting from a work item or the work items matching
+// a filter expression and writes the resulting graph in the requested format.
+func (c *WorkItemLinkGraphController) Export(ctx *app.ExportWorkItemLinkGraphContext) error {
+	if (ctx.Workitem == nil) == (ctx.FilterExpression == nil) {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("workitem", ctx.Workitem).Expected("either a work item ID or a filter expression"))
+	}
+	var g *link.Graph
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var startIDs []uuid.UUID
+		if ctx.Workitem != nil {
+			if err := appl.WorkItems().CheckExists(ctx, *ctx.Workitem); err != nil {
+				return err
+			}
+			startIDs = []uuid.UUID{*ctx.Workitem}
+		} else {
+			limit := linkGraphMaxStartItems
+			matches, count, _, _, err := appl.SearchItems().Filter(ctx, *ctx.FilterExpression, nil, nil, &limit)
+			if err != nil {
+				return err
+			}
+			if count > linkGraphMaxStartItems {
+				return errors.NewBadParameterError("filter[expression]", *ctx.FilterExpression).Expected(fmt.Sprintf("at most %d matching work items", linkGraphMaxStartItems))
+			}
+			for _, wi := range matches {
+				startIDs = append(startIDs, wi.ID)
+			}
+		}
+		var err error
+		g, err = appl.WorkItemLinks().WalkGraph(ctx, startIDs, ctx.Depth, ctx.Linktype...)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	format := link.GraphFormat(ctx.Format)
+	var buf bytes.Buffer
+	if err := g.Export(&buf, format); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	ctx.ResponseData.Header().Set("Content-Type", format.ContentType())
+	return ctx.OK(buf.Bytes())
+}
@@ -0,0 +1,72 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestWorkItemLinkGraphREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunWorkItemLinkGraphREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestWorkItemLinkGraphREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (rest *TestWorkItemLinkGraphREST) TestExport() {
+	fxt := tf.NewTestFixture(rest.T(), rest.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItemLinkTypes(1),
+		tf.WorkItems(3, tf.SetWorkItemTitles("A", "B", "C")),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.LinkChain("A", "B", "C")...)),
+	)
+	svc := testsupport.ServiceAsUser("WorkItemLinkGraph-Service", *fxt.Identities[0])
+	ctrl := NewWorkItemLinkGraphController(svc, rest.GormDB)
+	a := fxt.WorkItemByTitle("A").ID
+
+	rest.T().Run("json", func(t *testing.T) {
+		res, body := test.ExportWorkItemLinkGraphOK(t, svc.Context, svc, ctrl, 2, nil, "json", nil, &a)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+		var g link.Graph
+		require.NoError(t, json.Unmarshal(body, &g))
+		assert.Len(t, g.Nodes, 3)
+		assert.Len(t, g.Edges, 2)
+	})
+	rest.T().Run("dot", func(t *testing.T) {
+		_, body := test.ExportWorkItemLinkGraphOK(t, svc.Context, svc, ctrl, 1, nil, "dot", nil, &a)
+		assert.True(t, strings.HasPrefix(string(body), "digraph workitems {"))
+		assert.Contains(t, string(body), fmt.Sprintf("%q -> %q", a, fxt.WorkItemByTitle("B").ID))
+	})
+	rest.T().Run("filter expression", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"number": "%d"}`, fxt.WorkItemByTitle("C").Number)
+		filter = fmt.Sprintf(`{"$AND": [{"space": "%s"}, %s]}`, fxt.Spaces[0].ID, filter)
+		_, body := test.ExportWorkItemLinkGraphOK(t, svc.Context, svc, ctrl, 1, ptr.String(filter), "json", nil, nil)
+		var g link.Graph
+		require.NoError(t, json.Unmarshal(body, &g))
+		assert.Len(t, g.Nodes, 2)
+		assert.Len(t, g.Edges, 1)
+	})
+	rest.T().Run("bad request without start", func(t *testing.T) {
+		test.ExportWorkItemLinkGraphBadRequest(t, svc.Context, svc, ctrl, 1, nil, "json", nil, nil)
+	})
+	rest.T().Run("not found", func(t *testing.T) {
+		id := uuid.NewV4()
+		test.ExportWorkItemLinkGraphNotFound(t, svc.Context, svc, ctrl, 1, nil, "json", nil, &id)
+	})
+}
@@ -120,6 +120,22 @@ func ConvertWorkItemLinkTypeFromModel(request *http.Request, modelLinkType link.
 			},
 		},
 	}
+	if len(modelLinkType.Fields) > 0 {
+		converted.Data.Attributes.Fields = map[string]*app.FieldDefinition{}
+		for name, def := range modelLinkType.Fields {
+			converted.Data.Attributes.Fields[name] = ConvertFieldDefinitionFromModel(def)
+		}
+	}
+	if !modelLinkType.Constraints.IsEmpty() {
+		c := modelLinkType.Constraints
+		converted.Data.Attributes.Constraints = &app.WorkItemLinkTypeConstraints{
+			SourceTypes:      c.SourceTypes,
+			SourceTypeGroups: c.SourceTypeGroups,
+			TargetTypes:      c.TargetTypes,
+			TargetTypeGroups: c.TargetTypeGroups,
+			MaxChildren:      c.MaxChildren,
+		}
+	}
 	return converted
 }
 
@@ -183,6 +199,31 @@ func ConvertWorkItemLinkTypeToModel(appLinkType app.WorkItemLinkTypeSingle) (*li
 				return nil, err
 			}
 		}
+
+		if attrs.Fields != nil {
+			fields := map[string]app.FieldDefinition{}
+			for name, def := range attrs.Fields {
+				if def == nil || def.Type == nil {
+					return nil, errors.NewBadParameterError("data.attributes.fields."+name, def).Expected("a field definition with a type")
+				}
+				fields[name] = *def
+			}
+			modelFields, err := ConvertFieldDefinitionsToModel(fields)
+			if err != nil {
+				return nil, errors.NewBadParameterError("data.attributes.fields", attrs.Fields).Expected(err.Error())
+			}
+			modelLinkType.Fields = modelFields
+		}
+
+		if attrs.Constraints != nil {
+			modelLinkType.Constraints = link.Constraints{
+				SourceTypes:      attrs.Constraints.SourceTypes,
+				SourceTypeGroups: attrs.Constraints.SourceTypeGroups,
+				TargetTypes:      attrs.Constraints.TargetTypes,
+				TargetTypeGroups: attrs.Constraints.TargetTypeGroups,
+				MaxChildren:      attrs.Constraints.MaxChildren,
+			}
+		}
 	}
 
 	if rel != nil && rel.SpaceTemplate != nil && rel.SpaceTemplate.Data != nil {
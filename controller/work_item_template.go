@@ -0,0 +1,274 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	witemplate "github.com/fabric8-services/fabric8-wit/workitem/template"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// WorkItemTemplateController implements the work_item_template resource.
+type WorkItemTemplateController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewWorkItemTemplateController creates a work_item_template controller.
+func NewWorkItemTemplateController(service *goa.Service, db application.DB) *WorkItemTemplateController {
+	return &WorkItemTemplateController{
+		Controller: service.NewController("WorkItemTemplateController"),
+		db:         db,
+	}
+}
+
+// Show retrieves a single work item template
+func (c *WorkItemTemplateController) Show(ctx *app.ShowWorkItemTemplateContext) error {
+	var t *witemplate.Template
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		t, err = appl.WorkItemTemplates().Load(ctx, ctx.TemplateID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if t.SpaceID != ctx.SpaceID {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("work item template", ctx.TemplateID.String()))
+	}
+	return ctx.OK(&app.WorkItemTemplateSingle{
+		Data: ConvertWorkItemTemplate(ctx.Request, *t),
+	})
+}
+
+// List runs the list action.
+func (c *WorkItemTemplateController) List(ctx *app.ListWorkItemTemplateContext) error {
+	var templates []witemplate.Template
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return err
+		}
+		var err error
+		templates, err = appl.WorkItemTemplates().List(ctx, ctx.SpaceID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.WorkItemTemplateList{
+		Data: make([]*app.WorkItemTemplate, len(templates)),
+	}
+	for i, t := range templates {
+		res.Data[i] = ConvertWorkItemTemplate(ctx.Request, t)
+	}
+	return ctx.OK(res)
+}
+
+// Create runs the create action.
+func (c *WorkItemTemplateController) Create(ctx *app.CreateWorkItemTemplateContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if err := c.authorize(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil || ctx.Payload.Data.Attributes == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes", nil).Expected("not nil"))
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.Name == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.name", nil).Expected("not nil"))
+	}
+	rel := ctx.Payload.Data.Relationships
+	if rel == nil || rel.BaseType == nil || rel.BaseType.Data == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.baseType.data.id", nil).Expected("not nil"))
+	}
+	t := witemplate.Template{
+		SpaceID:        ctx.SpaceID,
+		Name:           strings.TrimSpace(*attrs.Name),
+		Description:    attrs.Description,
+		WorkItemTypeID: rel.BaseType.Data.ID,
+		Fields:         attrs.Fields,
+		Children:       convertWorkItemTemplateChildrenToModel(attrs.Children),
+		CreatorID:      *currentUserIdentityID,
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID); err != nil {
+			return err
+		}
+		return appl.WorkItemTemplates().Create(ctx, &t)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.WorkItemTemplateSingle{
+		Data: ConvertWorkItemTemplate(ctx.Request, t),
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.WorkItemTemplateHref(ctx.SpaceID, t.ID)))
+	return ctx.Created(res)
+}
+
+// Instantiate creates a work item and all of its children from a work item
+// template in a single transaction.
+func (c *WorkItemTemplateController) Instantiate(ctx *app.InstantiateWorkItemTemplateContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if err := c.authorize(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var overrides map[string]interface{}
+	if ctx.Payload != nil {
+		overrides = ctx.Payload.Fields
+	}
+	var wi *workitem.WorkItem
+	var wit *workitem.WorkItemType
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		t, err := appl.WorkItemTemplates().Load(ctx, ctx.TemplateID)
+		if err != nil {
+			return err
+		}
+		if t.SpaceID != ctx.SpaceID {
+			return errors.NewNotFoundError("work item template", ctx.TemplateID.String())
+		}
+		wi, _, err = appl.WorkItemTemplates().Instantiate(ctx, t.ID, overrides, *currentUserIdentityID)
+		if err != nil {
+			return err
+		}
+		wit, err = appl.WorkItemTypes().Load(ctx, wi.Type)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	hasChildren := workItemIncludeHasChildren(ctx, c.db)
+	computed := workItemIncludeComputedFields(ctx, c.db, []workitem.WorkItemType{*wit}, []workitem.WorkItem{*wi})
+	wi2, err := ConvertWorkItem(ctx.Request, *wit, *wi, hasChildren, computed)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	resp := &app.WorkItemSingle{
+		Data: wi2,
+		Links: &app.WorkItemLinks{
+			Self: buildAbsoluteURL(ctx.Request),
+		},
+	}
+	ctx.ResponseData.Header().Set("Last-Modified", lastModified(*wi))
+	ctx.ResponseData.Header().Set("Location", app.WorkitemHref(wi2.ID))
+	return ctx.Created(resp)
+}
+
+// authorize returns an error if the current user is not allowed to work with
+// the templates of the given space.
+func (c *WorkItemTemplateController) authorize(ctx context.Context, spaceID uuid.UUID) error {
+	authorized, err := authz.Authorize(ctx, spaceID.String())
+	if err != nil {
+		return errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return errors.NewForbiddenError("user is not authorized to access the space")
+	}
+	return nil
+}
+
+// ConvertWorkItemTemplate converts from internal to external REST representation
+func ConvertWorkItemTemplate(request *http.Request, t witemplate.Template) *app.WorkItemTemplate {
+	spaceID := t.SpaceID.String()
+	creatorID := t.CreatorID.String()
+	selfURL := rest.AbsoluteURL(request, app.WorkItemTemplateHref(spaceID, t.ID))
+	spaceRelatedURL := rest.AbsoluteURL(request, app.SpaceHref(spaceID))
+	witRelatedURL := rest.AbsoluteURL(request, app.WorkitemtypeHref(t.WorkItemTypeID))
+	creatorRelatedURL := rest.AbsoluteURL(request, app.UsersHref(creatorID))
+	return &app.WorkItemTemplate{
+		Type: witemplate.APIStringTypeWorkItemTemplates,
+		ID:   &t.ID,
+		Attributes: &app.WorkItemTemplateAttributes{
+			Name:        &t.Name,
+			Description: t.Description,
+			Fields:      t.Fields,
+			Children:    convertWorkItemTemplateChildren(t.Children),
+			CreatedAt:   ptr.Time(t.CreatedAt.UTC()),
+			UpdatedAt:   ptr.Time(t.UpdatedAt.UTC()),
+			Version:     &t.Version,
+		},
+		Relationships: &app.WorkItemTemplateRelations{
+			Space: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: &space.SpaceType,
+					ID:   &spaceID,
+				},
+				Links: &app.GenericLinks{
+					Self:    &spaceRelatedURL,
+					Related: &spaceRelatedURL,
+				},
+			},
+			BaseType: &app.RelationBaseType{
+				Data: &app.BaseTypeData{
+					ID:   t.WorkItemTypeID,
+					Type: APIStringTypeWorkItemType,
+				},
+				Links: &app.GenericLinks{
+					Self: &witRelatedURL,
+				},
+			},
+			Creator: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APIStringTypeUser),
+					ID:   &creatorID,
+				},
+				Links: &app.GenericLinks{
+					Self:    &creatorRelatedURL,
+					Related: &creatorRelatedURL,
+				},
+			},
+		},
+		Links: &app.GenericLinks{
+			Self:    &selfURL,
+			Related: &selfURL,
+		},
+	}
+}
+
+func convertWorkItemTemplateChildren(children witemplate.Children) []*app.WorkItemTemplateChild {
+	if len(children) == 0 {
+		return nil
+	}
+	res := make([]*app.WorkItemTemplateChild, len(children))
+	for i, child := range children {
+		res[i] = &app.WorkItemTemplateChild{
+			Type:     child.TypeID,
+			Fields:   child.Fields,
+			Children: convertWorkItemTemplateChildren(child.Children),
+		}
+	}
+	return res
+}
+
+func convertWorkItemTemplateChildrenToModel(children []*app.WorkItemTemplateChild) witemplate.Children {
+	if len(children) == 0 {
+		return nil
+	}
+	res := make(witemplate.Children, len(children))
+	for i, child := range children {
+		res[i] = witemplate.Child{
+			TypeID:   child.Type,
+			Fields:   child.Fields,
+			Children: convertWorkItemTemplateChildrenToModel(child.Children),
+		}
+	}
+	return res
+}
@@ -0,0 +1,87 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	witemplate "github.com/fabric8-services/fabric8-wit/workitem/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestWorkItemTemplateREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunWorkItemTemplateREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestWorkItemTemplateREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (rest *TestWorkItemTemplateREST) createPayload(fxt *tf.TestFixture) *app.CreateWorkItemTemplatePayload {
+	return &app.CreateWorkItemTemplatePayload{
+		Data: &app.WorkItemTemplate{
+			Type: witemplate.APIStringTypeWorkItemTemplates,
+			Attributes: &app.WorkItemTemplateAttributes{
+				Name:   ptr.String("incident"),
+				Fields: map[string]interface{}{workitem.SystemTitle: "Incident"},
+				Children: []*app.WorkItemTemplateChild{
+					{
+						Type:   fxt.WorkItemTypes[0].ID,
+						Fields: map[string]interface{}{workitem.SystemTitle: "Write post mortem"},
+					},
+				},
+			},
+			Relationships: &app.WorkItemTemplateRelations{
+				BaseType: &app.RelationBaseType{
+					Data: &app.BaseTypeData{
+						ID:   fxt.WorkItemTypes[0].ID,
+						Type: APIStringTypeWorkItemType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (rest *TestWorkItemTemplateREST) TestCreateAndInstantiate() {
+	fxt := tf.NewTestFixture(rest.T(), rest.DB, tf.CreateWorkItemEnvironment(), tf.Identities(2))
+	svc := testsupport.ServiceAsSpaceUser("WorkItemTemplate-Service", *fxt.Identities[0], &TestSpaceAuthzService{*fxt.Identities[0], ""})
+	ctrl := NewWorkItemTemplateController(svc, rest.GormDB)
+
+	rest.T().Run("create", func(t *testing.T) {
+		_, created := test.CreateWorkItemTemplateCreated(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, rest.createPayload(fxt))
+		require.NotNil(t, created.Data.ID)
+		assert.Equal(t, "incident", *created.Data.Attributes.Name)
+		require.Len(t, created.Data.Attributes.Children, 1)
+
+		t.Run("list", func(t *testing.T) {
+			_, list := test.ListWorkItemTemplateOK(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, nil, nil)
+			require.Len(t, list.Data, 1)
+			assert.Equal(t, *created.Data.ID, *list.Data[0].ID)
+		})
+
+		t.Run("instantiate", func(t *testing.T) {
+			payload := &app.InstantiateWorkItemTemplatePayload{
+				Fields: map[string]interface{}{workitem.SystemTitle: "Outage on 2018-05-01"},
+			}
+			_, wi := test.InstantiateWorkItemTemplateCreated(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, *created.Data.ID, payload)
+			assert.Equal(t, "Outage on 2018-05-01", wi.Data.Attributes[workitem.SystemTitle])
+		})
+	})
+
+	rest.T().Run("create forbidden for non collaborator", func(t *testing.T) {
+		svc := testsupport.ServiceAsSpaceUser("WorkItemTemplate-Service", *fxt.Identities[1], &TestSpaceAuthzService{*fxt.Identities[0], ""})
+		ctrl := NewWorkItemTemplateController(svc, rest.GormDB)
+		test.CreateWorkItemTemplateForbidden(t, svc.Context, svc, ctrl, fxt.Spaces[0].ID, rest.createPayload(fxt))
+	})
+}
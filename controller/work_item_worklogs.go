@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/worklog"
+	"github.com/goadesign/goa"
+)
+
+// WorkItemWorklogsController implements the work_item_worklogs resource.
+type WorkItemWorklogsController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewWorkItemWorklogsController creates a work_item_worklogs controller.
+func NewWorkItemWorklogsController(service *goa.Service, db application.DB) *WorkItemWorklogsController {
+	return &WorkItemWorklogsController{
+		Controller: service.NewController("WorkItemWorklogsController"),
+		db:         db,
+	}
+}
+
+// List runs the list action.
+func (c *WorkItemWorklogsController) List(ctx *app.ListWorkItemWorklogsContext) error {
+	if _, err := login.ContextIdentity(ctx); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	var wi *workitem.WorkItem
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		wi, err = appl.WorkItems().LoadByID(ctx, ctx.WiID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if err := authorizeWorklogAccess(ctx, wi.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var worklogs []worklog.Worklog
+	var timeSpent worklog.TimeSpent
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		worklogs, err = appl.Worklogs().ListByWorkItem(ctx, ctx.WiID)
+		if err != nil {
+			return err
+		}
+		timeSpentByWorkItem, err := appl.Worklogs().TimeSpent(ctx, ctx.WiID)
+		if err != nil {
+			return err
+		}
+		timeSpent = timeSpentByWorkItem[ctx.WiID]
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.WorkItemWorklogList{
+		Data: ConvertWorklogs(ctx.Request, worklogs),
+		Meta: &app.WorkItemWorklogListMeta{
+			TimeSpent:      int(timeSpent.Own),
+			TotalTimeSpent: int(timeSpent.Total),
+		},
+	})
+}
+
+// Create runs the create action.
+func (c *WorkItemWorklogsController) Create(ctx *app.CreateWorkItemWorklogsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil || ctx.Payload.Data.Attributes == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes", nil).Expected("not nil"))
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.StartedAt == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.started-at", nil).Expected("not nil"))
+	}
+	if attrs.Duration == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.duration", nil).Expected("not nil"))
+	}
+	var wi *workitem.WorkItem
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		wi, err = appl.WorkItems().LoadByID(ctx, ctx.WiID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	authorized, err := authz.Authorize(ctx, wi.SpaceID.String())
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	if !authorized {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not a space collaborator"))
+	}
+	w := worklog.Worklog{
+		WorkItemID: wi.ID,
+		IdentityID: *identityID,
+		StartedAt:  *attrs.StartedAt,
+		Duration:   int64(*attrs.Duration),
+	}
+	if attrs.Comment != nil {
+		w.Comment = *attrs.Comment
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		return appl.Worklogs().Create(ctx, &w)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.Request, app.WorklogsHref(w.ID)))
+	return ctx.Created(&app.WorklogSingle{
+		Data: ConvertWorklog(ctx.Request, w),
+	})
+}
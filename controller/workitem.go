@@ -175,11 +175,15 @@ func (c *WorkitemController) Update(ctx *app.UpdateWorkitemContext) error {
 		// we overwrite the values with its old value after the work item was
 		// converted.
 		oldNumber := wi.Number
+		oldArea := wi.Fields[workitem.SystemArea]
 		err = ConvertJSONAPIToWorkItem(ctx, ctx.Method, appl, *ctx.Payload.Data, wi, wi.Type, wi.SpaceID)
 		if err != nil {
 			return err
 		}
 		wi.Number = oldNumber
+		if err = applyAreaDefaults(ctx, appl, wi.Fields, oldArea); err != nil {
+			return err
+		}
 		wi, rev, err = appl.WorkItems().Save(ctx, wi.SpaceID, *wi, *currentUserIdentityID)
 		if err != nil {
 			return errs.Wrap(err, "Error updating work item")
@@ -194,7 +198,8 @@ func (c *WorkitemController) Update(ctx *app.UpdateWorkitemContext) error {
 		return jsonapi.JSONErrorResponse(ctx, errs.Wrapf(err, "failed to load work item type: %s", wi.Type))
 	}
 	c.notification.Send(ctx, notification.NewWorkItemUpdated(ctx.Payload.Data.ID.String(), rev.ID))
-	converted, err := ConvertWorkItem(ctx.Request, *wit, *wi, workItemIncludeHasChildren(ctx, c.db))
+	computed := workItemIncludeComputedFields(ctx, c.db, []workitem.WorkItemType{*wit}, []workitem.WorkItem{*wi})
+	converted, err := ConvertWorkItem(ctx.Request, *wit, *wi, workItemIncludeHasChildren(ctx, c.db), computed)
 	if err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
@@ -222,6 +227,13 @@ func (c *WorkitemController) Show(ctx *app.ShowWorkitemContext) error {
 		if err != nil {
 			return errs.Wrapf(err, "failed to load work item type: %s", wi.Type)
 		}
+		if len(wit.ComputedFields()) > 0 {
+			// the computed fields may aggregate over the children
+			wi.ChildrenChangedAt, err = appl.WorkItems().ChildrenChangedAt(ctx, wi.ID)
+			if err != nil {
+				return errs.Wrapf(err, "failed to load the latest change of the children of work item %s", wi.ID)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -230,7 +242,8 @@ func (c *WorkitemController) Show(ctx *app.ShowWorkitemContext) error {
 	return ctx.ConditionalRequest(*wi, c.config.GetCacheControlWorkItem, func() error {
 		comments := workItemIncludeCommentsAndTotal(ctx, c.db, ctx.WiID)
 		hasChildren := workItemIncludeHasChildren(ctx, c.db)
-		wi2, err := ConvertWorkItem(ctx.Request, *wit, *wi, comments, hasChildren)
+		computed := workItemIncludeComputedFields(ctx, c.db, []workitem.WorkItemType{*wit}, []workitem.WorkItem{*wi})
+		wi2, err := ConvertWorkItem(ctx.Request, *wit, *wi, comments, hasChildren, computed)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, err)
 		}
@@ -1117,6 +1130,31 @@ func workItemIncludeHasChildren(ctx context.Context, appl application.Applicatio
 	}
 }
 
+// workItemIncludeComputedFields adds the values of the computed fields of the
+// work item type to the attributes. The values of all given work items are
+// computed at once when the first of them is converted. The given work item
+// types must contain the types of all given work items.
+func workItemIncludeComputedFields(ctx context.Context, appl application.Application, wits []workitem.WorkItemType, wis []workitem.WorkItem) WorkItemConvertFunc {
+	var values map[uuid.UUID]map[string]interface{}
+	return func(request *http.Request, wi *workitem.WorkItem, wi2 *app.WorkItem) error {
+		if values == nil {
+			var err error
+			values, err = appl.WorkItems().ComputeFieldsList(ctx, wits, wis)
+			if err != nil {
+				log.Error(ctx, map[string]interface{}{
+					"wi_id": wi.ID,
+					"err":   err,
+				}, "unable to compute the fields of work items")
+				return errs.Wrapf(err, "failed to compute fields of work item %s", wi.ID)
+			}
+		}
+		for name, v := range values[wi.ID] {
+			wi2.Attributes[name] = v
+		}
+		return nil
+	}
+}
+
 // includeParentWorkItem adds the parent of given WI to relationships & included object
 func includeParentWorkItem(ctx context.Context, ancestors link.AncestorList, childLinks link.WorkItemLinkList) WorkItemConvertFunc {
 	return func(request *http.Request, wi *workitem.WorkItem, wi2 *app.WorkItem) error {
@@ -1174,8 +1212,11 @@ func (c *WorkitemController) ListChildren(ctx *app.ListChildrenWorkitemContext)
 	return ctx.ConditionalEntities(result, c.config.GetCacheControlWorkItems, func() error {
 		var response app.WorkItemList
 		application.Transactional(c.db, func(appl application.Application) error {
-			hasChildren := workItemIncludeHasChildren(ctx, appl)
-			converted, err := ConvertWorkItems(ctx.Request, wits, result, hasChildren)
+			options := []WorkItemConvertFunc{workItemIncludeHasChildren(ctx, appl)}
+			if ctx.Computed != nil && *ctx.Computed {
+				options = append(options, workItemIncludeComputedFields(ctx, appl, wits, result))
+			}
+			converted, err := ConvertWorkItems(ctx.Request, wits, result, options...)
 			if err != nil {
 				return errs.WithStack(err)
 			}
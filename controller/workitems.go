@@ -1,9 +1,11 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
+	"github.com/fabric8-services/fabric8-wit/actions/rules"
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/criteria"
@@ -123,6 +125,9 @@ func (c *WorkitemsController) Create(ctx *app.CreateWorkitemsContext) error {
 		if err != nil {
 			return errs.Wrap(err, fmt.Sprintf("Error creating work item"))
 		}
+		if err = applyAreaDefaults(ctx, appl, wi.Fields, nil); err != nil {
+			return err
+		}
 
 		wi, rev, err = appl.WorkItems().Create(ctx, ctx.SpaceID, *wit, wi.Fields, *currentUserIdentityID)
 		if err != nil {
@@ -138,7 +143,8 @@ func (c *WorkitemsController) Create(ctx *app.CreateWorkitemsContext) error {
 	if err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
-	wi2, err := ConvertWorkItem(ctx.Request, *workItemType, *wi, hasChildren)
+	computed := workItemIncludeComputedFields(ctx, c.db, []workitem.WorkItemType{*workItemType}, []workitem.WorkItem{*wi})
+	wi2, err := ConvertWorkItem(ctx.Request, *workItemType, *wi, hasChildren, computed)
 	if err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
@@ -170,10 +176,16 @@ func (c *WorkitemsController) List(ctx *app.ListWorkitemsContext) error {
 		// Then convert new Query object into simple string
 		queryWithSpaceID := fmt.Sprintf(`{"%s":[{"space": "%s" }, %s]}`, search.AND, ctx.SpaceID, q)
 		queryWithSpaceID = fmt.Sprintf("?filter[expression]=%s", queryWithSpaceID)
+		if ctx.Computed != nil && *ctx.Computed {
+			queryWithSpaceID += "&computed=true"
+		}
 		searchURL := app.SearchHref() + queryWithSpaceID
 		ctx.ResponseData.Header().Set("Location", searchURL)
 		return ctx.TemporaryRedirect()
 	}
+	if ctx.Computed != nil && *ctx.Computed {
+		additionalQuery = append(additionalQuery, "computed=true")
+	}
 	if ctx.FilterAssignee != nil {
 		if *ctx.FilterAssignee == none {
 			exp = criteria.And(exp, criteria.IsNull("system.assignees"))
@@ -243,12 +255,15 @@ func (c *WorkitemsController) List(ctx *app.ListWorkitemsContext) error {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
 	return ctx.ConditionalEntities(workitems, c.config.GetCacheControlWorkItems, func() error {
-		hasChildren := workItemIncludeHasChildren(ctx, c.db)
 		wits, err := loadWorkItemTypesFromArr(ctx.Context, c.db, workitems)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, err)
 		}
-		converted, err := ConvertWorkItems(ctx.Request, wits, workitems, hasChildren)
+		options := []WorkItemConvertFunc{workItemIncludeHasChildren(ctx, c.db)}
+		if ctx.Computed != nil && *ctx.Computed {
+			options = append(options, workItemIncludeComputedFields(ctx, c.db, wits, workitems))
+		}
+		converted, err := ConvertWorkItems(ctx.Request, wits, workitems, options...)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, err)
 		}
@@ -311,7 +326,8 @@ func (c *WorkitemsController) Reorder(ctx *app.ReorderWorkitemsContext) error {
 			if err != nil {
 				return errs.WithStack(err)
 			}
-			wi2, err := ConvertWorkItem(ctx.Request, *wit, *wi, hasChildren)
+			computed := workItemIncludeComputedFields(ctx, appl, []workitem.WorkItemType{*wit}, []workitem.WorkItem{*wi})
+			wi2, err := ConvertWorkItem(ctx.Request, *wit, *wi, hasChildren, computed)
 			if err != nil {
 				return errs.WithStack(err)
 			}
@@ -328,3 +344,23 @@ func (c *WorkitemsController) Reorder(ctx *app.ReorderWorkitemsContext) error {
 	}
 	return ctx.OK(resp)
 }
+
+// applyAreaDefaults assigns the owners and attaches the default labels of the
+// area of the given work item fields if the work item is created in or moved
+// to that area. The given old area is nil for newly created work items.
+func applyAreaDefaults(ctx context.Context, appl application.Application, fields map[string]interface{}, oldArea interface{}) error {
+	newArea := fields[workitem.SystemArea]
+	if newArea == nil || (oldArea != nil && fmt.Sprint(newArea) == fmt.Sprint(oldArea)) {
+		return nil
+	}
+	areaID, err := uuid.FromString(fmt.Sprint(newArea))
+	if err != nil {
+		return errors.NewBadParameterError(workitem.SystemArea, newArea)
+	}
+	a, err := appl.Areas().Load(ctx, areaID)
+	if err != nil {
+		return errs.Wrapf(err, "failed to load the area %s", areaID)
+	}
+	rules.AreaDefaults(fields, *a)
+	return nil
+}
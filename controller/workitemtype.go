@@ -79,13 +79,10 @@ func ConvertWorkItemTypeFromModel(request *http.Request, t *workitem.WorkItemTyp
 		},
 	}
 	for name, def := range t.Fields {
-		ct := ConvertFieldTypeFromModel(def.Type)
-		converted.Attributes.Fields[name] = &app.FieldDefinition{
-			Required:    def.Required,
-			Label:       def.Label,
-			Description: def.Description,
-			Type:        &ct,
-		}
+		converted.Attributes.Fields[name] = ConvertFieldDefinitionFromModel(def)
+	}
+	for _, tr := range t.Transitions {
+		converted.Attributes.Transitions = append(converted.Attributes.Transitions, ConvertTransitionFromModel(tr))
 	}
 	if len(t.ChildTypeIDs) > 0 {
 		converted.Relationships.GuidedChildTypes = &app.RelationGenericList{
@@ -101,6 +98,19 @@ func ConvertWorkItemTypeFromModel(request *http.Request, t *workitem.WorkItemTyp
 	return converted
 }
 
+// ConvertFieldDefinitionFromModel converts a field definition from model to
+// app representation
+func ConvertFieldDefinitionFromModel(def workitem.FieldDefinition) *app.FieldDefinition {
+	ct := ConvertFieldTypeFromModel(def.Type)
+	return &app.FieldDefinition{
+		Required:    def.Required,
+		Label:       def.Label,
+		Description: def.Description,
+		Type:        &ct,
+		Rules:       ConvertFieldRulesFromModel(def.Rules),
+	}
+}
+
 // converts the field type from model to app representation
 func ConvertFieldTypeFromModel(t workitem.FieldType) app.FieldType {
 	result := app.FieldType{}
@@ -121,11 +131,80 @@ func ConvertFieldTypeFromModel(t workitem.FieldType) app.FieldType {
 		if modelFieldType.DefaultValue != nil {
 			result.DefaultValue = &modelFieldType.DefaultValue
 		}
+	case workitem.ComputedType:
+		result.ResultKind = ptr.String(string(modelFieldType.ResultKind))
+		result.Expression = ptr.String(modelFieldType.Expression)
 	}
 
 	return result
 }
 
+// ConvertTransitionFromModel converts a state transition from model to app
+// representation
+func ConvertTransitionFromModel(t workitem.Transition) *app.WorkItemTypeTransition {
+	result := app.WorkItemTypeTransition{
+		From:           t.From,
+		To:             t.To,
+		RequiredFields: t.RequiredFields,
+	}
+	for _, g := range t.Guards {
+		result.Guards = append(result.Guards, &app.FieldCondition{
+			Field:  g.Field,
+			Values: g.Values,
+		})
+	}
+	return &result
+}
+
+// ConvertFieldRulesFromModel converts the field rules from model to app
+// representation
+func ConvertFieldRulesFromModel(r *workitem.FieldRules) *app.FieldRules {
+	if r == nil {
+		return nil
+	}
+	result := app.FieldRules{
+		Min:       r.Min,
+		Max:       r.Max,
+		Pattern:   r.Pattern,
+		MaxLength: r.MaxLength,
+		NotBefore: r.NotBefore,
+		NotAfter:  r.NotAfter,
+	}
+	for _, c := range r.RequiredWhen {
+		result.RequiredWhen = append(result.RequiredWhen, &app.FieldCondition{
+			Field:  c.Field,
+			Values: c.Values,
+		})
+	}
+	return &result
+}
+
+// ConvertFieldRulesToModel converts the field rules from app to model
+// representation
+func ConvertFieldRulesToModel(r *app.FieldRules) *workitem.FieldRules {
+	if r == nil {
+		return nil
+	}
+	result := workitem.FieldRules{
+		Min:       r.Min,
+		Max:       r.Max,
+		Pattern:   r.Pattern,
+		MaxLength: r.MaxLength,
+		NotBefore: r.NotBefore,
+		NotAfter:  r.NotAfter,
+	}
+	for _, c := range r.RequiredWhen {
+		if c == nil {
+			continue
+		}
+		result.RequiredWhen = append(result.RequiredWhen, workitem.FieldCondition{
+			Field:  c.Field,
+			Values: c.Values,
+		})
+	}
+	return &result
+}
+
 func ConvertFieldTypeToModel(t app.FieldType) (workitem.FieldType, error) {
 	kind, err := workitem.ConvertStringToKind(t.Kind)
 	if err != nil {
@@ -188,6 +267,19 @@ func ConvertFieldTypeToModel(t app.FieldType) (workitem.FieldType, error) {
 			return fieldType, nil
 		}
 		return enumType, nil
+	case workitem.KindComputed:
+		if t.ResultKind == nil || t.Expression == nil {
+			return nil, errs.Errorf("computed type needs a result kind and an expression: %+v", t)
+		}
+		computedType := workitem.ComputedType{
+			Kind:       *kind,
+			ResultKind: workitem.Kind(*t.ResultKind),
+			Expression: *t.Expression,
+		}
+		if err := computedType.Validate(); err != nil {
+			return nil, errs.WithStack(err)
+		}
+		return computedType, nil
 	default:
 		simpleType := workitem.SimpleType{Kind: *kind}
 		// convert simple type default value from app to model
@@ -215,6 +307,12 @@ func ConvertFieldDefinitionsToModel(fields map[string]app.FieldDefinition) (map[
 			Description: definition.Description,
 			Required:    definition.Required,
 			Type:        ct,
+			Rules:       ConvertFieldRulesToModel(definition.Rules),
+		}
+		if converted.Rules != nil {
+			if err := converted.Rules.Validate(ct); err != nil {
+				return nil, errs.Wrapf(err, "failed to validate rules of field %s", field)
+			}
 		}
 		modelFields[field] = converted
 	}
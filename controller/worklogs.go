@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/worklog"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// WorklogsController implements the worklogs resource.
+type WorklogsController struct {
+	*goa.Controller
+	db     application.DB
+	config WorklogsControllerConfiguration
+}
+
+// WorklogsControllerConfiguration the configuration for the WorklogsController
+type WorklogsControllerConfiguration interface {
+	GetCacheControlWorklog() string
+}
+
+// NewWorklogsController creates a worklogs controller.
+func NewWorklogsController(service *goa.Service, db application.DB, config WorklogsControllerConfiguration) *WorklogsController {
+	return &WorklogsController{
+		Controller: service.NewController("WorklogsController"),
+		db:         db,
+		config:     config,
+	}
+}
+
+// Show runs the show action.
+func (c *WorklogsController) Show(ctx *app.ShowWorklogsContext) error {
+	if _, err := login.ContextIdentity(ctx); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	var w *worklog.Worklog
+	var spaceID uuid.UUID
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		w, err = appl.Worklogs().Load(ctx, ctx.WorklogID)
+		if err != nil {
+			return err
+		}
+		wi, err := appl.WorkItems().LoadByID(ctx, w.WorkItemID)
+		if err != nil {
+			return err
+		}
+		spaceID = wi.SpaceID
+		return nil
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if err := authorizeWorklogAccess(ctx, spaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.ConditionalRequest(*w, c.config.GetCacheControlWorklog, func() error {
+		return ctx.OK(&app.WorklogSingle{
+			Data: ConvertWorklog(ctx.Request, *w),
+		})
+	})
+}
+
+// Update runs the update action.
+func (c *WorklogsController) Update(ctx *app.UpdateWorklogsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil || ctx.Payload.Data.Attributes == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes", nil).Expected("not nil"))
+	}
+	w, err := c.loadAuthorized(ctx, ctx.WorklogID, *identityID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.StartedAt != nil {
+		w.StartedAt = *attrs.StartedAt
+	}
+	if attrs.Duration != nil {
+		w.Duration = int64(*attrs.Duration)
+	}
+	if attrs.Comment != nil {
+		w.Comment = *attrs.Comment
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		w, err = appl.Worklogs().Save(ctx, *w)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.WorklogSingle{
+		Data: ConvertWorklog(ctx.Request, *w),
+	})
+}
+
+// Delete runs the delete action.
+func (c *WorklogsController) Delete(ctx *app.DeleteWorklogsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	w, err := c.loadAuthorized(ctx, ctx.WorklogID, *identityID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		return appl.Worklogs().Delete(ctx, w.ID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK([]byte{})
+}
+
+// loadAuthorized loads the worklog with the given ID and verifies that the
+// given identity is either the identity of the worklog or a collaborator of
+// the space of its work item.
+func (c *WorklogsController) loadAuthorized(ctx context.Context, worklogID, identityID uuid.UUID) (*worklog.Worklog, error) {
+	var w *worklog.Worklog
+	var spaceID uuid.UUID
+	err := application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		w, err = appl.Worklogs().Load(ctx, worklogID)
+		if err != nil {
+			return err
+		}
+		wi, err := appl.WorkItems().LoadByID(ctx, w.WorkItemID)
+		if err != nil {
+			return err
+		}
+		spaceID = wi.SpaceID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if uuid.Equal(w.IdentityID, identityID) {
+		return w, nil
+	}
+	authorized, err := authz.Authorize(ctx, spaceID.String())
+	if err != nil {
+		return nil, errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return nil, errors.NewForbiddenError("user is neither the identity of the worklog nor a space collaborator")
+	}
+	return w, nil
+}
+
+// authorizeWorklogAccess verifies that the current user is a collaborator of
+// the given space, which is required to see the worklogs of its work items.
+func authorizeWorklogAccess(ctx context.Context, spaceID uuid.UUID) error {
+	authorized, err := authz.Authorize(ctx, spaceID.String())
+	if err != nil {
+		return errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return errors.NewForbiddenError("user is not a space collaborator")
+	}
+	return nil
+}
+
+// ConvertWorklogs converts between internal and external REST representation
+func ConvertWorklogs(request *http.Request, worklogs []worklog.Worklog) []*app.Worklog {
+	res := make([]*app.Worklog, 0, len(worklogs))
+	for _, w := range worklogs {
+		res = append(res, ConvertWorklog(request, w))
+	}
+	return res
+}
+
+// ConvertWorklog converts between internal and external REST representation
+func ConvertWorklog(request *http.Request, w worklog.Worklog) *app.Worklog {
+	selfURL := rest.AbsoluteURL(request, app.WorklogsHref(w.ID))
+	workItemURL := rest.AbsoluteURL(request, app.WorkitemHref(w.WorkItemID))
+	identityURL := rest.AbsoluteURL(request, fmt.Sprintf("%s/%s", usersEndpoint, w.IdentityID))
+	return &app.Worklog{
+		Type: worklog.APIStringTypeWorklogs,
+		ID:   ptr.UUID(w.ID),
+		Attributes: &app.WorklogAttributes{
+			StartedAt: ptr.Time(w.StartedAt.UTC()),
+			Duration:  ptr.Int(int(w.Duration)),
+			Comment:   ptr.String(w.Comment),
+			CreatedAt: ptr.Time(w.CreatedAt.UTC()),
+			UpdatedAt: ptr.Time(w.UpdatedAt.UTC()),
+		},
+		Relationships: &app.WorklogRelations{
+			Workitem: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APIStringTypeWorkItem),
+					ID:   ptr.String(w.WorkItemID.String()),
+				},
+				Links: &app.GenericLinks{
+					Self:    &workItemURL,
+					Related: &workItemURL,
+				},
+			},
+			Identity: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: ptr.String(APIStringTypeUser),
+					ID:   ptr.String(w.IdentityID.String()),
+				},
+				Links: &app.GenericLinks{
+					Related: &identityURL,
+				},
+			},
+		},
+		Links: &app.GenericLinks{
+			Self:    &selfURL,
+			Related: &selfURL,
+		},
+	}
+}
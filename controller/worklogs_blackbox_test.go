@@ -0,0 +1,329 @@
+package controller_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/worklog"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestWorklogsREST struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunWorklogsREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestWorklogsREST{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+var worklogStart = time.Date(2018, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+// serviceAs returns a service for the given identity in which only the given
+// owner and collaborators are collaborators of the space.
+func (s *TestWorklogsREST) serviceAs(identity account.Identity, owner account.Identity, collaborators ...uuid.UUID) *goa.Service {
+	userIDs := ""
+	for _, id := range collaborators {
+		userIDs += id.String() + ","
+	}
+	return testsupport.ServiceAsSpaceUser("Worklogs-Service", identity, &TestSpaceAuthzService{owner: owner, userIDs: userIDs})
+}
+
+func newCreateWorklogPayload(startedAt time.Time, duration int, comment string) *app.CreateWorkItemWorklogsPayload {
+	return &app.CreateWorkItemWorklogsPayload{
+		Data: &app.Worklog{
+			Type: worklog.APIStringTypeWorklogs,
+			Attributes: &app.WorklogAttributes{
+				StartedAt: &startedAt,
+				Duration:  &duration,
+				Comment:   &comment,
+			},
+		},
+	}
+}
+
+func (s *TestWorklogsREST) createWorklog(t *testing.T, svc *goa.Service, wiID uuid.UUID, startedAt time.Time, duration int) *app.WorklogSingle {
+	ctrl := NewWorkItemWorklogsController(svc, s.GormDB)
+	_, res := test.CreateWorkItemWorklogsCreated(t, svc.Context, svc, ctrl, wiID, newCreateWorklogPayload(startedAt, duration, "some work"))
+	return res
+}
+
+func (s *TestWorklogsREST) TestCreate() {
+	s.T().Run("ok", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := s.serviceAs(*fxt.Identities[0], *fxt.Identities[0])
+		res := s.createWorklog(t, svc, fxt.WorkItems[0].ID, worklogStart, 3600)
+		require.NotNil(t, res.Data.ID)
+		assert.Equal(t, 3600, *res.Data.Attributes.Duration)
+		assert.Equal(t, "some work", *res.Data.Attributes.Comment)
+		assert.True(t, worklogStart.Equal(*res.Data.Attributes.StartedAt))
+		assert.Equal(t, fxt.Identities[0].ID.String(), *res.Data.Relationships.Identity.Data.ID)
+		assert.Equal(t, fxt.WorkItems[0].ID.String(), *res.Data.Relationships.Workitem.Data.ID)
+	})
+
+	s.T().Run("missing duration", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := s.serviceAs(*fxt.Identities[0], *fxt.Identities[0])
+		payload := newCreateWorklogPayload(worklogStart, 60, "")
+		payload.Data.Attributes.Duration = nil
+		test.CreateWorkItemWorklogsBadRequest(t, svc.Context, svc, NewWorkItemWorklogsController(svc, s.GormDB), fxt.WorkItems[0].ID, payload)
+	})
+
+	s.T().Run("not a collaborator", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		svc := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0])
+		test.CreateWorkItemWorklogsForbidden(t, svc.Context, svc, NewWorkItemWorklogsController(svc, s.GormDB), fxt.WorkItems[0].ID, newCreateWorklogPayload(worklogStart, 60, ""))
+	})
+
+	s.T().Run("unknown work item", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(1))
+		svc := s.serviceAs(*fxt.Identities[0], *fxt.Identities[0])
+		test.CreateWorkItemWorklogsNotFound(t, svc.Context, svc, NewWorkItemWorklogsController(svc, s.GormDB), uuid.NewV4(), newCreateWorklogPayload(worklogStart, 60, ""))
+	})
+
+	s.T().Run("unauthorized", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := goa.New("Worklogs-Service")
+		test.CreateWorkItemWorklogsUnauthorized(t, svc.Context, svc, NewWorkItemWorklogsController(svc, s.GormDB), fxt.WorkItems[0].ID, newCreateWorklogPayload(worklogStart, 60, ""))
+	})
+}
+
+func (s *TestWorklogsREST) TestListWorkItemWorklogs() {
+	// given a tree A -> B
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyTree)),
+		tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+		tf.WorkItemLinksCustom(1, tf.BuildLinks(tf.LinkChain("A", "B")...)),
+	)
+	svc := s.serviceAs(*fxt.Identities[0], *fxt.Identities[0])
+	s.createWorklog(s.T(), svc, fxt.WorkItemByTitle("A").ID, worklogStart, 3600)
+	s.createWorklog(s.T(), svc, fxt.WorkItemByTitle("B").ID, worklogStart, 1800)
+	s.createWorklog(s.T(), svc, fxt.WorkItemByTitle("B").ID, worklogStart.Add(time.Hour), 600)
+	ctrl := NewWorkItemWorklogsController(svc, s.GormDB)
+
+	s.T().Run("parent", func(t *testing.T) {
+		_, list := test.ListWorkItemWorklogsOK(t, svc.Context, svc, ctrl, fxt.WorkItemByTitle("A").ID)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, 3600, list.Meta.TimeSpent)
+		assert.Equal(t, 6000, list.Meta.TotalTimeSpent)
+	})
+
+	s.T().Run("child", func(t *testing.T) {
+		_, list := test.ListWorkItemWorklogsOK(t, svc.Context, svc, ctrl, fxt.WorkItemByTitle("B").ID)
+		require.Len(t, list.Data, 2)
+		assert.Equal(t, 2400, list.Meta.TimeSpent)
+		assert.Equal(t, 2400, list.Meta.TotalTimeSpent)
+	})
+
+	s.T().Run("unknown work item", func(t *testing.T) {
+		test.ListWorkItemWorklogsNotFound(t, svc.Context, svc, ctrl, uuid.NewV4())
+	})
+
+	s.T().Run("not a collaborator", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		other := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0])
+		test.ListWorkItemWorklogsForbidden(t, other.Context, other, NewWorkItemWorklogsController(other, s.GormDB), fxt.WorkItems[0].ID)
+	})
+
+	s.T().Run("unauthorized", func(t *testing.T) {
+		anonymous := goa.New("Worklogs-Service")
+		test.ListWorkItemWorklogsUnauthorized(t, anonymous.Context, anonymous, NewWorkItemWorklogsController(anonymous, s.GormDB), fxt.WorkItemByTitle("A").ID)
+	})
+}
+
+func (s *TestWorklogsREST) TestShowUpdateAndDelete() {
+	s.T().Run("show", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		svc := s.serviceAs(*fxt.Identities[0], *fxt.Identities[0])
+		created := s.createWorklog(t, svc, fxt.WorkItems[0].ID, worklogStart, 3600)
+		ctrl := NewWorklogsController(svc, s.GormDB, s.Configuration)
+		res, shown := test.ShowWorklogsOK(t, svc.Context, svc, ctrl, *created.Data.ID, nil, nil)
+		assert.Equal(t, *created.Data.ID, *shown.Data.ID)
+		assertResponseHeaders(t, res)
+
+		t.Run("not a collaborator", func(t *testing.T) {
+			others := tf.NewTestFixture(t, s.DB, tf.Identities(1))
+			other := s.serviceAs(*others.Identities[0], *fxt.Identities[0])
+			test.ShowWorklogsForbidden(t, other.Context, other, NewWorklogsController(other, s.GormDB, s.Configuration), *created.Data.ID, nil, nil)
+		})
+
+		t.Run("unauthorized", func(t *testing.T) {
+			anonymous := goa.New("Worklogs-Service")
+			test.ShowWorklogsUnauthorized(t, anonymous.Context, anonymous, NewWorklogsController(anonymous, s.GormDB, s.Configuration), *created.Data.ID, nil, nil)
+		})
+	})
+
+	s.T().Run("update by the identity of the worklog", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		// the identity was a collaborator when the time was logged but is not anymore
+		logger := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0], fxt.Identities[1].ID)
+		created := s.createWorklog(t, logger, fxt.WorkItems[0].ID, worklogStart, 3600)
+		svc := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0])
+		payload := &app.UpdateWorklogsPayload{
+			Data: &app.Worklog{
+				Type: worklog.APIStringTypeWorklogs,
+				Attributes: &app.WorklogAttributes{
+					Duration: ptr.Int(1800),
+					Comment:  ptr.String("less work"),
+				},
+			},
+		}
+		_, updated := test.UpdateWorklogsOK(t, svc.Context, svc, NewWorklogsController(svc, s.GormDB, s.Configuration), *created.Data.ID, payload)
+		assert.Equal(t, 1800, *updated.Data.Attributes.Duration)
+		assert.Equal(t, "less work", *updated.Data.Attributes.Comment)
+		assert.True(t, worklogStart.Equal(*updated.Data.Attributes.StartedAt))
+	})
+
+	s.T().Run("update by other user", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		created := s.createWorklog(t, s.serviceAs(*fxt.Identities[0], *fxt.Identities[0]), fxt.WorkItems[0].ID, worklogStart, 3600)
+		other := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0])
+		payload := &app.UpdateWorklogsPayload{
+			Data: &app.Worklog{
+				Type:       worklog.APIStringTypeWorklogs,
+				Attributes: &app.WorklogAttributes{Duration: ptr.Int(1)},
+			},
+		}
+		test.UpdateWorklogsForbidden(t, other.Context, other, NewWorklogsController(other, s.GormDB, s.Configuration), *created.Data.ID, payload)
+	})
+
+	s.T().Run("delete by other user", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		created := s.createWorklog(t, s.serviceAs(*fxt.Identities[0], *fxt.Identities[0]), fxt.WorkItems[0].ID, worklogStart, 3600)
+		other := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0])
+		test.DeleteWorklogsForbidden(t, other.Context, other, NewWorklogsController(other, s.GormDB, s.Configuration), *created.Data.ID)
+	})
+
+	s.T().Run("delete by space collaborator", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Identities(2), tf.WorkItems(1))
+		created := s.createWorklog(t, s.serviceAs(*fxt.Identities[0], *fxt.Identities[0]), fxt.WorkItems[0].ID, worklogStart, 3600)
+		collaborator := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0], fxt.Identities[1].ID)
+		ctrl := NewWorklogsController(collaborator, s.GormDB, s.Configuration)
+		test.DeleteWorklogsOK(t, collaborator.Context, collaborator, ctrl, *created.Data.ID)
+		test.ShowWorklogsNotFound(t, collaborator.Context, collaborator, ctrl, *created.Data.ID, nil, nil)
+	})
+}
+
+func (s *TestWorklogsREST) TestSpaceWorklogs() {
+	// given two identities logging time in the same space
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Identities(3), tf.Iterations(1), tf.WorkItems(2, tf.SetWorkItemTitles("first", "second"), func(fxt *tf.TestFixture, idx int) error {
+		fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.Iterations[0].ID.String()
+		return nil
+	}))
+	alice := s.serviceAs(*fxt.Identities[0], *fxt.Identities[0], fxt.Identities[1].ID)
+	bob := s.serviceAs(*fxt.Identities[1], *fxt.Identities[0], fxt.Identities[1].ID)
+	s.createWorklog(s.T(), alice, fxt.WorkItems[0].ID, worklogStart, 3600)
+	s.createWorklog(s.T(), alice, fxt.WorkItems[1].ID, worklogStart.Add(time.Hour), 1800)
+	s.createWorklog(s.T(), bob, fxt.WorkItems[1].ID, worklogStart.Add(24*time.Hour), 900)
+	svc := alice
+	ctrl := NewSpaceWorklogsController(svc, s.GormDB)
+	spaceID := fxt.Spaces[0].ID
+
+	s.T().Run("list", func(t *testing.T) {
+		_, list := test.ListSpaceWorklogsOK(t, svc.Context, svc, ctrl, spaceID, nil, nil, nil, nil)
+		require.Len(t, list.Data, 3)
+		assert.Equal(t, 3, list.Meta.TotalCount)
+		assert.Equal(t, 6300, list.Meta.TotalTimeSpent)
+	})
+
+	s.T().Run("list filtered", func(t *testing.T) {
+		_, list := test.ListSpaceWorklogsOK(t, svc.Context, svc, ctrl, spaceID, ptr.Time(worklogStart.Add(time.Minute)), &fxt.Identities[0].ID, nil, nil)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, 1800, list.Meta.TotalTimeSpent)
+	})
+
+	s.T().Run("timesheet", func(t *testing.T) {
+		res := test.TimesheetSpaceWorklogsOK(t, svc.Context, svc, ctrl, spaceID, nil, nil, nil, nil, nil)
+		lines := readTimesheet(t, res)
+		require.Len(t, lines, 4)
+		assert.Equal(t, []string{"Started At", "Identity", "Work Item", "Title", "Iteration", "Hours", "Comment"}, lines[0])
+		assert.Equal(t, worklogStart.Format(time.RFC3339), lines[1][0])
+		assert.Equal(t, fxt.Identities[0].Username, lines[1][1])
+		assert.Equal(t, strconv.Itoa(fxt.WorkItems[0].Number), lines[1][2])
+		assert.Equal(t, "first", lines[1][3])
+		assert.Equal(t, fxt.Iterations[0].Name, lines[1][4])
+		assert.Equal(t, "1.00", lines[1][5])
+		assert.Equal(t, "some work", lines[1][6])
+		assert.Equal(t, "0.25", lines[3][5])
+		assert.Contains(t, res.Header().Get("Content-Disposition"), `attachment; filename="timesheet-`)
+	})
+
+	s.T().Run("timesheet grouped by identity", func(t *testing.T) {
+		res := test.TimesheetSpaceWorklogsOK(t, svc.Context, svc, ctrl, spaceID, nil, nil, nil, nil, []string{"identity"})
+		lines := readTimesheet(t, res)
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"Identity", "Worklogs", "Hours"}, lines[0])
+		assert.ElementsMatch(t, [][]string{
+			{fxt.Identities[0].Username, "2", "1.50"},
+			{fxt.Identities[1].Username, "1", "0.25"},
+		}, lines[1:])
+	})
+
+	s.T().Run("timesheet grouped by identity and iteration", func(t *testing.T) {
+		res := test.TimesheetSpaceWorklogsOK(t, svc.Context, svc, ctrl, spaceID, nil, &fxt.Identities[1].ID, nil, nil, []string{"identity", "iteration"})
+		lines := readTimesheet(t, res)
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"Identity", "Iteration", "Worklogs", "Hours"}, lines[0])
+		assert.Equal(t, []string{fxt.Identities[1].Username, fxt.Iterations[0].Name, "1", "0.25"}, lines[1])
+	})
+
+	s.T().Run("unknown space", func(t *testing.T) {
+		test.TimesheetSpaceWorklogsNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), nil, nil, nil, nil, nil)
+	})
+
+	s.T().Run("not a collaborator", func(t *testing.T) {
+		eve := s.serviceAs(*fxt.Identities[2], *fxt.Identities[0], fxt.Identities[1].ID)
+		ctrl := NewSpaceWorklogsController(eve, s.GormDB)
+		test.ListSpaceWorklogsForbidden(t, eve.Context, eve, ctrl, spaceID, nil, nil, nil, nil)
+		test.TimesheetSpaceWorklogsForbidden(t, eve.Context, eve, ctrl, spaceID, nil, nil, nil, nil, nil)
+	})
+
+	s.T().Run("unauthorized", func(t *testing.T) {
+		anonymous := goa.New("Worklogs-Service")
+		ctrl := NewSpaceWorklogsController(anonymous, s.GormDB)
+		test.ListSpaceWorklogsUnauthorized(t, anonymous.Context, anonymous, ctrl, spaceID, nil, nil, nil, nil)
+		test.TimesheetSpaceWorklogsUnauthorized(t, anonymous.Context, anonymous, ctrl, spaceID, nil, nil, nil, nil, nil)
+	})
+}
+
+func (s *TestWorklogsREST) TestTimesheetEscapesFormulas() {
+	// given a work item title and a comment that spreadsheets would evaluate
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItems(1, tf.SetWorkItemTitles("=1+1")))
+	svc := s.serviceAs(*fxt.Identities[0], *fxt.Identities[0])
+	test.CreateWorkItemWorklogsCreated(s.T(), svc.Context, svc, NewWorkItemWorklogsController(svc, s.GormDB), fxt.WorkItems[0].ID, newCreateWorklogPayload(worklogStart, 60, "@SUM(A1:A2)"))
+	// when
+	res := test.TimesheetSpaceWorklogsOK(s.T(), svc.Context, svc, NewSpaceWorklogsController(svc, s.GormDB), fxt.Spaces[0].ID, nil, nil, nil, nil, nil)
+	// then
+	lines := readTimesheet(s.T(), res)
+	require.Len(s.T(), lines, 2)
+	assert.Equal(s.T(), "'=1+1", lines[1][3])
+	assert.Equal(s.T(), "'@SUM(A1:A2)", lines[1][6])
+}
+
+// readTimesheet parses the CSV body of the given timesheet response
+func readTimesheet(t *testing.T, res http.ResponseWriter) [][]string {
+	recorder, ok := res.(*httptest.ResponseRecorder)
+	require.True(t, ok)
+	lines, err := csv.NewReader(recorder.Body).ReadAll()
+	require.NoError(t, err)
+	return lines
+}
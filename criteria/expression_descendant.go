@@ -0,0 +1,25 @@
+package criteria
+
+// DescendantExpression represents the descendant operator. The right side
+// holds the ID of a work item and the expression matches all work items that
+// are below it in a tree topology.
+type DescendantExpression struct {
+	binaryExpression
+	// MaxDepth limits the number of tree levels below the ancestor that are
+	// searched; 0 means all levels.
+	MaxDepth int
+}
+
+// Ensure DescendantExpression implements the Expression interface
+var _ Expression = &DescendantExpression{}
+var _ Expression = (*DescendantExpression)(nil)
+
+// Accept implements ExpressionVisitor
+func (t *DescendantExpression) Accept(visitor ExpressionVisitor) interface{} {
+	return visitor.Descendant(t)
+}
+
+// Descendant constructs a DescendantExpression
+func Descendant(left Expression, right Expression, maxDepth int) Expression {
+	return reparent(&DescendantExpression{binaryExpression{expression{}, left, right}, maxDepth})
+}
@@ -11,5 +11,6 @@ type ExpressionVisitor interface {
 	Literal(c *LiteralExpression) interface{}
 	Not(e *NotExpression) interface{}
 	Child(e *ChildExpression) interface{}
+	Descendant(e *DescendantExpression) interface{}
 	IsNull(e *IsNullExpression) interface{}
 }
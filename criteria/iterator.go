@@ -50,6 +50,10 @@ func (i *postOrderIterator) Child(exp *ChildExpression) interface{} {
 	return i.binary(exp)
 }
 
+func (i *postOrderIterator) Descendant(exp *DescendantExpression) interface{} {
+	return i.binary(exp)
+}
+
 func (i *postOrderIterator) IsNull(exp *IsNullExpression) interface{} {
 	return i.visit(exp)
 }
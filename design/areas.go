@@ -45,6 +45,8 @@ var areaRelationships = a.Type("AreaRelations", func() {
 	a.Attribute("parent", relationGeneric, "This defines the parents' hierarchy for areas")
 	a.Attribute("children", relationGeneric, "This defines the sub-areas present for this area")
 	a.Attribute("workitems", relationGeneric, "This defines the workitems associated with the Area")
+	a.Attribute("owners", relationGenericList, "The identities that are assigned to unassigned work items created in or moved to the area")
+	a.Attribute("default-labels", relationGenericList, "The labels that are attached to work items created in or moved to the area")
 })
 
 var areaList = JSONList(
@@ -111,6 +113,24 @@ var _ = a.Resource("area", func() {
 		a.Response(d.Forbidden, JSONAPIErrors)
 		a.Response(d.Conflict, JSONAPIErrors)
 	})
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:id"),
+		)
+		a.Params(func() {
+			a.Param("id", d.String, "id")
+		})
+		a.Description("update the name, owners and default labels of the area with the given id.")
+		a.Payload(areaSingle)
+		a.Response(d.OK, areaSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
 })
 
 // new version of "list" for migration
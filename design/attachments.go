@@ -0,0 +1,200 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var attachment = a.Type("Attachment", func() {
+	a.Description(`JSONAPI store for the metadata of a file attached to a work item or a comment. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("attachments")
+	})
+	a.Attribute("id", d.UUID, "ID of the attachment", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", attachmentAttributes)
+	a.Attribute("relationships", attachmentRelationships)
+	a.Attribute("links", attachmentLinks)
+	a.Required("type", "id", "attributes")
+})
+
+var attachmentAttributes = a.Type("AttachmentAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of an attachment. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("filename", d.String, "The name of the attached file", func() {
+		a.Example("screenshot.png")
+	})
+	a.Attribute("content-type", d.String, "The content type of the attached file", func() {
+		a.Example("image/png")
+	})
+	a.Attribute("size", d.Integer, "The size of the attached file in bytes", func() {
+		a.Example(4711)
+	})
+	a.Attribute("markdown", d.String, "A Markdown snippet that references the attachment and can be used in descriptions and comments", func() {
+		a.Example("![screenshot.png](/api/attachments/40bbdd3d-8b5d-4fd6-ac90-7236b669af04/content)")
+	})
+	a.Attribute("created-at", d.DateTime, "When the attachment was uploaded", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+})
+
+var attachmentRelationships = a.Type("AttachmentRelations", func() {
+	a.Attribute("workitem", relationGeneric, "The work item to which the file is attached (also set for attachments of comments)")
+	a.Attribute("comment", relationGeneric, "The comment to which the file is attached (if any)")
+	a.Attribute("space", relationGeneric, "The space whose quota the attachment counts against")
+	a.Attribute("uploader", relationGeneric, "The identity that uploaded the file")
+})
+
+var attachmentLinks = a.Type("AttachmentLinks", func() {
+	a.Attribute("self", d.String, "Link to the metadata of the attachment", func() {
+		a.Example("http://api.openshift.io/api/attachments/40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("related", d.String, "Link to the content of the attachment", func() {
+		a.Example("http://api.openshift.io/api/attachments/40bbdd3d-8b5d-4fd6-ac90-7236b669af04/content")
+	})
+})
+
+var attachmentList = JSONList(
+	"Attachment", "Holds the list of attachments",
+	attachment,
+	nil,
+	nil)
+
+var attachmentSingle = JSONSingle(
+	"Attachment", "Holds a single attachment",
+	attachment,
+	nil)
+
+var _ = a.Resource("attachments", func() {
+	a.BasePath("/attachments")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET("/:attachmentID"),
+		)
+		a.Description("Retrieve the metadata of the attachment with the given ID.")
+		a.Params(func() {
+			a.Param("attachmentID", d.UUID, "ID of the attachment")
+		})
+		a.UseTrait("conditional")
+		a.Response(d.OK, attachmentSingle)
+		a.Response(d.NotModified)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("download", func() {
+		a.Routing(
+			a.GET("/:attachmentID/content"),
+		)
+		a.Description(`Download the content of the attachment with the given ID. Images are served inline so that they
+can be embedded in rendered Markdown, all other files are served as downloads.`)
+		a.Params(func() {
+			a.Param("attachmentID", d.UUID, "ID of the attachment")
+		})
+		a.Response(d.OK, "application/octet-stream")
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:attachmentID"),
+		)
+		a.Description("Delete the attachment with the given ID. Only the uploader and collaborators of the space may do so.")
+		a.Params(func() {
+			a.Param("attachmentID", d.UUID, "ID of the attachment")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
+
+var _ = a.Resource("work_item_attachments", func() {
+	a.Parent("workitem")
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET("attachments"),
+		)
+		a.Description("List the files attached to the given work item (without the files attached to its comments).")
+		a.UseTrait("conditional")
+		a.Response(d.OK, attachmentList)
+		a.Response(d.NotModified)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("upload", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("attachments"),
+		)
+		a.Description(`Attach a file to the given work item. The request body is the raw content of the file and the
+Content-Type header its content type. The upload is rejected if the file is larger than the configured maximum size or
+if the attachments of the space would exceed the space's quota.`)
+		a.Params(func() {
+			a.Param("filename", d.String, "The name of the attached file", func() {
+				a.MinLength(1)
+			})
+			a.Required("filename")
+		})
+		a.Response(d.Created, "/attachments/.*", func() {
+			a.Media(attachmentSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
+
+var _ = a.Resource("comment_attachments", func() {
+	a.Parent("comments")
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET("attachments"),
+		)
+		a.Description("List the files attached to the given comment.")
+		a.UseTrait("conditional")
+		a.Response(d.OK, attachmentList)
+		a.Response(d.NotModified)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("upload", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("attachments"),
+		)
+		a.Description(`Attach a file to the given comment. The request body is the raw content of the file and the
+Content-Type header its content type. The upload is rejected if the file is larger than the configured maximum size or
+if the attachments of the space would exceed the space's quota.`)
+		a.Params(func() {
+			a.Param("filename", d.String, "The name of the attached file", func() {
+				a.MinLength(1)
+			})
+			a.Required("filename")
+		})
+		a.Response(d.Created, "/attachments/.*", func() {
+			a.Media(attachmentSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
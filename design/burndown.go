@@ -0,0 +1,70 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var burndown = a.Type("Burndown", func() {
+	a.Description(`JSONAPI store for the burndown of an iteration. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("burndowns")
+	})
+	a.Attribute("id", d.UUID, "ID of the iteration", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", burndownAttributes)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "id", "attributes")
+})
+
+var burndownAttributes = a.Type("BurndownAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a burndown. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("start", d.DateTime, "Start of the iteration")
+	a.Attribute("end", d.DateTime, "End of the iteration or now if the iteration has not ended yet")
+	a.Attribute("field", d.String, "Name of the numeric field whose values are summed up", func() {
+		a.Example("storypoints")
+	})
+	a.Attribute("days", a.ArrayOf(burndownDay), "The state of the iteration at the end of each day")
+	a.Required("start", "end", "days")
+})
+
+var burndownDay = a.Type("BurndownDay", func() {
+	a.Description(`The work items of an iteration at the end of a day`)
+	a.Attribute("date", d.DateTime, "The day")
+	a.Attribute("open", d.Integer, "Number of open work items")
+	a.Attribute("closed", d.Integer, "Number of closed work items")
+	a.Attribute("open-value", d.Number, "Sum of the field values of the open work items")
+	a.Attribute("closed-value", d.Number, "Sum of the field values of the closed work items")
+	a.Attribute("added", d.Integer, "Number of work items moved into the iteration during the day")
+	a.Attribute("removed", d.Integer, "Number of work items moved out of the iteration during the day")
+	a.Required("date", "open", "closed", "open-value", "closed-value", "added", "removed")
+})
+
+var burndownSingle = JSONSingle(
+	"Burndown", "Holds the burndown of an iteration",
+	burndown,
+	nil)
+
+var _ = a.Resource("burndown", func() {
+	a.Parent("iteration")
+	a.BasePath("/burndown")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description(`Retrieve the number of open and closed work items of the iteration (and its child iterations) for
+every day from the start until the end of the iteration. The series is reconstructed from the work item revisions
+and includes work items that were added to or removed from the iteration while it was running.`)
+		a.Params(func() {
+			a.Param("field", d.String, "Name of a numeric field whose values are summed up for open and closed work items", func() {
+				a.Example("storypoints")
+			})
+		})
+		a.Response(d.OK, burndownSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+})
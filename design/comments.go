@@ -46,6 +46,25 @@ var commentAttributes = a.Type("CommentAttributes", func() {
 	a.Attribute("markup", d.String, "The comment markup associated with the body", func() {
 		a.Example("Markdown")
 	})
+	a.Attribute("resolved", d.Boolean, "Whether the comment thread is resolved. Only top-level comments can be resolved.", func() {
+		a.Example(false)
+	})
+	a.Attribute("resolved-at", d.DateTime, "When the comment thread was resolved", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("reactions", a.ArrayOf(commentReaction), "The emoji reactions on the comment")
+})
+
+var commentReaction = a.Type("CommentReaction", func() {
+	a.Description(`The reactions of all identities with one emoji on a comment`)
+	a.Attribute("emoji", d.String, "The emoji", func() {
+		a.Example("thumbsup")
+	})
+	a.Attribute("count", d.Integer, "The number of identities that reacted with the emoji", func() {
+		a.Example(2)
+	})
+	a.Attribute("identities", a.ArrayOf(d.UUID), "The identities that reacted with the emoji")
+	a.Required("emoji", "count", "identities")
 })
 
 var createCommentAttributes = a.Type("CreateCommentAttributes", func() {
@@ -71,6 +90,7 @@ var commentRelationships = a.Type("CommentRelations", func() {
 	a.Attribute("created-by", commentCreatedBy, "DEPRECATED. This defines the creator of the comment.")
 	a.Attribute("parent", relationGeneric, "This defines the owning resource of the comment.")
 	a.Attribute("parent-comment", relationGeneric, "This defines the parent comment resource.")
+	a.Attribute("resolved-by", relationGeneric, "This defines the identity that resolved the comment thread.")
 })
 
 var commentCreatedBy = a.Type("CommentCreatedBy", func() {
@@ -170,7 +190,70 @@ var _ = a.Resource("comments", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
-
+	a.Action("resolve", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PUT("/:commentId/resolution"),
+		)
+		a.Description("Mark the comment thread with the given top-level commentId as resolved.")
+		a.Params(func() {
+			a.Param("commentId", d.UUID, "commentId")
+		})
+		a.Response(d.OK, commentSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("reopen", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:commentId/resolution"),
+		)
+		a.Description("Reopen the resolved comment thread with the given top-level commentId.")
+		a.Params(func() {
+			a.Param("commentId", d.UUID, "commentId")
+		})
+		a.Response(d.OK, commentSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("add-reaction", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PUT("/:commentId/reactions/:emoji"),
+		)
+		a.Description("Add a reaction of the current user with the given emoji to the comment.")
+		a.Params(func() {
+			a.Param("commentId", d.UUID, "commentId")
+			a.Param("emoji", d.String, "The emoji to react with")
+		})
+		a.Response(d.OK, commentSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
+	a.Action("remove-reaction", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:commentId/reactions/:emoji"),
+		)
+		a.Description("Remove the reaction of the current user with the given emoji from the comment.")
+		a.Params(func() {
+			a.Param("commentId", d.UUID, "commentId")
+			a.Param("emoji", d.String, "The emoji of the reaction to remove")
+		})
+		a.Response(d.OK, commentSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
 })
 
 var _ = a.Resource("work_item_comments", func() {
@@ -185,6 +268,7 @@ var _ = a.Resource("work_item_comments", func() {
 			a.Param("page[offset]", d.String, `Paging start position is a string pointing to
 			the beginning of pagination.  The value starts from 0 onwards.`)
 			a.Param("page[limit]", d.Integer, `Paging size is the number of items in a page`)
+			a.Param("filter[resolved]", d.Boolean, `Only list comments of resolved (true) or unresolved (false) threads`)
 		})
 		a.UseTrait("conditional")
 		a.Response(d.OK, commentArray)
@@ -0,0 +1,89 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var dependencyGraph = a.Type("DependencyGraph", func() {
+	a.Description(`JSONAPI store for the dependency graph of a space or an iteration. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("dependencygraphs")
+	})
+	a.Attribute("id", d.UUID, "ID of the space or iteration for which the graph was computed", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", dependencyGraphAttributes)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "id", "attributes")
+})
+
+var dependencyGraphAttributes = a.Type("DependencyGraphAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a dependency graph. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("nodes", a.ArrayOf(dependencyGraphNode), "The work items of the graph")
+	a.Attribute("edges", a.ArrayOf(dependencyGraphEdge), "The dependency links between the work items")
+	a.Attribute("critical-path", a.ArrayOf(d.UUID), "IDs of the work items on the path with the highest weight, starting with the first predecessor")
+	a.Attribute("critical-path-weight", d.Number, "Sum of the weights of all work items on the critical path", func() {
+		a.Example(8.5)
+	})
+	a.Required("nodes", "edges", "critical-path", "critical-path-weight")
+})
+
+var dependencyGraphNode = a.Type("DependencyGraphNode", func() {
+	a.Description(`A work item in a dependency graph`)
+	a.Attribute("id", d.UUID, "ID of the work item", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("number", d.Integer, "Number of the work item", func() {
+		a.Example(42)
+	})
+	a.Attribute("title", d.String, "Title of the work item", func() {
+		a.Example("Implement the login page")
+	})
+	a.Attribute("state", d.String, "State of the work item", func() {
+		a.Example("in progress")
+	})
+	a.Attribute("weight", d.Number, "Weight of the work item on the critical path (0 for closed work items)", func() {
+		a.Example(3)
+	})
+	a.Attribute("blocked", d.Boolean, "Whether the work item has predecessors that are not closed")
+	a.Attribute("blocked-by", a.ArrayOf(d.UUID), "IDs of all (transitive) predecessors that are not closed")
+	a.Required("id", "number", "title", "state", "weight", "blocked", "blocked-by")
+})
+
+var dependencyGraphEdge = a.Type("DependencyGraphEdge", func() {
+	a.Description(`A dependency link in a dependency graph; the source must be done before the target`)
+	a.Attribute("link-id", d.UUID, "ID of the work item link")
+	a.Attribute("link-type-id", d.UUID, "ID of the work item link type")
+	a.Attribute("source", d.UUID, "ID of the predecessor work item")
+	a.Attribute("target", d.UUID, "ID of the successor work item")
+	a.Required("link-id", "link-type-id", "source", "target")
+})
+
+var dependencyGraphSingle = JSONSingle(
+	"DependencyGraph", "Holds the dependency graph of a space or an iteration",
+	dependencyGraph,
+	nil)
+
+var _ = a.Resource("dependency_graph", func() {
+	a.Parent("space")
+	a.BasePath("/dependencygraph")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description(`Retrieve the graph spanned by the links of all "dependency" link types between the work items of the space.
+The graph marks work items that are blocked by predecessors that are not closed and contains the critical path.`)
+		a.Params(func() {
+			a.Param("iteration", d.UUID, "Only include work items of this iteration (and their predecessors)")
+			a.Param("weight", d.String, "Name of a numeric field used to weigh the work items on the critical path; every work item weighs 1 if omitted", func() {
+				a.Example("effort")
+			})
+		})
+		a.Response(d.OK, dependencyGraphSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+})
@@ -0,0 +1,87 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var flowMetrics = a.Type("FlowMetrics", func() {
+	a.Description(`JSONAPI store for the flow metrics of a space or an iteration. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("flowmetrics")
+	})
+	a.Attribute("id", d.UUID, "ID of the space or iteration for which the metrics were computed", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", flowMetricsAttributes)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "id", "attributes")
+})
+
+var flowMetricsAttributes = a.Type("FlowMetricsAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of the flow metrics. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("start", d.DateTime, "Start of the date range")
+	a.Attribute("end", d.DateTime, "End of the date range")
+	a.Attribute("dates", a.ArrayOf(d.DateTime), "The days at whose end the cumulative flow was sampled")
+	a.Attribute("cumulative-flow", a.ArrayOf(flowSeries), "Number of work items per board column (or state) for each of the dates")
+	a.Attribute("lead-times", a.ArrayOf(flowTimeStats), "Distribution of the days from creation until closing per work item type")
+	a.Attribute("cycle-times", a.ArrayOf(flowTimeStats), "Distribution of the days from the start of work until closing per work item type")
+	a.Required("start", "end", "dates", "cumulative-flow", "lead-times", "cycle-times")
+})
+
+var flowSeries = a.Type("FlowSeries", func() {
+	a.Description(`The number of work items in a board column or state over time`)
+	a.Attribute("key", d.String, "ID of the board column or the state", func() {
+		a.Example("in progress")
+	})
+	a.Attribute("name", d.String, "Name of the board column or the state", func() {
+		a.Example("In Progress")
+	})
+	a.Attribute("counts", a.ArrayOf(d.Integer), "Number of work items for each of the dates")
+	a.Required("key", "name", "counts")
+})
+
+var flowTimeStats = a.Type("FlowTimeStats", func() {
+	a.Description(`Distribution of the lead or cycle times (in days) of the work items of one type that were closed within the date range`)
+	a.Attribute("work-item-type", d.UUID, "ID of the work item type")
+	a.Attribute("count", d.Integer, "Number of work items closed within the date range", func() {
+		a.Example(12)
+	})
+	a.Attribute("mean", d.Number, "Mean time in days", func() {
+		a.Example(4.5)
+	})
+	a.Attribute("p50", d.Number, "50th percentile in days")
+	a.Attribute("p75", d.Number, "75th percentile in days")
+	a.Attribute("p85", d.Number, "85th percentile in days")
+	a.Attribute("p95", d.Number, "95th percentile in days")
+	a.Required("work-item-type", "count", "mean", "p50", "p75", "p85", "p95")
+})
+
+var flowMetricsSingle = JSONSingle(
+	"FlowMetrics", "Holds the flow metrics of a space or an iteration",
+	flowMetrics,
+	nil)
+
+var _ = a.Resource("flow_metrics", func() {
+	a.Parent("space")
+	a.BasePath("/flowmetrics")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description(`Retrieve the cumulative flow diagram as well as the lead and cycle times of the work items of the space.
+All metrics are computed from the revisions of the work items. The date range defaults to the iteration's
+start and end date or to the last 30 days and must not exceed 366 days.`)
+		a.Params(func() {
+			a.Param("board", d.UUID, "Compute the cumulative flow per column of this board instead of per state")
+			a.Param("end", d.DateTime, "End of the date range")
+			a.Param("iteration", d.UUID, "Only include work items of this iteration")
+			a.Param("start", d.DateTime, "Start of the date range")
+		})
+		a.Response(d.OK, flowMetricsSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+})
@@ -0,0 +1,127 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var iterationSchedule = a.Type("IterationSchedule", func() {
+	a.Description(`JSONAPI store for the iteration schedule of a space. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("iterationschedules")
+	})
+	a.Attribute("id", d.UUID, "ID of the iteration schedule", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", iterationScheduleAttributes)
+	a.Attribute("relationships", iterationScheduleRelationships)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+var iterationScheduleAttributes = a.Type("IterationScheduleAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of an iteration schedule. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("startAt", d.DateTime, "When the first iteration of the schedule starts", func() {
+		a.Example("2018-01-08T09:00:00Z")
+	})
+	a.Attribute("cadence", d.Integer, "Number of days between the starts of two consecutive iterations", func() {
+		a.Minimum(1)
+		a.Example(14)
+	})
+	a.Attribute("length", d.Integer, "Number of days an iteration lasts", func() {
+		a.Minimum(1)
+		a.Example(14)
+	})
+	a.Attribute("name_pattern", d.String, `Name of the generated iterations in which "{n}" is replaced by the counter`, func() {
+		a.Example("Sprint {n}")
+	})
+	a.Attribute("counter", d.Integer, "Number used in the name of the next generated iteration", func() {
+		a.Minimum(1)
+		a.Example(42)
+	})
+	a.Attribute("ahead", d.Integer, "Number of current and upcoming iterations to keep", func() {
+		a.Minimum(1)
+		a.Maximum(52)
+		a.Example(3)
+	})
+	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creation)", func() {
+		a.Example(0)
+	})
+	a.Attribute("created-at", d.DateTime, "When the iteration schedule was created", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("updated-at", d.DateTime, "When the iteration schedule was updated", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+})
+
+var iterationScheduleRelationships = a.Type("IterationScheduleRelations", func() {
+	a.Attribute("space", relationGeneric, "This defines the owning space")
+	a.Attribute("parent", relationGeneric, "The iteration under which the iterations are created; defaults to the root iteration")
+})
+
+var iterationScheduleSingle = JSONSingle(
+	"IterationSchedule", "Holds the iteration schedule of a space",
+	iterationSchedule,
+	nil)
+
+var _ = a.Resource("iteration_schedule", func() {
+	a.Parent("space")
+	a.BasePath("/iterationschedule")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("Retrieve the iteration schedule of the space.")
+		a.Response(d.OK, iterationScheduleSingle)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PUT(""),
+		)
+		a.Description(`Create the iteration schedule of the space or replace the existing one. Only the space owner
+can change the schedule.`)
+		a.Payload(iterationScheduleSingle)
+		a.Response(d.OK, iterationScheduleSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE(""),
+		)
+		a.Description("Delete the iteration schedule of the space. Iterations generated from it are kept.")
+		a.Response(d.OK)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("materialize", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/materialize"),
+		)
+		a.Description(`Create the iterations that the schedule requires now: the running iteration and the upcoming
+ones until "ahead" iterations exist. Iterations that start on the same day as a scheduled one are kept as they are.
+The same happens periodically in the background. The created iterations are returned.`)
+		a.Response(d.OK, iterationList)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+})
@@ -49,6 +49,12 @@ var iterationAttributes = a.Type("IterationAttributes", func() {
 		a.Example("/beta/Web-App/Sprint 9/Sprint 9.1")
 	})
 	a.Attribute("number", d.Integer, "Human-friendly number of the iteration that is unique inside the iteration's space")
+	a.Attribute("capacity", a.HashOf(d.String, d.Number), "Amount of work each assignee (identified by the identity ID) can take on during the iteration, measured in the capacity unit", func() {
+		a.Example(map[string]interface{}{"8ab013be-6477-41e2-b206-53593dac6543": 8})
+	})
+	a.Attribute("capacity_unit", d.String, "Unit in which the capacity is measured", func() {
+		a.Enum("points", "days")
+	})
 })
 
 var iterationRelationships = a.Type("IterationRelations", func() {
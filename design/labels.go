@@ -44,6 +44,34 @@ var labelAttributes = a.Type("LabelAttributes", func() {
 
 var labelRelationships = a.Type("LabelRelations", func() {
 	a.Attribute("space", relationGeneric, "This defines the owning space")
+	a.Attribute("parent", relationGeneric, "This defines the label this label is grouped under (optional)")
+})
+
+var labelUsage = a.Type("LabelUsage", func() {
+	a.Description(`JSONAPI store for the number of work items a label is attached to. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("label-usages")
+	})
+	a.Attribute("id", d.UUID, "ID of the label", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", labelUsageAttributes)
+	a.Attribute("relationships", labelUsageRelationships)
+	a.Required("type", "id", "attributes")
+})
+
+var labelUsageAttributes = a.Type("LabelUsageAttributes", func() {
+	a.Attribute("count", d.Integer, "The number of work items the label is attached to", func() {
+		a.Example(12)
+	})
+	a.Attribute("total-count", d.Integer, "The number of work items the label or any of its descendant labels is attached to", func() {
+		a.Example(15)
+	})
+	a.Required("count", "total-count")
+})
+
+var labelUsageRelationships = a.Type("LabelUsageRelations", func() {
+	a.Attribute("label", relationGeneric, "The label that is counted")
 })
 
 var labelList = JSONList(
@@ -52,6 +80,12 @@ var labelList = JSONList(
 	pagingLinks,
 	meta)
 
+var labelUsageList = JSONList(
+	"LabelUsage", "Holds the usage of the Labels of a space",
+	labelUsage,
+	nil,
+	nil)
+
 var labelSingle = JSONSingle(
 	"Label", "Holds a single Label",
 	label,
@@ -127,6 +161,58 @@ var _ = a.Resource("label", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:labelID"),
+		)
+		a.Description(`Delete the label with the given id and remove it from all work items. Its children are moved up
+to its parent. Only collaborators of the space may do so.`)
+		a.Params(func() {
+			a.Param("labelID", d.UUID, "ID of the label to delete")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("merge", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:labelID/merge"),
+		)
+		a.Description(`Merge the labels given in the payload into the label with the given id. The merged labels are
+replaced by the label on all work items and deleted afterwards. Only collaborators of the space may do so.`)
+		a.Params(func() {
+			a.Param("labelID", d.UUID, "ID of the label to merge the other labels into")
+		})
+		a.Payload(relationGenericList)
+		a.Response(d.OK, func() {
+			a.Media(labelSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("usage", func() {
+		a.Routing(
+			a.GET("/usage"),
+		)
+		a.Description("Count the work items each label of the space is attached to.")
+		a.Response(d.OK, labelUsageList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
 })
 
 var _ = a.Resource("work_item_labels", func() {
@@ -36,16 +36,12 @@ var spaceTemplateAttributes = a.Type("SpaceTemplateAttributes", func() {
 	a.Attribute("description", d.String, "optional description of the space template", func() {
 		a.Example("A very simple development methodology focused on the tracking of Issues and the Tasks needed to be completed to resolve a particular Issue.")
 	})
-	// a.Attribute("template", d.String, "base64 encoded YAML template (no newlines allowed in Base64)", func() {
-	// 	a.Example("d29ya19pdGVtX3R5cGVzOiAhIW1hcAo=")
-	// 	// Minimum length is 4 because an empty string is disallowed and the
-	// 	// minimum base64 string is 4.
-	// 	a.MinLength(4)
-	// 	// found here: http://stackoverflow.com/questions/475074/regex-to-parse-or-validate-base64-data
-	// 	a.Pattern("^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$")
-	// 	// We don't accept templates that are bigger than 1MB of characters
-	// 	a.MaxLength(1048576)
-	// })
+	a.Attribute("template", d.String, `YAML or JSON definition of the space template with its work item types, work
+item link types, work item type groups and boards. It is only read when creating or updating a space template.`, func() {
+		a.Example("space_template:\n  name: My template\nwork_item_types: []\n")
+		// We don't accept templates that are bigger than 1MB of characters
+		a.MaxLength(1048576)
+	})
 	a.Attribute("version", d.Integer, "version for optimistic concurrency control (optional during creating)", func() {
 		a.Example(23)
 	})
@@ -84,6 +80,59 @@ var spaceTemplateSingle = JSONSingle(
 	spaceTemplate,
 	nil)
 
+var spaceTemplateChange = a.Type("SpaceTemplateChange", func() {
+	a.Description(`A single difference between two space templates`)
+	a.Attribute("attribute", d.String, "The attribute that differs", func() {
+		a.Example("fields.system.title")
+	})
+	a.Attribute("kind", d.String, "How the attribute differs", func() {
+		a.Enum("added", "removed", "changed")
+	})
+	a.Required("attribute", "kind")
+})
+
+var spaceTemplateArtifactChange = a.Type("SpaceTemplateArtifactChange", func() {
+	a.Description(`The differences of an artifact (e.g. a work item type) that exists in both space templates`)
+	a.Attribute("id", d.UUID, "ID of the artifact in the second space template")
+	a.Attribute("name", d.String, "Name of the artifact in the second space template", func() {
+		a.Example("Bug")
+	})
+	a.Attribute("changes", a.ArrayOf(spaceTemplateChange))
+})
+
+var spaceTemplateArtifactDiff = a.Type("SpaceTemplateArtifactDiff", func() {
+	a.Description(`The differences of one kind of artifacts between two space templates`)
+	a.Attribute("added", a.ArrayOf(d.String), "Names of the artifacts that only exist in the second space template")
+	a.Attribute("removed", a.ArrayOf(d.String), "Names of the artifacts that only exist in the first space template")
+	a.Attribute("changed", a.ArrayOf(spaceTemplateArtifactChange), "Artifacts that exist in both space templates but differ")
+})
+
+var spaceTemplateDiff = a.Type("SpaceTemplateDiff", func() {
+	a.Description(`JSONAPI store for the differences between two space templates. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("spacetemplatediffs")
+	})
+	a.Attribute("id", d.UUID, "ID of the space template that was compared")
+	a.Attribute("attributes", spaceTemplateDiffAttributes)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+var spaceTemplateDiffAttributes = a.Type("SpaceTemplateDiffAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a space template diff. See also see http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("empty", d.Boolean, "Whether or not both space templates are equal")
+	a.Attribute("template", a.ArrayOf(spaceTemplateChange), "Differences of the space templates themselves")
+	a.Attribute("work_item_types", spaceTemplateArtifactDiff)
+	a.Attribute("work_item_link_types", spaceTemplateArtifactDiff)
+	a.Attribute("work_item_type_groups", spaceTemplateArtifactDiff)
+	a.Attribute("work_item_boards", spaceTemplateArtifactDiff)
+})
+
+var spaceTemplateDiffSingle = JSONSingle(
+	"SpaceTemplateDiff", "Holds the differences between two space templates",
+	spaceTemplateDiff,
+	nil)
+
 var _ = a.Resource("space_template", func() {
 	a.BasePath("/spacetemplates")
 	a.Action("show", func() {
@@ -101,6 +150,74 @@ var _ = a.Resource("space_template", func() {
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.NotFound, JSONAPIErrors)
 	})
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST(""),
+		)
+		a.Description(`Create a space template from the YAML or JSON definition given in the "template" attribute.`)
+		a.Payload(spaceTemplateSingle)
+		a.Response(d.Created, "/spacetemplates/.*", func() {
+			a.Media(spaceTemplateSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:spaceTemplateID"),
+		)
+		a.Description(`Update the space template with the given ID from the YAML or JSON definition given in the
+"template" attribute. Existing work item types and work item link types must not be removed. Fields of work item
+types can only be renamed or removed and enum values can only be removed if the "field_migrations" of the template
+say how the existing work items are updated (rename_field, map_enum_values, set_default or drop_field).`)
+		a.Params(func() {
+			a.Param("spaceTemplateID", d.UUID, "id of the space template to update")
+		})
+		a.Payload(spaceTemplateSingle)
+		a.Response(d.OK, spaceTemplateSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
+	a.Action("export", func() {
+		a.Routing(
+			a.GET("/:spaceTemplateID/export"),
+		)
+		a.Description(`Export the space template with the given ID together with its work item types, work item link
+types, work item type groups and boards in the YAML format that is accepted when creating or updating a space
+template.`)
+		a.Params(func() {
+			a.Param("spaceTemplateID", d.UUID, "id of the space template to export")
+		})
+		a.Response(d.OK, "application/x-yaml")
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+	a.Action("diff", func() {
+		a.Routing(
+			a.POST("/:spaceTemplateID/diff"),
+		)
+		a.Description(`Report the differences between the space template with the given ID and either another
+existing space template (given by the "with" parameter) or a candidate YAML or JSON definition given in the
+"template" attribute of the payload. Nothing is imported.`)
+		a.Params(func() {
+			a.Param("spaceTemplateID", d.UUID, "id of the space template to compare")
+			a.Param("with", d.UUID, "id of the space template to compare with")
+		})
+		a.OptionalPayload(spaceTemplateSingle)
+		a.Response(d.OK, spaceTemplateDiffSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
 	a.Action("list", func() {
 		a.Routing(
 			a.GET(""),
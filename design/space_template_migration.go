@@ -0,0 +1,157 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var spaceTemplateMigration = a.Type("SpaceTemplateMigration", func() {
+	a.Description(`JSONAPI store for the migration of a space to another space template. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("spacetemplatemigrations")
+	})
+	a.Attribute("id", d.UUID, "ID of the space template migration", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", spaceTemplateMigrationAttributes)
+	a.Attribute("relationships", spaceTemplateMigrationRelationships)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+var spaceTemplateMigrationAttributes = a.Type("SpaceTemplateMigrationAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a space template migration. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("type_mapping", a.HashOf(d.String, spaceTemplateMigrationTypeMapping), `Conversion of the work item types of the
+old space template by their ID. Types that are not mapped are converted to the type with the same name.`)
+	a.Attribute("link_type_mapping", a.HashOf(d.String, d.UUID), `Link types of the new space template by the ID of the link type
+of the old space template. Link types that are not mapped are converted to the link type with the same name.`)
+	a.Attribute("dry_run", d.Boolean, "Only compute the report without changing anything (defaults to false)")
+	a.Attribute("state", d.String, "State of the migration (read-only)", func() {
+		a.Enum("pending", "running", "completed", "failed")
+	})
+	a.Attribute("migrated_work_items", d.Integer, "Number of work items converted so far (read-only)", func() {
+		a.Example(42)
+	})
+	a.Attribute("report", spaceTemplateMigrationReport, "What the migration changes, available once it has started (read-only)")
+	a.Attribute("error", d.String, "The last error that interrupted the migration; the migration is retried (read-only)")
+	a.Attribute("created-at", d.DateTime, "When the migration was requested", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("updated-at", d.DateTime, "When the migration was updated", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+})
+
+var spaceTemplateMigrationTypeMapping = a.Type("SpaceTemplateMigrationTypeMapping", func() {
+	a.Description("Conversion of a work item type of the old space template")
+	a.Attribute("type_id", d.UUID, "ID of the work item type of the new space template", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("fields", a.HashOf(d.String, d.String), "New field names by old field name")
+	a.Attribute("enum_values", a.HashOf(d.String, a.HashOf(d.String, d.String)), "New enum values by old value, by field name in the new type")
+	a.Required("type_id")
+})
+
+var spaceTemplateMigrationTypeReport = a.Type("SpaceTemplateMigrationTypeReport", func() {
+	a.Description("How the work items of a work item type are converted")
+	a.Attribute("source_type_id", d.UUID, "ID of the work item type of the old space template")
+	a.Attribute("source_type_name", d.String, "Name of the work item type of the old space template")
+	a.Attribute("target_type_id", d.UUID, "ID of the work item type of the new space template, unset if there is none")
+	a.Attribute("target_type_name", d.String, "Name of the work item type of the new space template")
+	a.Attribute("work_items", d.Integer, "Number of work items of the type")
+	a.Attribute("moved_fields", a.ArrayOf(d.String), "Fields without counterpart whose values are appended to the description")
+	a.Attribute("unmapped_values", a.HashOf(d.String, a.ArrayOf(d.String)), "Enum values in use that the new type doesn't allow, by field name")
+	a.Required("source_type_id", "source_type_name", "work_items")
+})
+
+var spaceTemplateMigrationLinkTypeReport = a.Type("SpaceTemplateMigrationLinkTypeReport", func() {
+	a.Description("How the links of a link type are converted")
+	a.Attribute("source_link_type_id", d.UUID, "ID of the link type of the old space template")
+	a.Attribute("source_link_type_name", d.String, "Name of the link type of the old space template")
+	a.Attribute("target_link_type_id", d.UUID, "ID of the link type of the new space template, unset if there is none")
+	a.Attribute("target_link_type_name", d.String, "Name of the link type of the new space template")
+	a.Attribute("links", d.Integer, "Number of links of the link type")
+	a.Required("source_link_type_id", "source_link_type_name", "links")
+})
+
+var spaceTemplateMigrationReport = a.Type("SpaceTemplateMigrationReport", func() {
+	a.Description("What a space template migration changes. Problems prevent the migration from running.")
+	a.Attribute("work_items", d.Integer, "Number of work items to convert")
+	a.Attribute("links", d.Integer, "Number of links to convert")
+	a.Attribute("types", a.ArrayOf(spaceTemplateMigrationTypeReport))
+	a.Attribute("link_types", a.ArrayOf(spaceTemplateMigrationLinkTypeReport))
+	a.Attribute("removed_boards", a.ArrayOf(d.String), "Names of the boards customized for the space that are removed")
+	a.Attribute("problems", a.ArrayOf(d.String))
+	a.Attribute("unplanned", a.ArrayOf(spaceTemplateMigrationTypeReport), "Types of the work items that were created or moved into the space after the migration was planned")
+	a.Required("work_items", "links")
+})
+
+var spaceTemplateMigrationRelationships = a.Type("SpaceTemplateMigrationRelations", func() {
+	a.Attribute("space", relationGeneric, "The migrated space")
+	a.Attribute("source_template", relationGeneric, "The space template of the space before the migration")
+	a.Attribute("target_template", relationGeneric, "The space template of the space after the migration (required during creation)")
+	a.Attribute("creator", relationGeneric, "The user who requested the migration")
+})
+
+var spaceTemplateMigrationList = JSONList(
+	"SpaceTemplateMigration", "Holds the list of space template migrations",
+	spaceTemplateMigration,
+	nil,
+	nil)
+
+var spaceTemplateMigrationSingle = JSONSingle(
+	"SpaceTemplateMigration", "Holds a single space template migration",
+	spaceTemplateMigration,
+	nil)
+
+var _ = a.Resource("space_template_migration", func() {
+	a.Parent("space")
+	a.BasePath("/templatemigrations")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET("/:migrationID"),
+		)
+		a.Description("Retrieve the space template migration with the given ID.")
+		a.Params(func() {
+			a.Param("migrationID", d.UUID, "ID of the space template migration")
+		})
+		a.Response(d.OK, spaceTemplateMigrationSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("List the space template migrations of the space, newest first.")
+		a.Response(d.OK, spaceTemplateMigrationList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST(""),
+		)
+		a.Description(`Request the migration of the space to another space template. Only the space owner can migrate a
+space. The migration runs in the background: it switches the space template and then converts the work items and
+links to the types of the new space template in batches. Dry runs only compute the report. A space can only have one
+unfinished migration. A migration that failed after it switched the space template is retried by requesting a migration
+to the current space template of the space.`)
+		a.Payload(spaceTemplateMigrationSingle)
+		a.Response(d.Created, "/templatemigrations/.*", func() {
+			a.Media(spaceTemplateMigrationSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+})
@@ -0,0 +1,148 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var velocity = a.Type("Velocity", func() {
+	a.Description(`JSONAPI store for the velocity of the past iterations of a space. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("velocities")
+	})
+	a.Attribute("id", d.UUID, "ID of the space", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", velocityAttributes)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "id", "attributes")
+})
+
+var velocityAttributes = a.Type("VelocityAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a velocity. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("field", d.String, "Name of the numeric field whose values are summed up", func() {
+		a.Example("storypoints")
+	})
+	a.Attribute("average", d.Number, "Work completed per iteration on average")
+	a.Attribute("average-per-assignee", a.HashOf(d.String, d.Number), "Work completed by each assignee (identified by the identity ID) per iteration on average")
+	a.Attribute("iterations", a.ArrayOf(iterationVelocity), "The work completed in each of the past iterations, latest first")
+	a.Required("average", "average-per-assignee", "iterations")
+})
+
+var iterationVelocity = a.Type("IterationVelocity", func() {
+	a.Description(`The work completed in a single iteration`)
+	a.Attribute("id", d.UUID, "ID of the iteration")
+	a.Attribute("name", d.String, "Name of the iteration")
+	a.Attribute("startAt", d.DateTime, "When the iteration started")
+	a.Attribute("endAt", d.DateTime, "When the iteration ended")
+	a.Attribute("closed", d.Integer, "Number of closed work items")
+	a.Attribute("value", d.Number, "Work of the closed work items")
+	a.Attribute("per-assignee", a.HashOf(d.String, d.Number), "Work of the closed work items per assignee (identified by the identity ID)")
+	a.Required("id", "name", "closed", "value", "per-assignee")
+})
+
+var velocitySingle = JSONSingle(
+	"Velocity", "Holds the velocity of the past iterations of a space",
+	velocity,
+	nil)
+
+var iterationPlan = a.Type("IterationPlan", func() {
+	a.Description(`JSONAPI store for the capacity planning of an iteration. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("iterationplans")
+	})
+	a.Attribute("id", d.UUID, "ID of the iteration", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", iterationPlanAttributes)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "id", "attributes")
+})
+
+var iterationPlanAttributes = a.Type("IterationPlanAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of an iteration plan. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("field", d.String, "Name of the numeric field whose values are summed up", func() {
+		a.Example("storypoints")
+	})
+	a.Attribute("capacity_unit", d.String, "Unit in which the capacity is measured", func() {
+		a.Enum("points", "days")
+	})
+	a.Attribute("capacity", d.Number, "Capacity of all assignees together")
+	a.Attribute("committed", d.Number, "Work of all open work items in the iteration")
+	a.Attribute("unassigned", d.Number, "Part of the committed work that has no assignee")
+	a.Attribute("velocity", d.Number, "Work completed per iteration on average in the past iterations")
+	a.Attribute("assignees", a.ArrayOf(assigneeLoad), "The committed work per assignee")
+	a.Attribute("warnings", a.ArrayOf(d.String), "Warnings about overcommitted assignees or an overcommitted iteration")
+	a.Required("capacity_unit", "capacity", "committed", "unassigned", "velocity", "assignees", "warnings")
+})
+
+var assigneeLoad = a.Type("AssigneeLoad", func() {
+	a.Description(`The work committed by a single assignee compared with their capacity`)
+	a.Attribute("assignee", d.UUID, "Identity ID of the assignee")
+	a.Attribute("capacity", d.Number, "Capacity of the assignee if one was set")
+	a.Attribute("committed", d.Number, "Work of the open work items of the assignee")
+	a.Attribute("velocity", d.Number, "Work completed by the assignee per iteration on average in the past iterations")
+	a.Attribute("overcommitted", d.Boolean, "Whether the committed work exceeds the capacity")
+	a.Required("assignee", "committed", "velocity", "overcommitted")
+})
+
+var iterationPlanSingle = JSONSingle(
+	"IterationPlan", "Holds the capacity planning of an iteration",
+	iterationPlan,
+	nil)
+
+var _ = a.Resource("velocity", func() {
+	a.Parent("space")
+	a.BasePath("/velocity")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description(`Retrieve the work completed in the latest iterations of the space that have already ended. The work of
+a closed work item is the value of the given numeric field or 1 if no field is given; it is split evenly among
+the assignees of the work item.`)
+		a.Params(func() {
+			a.Param("field", d.String, "Name of a numeric field whose values are summed up", func() {
+				a.Example("storypoints")
+			})
+			a.Param("history", d.Integer, "Number of past iterations to include", func() {
+				a.Default(3)
+				a.Minimum(1)
+				a.Maximum(20)
+			})
+		})
+		a.Response(d.OK, velocitySingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+})
+
+var _ = a.Resource("iteration_planning", func() {
+	a.Parent("iteration")
+	a.BasePath("/planning")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description(`Compare the work of the open work items of the iteration per assignee with the capacity of the
+iteration and with the velocity of the past iterations that ended before the iteration starts. Warnings are
+returned for overcommitted assignees and if the iteration as a whole is overcommitted.`)
+		a.Params(func() {
+			a.Param("field", d.String, "Name of a numeric field whose values are summed up", func() {
+				a.Example("storypoints")
+			})
+			a.Param("history", d.Integer, "Number of past iterations to compute the velocity from", func() {
+				a.Default(3)
+				a.Minimum(1)
+				a.Maximum(20)
+			})
+		})
+		a.Response(d.OK, iterationPlanSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+})
@@ -42,11 +42,28 @@ var workItemBoardColumnData = a.Type("WorkItemBoardColumnData", func() {
 var workItemBoardColumnAttributes = a.Type("WorkItemBoardColumnAttributes", func() {
 	a.Attribute("name", d.String)
 	a.Attribute("order", d.Integer)
-	// TODO(michaelkleinhenz): as soon as we allow column customization, we need
-	// to also provide transRuleKey and transRuleArguments.
+	a.Attribute("transRuleKey", d.String, "Key of the rule that is applied when a work item is moved into this column")
+	a.Attribute("transRuleArgument", d.String, "JSON argument of the rule that is applied when a work item is moved into this column")
+	a.Attribute("wipLimit", d.Integer, "Maximum number of work items in this column", func() {
+		a.Minimum(1)
+	})
+	a.Attribute("wipLimitMode", d.String, "Whether exceeding the WIP limit only warns or blocks the move of a work item into this column", func() {
+		a.Enum("warn", "block")
+	})
+	a.Attribute("states", a.ArrayOf(d.String), "States of work items that belong to this column")
+	a.Attribute("wipCount", d.Integer, "Number of work items in this column (read-only, only for boards of a space)")
+	a.Attribute("wipExceeded", d.Boolean, "Whether the number of work items exceeds the WIP limit (read-only, only for boards of a space)")
 	a.Required("name")
 })
 
+var workItemBoardColumnPayload = a.Type("WorkItemBoardColumnPayload", func() {
+	a.Description(`a column of a board as it is sent when creating or updating the board of a space.
+Columns without an ID are added, columns of the board that are not listed are removed.`)
+	a.Attribute("id", d.UUID, "ID of an existing column of the board (optional)")
+	a.Attribute("attributes", workItemBoardColumnAttributes)
+	a.Required("attributes")
+})
+
 var workItemBoardData = a.Type("WorkItemBoardData", func() {
 	a.Description(`a board shows different work item type together in a board`)
 	a.Attribute("type", d.String, "The type string of the work item board", func() {
@@ -71,12 +88,19 @@ var workItemBoardAttributes = a.Type("WorkItemBoardAttributes", func() {
 		// TODO(kwk): once we allow more context types, this can be relaxed.
 		a.Enum("TypeLevelContext")
 	})
+	a.Attribute("description", d.String, "Description of this board")
+	a.Attribute("swimlane", d.String, "Attribute by which the cards of this board are grouped into horizontal lanes", func() {
+		a.Enum("", "assignee", "area", "parent", "label")
+	})
+	a.Attribute("columns", a.ArrayOf(workItemBoardColumnPayload), "Columns of the board in their order (only used when creating or updating the board of a space)")
 	a.Required("name", "context", "contextType")
 })
 
 var workItemBoardRelationships = a.Type("WorkItemBoardRelationships", func() {
 	a.Attribute("columns", relationGenericList, "List of work item board columns attached to the board")
 	a.Attribute("spaceTemplate", relationGeneric, "The space template to which this board belongs")
+	a.Attribute("space", relationGeneric, "The space for which this board was customized")
+	a.Attribute("template", relationGeneric, "The board of the space template from which a board of a space is created (only used on creation)")
 })
 
 var _ = a.Resource("work_item_boards", func() {
@@ -109,4 +133,74 @@ var _ = a.Resource("work_item_board", func() {
 		a.Response(d.InternalServerError, JSONAPIErrors)
 		a.Response(d.NotFound, JSONAPIErrors)
 	})
+
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:boardID"),
+		)
+		a.Params(func() {
+			a.Param("boardID", d.UUID, "ID of the work item board")
+		})
+		a.Description("Update the name, swimlane and columns of a board of a space")
+		a.Payload(workItemBoardSingle)
+		a.Response(d.OK, workItemBoardSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:boardID"),
+		)
+		a.Params(func() {
+			a.Param("boardID", d.UUID, "ID of the work item board")
+		})
+		a.Description("Delete a board of a space")
+		a.Response(d.NoContent)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
+
+var _ = a.Resource("space_work_item_boards", func() {
+	a.BasePath("/workitemboards")
+	a.Parent("space")
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("List the boards customized for a space")
+		a.Response(d.OK, workItemBoardList)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST(""),
+		)
+		a.Description(`Create a board for a space. If a template board is given, its columns
+are copied unless the payload lists columns itself.`)
+		a.Payload(workItemBoardSingle)
+		a.Response(d.Created, "/workitemboards/.*", func() {
+			a.Media(workItemBoardSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
 })
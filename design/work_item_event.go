@@ -44,6 +44,7 @@ var eventRelationships = a.Type("EventRelations", func() {
 	a.Attribute("oldValue", relationGenericList)
 	a.Attribute("newValue", relationGenericList)
 	a.Attribute("workItemType", relationGeneric, "The type of the work item at the event's point in time")
+	a.Attribute("comment", relationGeneric, "The comment the event happened on. Only for comment events.")
 
 	a.Required("workItemType", "modifier")
 })
@@ -60,6 +60,9 @@ See also see http://jsonapi.org/format/#document-resource-object-attributes`)
 	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creating)", func() {
 		a.Example(0)
 	})
+	a.Attribute("fields", a.HashOf(d.String, d.Any), "Values of the attributes defined by the link type (optional)", func() {
+		a.Example(map[string]interface{}{"reason": "waiting for the API to be released"})
+	})
 
 	// IMPORTANT: We cannot require any field here because these "attributes" will be used
 	// during the creation as well as the update of a work item link type.
@@ -157,6 +160,24 @@ var _ = a.Resource("work_item_link", func() {
 		a.Response(d.Conflict, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("update", func() {
+		a.Description("Update the attribute values of the work item link with the given ID.")
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:linkId"),
+		)
+		a.Params(func() {
+			a.Param("linkId", d.UUID, "ID of the work item link to update")
+		})
+		a.Payload(updateWorkItemLinkPayload)
+		a.Response(d.OK, workItemLink)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
 	a.Action("delete", func() {
 		a.Description("Delete work item link with given id.")
 		a.Security("jwt")
@@ -0,0 +1,39 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var _ = a.Resource("work_item_link_graph", func() {
+	a.BasePath("/linkgraph")
+
+	a.Action("export", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description(`Export the graph of work items and links that is reached by following the links of a work item
+(or of all work items matching a filter expression) in both directions. The graph is returned as a nodes/edges
+JSON document, in the Graphviz DOT format or as GraphML.`)
+		a.Params(func() {
+			a.Param("workitem", d.UUID, "ID of the work item to start from")
+			a.Param("filter[expression]", d.String, "Filter expression in JSON format that selects the work items to start from", func() {
+				a.Example(`{"$AND": [{"space": "f73988a2-1916-4572-910b-2df23df4dcc3"}, {"type": "71171e90-6d35-498f-a6a7-2083b5267c18"}]}`)
+			})
+			a.Param("depth", d.Integer, "Number of links to follow from the start work items", func() {
+				a.Minimum(0)
+				a.Maximum(10)
+				a.Default(1)
+			})
+			a.Param("linktype", a.ArrayOf(d.UUID), "Only follow links of these link types")
+			a.Param("format", d.String, "The export format", func() {
+				a.Enum("json", "dot", "graphml")
+				a.Default("json")
+			})
+		})
+		a.Response(d.OK, "application/octet-stream")
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+})
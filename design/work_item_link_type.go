@@ -65,6 +65,8 @@ For example, if a bug blocks a user story, the reverse name name is "blocked by"
 	a.Attribute("topology", d.String, `The topology determines the restrictions placed on the usage of each work item link type.`, func() {
 		a.Enum("network", "tree", "dependency")
 	})
+	a.Attribute("fields", a.HashOf(d.String, fieldDefinition), "Definitions of the attributes that links of this type can carry (optional)")
+	a.Attribute("constraints", workItemLinkTypeConstraints, "Restrictions on the work items that can be linked with this link type (optional)")
 
 	// IMPORTANT: We cannot require any field here because these "attributes" will be used
 	// during the creation as well as the update of a work item link type.
@@ -73,6 +75,21 @@ For example, if a bug blocks a user story, the reverse name name is "blocked by"
 	//a.Required("name")
 })
 
+// workItemLinkTypeConstraints restricts the work items that can be linked with a
+// work item link type.
+var workItemLinkTypeConstraints = a.Type("WorkItemLinkTypeConstraints", func() {
+	a.Description(`Restrictions on the work items that can be linked with a work item link type.
+A work item is allowed as source (or target) if its type is listed in the types or is a member of one of the listed type groups.
+Empty lists impose no restriction.`)
+	a.Attribute("source_types", a.ArrayOf(d.UUID), "IDs of the work item types allowed as source")
+	a.Attribute("source_type_groups", a.ArrayOf(d.UUID), "IDs of the work item type groups whose types are allowed as source")
+	a.Attribute("target_types", a.ArrayOf(d.UUID), "IDs of the work item types allowed as target")
+	a.Attribute("target_type_groups", a.ArrayOf(d.UUID), "IDs of the work item type groups whose types are allowed as target")
+	a.Attribute("max_children", d.Integer, "Maximum number of links of this type that can start at the same work item", func() {
+		a.Minimum(1)
+	})
+})
+
 // workItemLinkTypeRelationships is the JSONAPI store for the relationships of a work item link type.
 var workItemLinkTypeRelationships = a.Type("WorkItemLinkTypeRelationships", func() {
 	a.Description(`JSONAPI store for the data of a work item link type.
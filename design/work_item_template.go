@@ -0,0 +1,149 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var workItemTemplate = a.Type("WorkItemTemplate", func() {
+	a.Description(`JSONAPI store for the data of a work item template. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemtemplates")
+	})
+	a.Attribute("id", d.UUID, "ID of the work item template", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", workItemTemplateAttributes)
+	a.Attribute("relationships", workItemTemplateRelationships)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+var workItemTemplateAttributes = a.Type("WorkItemTemplateAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a work item template. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("name", d.String, "The work item template name", nameValidationFunction)
+	a.Attribute("description", d.String, "Description of the work item template", func() {
+		a.Example("Checklist for a new release")
+	})
+	a.Attribute("fields", a.HashOf(d.String, d.Any), "Field values preset on the work item created from this template", func() {
+		a.Example(map[string]interface{}{"system.title": "Release checklist", "system.state": "new"})
+	})
+	a.Attribute("children", a.ArrayOf(workItemTemplateChild), "Work items that are created as children of the work item created from this template")
+	a.Attribute("created-at", d.DateTime, "When the work item template was created", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("updated-at", d.DateTime, "When the work item template was updated", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creating)", func() {
+		a.Example(23)
+	})
+})
+
+var workItemTemplateChild = a.Type("WorkItemTemplateChild", func() {
+	a.Description(`A node in the tree of child work items of a work item template`)
+	a.Attribute("type", d.UUID, "ID of the work item type of the child", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("fields", a.HashOf(d.String, d.Any), "Field values preset on the child work item", func() {
+		a.Example(map[string]interface{}{"system.title": "Update the changelog"})
+	})
+	a.Attribute("children", a.ArrayOf("WorkItemTemplateChild"), "Work items that are created as children of this child")
+	a.Required("type")
+})
+
+var workItemTemplateRelationships = a.Type("WorkItemTemplateRelations", func() {
+	a.Attribute("space", relationGeneric, "This defines the owning space")
+	a.Attribute("baseType", relationBaseType, "This defines the type of the work item created from this template")
+	a.Attribute("creator", relationGeneric, "This defines the creator of the work item template")
+})
+
+var workItemTemplateList = JSONList(
+	"WorkItemTemplate", "Holds the list of work item templates",
+	workItemTemplate,
+	pagingLinks,
+	meta)
+
+var workItemTemplateSingle = JSONSingle(
+	"WorkItemTemplate", "Holds a single work item template",
+	workItemTemplate,
+	nil)
+
+var workItemTemplateInstantiation = a.Type("WorkItemTemplateInstantiation", func() {
+	a.Description(`Field values that override the presets of the work item template when it is instantiated`)
+	a.Attribute("fields", a.HashOf(d.String, d.Any), func() {
+		a.Example(map[string]interface{}{"system.title": "Release 1.2 checklist"})
+	})
+})
+
+var _ = a.Resource("work_item_template", func() {
+	a.Parent("space")
+	a.BasePath("/workitemtemplates")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET("/:templateID"),
+		)
+		a.Description("Retrieve work item template for the given id.")
+		a.Params(func() {
+			a.Param("templateID", d.UUID, "ID of the work item template")
+		})
+		a.UseTrait("conditional")
+		a.Response(d.OK, workItemTemplateSingle)
+		a.Response(d.NotModified)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("List work item templates of the space.")
+		a.UseTrait("conditional")
+		a.Response(d.OK, workItemTemplateList)
+		a.Response(d.NotModified)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
+
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST(""),
+		)
+		a.Description("Create a work item template with a type, preset field values and optional children.")
+		a.Payload(workItemTemplateSingle)
+		a.Response(d.Created, "/workitemtemplates/.*", func() {
+			a.Media(workItemTemplateSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+
+	a.Action("instantiate", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:templateID/instantiate"),
+		)
+		a.Description("Create a work item and all of its children from the work item template with the given id.")
+		a.Params(func() {
+			a.Param("templateID", d.UUID, "ID of the work item template")
+		})
+		a.Payload(workItemTemplateInstantiation)
+		a.Response(d.Created, "/workitems/.*", func() {
+			a.Media(workItemSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+	})
+})
@@ -132,6 +132,7 @@ var _ = a.Resource("workitem", func() {
 		a.Description("List children associated with the given work item")
 		a.Params(func() {
 			a.Param("wiID", d.UUID, "ID of the work item to look-up")
+			a.Param("computed", d.Boolean, "if true the values of the computed fields are included in the attributes of the work items")
 			a.Param("page[offset]", d.String, `Paging start position is a string pointing to the beginning of pagination.  The value starts from 0 onwards.`)
 			a.Param("page[limit]", d.Integer, `Paging size is the number of items in a page`)
 		})
@@ -194,6 +195,7 @@ var _ = a.Resource("workitems", func() {
 			a.Param("filter", d.String, "a query language expression restricting the set of found work items")
 			a.Param("page[offset]", d.String, "Paging start position")
 			a.Param("page[limit]", d.Integer, "Paging size")
+			a.Param("computed", d.Boolean, "if true the values of the computed fields are included in the attributes of the work items")
 			a.Param("filter[assignee]", d.String, "Work Items assigned to the given user")
 			a.Param("filter[iteration]", d.String, "IterationID to filter work items")
 			a.Param("filter[workitemtype]", d.UUID, "ID of work item type to filter work items by")
@@ -13,14 +13,43 @@ var fieldType = a.Type("fieldType", func() {
 	a.Attribute("baseType", d.String, "The kind of type of the enumeration values for an enum type. Required for enum types. Must be a simple type, not  enum or list")
 	a.Attribute("values", a.ArrayOf(d.Any), "The possible values for an enum type. The values must be of a type convertible to the base type")
 	a.Attribute("defaultValue", d.Any, "Optional default value (if any)")
+	a.Attribute("resultKind", d.String, "The kind of the value of a computed type. Required for computed types. Must be 'integer' or 'float'")
+	a.Attribute("expression", d.String, "The expression from which the value of a computed type is derived. Required for computed types", func() {
+		a.Example("sum(children.effort)")
+	})
 	a.Required("kind")
 })
 
+// fieldRules defines optional validation rules for the value of a field
+var fieldRules = a.Type("fieldRules", func() {
+	a.Description("Optional validation rules for the value of a field. For list fields the rules apply to each element.")
+	a.Attribute("min", d.Number, "The minimum value of an integer or float field (inclusive)")
+	a.Attribute("max", d.Number, "The maximum value of an integer or float field (inclusive)")
+	a.Attribute("pattern", d.String, "A regular expression that the value of a string, url or markup field must match", func() {
+		a.Example("^[A-Z].*")
+	})
+	a.Attribute("maxLength", d.Integer, "The maximum number of characters of a string, url or markup field")
+	a.Attribute("notBefore", d.DateTime, "The earliest allowed value of an instant field (inclusive)")
+	a.Attribute("notAfter", d.DateTime, "The latest allowed value of an instant field (inclusive)")
+	a.Attribute("requiredWhen", a.ArrayOf(fieldCondition), "The field is required as soon as one of these conditions is met")
+})
+
+// fieldCondition is met when a field has one of the given values
+var fieldCondition = a.Type("fieldCondition", func() {
+	a.Description("A fieldCondition is met when the value of the given field equals one of the given values")
+	a.Attribute("field", d.String, "The name of the field", func() {
+		a.Example("system.state")
+	})
+	a.Attribute("values", a.ArrayOf(d.Any), "The values for which the condition is met")
+	a.Required("field", "values")
+})
+
 // fieldDefinition defines the possible values for a field in a work item type
 var fieldDefinition = a.Type("fieldDefinition", func() {
 	a.Description("A fieldDefinition aggregates a fieldType and additional field metadata")
 	a.Attribute("required", d.Boolean)
 	a.Attribute("type", fieldType)
+	a.Attribute("rules", fieldRules)
 	a.Attribute("label", d.String, "A label for the field that is shown in the UI", func() {
 		a.Example("Iteration")
 		a.MinLength(1)
@@ -32,6 +61,20 @@ var fieldDefinition = a.Type("fieldDefinition", func() {
 	a.Required("required", "type", "label", "description")
 })
 
+// workItemTypeTransition defines an allowed change of the state of a work item
+var workItemTypeTransition = a.Type("workItemTypeTransition", func() {
+	a.Description("An allowed change of the system.state field of a work item")
+	a.Attribute("from", d.String, "The state from which the transition starts or '*' for any state", func() {
+		a.Example("in progress")
+	})
+	a.Attribute("to", d.String, "The state to which the transition leads", func() {
+		a.Example("resolved")
+	})
+	a.Attribute("requiredFields", a.ArrayOf(d.String), "Fields that must have a value to take the transition")
+	a.Attribute("guards", a.ArrayOf(fieldCondition), "Conditions that must all be met to take the transition")
+	a.Required("from", "to")
+})
+
 var workItemTypeAttributes = a.Type("WorkItemTypeAttributes", func() {
 	a.Description("A work item type describes the values a work item type instance can hold.")
 	a.Attribute("version", d.Integer, "Version for optimistic concurrency control")
@@ -54,6 +97,8 @@ var workItemTypeAttributes = a.Type("WorkItemTypeAttributes", func() {
 		a.MinLength(1)
 	})
 
+	a.Attribute("transitions", a.ArrayOf(workItemTypeTransition), "The allowed changes of the system.state field. If empty, every state can be set at any time.")
+
 	// TODO: Maybe this needs to be abandoned at some point
 	a.Attribute("extendedTypeName", d.UUID, "If newly created type extends any existing type (This is never present in any response and is only optional when creating.)")
 
@@ -0,0 +1,218 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var worklog = a.Type("Worklog", func() {
+	a.Description(`JSONAPI store for the time an identity spent on a work item. See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("worklogs")
+	})
+	a.Attribute("id", d.UUID, "ID of the worklog", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", worklogAttributes)
+	a.Attribute("relationships", worklogRelationships)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+var worklogAttributes = a.Type("WorklogAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a worklog. See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("started-at", d.DateTime, "When the work started", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("duration", d.Integer, "The time spent in seconds", func() {
+		a.Minimum(1)
+		a.Example(5400)
+	})
+	a.Attribute("comment", d.String, "What the time was spent on", func() {
+		a.Example("Reproduced the bug")
+	})
+	a.Attribute("created-at", d.DateTime, "When the worklog was created", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("updated-at", d.DateTime, "When the worklog was updated", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+})
+
+var worklogRelationships = a.Type("WorklogRelations", func() {
+	a.Attribute("workitem", relationGeneric, "The work item the time was spent on")
+	a.Attribute("identity", relationGeneric, "The identity that spent the time")
+})
+
+var workItemWorklogListMeta = a.Type("WorkItemWorklogListMeta", func() {
+	a.Attribute("timeSpent", d.Integer, "The time spent on the work item itself in seconds")
+	a.Attribute("totalTimeSpent", d.Integer, "The time spent on the work item and on all of its descendants over tree links in seconds")
+	a.Required("timeSpent", "totalTimeSpent")
+})
+
+var spaceWorklogListMeta = a.Type("SpaceWorklogListMeta", func() {
+	a.Attribute("totalCount", d.Integer)
+	a.Attribute("totalTimeSpent", d.Integer, "The time spent on all listed worklogs in seconds")
+	a.Required("totalCount", "totalTimeSpent")
+})
+
+var workItemWorklogList = JSONList(
+	"WorkItemWorklog", "Holds the list of worklogs of a work item",
+	worklog,
+	nil,
+	workItemWorklogListMeta)
+
+var spaceWorklogList = JSONList(
+	"SpaceWorklog", "Holds the list of worklogs of a space",
+	worklog,
+	nil,
+	spaceWorklogListMeta)
+
+var worklogSingle = JSONSingle(
+	"Worklog", "Holds a single worklog",
+	worklog,
+	nil)
+
+// worklogFilterParams defines the parameters to filter the worklogs of a
+// space by
+func worklogFilterParams() {
+	a.Param("filter[identity]", d.UUID, "Only the worklogs of the identity with the given ID")
+	a.Param("filter[iteration]", d.UUID, "Only the worklogs on work items in the iteration with the given ID")
+	a.Param("filter[from]", d.DateTime, "Only the worklogs started at or after the given time")
+	a.Param("filter[to]", d.DateTime, "Only the worklogs started before the given time")
+}
+
+var _ = a.Resource("worklogs", func() {
+	a.BasePath("/worklogs")
+
+	a.Action("show", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET("/:worklogID"),
+		)
+		a.Description("Retrieve the worklog with the given ID. Only collaborators of the space may do so.")
+		a.Params(func() {
+			a.Param("worklogID", d.UUID, "ID of the worklog")
+		})
+		a.UseTrait("conditional")
+		a.Response(d.OK, worklogSingle)
+		a.Response(d.NotModified)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:worklogID"),
+		)
+		a.Description("Update the worklog with the given ID. Only the identity of the worklog and collaborators of the space may do so.")
+		a.Params(func() {
+			a.Param("worklogID", d.UUID, "ID of the worklog")
+		})
+		a.Payload(worklogSingle)
+		a.Response(d.OK, worklogSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:worklogID"),
+		)
+		a.Description("Delete the worklog with the given ID. Only the identity of the worklog and collaborators of the space may do so.")
+		a.Params(func() {
+			a.Param("worklogID", d.UUID, "ID of the worklog")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
+
+var _ = a.Resource("work_item_worklogs", func() {
+	a.Parent("workitem")
+
+	a.Action("list", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET("worklogs"),
+		)
+		a.Description(`List the worklogs of the given work item together with the time spent on it and on its descendants.
+Only collaborators of the space may do so.`)
+		a.Response(d.OK, workItemWorklogList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("worklogs"),
+		)
+		a.Description("Record time the current user spent on the given work item. Only collaborators of the space may do so.")
+		a.Payload(worklogSingle)
+		a.Response(d.Created, "/worklogs/.*", func() {
+			a.Media(worklogSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
+
+var _ = a.Resource("space_worklogs", func() {
+	a.Parent("space")
+
+	a.Action("list", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET("worklogs"),
+		)
+		a.Description("List the worklogs on the work items of the given space. Only collaborators of the space may do so.")
+		a.Params(worklogFilterParams)
+		a.Response(d.OK, spaceWorklogList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("timesheet", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET("worklogs/timesheet"),
+		)
+		a.Description(`Export the worklogs on the work items of the given space as CSV. Without grouping there is one line
+per worklog, otherwise one line per group with the number of worklogs and the time spent in hours. Only collaborators of the
+space may do so.`)
+		a.Params(func() {
+			worklogFilterParams()
+			a.Param("group-by", a.ArrayOf(d.String, func() {
+				a.Enum("identity", "iteration")
+			}), "The dimensions to group the worklogs by")
+		})
+		a.Response(d.OK, "text/csv")
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
@@ -164,6 +164,7 @@ type BadParameterError struct {
 	expectedValue          interface{}
 	hasExpectedValue       bool
 	preDefinedErrorMessage *string
+	pointer                *string
 }
 
 // Error implements the error interface
@@ -185,6 +186,20 @@ func (err BadParameterError) Expected(expexcted interface{}) BadParameterError {
 	return err
 }
 
+// Pointer sets the optional JSON pointer (RFC6901) to the attribute in the
+// request document that caused the BadParameterError, e.g.
+// "/data/attributes/system.title".
+func (err BadParameterError) Pointer(pointer string) BadParameterError {
+	err.pointer = &pointer
+	return err
+}
+
+// SourcePointer returns the JSON pointer to the attribute in the request
+// document that caused the BadParameterError or nil if none was set.
+func (err BadParameterError) SourcePointer() *string {
+	return err.pointer
+}
+
 // NewBadParameterError returns the custom defined error of type NewBadParameterError.
 func NewBadParameterError(param string, actual interface{}) BadParameterError {
 	return BadParameterError{parameter: param, value: actual}
@@ -86,6 +86,8 @@ func init() {
 		"spacetemplatedsl":  "github.com/fabric8-services/fabric8-wit/spacetemplate",
 		"eventdsl":          "github.com/fabric8-services/fabric8-wit/workitem/event",
 		"remoteworkitemdsl": "github.com/fabric8-services/fabric8-wit/remoteworkitem",
+		"attachmentdsl":     "github.com/fabric8-services/fabric8-wit/attachment",
+		"worklogdsl":        "github.com/fabric8-services/fabric8-wit/worklog",
 	}
 	// model structures and their corresponding package alias
 	structPackages = map[string]string{
@@ -104,6 +106,8 @@ func init() {
 		"SpaceTemplate":    "spacetemplatedsl",
 		"Event":            "eventdsl",
 		"TrackerQuery":     "remoteworkitemdsl",
+		"Attachment":       "attachmentdsl",
+		"Worklog":          "worklogdsl",
 	}
 	// structures to ignore during code generation (mostly because they correspond to model structures which were already taken into account)
 	ignoredStructs = []string{
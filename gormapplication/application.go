@@ -7,6 +7,8 @@ import (
 	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/attachment"
+	"github.com/fabric8-services/fabric8-wit/cache"
 	"github.com/fabric8-services/fabric8-wit/codebase"
 	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/iteration"
@@ -16,9 +18,13 @@ import (
 	"github.com/fabric8-services/fabric8-wit/search"
 	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/spacetemplate"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/importer"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/templatemigration"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/event"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
+	"github.com/fabric8-services/fabric8-wit/worklog"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
@@ -122,6 +128,11 @@ func (g *GormBase) Iterations() iteration.Repository {
 	return iteration.NewIterationRepository(g.db)
 }
 
+// IterationSchedules returns an iteration schedule repository
+func (g *GormBase) IterationSchedules() iteration.ScheduleRepository {
+	return iteration.NewScheduleRepository(g.db)
+}
+
 // Areas returns a area repository
 func (g *GormBase) Areas() area.Repository {
 	return area.NewAreaRepository(g.db)
@@ -152,6 +163,26 @@ func (g *GormBase) SpaceTemplates() spacetemplate.Repository {
 	return spacetemplate.NewRepository(g.db)
 }
 
+// SpaceTemplateImporter returns a space template importer repository
+func (g *GormBase) SpaceTemplateImporter() importer.Repository {
+	return importer.NewRepository(g.db)
+}
+
+// SpaceTemplateMigrations returns a space template migration repository
+func (g *GormBase) SpaceTemplateMigrations() templatemigration.Repository {
+	return templatemigration.NewRepository(g.db)
+}
+
+// Attachments returns an attachment repository
+func (g *GormBase) Attachments() attachment.Repository {
+	return attachment.NewRepository(g.db)
+}
+
+// Worklogs returns a worklog repository
+func (g *GormBase) Worklogs() worklog.Repository {
+	return worklog.NewRepository(g.db)
+}
+
 // WorkItemTypeGroups returns a work item type group repository
 func (g *GormBase) WorkItemTypeGroups() workitem.WorkItemTypeGroupRepository {
 	return workitem.NewWorkItemTypeGroupRepository(g.db)
@@ -162,6 +193,16 @@ func (g *GormBase) Boards() workitem.BoardRepository {
 	return workitem.NewBoardRepository(g.db)
 }
 
+// WorkItemRevisions returns a work item revision repository
+func (g *GormBase) WorkItemRevisions() workitem.RevisionRepository {
+	return workitem.NewRevisionRepository(g.db)
+}
+
+// WorkItemTemplates returns a work item template repository
+func (g *GormBase) WorkItemTemplates() template.Repository {
+	return template.NewRepository(g.db)
+}
+
 func (g *GormBase) DB() *gorm.DB {
 	return g.db
 }
@@ -203,6 +244,7 @@ func (g *GormDB) BeginTransaction() (application.Transaction, error) {
 // Commit implements TransactionSupport
 func (g *GormTransaction) Commit() error {
 	err := g.db.Commit().Error
+	cache.TransactionEnded(g.db)
 	g.db = nil
 	return errors.WithStack(err)
 }
@@ -210,6 +252,7 @@ func (g *GormTransaction) Commit() error {
 // Rollback implements TransactionSupport
 func (g *GormTransaction) Rollback() error {
 	err := g.db.Rollback().Error
+	cache.TransactionEnded(g.db)
 	g.db = nil
 	return errors.WithStack(err)
 }
@@ -3,8 +3,8 @@ package cleaner
 import (
 	"database/sql"
 
+	"github.com/fabric8-services/fabric8-wit/cache"
 	"github.com/fabric8-services/fabric8-wit/log"
-	"github.com/fabric8-services/fabric8-wit/workitem"
 	uuid "github.com/satori/go.uuid"
 
 	"fmt"
@@ -73,9 +73,8 @@ func DeleteCreatedEntities(db *gorm.DB) func() {
 			tx.Table(entry.table).Where(entry.keyname+" = ?", entry.key).Delete("")
 		}
 
-		// Delete the work item cache as well
-		// NOTE: Feel free to add more cache freeing calls here as needed.
-		workitem.ClearGlobalWorkItemTypeCache()
+		// Delete the caches as well
+		cache.ClearAll()
 
 		if !inTransaction {
 			tx.Commit()
@@ -0,0 +1,109 @@
+package iteration
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CapacityUnit defines the unit in which the capacity of an iteration is
+// measured
+type CapacityUnit string
+
+const (
+	// CapacityUnitPoints measures the capacity in (story) points
+	CapacityUnitPoints CapacityUnit = "points"
+	// CapacityUnitDays measures the capacity in working days
+	CapacityUnitDays CapacityUnit = "days"
+)
+
+// String implements the Stringer interface
+func (u CapacityUnit) String() string { return string(u) }
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (u *CapacityUnit) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		*u = CapacityUnit(v)
+	case []byte:
+		*u = CapacityUnit(v)
+	default:
+		*u = CapacityUnit(fmt.Sprintf("illegal value: %+v", value))
+	}
+	return u.CheckValid()
+}
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer
+// interface
+func (u CapacityUnit) Value() (driver.Value, error) {
+	if u == "" {
+		return string(CapacityUnitPoints), nil
+	}
+	return string(u), nil
+}
+
+// CheckValid returns nil if the given capacity unit is valid; otherwise a
+// BadParameterError is returned. An empty unit defaults to points.
+func (u CapacityUnit) CheckValid() error {
+	switch u {
+	case "", CapacityUnitPoints, CapacityUnitDays:
+		return nil
+	default:
+		return errors.NewBadParameterError("capacity unit", u).Expected(CapacityUnitPoints + "|" + CapacityUnitDays)
+	}
+}
+
+// Capacity maps the identity IDs of the assignees of an iteration to the
+// amount of work (in the capacity unit of the iteration) they can take on
+// during the iteration.
+type Capacity map[uuid.UUID]float64
+
+// Ensure Capacity implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*Capacity)(nil)
+var _ driver.Valuer = (*Capacity)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (c Capacity) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (c *Capacity) Scan(src interface{}) error {
+	if src == nil {
+		*c = nil
+		return nil
+	}
+	b, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("scan source was not a byte array but %T", src)
+	}
+	return json.Unmarshal(b, c)
+}
+
+// Total returns the capacity of all assignees together.
+func (c Capacity) Total() float64 {
+	var res float64
+	for _, v := range c {
+		res += v
+	}
+	return res
+}
+
+// CheckValid returns nil if no assignee has a negative capacity; otherwise a
+// BadParameterError is returned.
+func (c Capacity) CheckValid() error {
+	for id, v := range c {
+		if v < 0 {
+			return errors.NewBadParameterError("capacity of "+id.String(), v).Expected("a positive number or zero")
+		}
+	}
+	return nil
+}
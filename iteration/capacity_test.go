@@ -0,0 +1,87 @@
+package iteration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/path"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapacityUnit_CheckValid(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	for _, u := range []iteration.CapacityUnit{"", iteration.CapacityUnitPoints, iteration.CapacityUnitDays} {
+		assert.NoError(t, u.CheckValid(), "unit %q", u)
+	}
+	assert.Error(t, iteration.CapacityUnit("hours").CheckValid())
+}
+
+func TestCapacity(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	alice, bob := uuid.NewV4(), uuid.NewV4()
+
+	t.Run("value and scan", func(t *testing.T) {
+		t.Parallel()
+		c := iteration.Capacity{alice: 3, bob: 0.5}
+		v, err := c.Value()
+		require.NoError(t, err)
+		var scanned iteration.Capacity
+		require.NoError(t, scanned.Scan(v))
+		assert.Equal(t, c, scanned)
+	})
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		v, err := iteration.Capacity{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, v)
+		var scanned iteration.Capacity
+		require.NoError(t, scanned.Scan(nil))
+		assert.Empty(t, scanned)
+	})
+	t.Run("check valid", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, iteration.Capacity{alice: 0, bob: 2}.CheckValid())
+		assert.Error(t, iteration.Capacity{alice: -1}.CheckValid())
+	})
+}
+
+func TestPastIterations(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	now := time.Now()
+	at := func(days int) *time.Time {
+		res := now.Add(time.Duration(days) * 24 * time.Hour)
+		return &res
+	}
+	root := iteration.Iteration{ID: uuid.NewV4(), Name: "root", EndAt: at(-30)}
+	root.Path = path.Path{root.ID}
+	newChild := func(name string, end *time.Time) iteration.Iteration {
+		itr := iteration.Iteration{Name: name, EndAt: end}
+		itr.MakeChildOf(root)
+		return itr
+	}
+	iterations := []iteration.Iteration{
+		root,
+		newChild("sprint 1", at(-21)),
+		newChild("sprint 3", at(-7)),
+		newChild("sprint 2", at(-14)),
+		newChild("sprint 4", at(7)),
+		newChild("backlog", nil),
+	}
+	names := func(iterations []iteration.Iteration) []string {
+		res := []string{}
+		for _, itr := range iterations {
+			res = append(res, itr.Name)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"sprint 3", "sprint 2"}, names(iteration.PastIterations(iterations, now, 2)))
+	assert.Equal(t, []string{"sprint 2", "sprint 1"}, names(iteration.PastIterations(iterations, *at(-10), 5)))
+	assert.Equal(t, []string{}, names(iteration.PastIterations(iterations, *at(-25), 5)))
+}
@@ -3,6 +3,7 @@ package iteration
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -40,6 +41,10 @@ type Iteration struct {
 	Description *string
 	State       State // this tells if iteration is currently running or not
 	UserActive  bool
+	// Capacity holds the amount of work each assignee can take on during the
+	// iteration, measured in the CapacityUnit
+	Capacity     Capacity `sql:"type:jsonb"`
+	CapacityUnit CapacityUnit
 	// optional, private timestamp of the latest addition/removal of a relationship with this iteration
 	// this field is used to generate the `ETag` and `Last-Modified` values in the HTTP responses and conditional requests processing
 	RelationShipsChangedAt *time.Time `sql:"column:relationships_changed_at"`
@@ -155,6 +160,9 @@ func (m *GormIterationRepository) Create(ctx context.Context, u *Iteration) erro
 	if !u.State.IsSet() {
 		u.State = StateNew
 	}
+	if u.CapacityUnit == "" {
+		u.CapacityUnit = CapacityUnitPoints
+	}
 	err := m.db.Create(u).Error
 	// Composite key (name,space,path) must be unique
 	// ( name, spaceID ,path ) needs to be unique
@@ -316,6 +324,25 @@ func (m *Iteration) IsActive() bool {
 	return inTimeframe(*m.StartAt, *m.EndAt)
 }
 
+// PastIterations returns at most limit of the given iterations that ended
+// before the given time, the latest first. Root iterations and iterations
+// without an end date are skipped.
+func PastIterations(iterations []Iteration, before time.Time, limit int) []Iteration {
+	var res []Iteration
+	for _, itr := range iterations {
+		if len(itr.Path) > 1 && itr.EndAt != nil && itr.EndAt.Before(before) {
+			res = append(res, itr)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].EndAt.After(*res[j].EndAt)
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
 // LoadChildren executes - select * from iterations where path <@ 'parent_path.parent_id';
 func (m *GormIterationRepository) LoadChildren(ctx context.Context, parentIterationID uuid.UUID) ([]Iteration, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "iteration", "loadchildren"}, time.Now())
@@ -393,6 +393,24 @@ func (s *TestIterationRepository) TestUpdate() {
 		assert.Equal(t, changedStart, *updatedIteration.StartAt)
 		assert.Equal(t, changedEnd, *updatedIteration.EndAt)
 	})
+
+	t.Run("update capacity", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Iterations(1))
+		i := *fxt.Iterations[0]
+		assert.Equal(t, iteration.CapacityUnitPoints, i.CapacityUnit)
+		alice, bob := uuid.NewV4(), uuid.NewV4()
+		i.Capacity = iteration.Capacity{alice: 8, bob: 4.5}
+		i.CapacityUnit = iteration.CapacityUnitDays
+		// when
+		_, err := repo.Save(context.Background(), i)
+		require.NoError(t, err)
+		// then
+		loaded, err := repo.Load(context.Background(), i.ID)
+		require.NoError(t, err)
+		assert.Equal(t, iteration.CapacityUnitDays, loaded.CapacityUnit)
+		assert.Equal(t, iteration.Capacity{alice: 8, bob: 4.5}, loaded.Capacity)
+		assert.Equal(t, 12.5, loaded.Capacity.Total())
+	})
 }
 
 func (s *TestIterationRepository) TestExistsIteration() {
@@ -0,0 +1,315 @@
+package iteration
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// ScheduleCounterPlaceholder is replaced by the counter of the schedule
+	// in the name pattern of a schedule
+	ScheduleCounterPlaceholder = "{n}"
+	// MaxScheduleAhead is the maximum number of iterations a schedule can
+	// keep ahead
+	MaxScheduleAhead = 52
+)
+
+// Schedule describes how the iterations of a space are generated in a fixed
+// cadence, e.g. two-week sprints named "Sprint {n}".
+type Schedule struct {
+	gormsupport.Lifecycle
+	ID      uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	SpaceID uuid.UUID `sql:"type:uuid"`
+	// ParentID is the iteration under which the iterations are created. The
+	// root iteration of the space is used if it is nil.
+	ParentID *uuid.UUID `sql:"type:uuid"`
+	// StartAt is the start of the first iteration of the schedule
+	StartAt time.Time
+	// Cadence is the number of days between the starts of two consecutive
+	// iterations
+	Cadence int
+	// Length is the number of days an iteration lasts
+	Length int
+	// NamePattern is the name of the generated iterations in which "{n}" is
+	// replaced by the counter
+	NamePattern string
+	// Counter is the number used in the name of the next generated iteration
+	Counter int
+	// Ahead is the number of current and upcoming iterations to keep
+	Ahead   int
+	Version int
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (s Schedule) TableName() string {
+	return "iteration_schedules"
+}
+
+// Validate returns a BadParameterError if the schedule is incomplete or
+// inconsistent.
+func (s Schedule) Validate() error {
+	if s.StartAt.IsZero() {
+		return errors.NewBadParameterError("startAt", s.StartAt).Expected("a start date")
+	}
+	if s.Cadence < 1 {
+		return errors.NewBadParameterError("cadence", s.Cadence).Expected("a positive number of days")
+	}
+	if s.Length < 1 {
+		return errors.NewBadParameterError("length", s.Length).Expected("a positive number of days")
+	}
+	if !strings.Contains(s.NamePattern, ScheduleCounterPlaceholder) {
+		return errors.NewBadParameterError("name_pattern", s.NamePattern).Expected("a name containing " + ScheduleCounterPlaceholder)
+	}
+	if s.Counter < 1 {
+		return errors.NewBadParameterError("counter", s.Counter).Expected("a positive number")
+	}
+	if s.Ahead < 1 || s.Ahead > MaxScheduleAhead {
+		return errors.NewBadParameterError("ahead", s.Ahead).Expected("a number between 1 and " + strconv.Itoa(MaxScheduleAhead))
+	}
+	return nil
+}
+
+// Name returns the name of the iteration with the given counter.
+func (s Schedule) Name(counter int) string {
+	return strings.Replace(s.NamePattern, ScheduleCounterPlaceholder, strconv.Itoa(counter), -1)
+}
+
+// Upcoming returns the iterations that the schedule requires at the given
+// time but that are missing among the given siblings (the existing children
+// of the parent iteration). The schedule requires the iteration that is
+// running at the given time (if any) and as many following ones as needed to
+// keep "Ahead" iterations. A required iteration is missing unless a sibling
+// starts on the same day. Names that are already taken by a sibling are
+// skipped. The counter of the schedule is advanced past the names of the
+// returned iterations.
+func (s *Schedule) Upcoming(siblings []Iteration, now time.Time) []Iteration {
+	day := 24 * time.Hour
+	cadence := time.Duration(s.Cadence) * day
+	length := time.Duration(s.Length) * day
+	startDays := map[time.Time]struct{}{}
+	names := map[string]struct{}{}
+	for _, sibling := range siblings {
+		if sibling.StartAt != nil {
+			startDays[sibling.StartAt.UTC().Truncate(day)] = struct{}{}
+		}
+		names[sibling.Name] = struct{}{}
+	}
+	// skip all iterations that have already ended
+	first := 0
+	if now.After(s.StartAt) {
+		first = int(now.Sub(s.StartAt) / cadence)
+		for first > 0 && s.StartAt.Add(time.Duration(first-1)*cadence).Add(length).After(now) {
+			first--
+		}
+		for !s.StartAt.Add(time.Duration(first) * cadence).Add(length).After(now) {
+			first++
+		}
+	}
+	var res []Iteration
+	for i := first; i < first+s.Ahead; i++ {
+		startAt := s.StartAt.Add(time.Duration(i) * cadence)
+		if _, ok := startDays[startAt.UTC().Truncate(day)]; ok {
+			continue
+		}
+		name := s.Name(s.Counter)
+		for _, taken := names[name]; taken; _, taken = names[name] {
+			s.Counter++
+			name = s.Name(s.Counter)
+		}
+		s.Counter++
+		names[name] = struct{}{}
+		endAt := startAt.Add(length)
+		res = append(res, Iteration{
+			SpaceID: s.SpaceID,
+			Name:    name,
+			StartAt: &startAt,
+			EndAt:   &endAt,
+		})
+	}
+	return res
+}
+
+// ScheduleRepository describes interactions with iteration schedules
+type ScheduleRepository interface {
+	Load(ctx context.Context, spaceID uuid.UUID) (*Schedule, error)
+	List(ctx context.Context) ([]Schedule, error)
+	Save(ctx context.Context, s Schedule) (*Schedule, error)
+	Delete(ctx context.Context, spaceID uuid.UUID) error
+	Materialize(ctx context.Context, spaceID uuid.UUID, now time.Time) ([]Iteration, error)
+}
+
+// NewScheduleRepository creates a new iteration schedule repository.
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &GormScheduleRepository{db: db}
+}
+
+// GormScheduleRepository is the implementation of the storage interface for
+// iteration schedules.
+type GormScheduleRepository struct {
+	db *gorm.DB
+}
+
+// Load returns the iteration schedule of the given space.
+func (r *GormScheduleRepository) Load(ctx context.Context, spaceID uuid.UUID) (*Schedule, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "iterationschedule", "get"}, time.Now())
+	var res Schedule
+	tx := r.db.Where("space_id = ?", spaceID).First(&res)
+	if tx.RecordNotFound() {
+		return nil, errors.NewNotFoundError("iteration schedule for space", spaceID.String())
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	return &res, nil
+}
+
+// List returns the iteration schedules of all spaces.
+func (r *GormScheduleRepository) List(ctx context.Context) ([]Schedule, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "iterationschedule", "query"}, time.Now())
+	var res []Schedule
+	if err := r.db.Order("created_at").Find(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return res, nil
+}
+
+// Save creates the iteration schedule of a space or updates the existing
+// one. Updates must carry the version of the stored schedule.
+// returns BadParameterError, NotFoundError, VersionConflictError or InternalError
+func (r *GormScheduleRepository) Save(ctx context.Context, s Schedule) (*Schedule, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "iterationschedule", "save"}, time.Now())
+	if s.Counter == 0 {
+		s.Counter = 1
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if s.ParentID != nil {
+		var parent Iteration
+		tx := r.db.Where("id = ?", *s.ParentID).First(&parent)
+		if tx.RecordNotFound() || (tx.Error == nil && parent.SpaceID != s.SpaceID) {
+			return nil, errors.NewBadParameterError("parent", *s.ParentID).Expected("an iteration of the space")
+		}
+		if tx.Error != nil {
+			return nil, errors.NewInternalError(ctx, tx.Error)
+		}
+	}
+	existing, err := r.Load(ctx, s.SpaceID)
+	if err != nil {
+		if notFound, _ := errors.IsNotFoundError(err); !notFound {
+			return nil, err
+		}
+		s.ID = uuid.NewV4()
+		s.Version = 0
+		if err := r.db.Create(&s).Error; err != nil {
+			if gormsupport.IsUniqueViolation(err, "iteration_schedules_space_id_unique_idx") {
+				return nil, errors.NewVersionConflictError("iteration schedule was created concurrently")
+			}
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to create iteration schedule"))
+		}
+		log.Debug(ctx, map[string]interface{}{"space_id": s.SpaceID, "schedule_id": s.ID}, "created iteration schedule")
+		return &s, nil
+	}
+	if existing.Version != s.Version {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	s.ID = existing.ID
+	s.CreatedAt = existing.CreatedAt
+	s.Version = existing.Version + 1
+	db := r.db.Model(&s).Where("version = ?", existing.Version).Updates(map[string]interface{}{
+		"parent_id":    s.ParentID,
+		"start_at":     s.StartAt,
+		"cadence":      s.Cadence,
+		"length":       s.Length,
+		"name_pattern": s.NamePattern,
+		"counter":      s.Counter,
+		"ahead":        s.Ahead,
+		"version":      s.Version,
+	})
+	if err := db.Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	return &s, nil
+}
+
+// Delete removes the iteration schedule of the given space. The iterations
+// that were generated from it are kept.
+// returns NotFoundError or InternalError
+func (r *GormScheduleRepository) Delete(ctx context.Context, spaceID uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "iterationschedule", "delete"}, time.Now())
+	tx := r.db.Where("space_id = ?", spaceID).Delete(&Schedule{})
+	if err := tx.Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	if tx.RowsAffected == 0 {
+		return errors.NewNotFoundError("iteration schedule for space", spaceID.String())
+	}
+	return nil
+}
+
+// Materialize creates the iterations that the schedule of the given space
+// requires at the given time below the schedule's parent iteration (see
+// Schedule.Upcoming) and stores the advanced counter of the schedule. The
+// created iterations are returned.
+//
+// NOTE: Run this inside a transaction so that the counter and the created
+// iterations stay consistent.
+func (r *GormScheduleRepository) Materialize(ctx context.Context, spaceID uuid.UUID, now time.Time) ([]Iteration, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "iterationschedule", "materialize"}, time.Now())
+	s, err := r.Load(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	iterationRepo := NewIterationRepository(r.db)
+	var parent *Iteration
+	if s.ParentID != nil {
+		parent, err = iterationRepo.Load(ctx, *s.ParentID)
+	} else {
+		parent, err = iterationRepo.Root(ctx, spaceID)
+	}
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to load the parent iteration of the schedule of space %s", spaceID)
+	}
+	descendants, err := iterationRepo.LoadChildren(ctx, parent.ID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to load the children of iteration %s", parent.ID)
+	}
+	var siblings []Iteration
+	for _, itr := range descendants {
+		if itr.Path.ParentID() == parent.ID {
+			siblings = append(siblings, itr)
+		}
+	}
+	upcoming := s.Upcoming(siblings, now)
+	if len(upcoming) == 0 {
+		return upcoming, nil
+	}
+	for i := range upcoming {
+		upcoming[i].MakeChildOf(*parent)
+		if err := iterationRepo.Create(ctx, &upcoming[i]); err != nil {
+			return nil, errs.Wrapf(err, "failed to create iteration %q", upcoming[i].Name)
+		}
+	}
+	if _, err := r.Save(ctx, *s); err != nil {
+		return nil, errs.Wrapf(err, "failed to advance the counter of the schedule of space %s", spaceID)
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_id":   spaceID,
+		"iterations": len(upcoming),
+	}, "materialized iterations from schedule")
+	return upcoming, nil
+}
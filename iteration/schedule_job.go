@@ -0,0 +1,65 @@
+package iteration
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/models"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+// ScheduleJob periodically materializes the iterations of all iteration
+// schedules.
+type ScheduleJob struct {
+	db   *gorm.DB
+	cron *cron.Cron
+}
+
+// NewScheduleJob creates a new job for the iteration schedules.
+func NewScheduleJob(db *gorm.DB) *ScheduleJob {
+	return &ScheduleJob{db: db, cron: cron.New()}
+}
+
+// Start runs the job in the given interval until Stop is called.
+func (j *ScheduleJob) Start(ctx context.Context, interval time.Duration) error {
+	err := j.cron.AddFunc("@every "+interval.String(), func() {
+		j.Run(ctx, time.Now())
+	})
+	if err != nil {
+		return errs.Wrapf(err, "failed to schedule the iteration schedule job every %s", interval)
+	}
+	j.cron.Start()
+	return nil
+}
+
+// Stop stops the job.
+// This should be called only from main
+func (j *ScheduleJob) Stop() {
+	j.cron.Stop()
+}
+
+// Run materializes the iterations of all schedules at the given time. Every
+// schedule is materialized in its own transaction so that a failing schedule
+// doesn't affect the others.
+func (j *ScheduleJob) Run(ctx context.Context, now time.Time) {
+	schedules, err := NewScheduleRepository(j.db).List(ctx)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{"err": err}, "failed to list the iteration schedules")
+		return
+	}
+	for _, s := range schedules {
+		err := models.Transactional(j.db, func(tx *gorm.DB) error {
+			_, err := NewScheduleRepository(tx).Materialize(ctx, s.SpaceID, now)
+			return err
+		})
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"err":      err,
+				"space_id": s.SpaceID,
+			}, "failed to materialize the iterations of the schedule")
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package iteration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	valid := iteration.Schedule{
+		StartAt:     time.Date(2018, time.January, 1, 9, 0, 0, 0, time.UTC),
+		Cadence:     14,
+		Length:      14,
+		NamePattern: "Sprint {n}",
+		Counter:     1,
+		Ahead:       3,
+	}
+	require.NoError(t, valid.Validate())
+	invalid := map[string]func(s *iteration.Schedule){
+		"no start":          func(s *iteration.Schedule) { s.StartAt = time.Time{} },
+		"no cadence":        func(s *iteration.Schedule) { s.Cadence = 0 },
+		"negative length":   func(s *iteration.Schedule) { s.Length = -1 },
+		"no placeholder":    func(s *iteration.Schedule) { s.NamePattern = "Sprint" },
+		"wrong placeholder": func(s *iteration.Schedule) { s.NamePattern = "Sprint {m}" },
+		"no counter":        func(s *iteration.Schedule) { s.Counter = 0 },
+		"nothing ahead":     func(s *iteration.Schedule) { s.Ahead = 0 },
+		"too much ahead":    func(s *iteration.Schedule) { s.Ahead = iteration.MaxScheduleAhead + 1 },
+	}
+	for name, change := range invalid {
+		t.Run(name, func(t *testing.T) {
+			s := valid
+			change(&s)
+			err := s.Validate()
+			require.Error(t, err)
+			assert.IsType(t, errors.BadParameterError{}, err)
+		})
+	}
+}
+
+func TestSchedule_Upcoming(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	day := 24 * time.Hour
+	start := time.Date(2018, time.January, 1, 9, 0, 0, 0, time.UTC)
+	newSchedule := func() *iteration.Schedule {
+		return &iteration.Schedule{
+			StartAt:     start,
+			Cadence:     14,
+			Length:      14,
+			NamePattern: "Sprint {n}",
+			Counter:     1,
+			Ahead:       3,
+		}
+	}
+	names := func(iterations []iteration.Iteration) []string {
+		res := []string{}
+		for _, itr := range iterations {
+			res = append(res, itr.Name)
+		}
+		return res
+	}
+
+	t.Run("before the first iteration", func(t *testing.T) {
+		s := newSchedule()
+		res := s.Upcoming(nil, start.Add(-5*day))
+		require.Len(t, res, 3)
+		assert.Equal(t, []string{"Sprint 1", "Sprint 2", "Sprint 3"}, names(res))
+		assert.Equal(t, start, *res[0].StartAt)
+		assert.Equal(t, start.Add(14*day), *res[0].EndAt)
+		assert.Equal(t, start.Add(28*day), *res[2].StartAt)
+		assert.Equal(t, 4, s.Counter)
+	})
+	t.Run("while an iteration is running", func(t *testing.T) {
+		s := newSchedule()
+		res := s.Upcoming(nil, start.Add(20*day))
+		require.Len(t, res, 3)
+		// the first iteration has ended, the second one is running
+		assert.Equal(t, start.Add(14*day), *res[0].StartAt)
+	})
+	t.Run("overlapping iterations", func(t *testing.T) {
+		s := newSchedule()
+		s.Cadence = 7
+		res := s.Upcoming(nil, start.Add(15*day))
+		require.Len(t, res, 3)
+		// the iteration that started on day 7 is still running
+		assert.Equal(t, start.Add(7*day), *res[0].StartAt)
+	})
+	t.Run("existing iterations are respected", func(t *testing.T) {
+		s := newSchedule()
+		existingStart := start.Add(14*day + 3*time.Hour)
+		siblings := []iteration.Iteration{
+			{Name: "Sprint 1", StartAt: &start},
+			{Name: "Hardening", StartAt: &existingStart},
+			{Name: "Sprint 3"},
+		}
+		res := s.Upcoming(siblings, start.Add(-day))
+		// the first two iterations exist already and "Sprint 3" is taken
+		assert.Equal(t, []string{"Sprint 2"}, names(res))
+		assert.Equal(t, start.Add(28*day), *res[0].StartAt)
+		assert.Equal(t, 3, s.Counter)
+		// a second run creates nothing
+		created := append(siblings, res...)
+		assert.Empty(t, s.Upcoming(created, start.Add(-day)))
+	})
+}
+
+type TestScheduleRepository struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunScheduleRepository(t *testing.T) {
+	suite.Run(t, &TestScheduleRepository{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *TestScheduleRepository) newSchedule(spaceID uuid.UUID) iteration.Schedule {
+	return iteration.Schedule{
+		SpaceID:     spaceID,
+		StartAt:     time.Now().Add(-24 * time.Hour),
+		Cadence:     14,
+		Length:      14,
+		NamePattern: "Sprint {n}",
+		Ahead:       2,
+	}
+}
+
+func (s *TestScheduleRepository) TestSave() {
+	resource.Require(s.T(), resource.Database)
+	repo := iteration.NewScheduleRepository(s.DB)
+
+	s.T().Run("create and update", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		// when
+		created, err := repo.Save(context.Background(), s.newSchedule(fxt.Spaces[0].ID))
+		// then
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, created.ID)
+		assert.Equal(t, 1, created.Counter)
+		assert.Equal(t, 0, created.Version)
+
+		created.Ahead = 4
+		updated, err := repo.Save(context.Background(), *created)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.Version)
+		loaded, err := repo.Load(context.Background(), fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, loaded.Ahead)
+		assert.Equal(t, created.ID, loaded.ID)
+
+		t.Run("version conflict", func(t *testing.T) {
+			_, err := repo.Save(context.Background(), *created)
+			require.Error(t, err)
+			assert.IsType(t, errors.VersionConflictError{}, err)
+		})
+	})
+	s.T().Run("invalid", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		sched := s.newSchedule(fxt.Spaces[0].ID)
+		sched.NamePattern = "Sprint"
+		_, err := repo.Save(context.Background(), sched)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, err)
+	})
+	s.T().Run("parent of another space", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(2), tf.Iterations(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Iterations[idx].SpaceID = fxt.Spaces[1].ID
+			return nil
+		}))
+		sched := s.newSchedule(fxt.Spaces[0].ID)
+		sched.ParentID = &fxt.Iterations[0].ID
+		_, err := repo.Save(context.Background(), sched)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, err)
+	})
+}
+
+func (s *TestScheduleRepository) TestMaterialize() {
+	resource.Require(s.T(), resource.Database)
+	repo := iteration.NewScheduleRepository(s.DB)
+	iterationRepo := iteration.NewIterationRepository(s.DB)
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Iterations(2, tf.SetIterationNames("root", "parent"), tf.PlaceIterationUnderRootIteration()))
+		sched := s.newSchedule(fxt.Spaces[0].ID)
+		sched.ParentID = &fxt.IterationByName("parent").ID
+		_, err := repo.Save(context.Background(), sched)
+		require.NoError(t, err)
+		// when
+		created, err := repo.Materialize(context.Background(), fxt.Spaces[0].ID, time.Now())
+		// then
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.Equal(t, "Sprint 1", created[0].Name)
+		assert.Equal(t, "Sprint 2", created[1].Name)
+		children, err := iterationRepo.LoadChildren(context.Background(), fxt.IterationByName("parent").ID)
+		require.NoError(t, err)
+		require.Len(t, children, 2)
+		for _, child := range children {
+			assert.Equal(t, fxt.IterationByName("parent").ID, child.Parent())
+		}
+		loaded, err := repo.Load(context.Background(), fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, loaded.Counter)
+
+		t.Run("again", func(t *testing.T) {
+			created, err := repo.Materialize(context.Background(), fxt.Spaces[0].ID, time.Now())
+			require.NoError(t, err)
+			assert.Empty(t, created)
+		})
+		t.Run("later", func(t *testing.T) {
+			created, err := repo.Materialize(context.Background(), fxt.Spaces[0].ID, time.Now().Add(14*24*time.Hour))
+			require.NoError(t, err)
+			require.Len(t, created, 1)
+			assert.Equal(t, "Sprint 3", created[0].Name)
+		})
+	})
+	s.T().Run("under root iteration", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Iterations(1))
+		_, err := repo.Save(context.Background(), s.newSchedule(fxt.Spaces[0].ID))
+		require.NoError(t, err)
+		created, err := repo.Materialize(context.Background(), fxt.Spaces[0].ID, time.Now())
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.Equal(t, fxt.Iterations[0].ID, created[0].Parent())
+	})
+	s.T().Run("no schedule", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		_, err := repo.Materialize(context.Background(), fxt.Spaces[0].ID, time.Now())
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
+func (s *TestScheduleRepository) TestDelete() {
+	resource.Require(s.T(), resource.Database)
+	repo := iteration.NewScheduleRepository(s.DB)
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.Spaces(1))
+	_, err := repo.Save(context.Background(), s.newSchedule(fxt.Spaces[0].ID))
+	require.NoError(s.T(), err)
+
+	require.NoError(s.T(), repo.Delete(context.Background(), fxt.Spaces[0].ID))
+	_, err = repo.Load(context.Background(), fxt.Spaces[0].ID)
+	assert.IsType(s.T(), errors.NotFoundError{}, err)
+	err = repo.Delete(context.Background(), fxt.Spaces[0].ID)
+	assert.IsType(s.T(), errors.NotFoundError{}, err)
+}
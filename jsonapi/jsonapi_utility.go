@@ -39,6 +39,7 @@ func ErrorToJSONAPIError(ctx context.Context, err error) (app.JSONAPIError, int)
 	var title, code string
 	var statusCode int
 	var id *string
+	var source map[string]interface{}
 	log.Error(ctx, map[string]interface{}{"err": cause, "error_message": cause.Error(), "err_type": reflect.TypeOf(cause)}, "an error occurred in our api")
 	switch cause.(type) {
 	case errors.NotFoundError:
@@ -53,6 +54,9 @@ func ErrorToJSONAPIError(ctx context.Context, err error) (app.JSONAPIError, int)
 		code = ErrorCodeBadParameter
 		title = "Bad parameter error"
 		statusCode = http.StatusBadRequest
+		if pointer := cause.(errors.BadParameterError).SourcePointer(); pointer != nil {
+			source = map[string]interface{}{"pointer": *pointer}
+		}
 	case errors.VersionConflictError:
 		code = ErrorCodeVersionConflict
 		title = "Version conflict error"
@@ -97,6 +101,7 @@ func ErrorToJSONAPIError(ctx context.Context, err error) (app.JSONAPIError, int)
 		Status: &statusCodeStr,
 		Title:  &title,
 		Detail: detail,
+		Source: source,
 	}
 	return jerr, statusCode
 }
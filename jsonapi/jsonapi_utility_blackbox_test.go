@@ -45,6 +45,13 @@ func TestErrorToJSONAPIError(t *testing.T) {
 	require.NotNil(t, jerr.Status)
 	require.Equal(t, jsonapi.ErrorCodeBadParameter, *jerr.Code)
 	require.Equal(t, strconv.Itoa(httpStatus), *jerr.Status)
+	require.Nil(t, jerr.Source)
+
+	// test bad parameter error with a pointer to the offending attribute
+	jerr, httpStatus = jsonapi.ErrorToJSONAPIError(nil, errors.NewBadParameterError("foo", "bar").Pointer("/data/attributes/foo"))
+	require.Equal(t, http.StatusBadRequest, httpStatus)
+	require.Equal(t, jsonapi.ErrorCodeBadParameter, *jerr.Code)
+	require.Equal(t, map[string]interface{}{"pointer": "/data/attributes/foo"}, jerr.Source)
 
 	// test internal server error
 	jerr, httpStatus = jsonapi.ErrorToJSONAPIError(nil, errors.NewInternalError(context.Background(), errs.New("foo")))
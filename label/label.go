@@ -13,6 +13,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/goadesign/goa"
 	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -28,7 +29,10 @@ type Label struct {
 	TextColor       string `sql:"DEFAULT:#000000"`
 	BackgroundColor string `sql:"DEFAULT:#FFFFFF"`
 	BorderColor     string `sql:"DEFAULT:#000000"`
-	Version         int
+	// ParentID optionally groups the label under another label of the same
+	// space
+	ParentID *uuid.UUID `sql:"type:uuid"`
+	Version  int
 }
 
 // GetETagData returns the field values to use to generate the ETag
@@ -54,6 +58,8 @@ type Repository interface {
 	IsValid(ctx context.Context, id uuid.UUID) bool
 	Load(ctx context.Context, labelID uuid.UUID) (*Label, error)
 	Save(ctx context.Context, lbl Label) (*Label, error)
+	Delete(ctx context.Context, labelID uuid.UUID) error
+	Merge(ctx context.Context, targetID uuid.UUID, sourceIDs ...uuid.UUID) (*Label, error)
 }
 
 // NewLabelRepository creates a new storage type.
@@ -76,6 +82,9 @@ func (m *GormLabelRepository) Create(ctx context.Context, u *Label) error {
 	if strings.TrimSpace(u.Name) == "" {
 		return errors.NewBadParameterError("label name cannot be empty string", u.Name).Expected("non empty string")
 	}
+	if err := m.checkParent(ctx, *u); err != nil {
+		return err
+	}
 	err := m.db.Create(u).Error
 	if err != nil {
 		// combination of name and space ID should be unique
@@ -99,6 +108,9 @@ func (m *GormLabelRepository) Save(ctx context.Context, l Label) (*Label, error)
 	if strings.TrimSpace(l.Name) == "" {
 		return nil, errors.NewBadParameterError("label name cannot be empty string", l.Name).Expected("non empty string")
 	}
+	if err := m.checkParent(ctx, l); err != nil {
+		return nil, err
+	}
 	lbl := Label{}
 	tx := m.db.Where("id = ?", l.ID).First(&lbl)
 	oldVersion := l.Version
@@ -179,3 +191,134 @@ func (m *GormLabelRepository) Load(ctx context.Context, ID uuid.UUID) (*Label, e
 	}
 	return &lbl, nil
 }
+
+// checkParent verifies that the parent of the given label exists in the same
+// space and that the label is not one of its own ancestors.
+func (m *GormLabelRepository) checkParent(ctx context.Context, l Label) error {
+	parentID := l.ParentID
+	for parentID != nil {
+		if uuid.Equal(*parentID, l.ID) {
+			return errors.NewBadParameterError("parent", l.ParentID.String()).Expected("a label that is not a descendant of the label itself")
+		}
+		parent := Label{}
+		tx := m.db.Where("id = ?", *parentID).First(&parent)
+		if tx.RecordNotFound() {
+			return errors.NewBadParameterError("parent", parentID.String()).Expected("existing label")
+		}
+		if tx.Error != nil {
+			return errors.NewInternalError(ctx, errs.Wrapf(tx.Error, "failed to load label %s", *parentID))
+		}
+		if !uuid.Equal(parent.SpaceID, l.SpaceID) {
+			return errors.NewBadParameterError("parent", parentID.String()).Expected("label in space " + l.SpaceID.String())
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+// Delete deletes the label with the given ID. Its children are moved up to
+// the parent of the deleted label. The label is not removed from the work
+// items it is attached to.
+func (m *GormLabelRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "label", "delete"}, time.Now())
+	lbl, err := m.Load(ctx, ID)
+	if err != nil {
+		return err
+	}
+	if err := m.reparent(ctx, lbl.ParentID, ID); err != nil {
+		return err
+	}
+	if err := m.db.Delete(lbl).Error; err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to delete label %s", ID))
+	}
+	log.Debug(ctx, map[string]interface{}{
+		"label_id": ID,
+	}, "label deleted successfully")
+	return nil
+}
+
+// Merge merges the labels with the given source IDs into the label with the
+// given target ID and deletes the source labels. The children of the source
+// labels become children of the target label. If the target label is a
+// descendant of a source label, it takes the place of the topmost of them in
+// the hierarchy. The source labels are not replaced on the work items they
+// are attached to.
+func (m *GormLabelRepository) Merge(ctx context.Context, targetID uuid.UUID, sourceIDs ...uuid.UUID) (*Label, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "label", "merge"}, time.Now())
+	target, err := m.Load(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sourceIDs) == 0 {
+		return nil, errors.NewBadParameterError("sources", sourceIDs).Expected("at least one label")
+	}
+	sources := map[uuid.UUID]Label{}
+	for _, id := range sourceIDs {
+		if uuid.Equal(id, targetID) {
+			return nil, errors.NewBadParameterError("sources", id.String()).Expected("labels other than the target label")
+		}
+		source, err := m.Load(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !uuid.Equal(source.SpaceID, target.SpaceID) {
+			return nil, errors.NewBadParameterError("sources", id.String()).Expected("label in space " + target.SpaceID.String())
+		}
+		sources[id] = *source
+	}
+	// the target label takes the place of its topmost ancestor that is merged
+	parentID, moved := target.ParentID, false
+	for ancestorID := target.ParentID; ancestorID != nil; {
+		ancestor, ok := sources[*ancestorID]
+		if !ok {
+			ancestor = Label{}
+			if err := m.db.Where("id = ?", *ancestorID).First(&ancestor).Error; err != nil {
+				return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to load label %s", *ancestorID))
+			}
+		} else {
+			parentID, moved = ancestor.ParentID, true
+		}
+		ancestorID = ancestor.ParentID
+	}
+	ids := make([]uuid.UUID, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	if err := m.reparent(ctx, &targetID, ids...); err != nil {
+		return nil, err
+	}
+	if err := m.db.Where("id IN (?)", ids).Delete(&Label{}).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to delete the labels merged into label %s", targetID))
+	}
+	if moved {
+		target.ParentID = parentID
+		target.Version++
+		if err := m.db.Save(target).Error; err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to update the parent of label %s", targetID))
+		}
+	}
+	log.Debug(ctx, map[string]interface{}{
+		"label_id":   targetID,
+		"source_ids": ids,
+	}, "labels merged successfully")
+	return target, nil
+}
+
+// reparent moves the children of the labels with the given IDs to the given
+// parent, except for the parent itself.
+func (m *GormLabelRepository) reparent(ctx context.Context, parentID *uuid.UUID, ids ...uuid.UUID) error {
+	updates := map[string]interface{}{
+		"parent_id": nil,
+		"version":   gorm.Expr("version + 1"),
+	}
+	db := m.db.Model(&Label{}).Where("parent_id IN (?)", ids).Where("id NOT IN (?)", ids)
+	if parentID != nil {
+		updates["parent_id"] = *parentID
+		db = db.Where("id <> ?", *parentID)
+	}
+	db = db.Updates(updates)
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to move the children of labels %v", ids))
+	}
+	return nil
+}
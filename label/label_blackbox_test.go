@@ -187,3 +187,133 @@ func (s *TestLabelRepository) TestLoadLabel() {
 	require.NotNil(s.T(), lbl)
 	assert.Equal(s.T(), testFxt.Labels[0].Name, lbl.Name)
 }
+
+// setParents makes each label the parent of the next one, so that the labels
+// form a chain
+func setParents(fxt *tf.TestFixture, idx int) error {
+	if idx > 0 {
+		fxt.Labels[idx].ParentID = &fxt.Labels[idx-1].ID
+	}
+	return nil
+}
+
+func (s *TestLabelRepository) TestParent() {
+	repo := label.NewLabelRepository(s.DB)
+
+	s.T().Run("ok", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, s.DB, tf.Labels(2, setParents))
+		lbl, err := repo.Load(context.Background(), testFxt.Labels[1].ID)
+		require.NoError(t, err)
+		require.NotNil(t, lbl.ParentID)
+		assert.Equal(t, testFxt.Labels[0].ID, *lbl.ParentID)
+	})
+
+	s.T().Run("cycle", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, s.DB, tf.Labels(3, setParents))
+		root := *testFxt.Labels[0]
+		root.ParentID = &testFxt.Labels[2].ID
+		_, err := repo.Save(context.Background(), root)
+		require.Error(t, err)
+		assert.IsType(t, errs.BadParameterError{}, errors.Cause(err))
+		root.ParentID = &root.ID
+		_, err = repo.Save(context.Background(), root)
+		assert.IsType(t, errs.BadParameterError{}, errors.Cause(err))
+	})
+
+	s.T().Run("parent in other space", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, s.DB, tf.Spaces(2), tf.Labels(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Labels[idx].SpaceID = fxt.Spaces[idx].ID
+			return nil
+		}))
+		lbl := *testFxt.Labels[1]
+		lbl.ParentID = &testFxt.Labels[0].ID
+		_, err := repo.Save(context.Background(), lbl)
+		require.Error(t, err)
+		assert.IsType(t, errs.BadParameterError{}, errors.Cause(err))
+	})
+}
+
+func (s *TestLabelRepository) TestDelete() {
+	repo := label.NewLabelRepository(s.DB)
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given a chain 0 -> 1 -> 2
+		testFxt := tf.NewTestFixture(t, s.DB, tf.Labels(3, setParents))
+		// when
+		err := repo.Delete(context.Background(), testFxt.Labels[1].ID)
+		// then
+		require.NoError(t, err)
+		_, err = repo.Load(context.Background(), testFxt.Labels[1].ID)
+		assert.IsType(t, errs.NotFoundError{}, err)
+		child, err := repo.Load(context.Background(), testFxt.Labels[2].ID)
+		require.NoError(t, err)
+		require.NotNil(t, child.ParentID)
+		assert.Equal(t, testFxt.Labels[0].ID, *child.ParentID)
+		assert.Equal(t, testFxt.Labels[2].Version+1, child.Version)
+		// the name can be used again
+		err = repo.Create(context.Background(), &label.Label{SpaceID: testFxt.Spaces[0].ID, Name: testFxt.Labels[1].Name})
+		require.NoError(t, err)
+	})
+
+	s.T().Run("not found", func(t *testing.T) {
+		err := repo.Delete(context.Background(), uuid.NewV4())
+		assert.IsType(t, errs.NotFoundError{}, err)
+	})
+}
+
+func (s *TestLabelRepository) TestMerge() {
+	repo := label.NewLabelRepository(s.DB)
+
+	s.T().Run("children move to the target", func(t *testing.T) {
+		// given a chain a -> b and a label c
+		testFxt := tf.NewTestFixture(t, s.DB, tf.Labels(3, tf.SetLabelNames("a", "b", "c"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 1 {
+				fxt.Labels[idx].ParentID = &fxt.Labels[0].ID
+			}
+			return nil
+		}))
+		// when merging a into c
+		target, err := repo.Merge(context.Background(), testFxt.LabelByName("c").ID, testFxt.LabelByName("a").ID)
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, target.ParentID)
+		labels, err := repo.List(context.Background(), testFxt.Spaces[0].ID)
+		require.NoError(t, err)
+		require.Len(t, labels, 2)
+		b, err := repo.Load(context.Background(), testFxt.LabelByName("b").ID)
+		require.NoError(t, err)
+		require.NotNil(t, b.ParentID)
+		assert.Equal(t, target.ID, *b.ParentID)
+	})
+
+	s.T().Run("target takes the place of merged ancestors", func(t *testing.T) {
+		// given a chain a -> b -> c -> d -> e
+		testFxt := tf.NewTestFixture(t, s.DB, tf.Labels(5, tf.SetLabelNames("a", "b", "c", "d", "e"), setParents))
+		// when merging b and d into e
+		target, err := repo.Merge(context.Background(), testFxt.LabelByName("e").ID, testFxt.LabelByName("b").ID, testFxt.LabelByName("d").ID)
+		// then a -> e -> c
+		require.NoError(t, err)
+		require.NotNil(t, target.ParentID)
+		assert.Equal(t, testFxt.LabelByName("a").ID, *target.ParentID)
+		c, err := repo.Load(context.Background(), testFxt.LabelByName("c").ID)
+		require.NoError(t, err)
+		require.NotNil(t, c.ParentID)
+		assert.Equal(t, target.ID, *c.ParentID)
+	})
+
+	s.T().Run("invalid sources", func(t *testing.T) {
+		testFxt := tf.NewTestFixture(t, s.DB, tf.Labels(1))
+		other := tf.NewTestFixture(t, s.DB, tf.Labels(1))
+		for name, sources := range map[string][]uuid.UUID{
+			"none":           {},
+			"target":         {testFxt.Labels[0].ID},
+			"other space":    {other.Labels[0].ID},
+			"unknown source": {uuid.NewV4()},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := repo.Merge(context.Background(), testFxt.Labels[0].ID, sources...)
+				require.Error(t, err)
+			})
+		}
+	})
+}
@@ -15,12 +15,15 @@ import (
 	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/attachment"
 	"github.com/fabric8-services/fabric8-wit/auth"
+	"github.com/fabric8-services/fabric8-wit/cache"
 	"github.com/fabric8-services/fabric8-wit/closeable"
 	"github.com/fabric8-services/fabric8-wit/configuration"
 	"github.com/fabric8-services/fabric8-wit/controller"
 	witmiddleware "github.com/fabric8-services/fabric8-wit/goamiddleware"
 	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/iteration"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
@@ -31,6 +34,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/remoteworkitem"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/templatemigration"
 	"github.com/fabric8-services/fabric8-wit/swagger"
 	"github.com/fabric8-services/fabric8-wit/token"
 	"github.com/goadesign/goa"
@@ -156,6 +160,25 @@ func main() {
 		}
 	}
 
+	// Keep the caches of work item types, work item link types and space
+	// templates in sync with the other instances of the service
+	cache.SetTTL(config.GetCacheTTL())
+	cacheListener := cache.NewListener(config.GetPostgresConfigString())
+	if err := cacheListener.Start(context.Background()); err != nil {
+		log.Panic(nil, map[string]interface{}{
+			"err": err,
+		}, "failed to start the cache invalidation listener")
+	}
+	defer cacheListener.Stop()
+
+	attachmentStorage, err := attachment.NewBlobStorage(config)
+	if err != nil {
+		log.Panic(nil, map[string]interface{}{
+			"err":     err,
+			"storage": config.GetAttachmentsStorage(),
+		}, "failed to create the attachment storage")
+	}
+
 	// Create service
 	service := goa.New("wit")
 
@@ -278,6 +301,30 @@ func main() {
 	workItemLabelCtrl := controller.NewWorkItemLabelsController(service, appDB, config)
 	app.MountWorkItemLabelsController(service, workItemLabelCtrl)
 
+	// Mount "attachments" controller
+	attachmentsCtrl := controller.NewAttachmentsController(service, appDB, attachmentStorage, config)
+	app.MountAttachmentsController(service, attachmentsCtrl)
+
+	// Mount "work item attachments" controller
+	workItemAttachmentsCtrl := controller.NewWorkItemAttachmentsController(service, appDB, attachmentStorage, config)
+	app.MountWorkItemAttachmentsController(service, workItemAttachmentsCtrl)
+
+	// Mount "comment attachments" controller
+	commentAttachmentsCtrl := controller.NewCommentAttachmentsController(service, appDB, attachmentStorage, config)
+	app.MountCommentAttachmentsController(service, commentAttachmentsCtrl)
+
+	// Mount "worklogs" controller
+	worklogsCtrl := controller.NewWorklogsController(service, appDB, config)
+	app.MountWorklogsController(service, worklogsCtrl)
+
+	// Mount "work item worklogs" controller
+	workItemWorklogsCtrl := controller.NewWorkItemWorklogsController(service, appDB)
+	app.MountWorkItemWorklogsController(service, workItemWorklogsCtrl)
+
+	// Mount "space worklogs" controller
+	spaceWorklogsCtrl := controller.NewSpaceWorklogsController(service, appDB)
+	app.MountSpaceWorklogsController(service, spaceWorklogsCtrl)
+
 	// Mount "work item events relationships" controller
 	workItemEventsCtrl := controller.NewEventsController(service, appDB, config)
 	app.MountWorkItemEventsController(service, workItemEventsCtrl)
@@ -334,7 +381,7 @@ func main() {
 	app.MountUsersController(service, usersCtrl)
 
 	// Mount "labels" controller
-	labelCtrl := controller.NewLabelController(service, appDB, config)
+	labelCtrl := controller.NewNotifyingLabelController(service, appDB, notificationChannel, config)
 	app.MountLabelController(service, labelCtrl)
 
 	// Mount "endpoints" controller
@@ -406,6 +453,64 @@ func main() {
 	workItemBoardsCtrl := controller.NewWorkItemBoardsController(service, appDB)
 	app.MountWorkItemBoardsController(service, workItemBoardsCtrl)
 
+	// Mount "space boards" controller with "list" and "create" actions
+	spaceWorkItemBoardsCtrl := controller.NewSpaceWorkItemBoardsController(service, appDB)
+	app.MountSpaceWorkItemBoardsController(service, spaceWorkItemBoardsCtrl)
+
+	// Mount "work item template" controller
+	workItemTemplateCtrl := controller.NewWorkItemTemplateController(service, appDB)
+	app.MountWorkItemTemplateController(service, workItemTemplateCtrl)
+
+	// Mount "dependency graph" controller
+	dependencyGraphCtrl := controller.NewDependencyGraphController(service, appDB)
+	app.MountDependencyGraphController(service, dependencyGraphCtrl)
+
+	// Mount "flow metrics" controller
+	flowMetricsCtrl := controller.NewFlowMetricsController(service, appDB)
+	app.MountFlowMetricsController(service, flowMetricsCtrl)
+
+	// Mount "burndown" controller
+	burndownCtrl := controller.NewBurndownController(service, appDB)
+	app.MountBurndownController(service, burndownCtrl)
+
+	// Mount "velocity" controller
+	velocityCtrl := controller.NewVelocityController(service, appDB)
+	app.MountVelocityController(service, velocityCtrl)
+
+	// Mount "iteration planning" controller
+	iterationPlanningCtrl := controller.NewIterationPlanningController(service, appDB)
+	app.MountIterationPlanningController(service, iterationPlanningCtrl)
+
+	// Mount "iteration schedule" controller
+	iterationScheduleCtrl := controller.NewIterationScheduleController(service, appDB)
+	app.MountIterationScheduleController(service, iterationScheduleCtrl)
+
+	// Job to generate the iterations of the iteration schedules
+	iterationScheduleJob := iteration.NewScheduleJob(db)
+	if err := iterationScheduleJob.Start(service.Context, config.GetIterationScheduleInterval()); err != nil {
+		log.Panic(nil, map[string]interface{}{
+			"err": err,
+		}, "failed to start the iteration schedule job")
+	}
+	defer iterationScheduleJob.Stop()
+
+	// Mount "space template migration" controller
+	spaceTemplateMigrationCtrl := controller.NewSpaceTemplateMigrationController(service, appDB)
+	app.MountSpaceTemplateMigrationController(service, spaceTemplateMigrationCtrl)
+
+	// Job to run the space template migrations
+	templateMigrationJob := templatemigration.NewJob(db, config.GetTemplateMigrationBatchSize())
+	if err := templateMigrationJob.Start(service.Context, config.GetTemplateMigrationInterval()); err != nil {
+		log.Panic(nil, map[string]interface{}{
+			"err": err,
+		}, "failed to start the space template migration job")
+	}
+	defer templateMigrationJob.Stop()
+
+	// Mount "work item link graph" controller
+	workItemLinkGraphCtrl := controller.NewWorkItemLinkGraphController(service, appDB)
+	app.MountWorkItemLinkGraphController(service, workItemLinkGraphCtrl)
+
 	// Mount "queries" controller
 	queriesCtrl := controller.NewQueryController(service, appDB, config)
 	app.MountQueryController(service, queriesCtrl)
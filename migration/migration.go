@@ -474,6 +474,57 @@ func GetMigrations() Migrations {
 	// Version 112
 	m = append(m, steps{ExecuteSQLFile("112-cascading-delete.sql")})
 
+	// Version 113
+	m = append(m, steps{ExecuteSQLFile("113-work-item-origins.sql")})
+
+	// Version 114
+	m = append(m, steps{ExecuteSQLFile("114-work-item-templates.sql")})
+
+	// Version 115
+	m = append(m, steps{ExecuteSQLFile("115-work-item-type-transitions.sql")})
+
+	// Version 116
+	m = append(m, steps{ExecuteSQLFile("116-work-item-link-attributes.sql")})
+
+	// Version 117
+	m = append(m, steps{ExecuteSQLFile("117-work-item-link-type-constraints.sql")})
+
+	// Version 118
+	m = append(m, steps{ExecuteSQLFile("118-space-boards.sql")})
+
+	// Version 119
+	m = append(m, steps{ExecuteSQLFile("119-iteration-capacity.sql")})
+
+	// Version 120
+	m = append(m, steps{ExecuteSQLFile("120-iteration-schedules.sql")})
+
+	// Version 121
+	m = append(m, steps{ExecuteSQLFile("121-space-template-migrations.sql")})
+
+	// Version 122
+	m = append(m, steps{ExecuteSQLFile("122-attachments.sql")})
+
+	// Version 123
+	m = append(m, steps{ExecuteSQLFile("123-comment-reactions-and-resolution.sql")})
+
+	// Version 124
+	m = append(m, steps{ExecuteSQLFile("124-worklogs.sql")})
+
+	// Version 125
+	m = append(m, steps{ExecuteSQLFile("125-label-hierarchy.sql")})
+
+	// Version 126
+	m = append(m, steps{ExecuteSQLFile("126-area-owners-and-default-labels.sql")})
+
+	// Version 127
+	m = append(m, steps{ExecuteSQLFile("127-space-template-creator-and-versions.sql")})
+
+	// Version 128
+	m = append(m, steps{ExecuteSQLFile("128-space-template-migration-attempts.sql")})
+
+	// Version 129
+	m = append(m, steps{ExecuteSQLFile("129-work-item-link-revision-attributes.sql")})
+
 	// Version N
 	//
 	// In order to add an upgrade, simply append an array of MigrationFunc to the
@@ -162,6 +162,23 @@ func TestMigrations(t *testing.T) {
 	t.Run("TestMigration110", testMigration110TrackerQueryID)
 	t.Run("TestMigration111", testMigration111WITinTrackerQuery)
 	t.Run("TestMigration112", testMigration112CascadingDelete)
+	t.Run("TestMigration113", testMigration113WorkItemOrigins)
+	t.Run("TestMigration114", testMigration114WorkItemTemplates)
+	t.Run("TestMigration115", testMigration115WorkItemTypeTransitions)
+	t.Run("TestMigration116", testMigration116WorkItemLinkAttributes)
+	t.Run("TestMigration117", testMigration117WorkItemLinkTypeConstraints)
+	t.Run("TestMigration118", testMigration118SpaceBoards)
+	t.Run("TestMigration119", testMigration119IterationCapacity)
+	t.Run("TestMigration120", testMigration120IterationSchedules)
+	t.Run("TestMigration121", testMigration121SpaceTemplateMigrations)
+	t.Run("TestMigration122", testMigration122Attachments)
+	t.Run("TestMigration123", testMigration123CommentReactionsAndResolution)
+	t.Run("TestMigration124", testMigration124Worklogs)
+	t.Run("TestMigration125", testMigration125LabelHierarchy)
+	t.Run("TestMigration126", testMigration126AreaOwnersAndDefaultLabels)
+	t.Run("TestMigration127", testMigration127SpaceTemplateCreatorAndVersions)
+	t.Run("TestMigration128", testMigration128SpaceTemplateMigrationAttempts)
+	t.Run("TestMigration129", testMigration129WorkItemLinkRevisionAttributes)
 
 	// Perform the migration
 	err = migration.Migrate(sqlDB, databaseName)
@@ -1421,6 +1438,124 @@ func testMigration112CascadingDelete(t *testing.T) {
 	require.False(t, dialect.HasForeignKey("work_item_revisions", "work_item_revisions_identity_fk"))
 }
 
+func testMigration113WorkItemOrigins(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:114], 114)
+	require.True(t, dialect.HasTable("work_item_origins"))
+	require.True(t, dialect.HasColumn("work_item_origins", "work_item_id"))
+	require.True(t, dialect.HasColumn("work_item_origins", "source_work_item_id"))
+	require.True(t, dialect.HasColumn("work_item_origins", "operation"))
+	require.True(t, dialect.HasIndex("work_item_origins", "work_item_origins_work_item_id_idx"))
+}
+
+func testMigration114WorkItemTemplates(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:115], 115)
+	require.True(t, dialect.HasTable("work_item_templates"))
+	require.True(t, dialect.HasColumn("work_item_templates", "fields"))
+	require.True(t, dialect.HasColumn("work_item_templates", "children"))
+	require.True(t, dialect.HasIndex("work_item_templates", "work_item_templates_name_space_id_unique_idx"))
+}
+
+func testMigration115WorkItemTypeTransitions(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:116], 116)
+	require.True(t, dialect.HasColumn("work_item_types", "transitions"))
+}
+
+func testMigration116WorkItemLinkAttributes(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:117], 117)
+	require.True(t, dialect.HasColumn("work_item_link_types", "fields"))
+	require.True(t, dialect.HasColumn("work_item_links", "attributes"))
+}
+
+func testMigration117WorkItemLinkTypeConstraints(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:118], 118)
+	require.True(t, dialect.HasColumn("work_item_link_types", "constraints"))
+}
+
+func testMigration118SpaceBoards(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:119], 119)
+	require.True(t, dialect.HasColumn("work_item_boards", "space_id"))
+	require.True(t, dialect.HasColumn("work_item_boards", "swimlane"))
+	require.True(t, dialect.HasIndex("work_item_boards", "work_item_boards_space_idx"))
+	require.True(t, dialect.HasIndex("work_item_boards", "work_item_board_name_space_template_id_unique"))
+	require.True(t, dialect.HasIndex("work_item_boards", "work_item_boards_name_space_id_unique_idx"))
+	require.True(t, dialect.HasColumn("work_item_board_columns", "wip_limit"))
+	require.True(t, dialect.HasColumn("work_item_board_columns", "wip_limit_mode"))
+	require.True(t, dialect.HasColumn("work_item_board_columns", "states"))
+}
+
+func testMigration119IterationCapacity(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:120], 120)
+	require.True(t, dialect.HasColumn("iterations", "capacity"))
+	require.True(t, dialect.HasColumn("iterations", "capacity_unit"))
+}
+
+func testMigration120IterationSchedules(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:121], 121)
+	require.True(t, gormDB.HasTable("iteration_schedules"))
+	require.True(t, dialect.HasIndex("iteration_schedules", "iteration_schedules_space_id_unique_idx"))
+}
+
+func testMigration121SpaceTemplateMigrations(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:122], 122)
+	require.True(t, gormDB.HasTable("space_template_migrations"))
+	require.True(t, dialect.HasIndex("space_template_migrations", "space_template_migrations_space_id_idx"))
+	require.True(t, dialect.HasIndex("space_template_migrations", "space_template_migrations_unfinished_idx"))
+}
+
+func testMigration122Attachments(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:123], 123)
+	require.True(t, gormDB.HasTable("attachments"))
+	require.True(t, dialect.HasIndex("attachments", "attachments_space_id_idx"))
+	require.True(t, dialect.HasIndex("attachments", "attachments_work_item_id_idx"))
+	require.True(t, dialect.HasIndex("attachments", "attachments_comment_id_idx"))
+}
+
+func testMigration123CommentReactionsAndResolution(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:124], 124)
+	require.True(t, dialect.HasColumn("comments", "resolved_at"))
+	require.True(t, dialect.HasColumn("comments", "resolved_by"))
+	require.True(t, gormDB.HasTable("comment_reactions"))
+	require.True(t, dialect.HasColumn("comment_revisions", "comment_reaction"))
+	require.True(t, dialect.HasIndex("comment_revisions", "comment_revisions_comment_parent_id_idx"))
+}
+
+func testMigration124Worklogs(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:125], 125)
+	require.True(t, gormDB.HasTable("worklogs"))
+	require.True(t, dialect.HasColumn("worklogs", "duration"))
+	require.True(t, dialect.HasIndex("worklogs", "worklogs_work_item_id_idx"))
+	require.True(t, dialect.HasIndex("worklogs", "worklogs_identity_id_started_at_idx"))
+}
+
+func testMigration125LabelHierarchy(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:126], 126)
+	require.True(t, dialect.HasColumn("labels", "parent_id"))
+	require.True(t, dialect.HasIndex("labels", "labels_parent_id_idx"))
+}
+
+func testMigration126AreaOwnersAndDefaultLabels(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:127], 127)
+	require.True(t, dialect.HasColumn("areas", "owners"))
+	require.True(t, dialect.HasColumn("areas", "default_labels"))
+}
+
+func testMigration127SpaceTemplateCreatorAndVersions(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:128], 128)
+	require.True(t, dialect.HasColumn("space_templates", "creator_id"))
+	require.True(t, gormDB.HasTable("space_template_versions"))
+	require.True(t, dialect.HasColumn("space_template_versions", "template"))
+}
+
+func testMigration128SpaceTemplateMigrationAttempts(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:129], 129)
+	require.True(t, dialect.HasColumn("space_template_migrations", "attempts"))
+}
+
+func testMigration129WorkItemLinkRevisionAttributes(t *testing.T) {
+	migrateToVersion(t, sqlDB, migrations[:130], 130)
+	require.True(t, dialect.HasColumn("work_item_link_revisions", "work_item_link_attributes"))
+}
+
 // runSQLscript loads the given filename from the packaged SQL test files and
 // executes it on the given database. Golang text/template module is used
 // to handle all the optional arguments passed to the sql test files
@@ -0,0 +1,17 @@
+-- Keep track of the work items a work item was cloned from or of the space and
+-- number a work item had before it was moved to another space.
+CREATE TABLE work_item_origins (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid primary key DEFAULT uuid_generate_v4() NOT NULL,
+    work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    source_work_item_id uuid NOT NULL,
+    source_space_id uuid NOT NULL,
+    source_number integer NOT NULL,
+    operation text NOT NULL CHECK (operation IN ('clone', 'move')),
+    creator_id uuid NOT NULL
+);
+
+CREATE INDEX work_item_origins_work_item_id_idx ON work_item_origins (work_item_id);
+CREATE INDEX work_item_origins_source_work_item_id_idx ON work_item_origins (source_work_item_id);
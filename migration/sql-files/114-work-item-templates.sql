@@ -0,0 +1,18 @@
+-- Work item templates describe a work item with preset field values and an
+-- optional tree of children that can be created at once.
+CREATE TABLE work_item_templates (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid primary key DEFAULT uuid_generate_v4() NOT NULL,
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    name text NOT NULL CHECK (trim(name) <> ''),
+    description text,
+    work_item_type_id uuid NOT NULL REFERENCES work_item_types(id) ON DELETE CASCADE,
+    fields jsonb,
+    children jsonb,
+    creator_id uuid NOT NULL,
+    version integer DEFAULT 0 NOT NULL
+);
+
+CREATE UNIQUE INDEX work_item_templates_name_space_id_unique_idx ON work_item_templates (name, space_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- Allowed changes of the system.state field of work items of a type. NULL
+-- means that all transitions are allowed.
+ALTER TABLE work_item_types ADD COLUMN transitions jsonb;
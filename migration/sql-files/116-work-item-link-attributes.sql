@@ -0,0 +1,4 @@
+-- Attributes that links of a type can carry and the values of those
+-- attributes on the links themselves.
+ALTER TABLE work_item_link_types ADD COLUMN fields jsonb;
+ALTER TABLE work_item_links ADD COLUMN attributes jsonb;
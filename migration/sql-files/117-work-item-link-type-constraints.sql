@@ -0,0 +1,3 @@
+-- Restrictions on the types of work items that can be linked with a link type
+-- and on the number of links starting at the same work item.
+ALTER TABLE work_item_link_types ADD COLUMN constraints jsonb;
@@ -0,0 +1,15 @@
+-- Boards customized for a single space, their swimlanes and the WIP limits and
+-- states of their columns.
+ALTER TABLE work_item_boards ADD COLUMN space_id uuid REFERENCES spaces(id) ON DELETE CASCADE;
+ALTER TABLE work_item_boards ADD COLUMN swimlane text NOT NULL DEFAULT '';
+CREATE INDEX work_item_boards_space_idx ON work_item_boards (space_id) WHERE deleted_at IS NULL;
+
+-- Board names only need to be unique among the boards of a space template or
+-- among the boards of a space.
+ALTER TABLE work_item_boards DROP CONSTRAINT work_item_board_name_space_template_id_unique;
+CREATE UNIQUE INDEX work_item_board_name_space_template_id_unique ON work_item_boards (space_template_id, name) WHERE space_id IS NULL;
+CREATE UNIQUE INDEX work_item_boards_name_space_id_unique_idx ON work_item_boards (space_id, name) WHERE space_id IS NOT NULL AND deleted_at IS NULL;
+
+ALTER TABLE work_item_board_columns ADD COLUMN wip_limit integer CHECK (wip_limit > 0);
+ALTER TABLE work_item_board_columns ADD COLUMN wip_limit_mode text NOT NULL DEFAULT '';
+ALTER TABLE work_item_board_columns ADD COLUMN states jsonb;
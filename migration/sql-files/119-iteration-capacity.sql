@@ -0,0 +1,4 @@
+-- The capacity of the assignees of an iteration (a JSON object mapping
+-- identity IDs to numbers) and the unit in which it is measured.
+ALTER TABLE iterations ADD COLUMN capacity jsonb;
+ALTER TABLE iterations ADD COLUMN capacity_unit text NOT NULL DEFAULT 'points' CHECK (capacity_unit IN ('points', 'days'));
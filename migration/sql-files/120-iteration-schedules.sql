@@ -0,0 +1,19 @@
+-- Schedules from which the iterations of a space are generated in a fixed
+-- cadence.
+CREATE TABLE iteration_schedules (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid primary key DEFAULT uuid_generate_v4() NOT NULL,
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    parent_id uuid REFERENCES iterations(id) ON DELETE CASCADE,
+    start_at timestamp with time zone NOT NULL,
+    cadence integer NOT NULL CHECK (cadence > 0),
+    length integer NOT NULL CHECK (length > 0),
+    name_pattern text NOT NULL CHECK (trim(name_pattern) <> ''),
+    counter integer NOT NULL DEFAULT 1,
+    ahead integer NOT NULL CHECK (ahead > 0),
+    version integer DEFAULT 0 NOT NULL
+);
+
+CREATE UNIQUE INDEX iteration_schedules_space_id_unique_idx ON iteration_schedules (space_id) WHERE deleted_at IS NULL;
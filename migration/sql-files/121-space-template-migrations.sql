@@ -0,0 +1,26 @@
+-- Migrations of spaces from one space template to another. Work items, links
+-- and board columns are converted in batches by a background job.
+CREATE TABLE space_template_migrations (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid primary key DEFAULT uuid_generate_v4() NOT NULL,
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    source_template_id uuid NOT NULL REFERENCES space_templates(id) ON DELETE CASCADE,
+    target_template_id uuid NOT NULL REFERENCES space_templates(id) ON DELETE CASCADE,
+    type_mapping jsonb,
+    link_type_mapping jsonb,
+    dry_run boolean NOT NULL DEFAULT FALSE,
+    state text NOT NULL DEFAULT 'pending' CHECK (state IN ('pending', 'running', 'completed', 'failed')),
+    migrated_work_items integer NOT NULL DEFAULT 0,
+    report jsonb,
+    error text,
+    creator_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    version integer DEFAULT 0 NOT NULL
+);
+
+CREATE INDEX space_template_migrations_space_id_idx ON space_template_migrations (space_id);
+
+-- a space can only be migrated by one migration at a time
+CREATE UNIQUE INDEX space_template_migrations_unfinished_idx ON space_template_migrations (space_id)
+    WHERE state IN ('pending', 'running') AND deleted_at IS NULL;
@@ -0,0 +1,20 @@
+-- Metadata of the files attached to work items and comments. The content of
+-- the files lives in a blob storage under the given storage key.
+CREATE TABLE attachments (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid primary key DEFAULT uuid_generate_v4() NOT NULL,
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    comment_id uuid REFERENCES comments(id) ON DELETE CASCADE,
+    file_name text NOT NULL CHECK (trim(file_name) <> ''),
+    content_type text NOT NULL,
+    size bigint NOT NULL CHECK (size >= 0),
+    uploader_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    storage_key text NOT NULL UNIQUE
+);
+
+CREATE INDEX attachments_space_id_idx ON attachments (space_id) WHERE deleted_at IS NULL;
+CREATE INDEX attachments_work_item_id_idx ON attachments (work_item_id);
+CREATE INDEX attachments_comment_id_idx ON attachments (comment_id);
@@ -0,0 +1,20 @@
+-- Top-level comments start a thread that can be resolved.
+ALTER TABLE comments ADD COLUMN resolved_at timestamp with time zone;
+ALTER TABLE comments ADD COLUMN resolved_by uuid REFERENCES identities(id) ON DELETE SET NULL;
+
+-- Emoji reactions of identities on comments. An identity can react with any
+-- number of different emojis but only once with the same emoji.
+CREATE TABLE comment_reactions (
+    created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    comment_id uuid NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    identity_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    emoji text NOT NULL CHECK (trim(emoji) <> ''),
+    PRIMARY KEY (comment_id, identity_id, emoji)
+);
+
+-- the emoji of a reaction that was added or removed
+ALTER TABLE comment_revisions ADD COLUMN comment_reaction text;
+
+-- reactions and resolutions of the comments of a work item are listed in the
+-- work item's event stream
+CREATE INDEX comment_revisions_comment_parent_id_idx ON comment_revisions (comment_parent_id, revision_type);
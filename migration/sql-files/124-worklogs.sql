@@ -0,0 +1,15 @@
+-- Time spent by identities on work items. The duration is stored in seconds.
+CREATE TABLE worklogs (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid primary key DEFAULT uuid_generate_v4() NOT NULL,
+    work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    identity_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    started_at timestamp with time zone NOT NULL,
+    duration bigint NOT NULL CHECK (duration > 0),
+    comment text
+);
+
+CREATE INDEX worklogs_work_item_id_idx ON worklogs (work_item_id) WHERE deleted_at IS NULL;
+CREATE INDEX worklogs_identity_id_started_at_idx ON worklogs (identity_id, started_at) WHERE deleted_at IS NULL;
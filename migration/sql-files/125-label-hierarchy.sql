@@ -0,0 +1,4 @@
+-- Labels can optionally be grouped under a parent label of the same space.
+ALTER TABLE labels ADD COLUMN parent_id uuid REFERENCES labels(id) ON DELETE SET NULL;
+ALTER TABLE labels ADD CONSTRAINT labels_parent_id_not_self CHECK (parent_id <> id);
+CREATE INDEX labels_parent_id_idx ON labels (parent_id);
@@ -0,0 +1,5 @@
+-- The owners of an area are assigned to unassigned work items that are created
+-- in or moved to the area and the default labels of an area are attached to
+-- them. Both columns hold a JSON array of IDs.
+ALTER TABLE areas ADD COLUMN owners jsonb;
+ALTER TABLE areas ADD COLUMN default_labels jsonb;
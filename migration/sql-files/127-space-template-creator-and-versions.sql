@@ -0,0 +1,14 @@
+-- Only the identity that created a custom space template is allowed to update
+-- it. Space templates that are imported on startup have no creator.
+ALTER TABLE space_templates ADD COLUMN creator_id uuid REFERENCES identities(id) ON DELETE SET NULL;
+
+-- Every version of a custom space template keeps the definition it was created
+-- or updated from.
+CREATE TABLE space_template_versions (
+    space_template_id uuid NOT NULL REFERENCES space_templates(id) ON DELETE CASCADE,
+    version integer NOT NULL,
+    template text NOT NULL,
+    modifier_id uuid REFERENCES identities(id) ON DELETE SET NULL,
+    created_at timestamp with time zone DEFAULT now(),
+    PRIMARY KEY (space_template_id, version)
+);
@@ -0,0 +1,3 @@
+-- Number of failed attempts of a space template migration in a row. The job
+-- gives up on a migration after too many of them.
+ALTER TABLE space_template_migrations ADD COLUMN attempts integer NOT NULL DEFAULT 0;
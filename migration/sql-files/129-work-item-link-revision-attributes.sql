@@ -0,0 +1,3 @@
+-- Attribute values of a work item link as of a revision, since the attributes
+-- of a link can be updated.
+ALTER TABLE work_item_link_revisions ADD COLUMN work_item_link_attributes jsonb;
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"github.com/fabric8-services/fabric8-wit/cache"
 	"github.com/jinzhu/gorm"
 	errs "github.com/pkg/errors"
 )
@@ -11,6 +12,7 @@ func Transactional(db *gorm.DB, todo func(tx *gorm.DB) error) error {
 	if tx.Error != nil {
 		return tx.Error
 	}
+	defer cache.TransactionEnded(tx)
 	if err := todo(tx); err != nil {
 		tx.Rollback()
 		return errs.WithStack(err)
@@ -52,6 +52,50 @@ func TestParseMap(t *testing.T) {
 		assert.Equal(t, expectedQuery, actualQuery)
 	})
 
+	t.Run("blocked", func(t *testing.T) {
+		t.Parallel()
+		// given
+		fm := map[string]interface{}{}
+		err := json.Unmarshal([]byte(`{"blocked": true}`), &fm)
+		require.NoError(t, err)
+		// when
+		actualQuery := Query{}
+		parseMap(fm, &actualQuery)
+		// then
+		blocked := "true"
+		expectedQuery := Query{Name: "blocked", Value: &blocked}
+		assert.Equal(t, expectedQuery, actualQuery)
+		actualExpr, err := actualQuery.generateExpression()
+		require.NoError(t, err)
+		expectEqualExpr(t, c.Equals(c.Field(workitem.SystemBlocked), c.Literal(true)), actualExpr)
+	})
+
+	t.Run(DescendantOf, func(t *testing.T) {
+		t.Parallel()
+		// given
+		epicID := "c20882bd-3a70-48a4-9784-3d6735992a43"
+		input := fmt.Sprintf(`{"$AND": [{"%s": "%s", "%s": 2}, {"state": "new"}]}`, DescendantOf, epicID, Depth)
+		fm := map[string]interface{}{}
+		err := json.Unmarshal([]byte(input), &fm)
+		require.NoError(t, err)
+		// when
+		actualQuery := Query{}
+		parseMap(fm, &actualQuery)
+		// then
+		state := "new"
+		expectedQuery := Query{Name: AND, Children: []Query{
+			{Name: DescendantOf, Value: &epicID, Depth: 2},
+			{Name: "state", Value: &state}},
+		}
+		assert.Equal(t, expectedQuery, actualQuery)
+		actualExpr, err := actualQuery.generateExpression()
+		require.NoError(t, err)
+		expectEqualExpr(t, c.And(
+			c.Descendant(c.Field("ID"), c.Literal(epicID), 2),
+			c.Equals(c.Field(workitem.SystemState), c.Literal(state)),
+		), actualExpr)
+	})
+
 	t.Run("$SUBSTR within $AND", func(t *testing.T) {
 		t.Parallel()
 		// given
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -40,6 +41,13 @@ const (
 	SUBSTR = "$SUBSTR"
 	OPTS   = "$OPTS"
 
+	// DescendantOf matches all work items below the given work item in a tree
+	// topology, optionally limited by a "depth":
+	//
+	//	{"descendant-of": "<work item ID>", "depth": 2}
+	DescendantOf = "descendant-of"
+	Depth        = "depth"
+
 	// This is the replacement for $WITGROUP.
 	TypeGroupName = "typegroup.name"
 
@@ -290,8 +298,16 @@ func parseMap(queryMap map[string]interface{}, q *Query) {
 			} else if key == "child" {
 				q.Child = s
 				childSet = true
+			} else if key == "blocked" {
+				q.Name = key
+				v := strconv.FormatBool(s)
+				q.Value = &v
 			}
 
+		case float64:
+			if key == Depth {
+				q.Depth = int(concreteVal)
+			}
 		case nil:
 			q.Name = key
 			q.Value = nil
@@ -394,6 +410,9 @@ type Query struct {
 	Options *QueryOptions
 	// Consider child iteration/area
 	Child bool
+	// Depth limits the number of tree levels searched by a "descendant-of"
+	// query; 0 means all levels.
+	Depth int
 }
 
 func isOperator(str string) bool {
@@ -413,12 +432,16 @@ var searchKeyMap = map[string]string{
 	"workitemtype": "Type", // same as 'type' - added for compatibility. (Ref. #1564)
 	"space":        "SpaceID",
 	"number":       "Number",
+	"blocked":      workitem.SystemBlocked,
+	DescendantOf:   "ID",
 }
 
 func (q Query) determineLiteralType(key string, val string) criteria.Expression {
 	switch key {
 	case workitem.SystemAssignees, workitem.SystemLabels, workitem.SystemBoardcolumns, workitem.SystemBoard:
 		return criteria.Literal([]string{val})
+	case workitem.SystemBlocked:
+		return criteria.Literal(val == "true")
 	default:
 		return criteria.Literal(val)
 	}
@@ -444,7 +467,15 @@ func (q Query) generateExpression() (criteria.Expression, error) {
 			return nil, errors.NewBadParameterError("key not found", q.Name)
 		}
 		left := criteria.Field(key)
-		if q.Value != nil {
+		if q.Name == DescendantOf {
+			if q.Value == nil {
+				return nil, errors.NewBadParameterError(DescendantOf, nil).Expected("the ID of a work item")
+			}
+			if q.Negate {
+				return nil, errors.NewBadParameterError("negate for descendant-of not supported", q.Name)
+			}
+			myexpr = append(myexpr, criteria.Descendant(left, criteria.Literal(*q.Value), q.Depth))
+		} else if q.Value != nil {
 			right := q.determineLiteralType(key, *q.Value)
 			if q.Negate {
 				myexpr = append(myexpr, criteria.Not(left, right))
@@ -489,7 +520,15 @@ func (q Query) generateExpression() (criteria.Expression, error) {
 				return nil, errors.NewBadParameterError("key not found", child.Name)
 			}
 			left := criteria.Field(key)
-			if child.Value != nil {
+			if child.Name == DescendantOf {
+				if child.Value == nil {
+					return nil, errors.NewBadParameterError(DescendantOf, nil).Expected("the ID of a work item")
+				}
+				if child.Negate {
+					return nil, errors.NewBadParameterError("negate for descendant-of not supported", child.Name)
+				}
+				myexpr = append(myexpr, criteria.Descendant(left, criteria.Literal(*child.Value), child.Depth))
+			} else if child.Value != nil {
 				right := q.determineLiteralType(key, *child.Value)
 				if child.Negate {
 					myexpr = append(myexpr, criteria.Not(left, right))
@@ -701,7 +740,11 @@ func (r *GormSearchRepository) SearchFullText(ctx context.Context, rawSearchStri
 }
 
 func (r *GormSearchRepository) listItemsFromDB(ctx context.Context, criteria criteria.Expression, parentExists *bool, start *int, limit *int) ([]workitem.WorkItemStorage, int, error) {
-	where, parameters, joins, compileError := workitem.Compile(criteria)
+	computed, err := r.witr.ListComputedFields(ctx, workitem.FieldNames(criteria)...)
+	if err != nil {
+		return nil, 0, errs.Wrap(err, "failed to load computed fields")
+	}
+	where, parameters, joins, compileError := workitem.CompileWithComputedFields(criteria, computed)
 	if compileError != nil {
 		log.Error(ctx, map[string]interface{}{
 			"err":        compileError,
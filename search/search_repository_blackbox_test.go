@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -496,6 +497,94 @@ func (s *searchRepositoryBlackboxTest) TestFilterByParent() {
 	})
 }
 
+func (s *searchRepositoryBlackboxTest) TestFilterByDescendantOf() {
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItems(5, tf.SetWorkItemTitles("epic", "feature", "story1", "story2", "other")),
+		tf.WorkItemLinksCustom(3,
+			tf.BuildLinks(tf.L("epic", "feature"), tf.L("feature", "story1"), tf.L("feature", "story2")),
+			func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinks[idx].LinkTypeID = link.SystemWorkItemLinkTypeParentChildID
+				return nil
+			}),
+	)
+	titles := func(res []workitem.WorkItem) []string {
+		titles := make([]string, len(res))
+		for i, wi := range res {
+			titles[i] = wi.Fields[workitem.SystemTitle].(string)
+		}
+		sort.Strings(titles)
+		return titles
+	}
+	s.T().Run("all levels", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"descendant-of": "%s"}`, fxt.WorkItemByTitle("epic").ID)
+		res, count, _, _, err := s.searchRepo.Filter(context.Background(), filter, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+		assert.Equal(t, []string{"feature", "story1", "story2"}, titles(res))
+	})
+	s.T().Run("limited depth", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"descendant-of": "%s", "depth": 1}`, fxt.WorkItemByTitle("epic").ID)
+		res, count, _, _, err := s.searchRepo.Filter(context.Background(), filter, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		assert.Equal(t, []string{"feature"}, titles(res))
+	})
+	s.T().Run("combined with other conditions", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"$AND": [{"descendant-of": "%s"}, {"title": "story2"}]}`, fxt.WorkItemByTitle("epic").ID)
+		res, count, _, _, err := s.searchRepo.Filter(context.Background(), filter, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		assert.Equal(t, []string{"story2"}, titles(res))
+	})
+	s.T().Run("leaf has no descendants", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"descendant-of": "%s"}`, fxt.WorkItemByTitle("story1").ID)
+		_, count, _, _, err := s.searchRepo.Filter(context.Background(), filter, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+	s.T().Run("invalid ID", func(t *testing.T) {
+		_, _, _, _, err := s.searchRepo.Filter(context.Background(), `{"descendant-of": "foo"}`, nil, nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func (s *searchRepositoryBlackboxTest) TestFilterBlocked() {
+	// A blocks B blocks C; B is closed but C is still (transitively) blocked by
+	// A. D is not part of any dependency.
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyDependency)),
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 1 {
+				fxt.WorkItems[idx].Fields[workitem.SystemState] = workitem.SystemStateClosed
+			}
+			return nil
+		}),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.LinkChain("A", "B", "C")...)),
+	)
+	titles := func(res []workitem.WorkItem) []string {
+		titles := make([]string, len(res))
+		for i, wi := range res {
+			titles[i] = wi.Fields[workitem.SystemTitle].(string)
+		}
+		sort.Strings(titles)
+		return titles
+	}
+	s.T().Run("blocked", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"$AND": [{"space": "%s"}, {"blocked": true}]}`, fxt.Spaces[0].ID)
+		res, count, _, _, err := s.searchRepo.Filter(context.Background(), filter, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		assert.Equal(t, []string{"B", "C"}, titles(res))
+	})
+	s.T().Run("not blocked", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"$AND": [{"space": "%s"}, {"blocked": false}]}`, fxt.Spaces[0].ID)
+		res, count, _, _, err := s.searchRepo.Filter(context.Background(), filter, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		assert.Equal(t, []string{"A", "D"}, titles(res))
+	})
+}
+
 func (s *searchRepositoryBlackboxTest) TestFilterBoardID() {
 	s.T().Run("board", func(t *testing.T) {
 		fxt := tf.NewTestFixture(t, s.DB,
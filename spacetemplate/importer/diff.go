@@ -0,0 +1,288 @@
+package importer
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ChangeKind tells how an attribute or artifact differs between two space
+// templates.
+type ChangeKind string
+
+// The kinds of changes found by Diff
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change describes the difference of a single attribute (e.g. "name" or
+// "fields.system.title") between two space templates.
+type Change struct {
+	Attribute string     `json:"attribute"`
+	Kind      ChangeKind `json:"kind"`
+}
+
+// ArtifactChange lists the changes of an artifact (e.g. a work item type) that
+// exists in both space templates.
+type ArtifactChange struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Changes []Change  `json:"changes"`
+}
+
+// ArtifactDiff lists the differences of one kind of artifacts between two
+// space templates. Added and removed artifacts are referenced by name.
+type ArtifactDiff struct {
+	Added   []string         `json:"added,omitempty"`
+	Removed []string         `json:"removed,omitempty"`
+	Changed []ArtifactChange `json:"changed,omitempty"`
+}
+
+// Empty returns true if there are no differences.
+func (d ArtifactDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// TemplateDiff lists all differences between two space templates.
+type TemplateDiff struct {
+	Template           []Change     `json:"template,omitempty"`
+	WorkItemTypes      ArtifactDiff `json:"work_item_types"`
+	WorkItemLinkTypes  ArtifactDiff `json:"work_item_link_types"`
+	WorkItemTypeGroups ArtifactDiff `json:"work_item_type_groups"`
+	WorkItemBoards     ArtifactDiff `json:"work_item_boards"`
+}
+
+// Empty returns true if there are no differences.
+func (d TemplateDiff) Empty() bool {
+	return len(d.Template) == 0 &&
+		d.WorkItemTypes.Empty() &&
+		d.WorkItemLinkTypes.Empty() &&
+		d.WorkItemTypeGroups.Empty() &&
+		d.WorkItemBoards.Empty()
+}
+
+// Diff returns the differences between the old and the new space template.
+// Both templates are expected in the same shape, i.e. work item types only
+// contain the fields they don't inherit (see Export). Artifacts are matched by
+// ID and, if that fails, by name so that two different templates can be
+// compared as well.
+func Diff(old, new ImportHelper) TemplateDiff {
+	res := TemplateDiff{}
+	res.Template = diffAttributes(map[string][2]interface{}{
+		"name":          {old.Template.Name, new.Template.Name},
+		"description":   {old.Template.Description, new.Template.Description},
+		"can_construct": {old.Template.CanConstruct, new.Template.CanConstruct},
+	})
+
+	oldWITs, newWITs := []artifact{}, []artifact{}
+	for _, wit := range old.WITs {
+		oldWITs = append(oldWITs, artifact{wit.ID, wit.Name, wit})
+	}
+	for _, wit := range new.WITs {
+		newWITs = append(newWITs, artifact{wit.ID, wit.Name, wit})
+	}
+	res.WorkItemTypes = diffArtifacts(oldWITs, newWITs, func(o, n interface{}) []Change {
+		return diffWITs(*o.(*workitem.WorkItemType), *n.(*workitem.WorkItemType))
+	})
+
+	oldWILTs, newWILTs := []artifact{}, []artifact{}
+	for _, wilt := range old.WILTs {
+		oldWILTs = append(oldWILTs, artifact{wilt.ID, wilt.Name, wilt})
+	}
+	for _, wilt := range new.WILTs {
+		newWILTs = append(newWILTs, artifact{wilt.ID, wilt.Name, wilt})
+	}
+	res.WorkItemLinkTypes = diffArtifacts(oldWILTs, newWILTs, func(o, n interface{}) []Change {
+		return diffWILTs(*o.(*link.WorkItemLinkType), *n.(*link.WorkItemLinkType))
+	})
+
+	oldWITGs, newWITGs := []artifact{}, []artifact{}
+	for _, witg := range old.WITGs {
+		oldWITGs = append(oldWITGs, artifact{witg.ID, witg.Name, witg})
+	}
+	for _, witg := range new.WITGs {
+		newWITGs = append(newWITGs, artifact{witg.ID, witg.Name, witg})
+	}
+	res.WorkItemTypeGroups = diffArtifacts(oldWITGs, newWITGs, func(o, n interface{}) []Change {
+		oldGroup, newGroup := o.(*workitem.WorkItemTypeGroup), n.(*workitem.WorkItemTypeGroup)
+		return diffAttributes(map[string][2]interface{}{
+			"name":        {oldGroup.Name, newGroup.Name},
+			"description": {oldGroup.Description, newGroup.Description},
+			"bucket":      {oldGroup.Bucket, newGroup.Bucket},
+			"icon":        {oldGroup.Icon, newGroup.Icon},
+			"type_list":   {oldGroup.TypeList, newGroup.TypeList},
+		})
+	})
+
+	oldWIBs, newWIBs := []artifact{}, []artifact{}
+	for _, wib := range old.WIBs {
+		oldWIBs = append(oldWIBs, artifact{wib.ID, wib.Name, wib})
+	}
+	for _, wib := range new.WIBs {
+		newWIBs = append(newWIBs, artifact{wib.ID, wib.Name, wib})
+	}
+	res.WorkItemBoards = diffArtifacts(oldWIBs, newWIBs, func(o, n interface{}) []Change {
+		return diffBoards(*o.(*workitem.Board), *n.(*workitem.Board))
+	})
+	return res
+}
+
+// artifact is a generic view on the artifacts of a space template.
+type artifact struct {
+	ID    uuid.UUID
+	Name  string
+	Value interface{}
+}
+
+// diffArtifacts matches the old and new artifacts by ID or name and compares
+// the matching ones with the given function.
+func diffArtifacts(old, new []artifact, compare func(o, n interface{}) []Change) ArtifactDiff {
+	res := ArtifactDiff{}
+	matched := make([]bool, len(new))
+	find := func(o artifact) int {
+		for i, n := range new {
+			if !matched[i] && o.ID != uuid.Nil && uuid.Equal(o.ID, n.ID) {
+				return i
+			}
+		}
+		for i, n := range new {
+			if !matched[i] && o.Name == n.Name {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, o := range old {
+		i := find(o)
+		if i < 0 {
+			res.Removed = append(res.Removed, o.Name)
+			continue
+		}
+		matched[i] = true
+		if changes := compare(o.Value, new[i].Value); len(changes) > 0 {
+			res.Changed = append(res.Changed, ArtifactChange{ID: new[i].ID, Name: new[i].Name, Changes: changes})
+		}
+	}
+	for i, n := range new {
+		if !matched[i] {
+			res.Added = append(res.Added, n.Name)
+		}
+	}
+	return res
+}
+
+// diffAttributes returns a change for every attribute whose old and new
+// values (the first and second element) differ. The changes are sorted by
+// attribute.
+func diffAttributes(values map[string][2]interface{}) []Change {
+	res := []Change{}
+	for attr, v := range values {
+		if !equalValues(v[0], v[1]) {
+			res = append(res, Change{Attribute: attr, Kind: ChangeChanged})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Attribute < res[j].Attribute })
+	return res
+}
+
+// equalValues returns true if both values are deeply equal. Empty and nil
+// slices or maps are treated as equal.
+func equalValues(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() && (va.Kind() == reflect.Slice || va.Kind() == reflect.Map) && va.Len() == 0 && vb.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func diffWITs(old, new workitem.WorkItemType) []Change {
+	res := diffAttributes(map[string][2]interface{}{
+		"name":          {old.Name, new.Name},
+		"description":   {old.Description, new.Description},
+		"icon":          {old.Icon, new.Icon},
+		"can_construct": {old.CanConstruct, new.CanConstruct},
+		"extends":       {old.Extends, new.Extends},
+		"child_types":   {old.ChildTypeIDs, new.ChildTypeIDs},
+	})
+	if !old.Transitions.Equal(new.Transitions) {
+		res = append(res, Change{Attribute: "transitions", Kind: ChangeChanged})
+	}
+	fields := []Change{}
+	for name, oldField := range old.Fields {
+		newField, ok := new.Fields[name]
+		if !ok {
+			fields = append(fields, Change{Attribute: "fields." + name, Kind: ChangeRemoved})
+		} else if !oldField.Equal(newField) {
+			fields = append(fields, Change{Attribute: "fields." + name, Kind: ChangeChanged})
+		}
+	}
+	for name := range new.Fields {
+		if _, ok := old.Fields[name]; !ok {
+			fields = append(fields, Change{Attribute: "fields." + name, Kind: ChangeAdded})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Attribute < fields[j].Attribute })
+	return append(res, fields...)
+}
+
+func diffWILTs(old, new link.WorkItemLinkType) []Change {
+	return diffAttributes(map[string][2]interface{}{
+		"name":                {old.Name, new.Name},
+		"description":         {old.Description, new.Description},
+		"forward_name":        {old.ForwardName, new.ForwardName},
+		"forward_description": {old.ForwardDescription, new.ForwardDescription},
+		"reverse_name":        {old.ReverseName, new.ReverseName},
+		"reverse_description": {old.ReverseDescription, new.ReverseDescription},
+		"topology":            {old.Topology, new.Topology},
+		"fields":              {old.Fields, new.Fields},
+		"constraints":         {old.Constraints, new.Constraints},
+	})
+}
+
+func diffBoards(old, new workitem.Board) []Change {
+	res := diffAttributes(map[string][2]interface{}{
+		"name":         {old.Name, new.Name},
+		"description":  {old.Description, new.Description},
+		"context":      {old.Context, new.Context},
+		"context_type": {old.ContextType, new.ContextType},
+		"swimlane":     {old.Swimlane, new.Swimlane},
+	})
+	// columns are compared by name
+	columns := []Change{}
+	newColumns := map[string]workitem.BoardColumn{}
+	for _, c := range new.Columns {
+		newColumns[c.Name] = c
+	}
+	oldColumns := map[string]struct{}{}
+	for _, oldColumn := range old.Columns {
+		oldColumns[oldColumn.Name] = struct{}{}
+		newColumn, ok := newColumns[oldColumn.Name]
+		if !ok {
+			columns = append(columns, Change{Attribute: "columns." + oldColumn.Name, Kind: ChangeRemoved})
+			continue
+		}
+		changed := diffAttributes(map[string][2]interface{}{
+			"order":               {oldColumn.Order, newColumn.Order},
+			"trans_rule_key":      {oldColumn.TransRuleKey, newColumn.TransRuleKey},
+			"trans_rule_argument": {oldColumn.TransRuleArgument, newColumn.TransRuleArgument},
+			"wip_limit":           {oldColumn.WIPLimit, newColumn.WIPLimit},
+			"wip_limit_mode":      {oldColumn.WIPLimitMode, newColumn.WIPLimitMode},
+			"states":              {oldColumn.States, newColumn.States},
+		})
+		if len(changed) > 0 {
+			columns = append(columns, Change{Attribute: "columns." + oldColumn.Name, Kind: ChangeChanged})
+		}
+	}
+	for _, c := range new.Columns {
+		if _, ok := oldColumns[c.Name]; !ok {
+			columns = append(columns, Change{Attribute: "columns." + c.Name, Kind: ChangeAdded})
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Attribute < columns[j].Attribute })
+	return append(res, columns...)
+}
@@ -0,0 +1,92 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/importer"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	spaceTemplateID := uuid.NewV4()
+	witID := uuid.NewV4()
+	wiltID := uuid.NewV4()
+	witgID := uuid.NewV4()
+	wibID := uuid.NewV4()
+	newTemplate := func() importer.ImportHelper {
+		return getValidTestTemplateParsed(t, spaceTemplateID, witID, wiltID, witgID, wibID)
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		diff := importer.Diff(newTemplate(), newTemplate())
+		assert.True(t, diff.Empty(), "%+v", diff)
+	})
+
+	t.Run("template attributes", func(t *testing.T) {
+		templ := newTemplate()
+		templ.Template.Name = "another name"
+		diff := importer.Diff(newTemplate(), templ)
+		assert.Equal(t, []importer.Change{{Attribute: "name", Kind: importer.ChangeChanged}}, diff.Template)
+		assert.True(t, diff.WorkItemTypes.Empty())
+	})
+
+	t.Run("work item type fields", func(t *testing.T) {
+		templ := newTemplate()
+		wit := templ.WITs[0]
+		delete(wit.Fields, "priority")
+		title := wit.Fields["title"]
+		title.Type = workitem.SimpleType{Kind: workitem.KindMarkup}
+		wit.Fields["title"] = title
+		wit.Fields["effort"] = workitem.FieldDefinition{Label: "Effort", Type: workitem.SimpleType{Kind: workitem.KindFloat}}
+		wit.Icon = "fa fa-other"
+		// when
+		diff := importer.Diff(newTemplate(), templ)
+		// then
+		require.Len(t, diff.WorkItemTypes.Changed, 1)
+		assert.Equal(t, witID, diff.WorkItemTypes.Changed[0].ID)
+		assert.Equal(t, []importer.Change{
+			{Attribute: "icon", Kind: importer.ChangeChanged},
+			{Attribute: "fields.effort", Kind: importer.ChangeAdded},
+			{Attribute: "fields.priority", Kind: importer.ChangeRemoved},
+			{Attribute: "fields.title", Kind: importer.ChangeChanged},
+		}, diff.WorkItemTypes.Changed[0].Changes)
+	})
+
+	t.Run("added and removed artifacts", func(t *testing.T) {
+		templ := newTemplate()
+		// a work item type with a new ID and name is considered a different one
+		templ.WITs[0].ID = uuid.NewV4()
+		templ.WITs[0].Name = "Story"
+		// a link type with a new ID but the same name is matched by name
+		templ.WILTs[0].ID = uuid.NewV4()
+		templ.WIBs = nil
+		// when
+		diff := importer.Diff(newTemplate(), templ)
+		// then
+		assert.Equal(t, []string{"Story"}, diff.WorkItemTypes.Added)
+		assert.Equal(t, []string{"Bug"}, diff.WorkItemTypes.Removed)
+		assert.True(t, diff.WorkItemLinkTypes.Empty())
+		assert.Equal(t, []string{"Some Board Name"}, diff.WorkItemBoards.Removed)
+	})
+
+	t.Run("board columns", func(t *testing.T) {
+		templ := newTemplate()
+		templ.WIBs[0].Columns[0].Name = "Open"
+		templ.WIBs[0].Columns[1].TransRuleArgument = "{ 'metastate': 'mClosed' }"
+		// when
+		diff := importer.Diff(newTemplate(), templ)
+		// then
+		require.Len(t, diff.WorkItemBoards.Changed, 1)
+		assert.Equal(t, []importer.Change{
+			{Attribute: "columns.Done", Kind: importer.ChangeChanged},
+			{Attribute: "columns.New", Kind: importer.ChangeRemoved},
+			{Attribute: "columns.Open", Kind: importer.ChangeAdded},
+		}, diff.WorkItemBoards.Changed[0].Changes)
+	})
+}
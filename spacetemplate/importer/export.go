@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Export loads the space template with the given ID and all its artifacts in
+// the format that Import expects. Everything that is managed by the database
+// (e.g. timestamps and versions) is omitted and work item types only contain
+// the fields and transitions they don't inherit from the type they extend.
+func (r *GormRepository) Export(ctx context.Context, spaceTemplateID uuid.UUID) (*ImportHelper, error) {
+	templ, err := spacetemplate.NewRepository(r.db).Load(ctx, spaceTemplateID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	res := ImportHelper{Template: *templ}
+	res.Template.Lifecycle = gormsupport.Lifecycle{}
+	res.Template.Version = 0
+
+	witRepo := workitem.NewWorkItemTypeRepository(r.db)
+	wits, err := witRepo.List(ctx, spaceTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list work item types of space template %s", spaceTemplateID)
+	}
+	for i := range wits {
+		wit := wits[i]
+		wit.Extends = extendedTypeID(wit)
+		if wit.Extends != uuid.Nil {
+			extendedType, err := witRepo.Load(ctx, wit.Extends)
+			if err != nil {
+				return nil, errs.Wrapf(err, "failed to load work item type %s extended by %s", wit.Extends, wit.ID)
+			}
+			ownFields := workitem.FieldDefinitions{}
+			for name, fd := range wit.Fields {
+				if inherited, ok := extendedType.Fields[name]; !ok || !inherited.Equal(fd) {
+					ownFields[name] = fd
+				}
+			}
+			wit.Fields = ownFields
+			if wit.Transitions.Equal(extendedType.Transitions) {
+				wit.Transitions = nil
+			}
+		}
+		wit.Lifecycle = gormsupport.Lifecycle{}
+		wit.Version = 0
+		wit.Path = ""
+		res.WITs = append(res.WITs, &wit)
+	}
+
+	wilts, err := link.NewWorkItemLinkTypeRepository(r.db).List(ctx, spaceTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list work item link types of space template %s", spaceTemplateID)
+	}
+	for i := range wilts {
+		wilt := wilts[i]
+		wilt.Lifecycle = gormsupport.Lifecycle{}
+		wilt.Version = 0
+		res.WILTs = append(res.WILTs, &wilt)
+	}
+
+	res.WITGs, err = workitem.NewWorkItemTypeGroupRepository(r.db).List(ctx, spaceTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list work item type groups of space template %s", spaceTemplateID)
+	}
+	for _, witg := range res.WITGs {
+		witg.Lifecycle = gormsupport.Lifecycle{}
+	}
+
+	res.WIBs, err = workitem.NewBoardRepository(r.db).List(ctx, spaceTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list work item boards of space template %s", spaceTemplateID)
+	}
+	for _, wib := range res.WIBs {
+		wib.Lifecycle = gormsupport.Lifecycle{}
+		wib.Version = 0
+		for i := range wib.Columns {
+			wib.Columns[i].Lifecycle = gormsupport.Lifecycle{}
+		}
+	}
+	return &res, nil
+}
+
+// extendedTypeID returns the ID of the work item type that the given type
+// extends or uuid.Nil if it doesn't extend any type. The ID is taken from the
+// second to last element of the type's path.
+func extendedTypeID(wit workitem.WorkItemType) uuid.UUID {
+	parts := strings.Split(wit.Path, workitem.GetTypePathSeparator())
+	if len(parts) < 2 {
+		return uuid.Nil
+	}
+	return uuid.FromStringOrNil(strings.Replace(parts[len(parts)-2], "_", "-", -1))
+}
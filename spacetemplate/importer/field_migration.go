@@ -0,0 +1,308 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// fieldMigrationBatchSize is the number of work items that are loaded at once
+// when the field migrations of a work item type are applied.
+const fieldMigrationBatchSize = 100
+
+// FieldMigrationAction describes what a field migration does to the existing
+// work items of a work item type.
+type FieldMigrationAction string
+
+const (
+	// FieldMigrationRenameField moves the value of the field to the field
+	// named by NewName.
+	FieldMigrationRenameField FieldMigrationAction = "rename_field"
+	// FieldMigrationMapEnumValues replaces the enum values of the field
+	// according to Values.
+	FieldMigrationMapEnumValues FieldMigrationAction = "map_enum_values"
+	// FieldMigrationSetDefault sets the field to Value (or to the default value
+	// of the field type if Value is unset) where it has no value yet.
+	FieldMigrationSetDefault FieldMigrationAction = "set_default"
+	// FieldMigrationDropField removes the value of the field.
+	FieldMigrationDropField FieldMigrationAction = "drop_field"
+)
+
+// FieldMigration is a directive of a space template that tells the importer
+// how to update the data of the existing work items when the fields of a work
+// item type change. Field migrations are applied in the order in which they
+// are defined every time the space template is imported. They only touch work
+// items that still need to be updated, so importing the same space template
+// again doesn't change anything.
+type FieldMigration struct {
+	WorkItemTypeID uuid.UUID            `json:"work_item_type_id"`
+	Action         FieldMigrationAction `json:"action"`
+	Field          string               `json:"field"`
+	// NewName is the name of the field that receives the value when renaming
+	// a field.
+	NewName string `json:"new_name,omitempty"`
+	// Values maps old enum values to new ones.
+	Values map[string]string `json:"values,omitempty"`
+	// Value is the value that is set by a set_default directive.
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Validate checks that the field migration is complete. Whether it matches
+// the work item type is checked during the import.
+func (m FieldMigration) Validate() error {
+	if uuid.Equal(m.WorkItemTypeID, uuid.Nil) {
+		return errors.NewBadParameterError("work_item_type_id", m.WorkItemTypeID.String()).Expected("not nil")
+	}
+	if strings.TrimSpace(m.Field) == "" {
+		return errors.NewBadParameterError("field", m.Field).Expected("not empty")
+	}
+	switch m.Action {
+	case FieldMigrationRenameField:
+		if strings.TrimSpace(m.NewName) == "" || m.NewName == m.Field {
+			return errors.NewBadParameterError("new_name", m.NewName).Expected("name different from " + m.Field)
+		}
+	case FieldMigrationMapEnumValues:
+		if len(m.Values) == 0 {
+			return errors.NewBadParameterError("values", m.Values).Expected("not empty")
+		}
+	case FieldMigrationSetDefault, FieldMigrationDropField:
+	default:
+		return errors.NewBadParameterError("action", m.Action).Expected(fmt.Sprintf("one of %s, %s, %s, %s", FieldMigrationRenameField, FieldMigrationMapEnumValues, FieldMigrationSetDefault, FieldMigrationDropField))
+	}
+	return nil
+}
+
+// fieldMigrationsFor returns the field migrations of the given work item type.
+func (s ImportHelper) fieldMigrationsFor(witID uuid.UUID) []FieldMigration {
+	var res []FieldMigration
+	for _, m := range s.FieldMigrations {
+		if m != nil && uuid.Equal(m.WorkItemTypeID, witID) {
+			res = append(res, *m)
+		}
+	}
+	return res
+}
+
+// hasFieldMigration returns true if one of the given field migrations performs
+// the given action on the given field.
+func hasFieldMigration(migrations []FieldMigration, action FieldMigrationAction, field string) bool {
+	for _, m := range migrations {
+		if m.Action == action && m.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKey returns true if the given map has an entry for the given key.
+func hasKey(m map[string]string, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+// checkFieldMigrations makes sure that the field migrations fit the updated
+// work item type and converts the values that are set by set_default
+// directives to their storage format. The given old fields are the ones of the
+// work item type before the update; every enum value that has been removed from
+// them must be mapped to a new value.
+func checkFieldMigrations(oldFields workitem.FieldDefinitions, wit workitem.WorkItemType, migrations []FieldMigration) ([]FieldMigration, error) {
+	res := make([]FieldMigration, len(migrations))
+	for i, m := range migrations {
+		switch m.Action {
+		case FieldMigrationRenameField:
+			if _, ok := wit.Fields[m.Field]; ok {
+				return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("field %q of work item type %q cannot be renamed because it still exists", m.Field, wit.Name))
+			}
+			if _, ok := wit.Fields[m.NewName]; !ok {
+				return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("field %q of work item type %q cannot be renamed to the unknown field %q", m.Field, wit.Name, m.NewName))
+			}
+		case FieldMigrationMapEnumValues:
+			fd, ok := wit.Fields[m.Field]
+			if !ok {
+				return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("enum values of the unknown field %q of work item type %q cannot be mapped", m.Field, wit.Name))
+			}
+			enumType, ok := fd.Type.(workitem.EnumType)
+			if !ok {
+				return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("field %q of work item type %q is no enum", m.Field, wit.Name))
+			}
+			for _, newValue := range m.Values {
+				if _, err := enumType.ConvertToModel(newValue); err != nil {
+					return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("value %q is not allowed for field %q of work item type %q", newValue, m.Field, wit.Name))
+				}
+			}
+			if oldFD, ok := oldFields[m.Field]; ok {
+				if oldEnumType, ok := oldFD.Type.(workitem.EnumType); ok {
+					for _, oldValue := range oldEnumType.Values {
+						if _, err := enumType.ConvertToModel(oldValue); err == nil {
+							continue
+						}
+						if s, ok := oldValue.(string); !ok || !hasKey(m.Values, s) {
+							return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("removed value %v of field %q of work item type %q is not mapped to a new value", oldValue, m.Field, wit.Name))
+						}
+					}
+				}
+			}
+		case FieldMigrationSetDefault:
+			fd, ok := wit.Fields[m.Field]
+			if !ok {
+				return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("cannot set a default for the unknown field %q of work item type %q", m.Field, wit.Name))
+			}
+			v, err := fd.ConvertToModel(m.Field, m.Value)
+			if err != nil {
+				return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("invalid default for field %q of work item type %q: %s", m.Field, wit.Name, err))
+			}
+			m.Value = v
+		case FieldMigrationDropField:
+			if _, ok := wit.Fields[m.Field]; ok {
+				return nil, errors.NewBadParameterErrorFromString(fmt.Sprintf("field %q of work item type %q cannot be dropped because it still exists", m.Field, wit.Name))
+			}
+		}
+		res[i] = m
+	}
+	return res, nil
+}
+
+// applyFieldMigrations applies the given field migrations to the fields of a
+// work item and returns true if anything changed.
+func applyFieldMigrations(fields workitem.Fields, migrations []FieldMigration) bool {
+	changed := false
+	for _, m := range migrations {
+		v, ok := fields[m.Field]
+		switch m.Action {
+		case FieldMigrationRenameField:
+			if !ok {
+				continue
+			}
+			if v != nil {
+				fields[m.NewName] = v
+			}
+			delete(fields, m.Field)
+			changed = true
+		case FieldMigrationMapEnumValues:
+			switch oldValue := v.(type) {
+			case string:
+				if newValue, ok := m.Values[oldValue]; ok {
+					fields[m.Field] = newValue
+					changed = true
+				}
+			case []interface{}:
+				// the values of list fields are mapped one by one
+				for i, elem := range oldValue {
+					s, ok := elem.(string)
+					if !ok {
+						continue
+					}
+					if newValue, ok := m.Values[s]; ok {
+						oldValue[i] = newValue
+						changed = true
+					}
+				}
+			}
+		case FieldMigrationSetDefault:
+			if v == nil && m.Value != nil {
+				fields[m.Field] = m.Value
+				changed = true
+			}
+		case FieldMigrationDropField:
+			if ok {
+				delete(fields, m.Field)
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// fieldMigrationCondition returns an SQL condition (and its arguments) that
+// matches all work items that are affected by at least one of the given field
+// migrations. We use jsonb_exists instead of the "?" operator because gorm
+// treats the question mark as a placeholder.
+func fieldMigrationCondition(migrations []FieldMigration) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, m := range migrations {
+		switch m.Action {
+		case FieldMigrationRenameField, FieldMigrationDropField:
+			conditions = append(conditions, "jsonb_exists(fields, ?)")
+			args = append(args, m.Field)
+		case FieldMigrationMapEnumValues:
+			for oldValue := range m.Values {
+				conditions = append(conditions, "jsonb_exists(fields->?, ?)")
+				args = append(args, m.Field, oldValue)
+			}
+		case FieldMigrationSetDefault:
+			conditions = append(conditions, "(fields->? IS NULL OR fields->? = 'null'::jsonb)")
+			args = append(args, m.Field, m.Field)
+		}
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// migrateWorkItems applies the field migrations of the given work item type,
+// which must have been checked by checkFieldMigrations, to all its work items
+// in batches and records a revision for every work item
+// that has changed. The revisions are attributed to the given modifier or, if
+// there is none, to the creator of the work item. It returns the number of
+// updated work items.
+func (r *GormRepository) migrateWorkItems(ctx context.Context, wit workitem.WorkItemType, migrations []FieldMigration, modifierID uuid.UUID) (int, error) {
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	cond, args := fieldMigrationCondition(migrations)
+	revisionRepo := workitem.NewRevisionRepository(r.db)
+	updated := 0
+	lastID := uuid.Nil
+	for {
+		var items []workitem.WorkItemStorage
+		db := r.db.Where("type = ? AND id > ?", wit.ID, lastID).Where(cond, args...).Order("id").Limit(fieldMigrationBatchSize).Find(&items)
+		if db.Error != nil {
+			return updated, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to load the work items of type %s", wit.ID))
+		}
+		for i := range items {
+			wi := &items[i]
+			lastID = wi.ID
+			if !applyFieldMigrations(wi.Fields, migrations) {
+				continue
+			}
+			oldVersion := wi.Version
+			wi.Version++
+			tx := r.db.Where("version = ?", oldVersion).Save(wi)
+			if err := tx.Error; err != nil {
+				return updated, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to update work item %s", wi.ID))
+			}
+			if tx.RowsAffected == 0 {
+				return updated, errors.NewVersionConflictError("version conflict")
+			}
+			modifier := modifierID
+			if uuid.Equal(modifier, uuid.Nil) {
+				creator, ok := wi.Fields[workitem.SystemCreator].(string)
+				if !ok {
+					return updated, errs.Errorf("work item %s has no creator to record the revision for", wi.ID)
+				}
+				if modifier, err = uuid.FromString(creator); err != nil {
+					return updated, errs.Wrapf(err, "invalid creator of work item %s", wi.ID)
+				}
+			}
+			if _, err := revisionRepo.Create(ctx, modifier, workitem.RevisionTypeUpdate, *wi); err != nil {
+				return updated, errs.Wrapf(err, "failed to record revision of work item %s", wi.ID)
+			}
+			updated++
+		}
+		if len(items) < fieldMigrationBatchSize {
+			break
+		}
+	}
+	if updated > 0 {
+		log.Info(ctx, map[string]interface{}{
+			"wit_id":     wit.ID,
+			"work_items": updated,
+		}, "migrated the fields of the work items of work item type %q", wit.Name)
+	}
+	return updated, nil
+}
@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"github.com/fabric8-services/fabric8-common/id"
 	"github.com/fabric8-services/fabric8-wit/convert"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/log"
@@ -19,6 +20,13 @@ type ImportHelper struct {
 	WILTs    []*link.WorkItemLinkType      `gorm:"-" json:"work_item_link_types,omitempty"`
 	WITGs    []*workitem.WorkItemTypeGroup `gorm:"-" json:"work_item_type_groups,omitempty"`
 	WIBs     []*workitem.Board             `gorm:"-" json:"work_item_boards,omitempty"`
+	// FieldMigrations describe how the existing work items are updated when
+	// the fields of their work item types change.
+	FieldMigrations []*FieldMigration `gorm:"-" json:"field_migrations,omitempty"`
+	// ModifierID is the identity to which the revisions of the work items that
+	// are changed by field migrations are attributed. If it is not set, the
+	// creator of each work item is used.
+	ModifierID uuid.UUID `gorm:"-" json:"-"`
 }
 
 // Validate ensures that all inner-document references of the given space
@@ -54,6 +62,22 @@ func (s *ImportHelper) Validate() error {
 			return errors.NewBadParameterError("work item board's space template ID", wibs.SpaceTemplateID.String()).Expected(s.Template.ID.String())
 		}
 	}
+	// Field migrations must refer to work item types of this space template
+	witIDs := id.Map{}
+	for _, wit := range s.WITs {
+		witIDs[wit.ID] = struct{}{}
+	}
+	for _, m := range s.FieldMigrations {
+		if m == nil {
+			return errors.NewBadParameterError("field migration", nil).Expected("not nil")
+		}
+		if err := m.Validate(); err != nil {
+			return errs.Wrapf(err, "failed to validate %s field migration of field %q", m.Action, m.Field)
+		}
+		if _, ok := witIDs[m.WorkItemTypeID]; !ok {
+			return errors.NewBadParameterError("field migration's work item type ID", m.WorkItemTypeID.String()).Expected("ID of a work item type of the space template")
+		}
+	}
 
 	return nil
 }
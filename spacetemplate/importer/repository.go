@@ -21,8 +21,13 @@ type Repository interface {
 	// Import creates a new space template and all the artifacts (e.g.
 	// work item types, work item link types) in the system. In case a space
 	// template or a work item exists, we will update its description, label,
-	// icon, title. We don't touch the work item type fields or IDs of any kind.
+	// icon, title. We don't touch the work item type fields or IDs of any kind
+	// unless the template contains field migrations which are then applied
+	// to the existing work items.
 	Import(ctx context.Context, template ImportHelper) (*ImportHelper, error)
+	// Export loads the space template with the given ID and all its artifacts
+	// in the format that Import expects.
+	Export(ctx context.Context, spaceTemplateID uuid.UUID) (*ImportHelper, error)
 }
 
 // NewRepository creates a new importer repository
@@ -78,6 +83,9 @@ func (r *GormRepository) Import(ctx context.Context, s ImportHelper) (*ImportHel
 			log.Error(ctx, map[string]interface{}{"space_template": loadedSpaceTemplate, "err": err}, "failed to update space template")
 			return nil, errs.Wrapf(err, "failed to update space template %s", s.Template.ID)
 		}
+		if err := spacetemplate.InvalidateCache(ctx, r.db, s.Template.ID); err != nil {
+			return nil, errs.WithStack(err)
+		}
 		res.Template = *loadedSpaceTemplate
 	}
 
@@ -137,9 +145,11 @@ func (r *GormRepository) createOrUpdateWITs(ctx context.Context, s *ImportHelper
 			}
 		} else {
 			if loadedWIT.SpaceTemplateID != s.Template.ID {
-				return errs.Errorf("work item type %s exists and is bound to space template %s instead of the new one %s", loadedWIT.ID, loadedWIT.SpaceTemplateID, s.Template.ID)
+				return errors.NewBadParameterErrorFromString(fmt.Sprintf("work item type %s exists and is bound to space template %s instead of the new one %s", loadedWIT.ID, loadedWIT.SpaceTemplateID, s.Template.ID))
 			}
 
+			migrations := s.fieldMigrationsFor(wit.ID)
+
 			// Update work item type
 			loadedWIT.Name = wit.Name
 			loadedWIT.Description = wit.Description
@@ -177,7 +187,14 @@ func (r *GormRepository) createOrUpdateWITs(ctx context.Context, s *ImportHelper
 						origEnum, ok1 := oldFieldType.(workitem.EnumType)
 						newEnum, ok2 := newFieldType.(workitem.EnumType)
 						if ok1 && ok2 {
+							// Enum values may only be removed if the existing
+							// work items get their values mapped. That every
+							// removed value is mapped is checked by
+							// checkFieldMigrations.
 							equal = newEnum.EqualEnclosing(origEnum)
+							if !equal && hasFieldMigration(migrations, FieldMigrationMapEnumValues, fieldName) {
+								equal = newEnum.SimpleType.Equal(origEnum.SimpleType) && newEnum.BaseType.Equal(origEnum.BaseType)
+							}
 						}
 						if !equal {
 							return errs.Errorf("type of the field %s changed from %+v to %+v", fieldName, spew.Sdump(oldFieldType), spew.Sdump(fd.Type))
@@ -197,6 +214,12 @@ func (r *GormRepository) createOrUpdateWITs(ctx context.Context, s *ImportHelper
 					delete(toBeFoundFields, k)
 				}
 			}
+			// Remove fields that are renamed or dropped by field migrations
+			for _, m := range migrations {
+				if m.Action == FieldMigrationRenameField || m.Action == FieldMigrationDropField {
+					delete(toBeFoundFields, m.Field)
+				}
+			}
 			if len(toBeFoundFields) > 0 {
 				return errs.Errorf("you must not remove these fields from the new work item type definition of %q: %+v", wit.Name, toBeFoundFields)
 			}
@@ -204,17 +227,51 @@ func (r *GormRepository) createOrUpdateWITs(ctx context.Context, s *ImportHelper
 			// TODO(kwk): Check that fields have not changed types.
 
 			// Update fields
+			oldFields := make(workitem.FieldDefinitions, len(loadedWIT.Fields))
+			for name, field := range loadedWIT.Fields {
+				oldFields[name] = field
+			}
 			if extendedType != nil {
-				loadedWIT.Fields = extendedType.Fields
+				loadedWIT.Fields = make(workitem.FieldDefinitions, len(extendedType.Fields)+len(wit.Fields))
+				for name, field := range extendedType.Fields {
+					loadedWIT.Fields[name] = field
+				}
 			}
 			for name, field := range wit.Fields {
 				loadedWIT.Fields[name] = field
 			}
+			loadedWIT.Transitions = wit.Transitions
+			if len(loadedWIT.Transitions) == 0 && extendedType != nil {
+				loadedWIT.Transitions = extendedType.Transitions
+			}
+			if err := loadedWIT.Transitions.Validate(loadedWIT.Fields); err != nil {
+				return errs.Wrapf(err, "failed to validate transitions of work item type %s", wit.ID)
+			}
+			migrations, err = checkFieldMigrations(oldFields, *loadedWIT, migrations)
+			if err != nil {
+				return errs.WithStack(err)
+			}
 			db := r.db.Save(&loadedWIT)
 			if err := db.Error; err != nil {
 				return errs.Wrapf(err, "failed to update work item type %s", wit.ID)
 			}
-			workitem.ClearGlobalWorkItemTypeCache()
+			if err := workitem.InvalidateWorkItemTypeCache(ctx, r.db, wit.ID); err != nil {
+				return errs.WithStack(err)
+			}
+			if extendedType != nil {
+				if err := workitem.InvalidateWorkItemTypeCache(ctx, r.db, extendedType.ID); err != nil {
+					return errs.WithStack(err)
+				}
+			}
+			// the definitions of the computed fields might have changed
+			if err := workitem.InvalidateComputedFields(ctx, r.db, uuid.Nil); err != nil {
+				return errs.WithStack(err)
+			}
+
+			// Bring the existing work items in line with the updated type
+			if _, err := r.migrateWorkItems(ctx, *loadedWIT, migrations, s.ModifierID); err != nil {
+				return errs.Wrapf(err, "failed to migrate the work items of work item type %s", wit.ID)
+			}
 		}
 	}
 
@@ -228,6 +285,9 @@ func (r *GormRepository) createOrUpdateWITs(ctx context.Context, s *ImportHelper
 		if db.Error != nil {
 			return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to deleted previous work item child types for WIT '%s'", wit.Name))
 		}
+		if err := workitem.InvalidateWorkItemTypeCache(ctx, r.db, wit.ID); err != nil {
+			return errs.WithStack(err)
+		}
 		err := witRepo.AddChildTypes(ctx, wit.ID, wit.ChildTypeIDs)
 		if err != nil {
 			return errs.Wrapf(err, `failed to add child types to work item type "%s" (%s)`, wit.Name, wit.ID)
@@ -257,7 +317,7 @@ func (r *GormRepository) checkNoWITIsMissing(ctx context.Context, s *ImportHelpe
 		delete(toBeFoundIDs, wit.ID)
 	}
 	if len(toBeFoundIDs) > 0 {
-		return errs.Errorf("work item types to be imported must not remove these existing work item types: %s", toBeFoundIDs)
+		return errors.NewBadParameterErrorFromString(fmt.Sprintf("work item types to be imported must not remove these existing work item types: %s", toBeFoundIDs))
 	}
 	return nil
 }
@@ -288,12 +348,21 @@ func (r *GormRepository) createOrUpdateWILTs(ctx context.Context, s *ImportHelpe
 			}
 		} else {
 			if loadedWILT.SpaceTemplateID != s.Template.ID {
-				return errs.Errorf("work item link type %s exists and is bound to space template %s instead of the new one %s", loadedWILT.ID, loadedWILT.SpaceTemplateID, s.Template.ID)
+				return errors.NewBadParameterErrorFromString(fmt.Sprintf("work item link type %s exists and is bound to space template %s instead of the new one %s", loadedWILT.ID, loadedWILT.SpaceTemplateID, s.Template.ID))
+			}
+			// Updated link types have to be as valid as newly created ones,
+			// especially their fields and constraints.
+			wilt.SpaceTemplateID = s.Template.ID
+			if err := wilt.CheckValidForCreation(); err != nil {
+				return errs.Wrapf(err, "failed to update work item link type '%s' from space template '%s'", wilt.Name, s.Template.ID)
 			}
 			db := r.db.Save(&*wilt)
 			if err := db.Error; err != nil {
 				return errs.Wrapf(err, "failed to update work item link type %s", wilt.ID)
 			}
+			if err := link.InvalidateWorkItemLinkTypeCache(ctx, r.db, wilt.ID); err != nil {
+				return errs.WithStack(err)
+			}
 		}
 	}
 	return nil
@@ -319,7 +388,7 @@ func (r *GormRepository) checkNoWILTIsMissing(ctx context.Context, s *ImportHelp
 		delete(toBeFoundIDs, wilt.ID)
 	}
 	if len(toBeFoundIDs) > 0 {
-		return errs.Errorf("work item link types to be imported must not remove these existing work item link types: %s", toBeFoundIDs)
+		return errors.NewBadParameterErrorFromString(fmt.Sprintf("work item link types to be imported must not remove these existing work item link types: %s", toBeFoundIDs))
 	}
 	return nil
 }
@@ -346,8 +415,9 @@ func (r *GormRepository) createOrUpdateWITGs(ctx context.Context, s *ImportHelpe
 func (r *GormRepository) createOrUpdateWIBs(ctx context.Context, s *ImportHelper) error {
 	// Delete old work item boards (if any) associated with this space
 	// template. There's no need to retain information about old boards as
-	// it is just a linkage of work item type groups.
-	db := r.db.Unscoped().Delete(workitem.Board{}, "space_template_id = ?", s.Template.ID)
+	// it is just a linkage of work item type groups. Boards customized for a
+	// space are kept.
+	db := r.db.Unscoped().Delete(workitem.Board{}, "space_template_id = ? AND space_id IS NULL", s.Template.ID)
 	if db.Error != nil {
 		return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to delete previous work item boards for space template '%s'", s.Template.ID))
 	}
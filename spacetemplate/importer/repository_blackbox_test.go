@@ -14,6 +14,7 @@ import (
 	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/stretchr/testify/assert"
@@ -227,6 +228,29 @@ func (s *repoSuite) TestImport() {
 			// then
 			require.Error(t, err)
 		})
+		t.Run("invalid WILT constraints", func(t *testing.T) {
+			// Create fresh template
+			spaceTemplateID := uuid.NewV4()
+			witID := uuid.NewV4()
+			wiltID := uuid.NewV4()
+			witgID := uuid.NewV4()
+			wibID := uuid.NewV4()
+			oldTempl := getValidTestTemplateParsed(t, spaceTemplateID, witID, wiltID, witgID, wibID)
+			oldTempl.Template.Name = "old name for space template " + spaceTemplateID.String()
+			_, err := s.importerRepo.Import(s.Ctx, oldTempl)
+			require.NoError(t, err)
+			// Import it once more but this time with a WILT that allows no
+			// children at all
+			templ := getValidTestTemplateParsed(t, spaceTemplateID, witID, wiltID, witgID, wibID)
+			templ.Template.Name = oldTempl.Template.Name
+			templ.WILTs[0].Constraints.MaxChildren = ptr.Int(0)
+			// when
+			_, err = s.importerRepo.Import(s.Ctx, templ)
+			// then
+			require.Error(t, err)
+			isBadParameterError, _ := errors.IsBadParameterError(err)
+			require.True(t, isBadParameterError)
+		})
 		t.Run("WIT already exists", func(t *testing.T) {
 			// given old space template with new name, new ID, and new WILT ID
 			newWILTID := uuid.NewV4()
@@ -386,6 +410,43 @@ func (s *repoSuite) TestImport() {
 	})
 }
 
+func (s *repoSuite) TestExport() {
+	s.T().Run("round trip", func(t *testing.T) {
+		// given
+		spaceTemplateID := uuid.NewV4()
+		templ := getValidTestTemplateParsed(t, spaceTemplateID, uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4())
+		templ.Template.Name = "export " + spaceTemplateID.String()
+		_, err := s.importerRepo.Import(s.Ctx, templ)
+		require.NoError(t, err)
+		// when
+		exported, err := s.importerRepo.Export(s.Ctx, spaceTemplateID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, exported.WITs, 1)
+		assert.Equal(t, workitem.SystemPlannerItem, exported.WITs[0].Extends)
+		assert.Len(t, exported.WITs[0].Fields, 3, "inherited fields must not be exported")
+		diff := importer.Diff(getValidTestTemplateParsed(t, spaceTemplateID, templ.WITs[0].ID, templ.WILTs[0].ID, templ.WITGs[0].ID, templ.WIBs[0].ID), *exported)
+		assert.Equal(t, []importer.Change{{Attribute: "name", Kind: importer.ChangeChanged}}, diff.Template)
+		assert.True(t, diff.WorkItemTypes.Empty(), "%+v", diff.WorkItemTypes)
+		assert.True(t, diff.WorkItemLinkTypes.Empty(), "%+v", diff.WorkItemLinkTypes)
+		assert.True(t, diff.WorkItemTypeGroups.Empty(), "%+v", diff.WorkItemTypeGroups)
+		assert.True(t, diff.WorkItemBoards.Empty(), "%+v", diff.WorkItemBoards)
+
+		t.Run("can be parsed and imported again", func(t *testing.T) {
+			parsed, err := importer.FromString(exported.String())
+			require.NoError(t, err)
+			_, err = s.importerRepo.Import(s.Ctx, *parsed)
+			require.NoError(t, err)
+		})
+	})
+
+	s.T().Run("not existing template", func(t *testing.T) {
+		_, err := s.importerRepo.Export(s.Ctx, uuid.NewV4())
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
 func (s *repoSuite) TestExists() {
 	// given
 	spaceTemplateID := uuid.NewV4()
@@ -476,3 +537,146 @@ func (s *repoSuite) TestRepository_List() {
 		require.Len(t, spaceTemplatesToBeFound, 0, "these space templates where not found", spaceTemplatesToBeFound)
 	})
 }
+
+func (s *repoSuite) TestImportFieldMigrations() {
+	// given a space template with a work item type that has work items
+	setup := func(t *testing.T) (importer.ImportHelper, *tf.TestFixture, []*workitem.WorkItem) {
+		oldTempl := getValidTestTemplateParsed(t, uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4())
+		oldTempl.Template.Name = testsupport.CreateRandomValidTestName("field migrations")
+		oldTempl.WITs[0].CanConstruct = true
+		_, err := s.importerRepo.Import(s.Ctx, oldTempl)
+		require.NoError(t, err)
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Spaces[idx].SpaceTemplateID = oldTempl.Template.ID
+			return nil
+		}))
+		wiRepo := workitem.NewWorkItemRepository(s.DB)
+		var items []*workitem.WorkItem
+		for _, state := range []string{"new", "closed"} {
+			wi, _, err := wiRepo.Create(s.Ctx, fxt.Spaces[0].ID, oldTempl.WITs[0].ID, map[string]interface{}{
+				workitem.SystemTitle: "work item in state " + state,
+				"title":              "title in state " + state,
+				"state":              state,
+			}, fxt.Identities[0].ID)
+			require.NoError(t, err)
+			items = append(items, wi)
+		}
+		return oldTempl, fxt, items
+	}
+	// newTemplate renames "title" to "headline", replaces the "new" state by
+	// "open", adds the required "flavor" field and removes "priority".
+	newTemplate := func(t *testing.T, oldTempl importer.ImportHelper) importer.ImportHelper {
+		templ := getValidTestTemplateParsed(t, oldTempl.Template.ID, oldTempl.WITs[0].ID, oldTempl.WILTs[0].ID, oldTempl.WITGs[0].ID, oldTempl.WIBs[0].ID)
+		templ.Template.Name = oldTempl.Template.Name
+		templ.WITs[0].CanConstruct = true
+		wit := templ.WITs[0]
+		wit.Fields["headline"] = wit.Fields["title"]
+		delete(wit.Fields, "title")
+		delete(wit.Fields, "priority")
+		stateField := wit.Fields["state"]
+		enumType, ok := stateField.Type.(workitem.EnumType)
+		require.True(t, ok, "failed to convert state field type to enum type")
+		enumType.Values = []interface{}{"open", "closed"}
+		stateField.Type = enumType
+		wit.Fields["state"] = stateField
+		wit.Fields["flavor"] = workitem.FieldDefinition{
+			Required: true,
+			Label:    "Flavor",
+			Type:     workitem.SimpleType{Kind: workitem.KindString},
+		}
+		templ.FieldMigrations = []*importer.FieldMigration{
+			{WorkItemTypeID: wit.ID, Action: importer.FieldMigrationRenameField, Field: "title", NewName: "headline"},
+			{WorkItemTypeID: wit.ID, Action: importer.FieldMigrationMapEnumValues, Field: "state", Values: map[string]string{"new": "open"}},
+			{WorkItemTypeID: wit.ID, Action: importer.FieldMigrationSetDefault, Field: "flavor", Value: "vanilla"},
+			{WorkItemTypeID: wit.ID, Action: importer.FieldMigrationDropField, Field: "priority"},
+		}
+		return templ
+	}
+
+	s.T().Run("ok", func(t *testing.T) {
+		oldTempl, fxt, items := setup(t)
+		templ := newTemplate(t, oldTempl)
+		templ.ModifierID = fxt.Identities[0].ID
+		// when
+		_, err := s.importerRepo.Import(s.Ctx, templ)
+		// then
+		require.NoError(t, err)
+		wiRepo := workitem.NewWorkItemRepository(s.DB)
+		expectedStates := []string{"open", "closed"}
+		for i, wi := range items {
+			loaded, err := wiRepo.LoadByID(s.Ctx, wi.ID)
+			require.NoError(t, err)
+			assert.Equal(t, wi.Version+1, loaded.Version)
+			assert.Equal(t, wi.Fields["title"], loaded.Fields["headline"])
+			assert.NotContains(t, loaded.Fields, "title")
+			assert.NotContains(t, loaded.Fields, "priority")
+			assert.Equal(t, expectedStates[i], loaded.Fields["state"])
+			assert.Equal(t, "vanilla", loaded.Fields["flavor"])
+			revisions, err := workitem.NewRevisionRepository(s.DB).List(s.Ctx, wi.ID)
+			require.NoError(t, err)
+			require.Len(t, revisions, 2)
+			assert.Equal(t, workitem.RevisionTypeUpdate, revisions[1].Type)
+			assert.Equal(t, fxt.Identities[0].ID, revisions[1].ModifierIdentity)
+		}
+
+		t.Run("importing again changes nothing", func(t *testing.T) {
+			// when
+			_, err := s.importerRepo.Import(s.Ctx, newTemplate(t, oldTempl))
+			// then
+			require.NoError(t, err)
+			for _, wi := range items {
+				loaded, err := wiRepo.LoadByID(s.Ctx, wi.ID)
+				require.NoError(t, err)
+				assert.Equal(t, wi.Version+1, loaded.Version)
+			}
+		})
+	})
+	s.T().Run("invalid", func(t *testing.T) {
+		t.Run("enum value removed without mapping", func(t *testing.T) {
+			oldTempl, _, _ := setup(t)
+			templ := newTemplate(t, oldTempl)
+			templ.FieldMigrations = append(templ.FieldMigrations[:1], templ.FieldMigrations[2:]...)
+			_, err := s.importerRepo.Import(s.Ctx, templ)
+			require.Error(t, err)
+		})
+		t.Run("enum value removed with incomplete mapping", func(t *testing.T) {
+			oldTempl, _, _ := setup(t)
+			templ := newTemplate(t, oldTempl)
+			stateField := templ.WITs[0].Fields["state"]
+			enumType := stateField.Type.(workitem.EnumType)
+			enumType.Values = []interface{}{"open"}
+			enumType.DefaultValue = "open"
+			stateField.Type = enumType
+			templ.WITs[0].Fields["state"] = stateField
+			_, err := s.importerRepo.Import(s.Ctx, templ)
+			require.Error(t, err)
+			isBadParameterError, _ := errors.IsBadParameterError(err)
+			require.True(t, isBadParameterError)
+		})
+		t.Run("enum turned into a string field", func(t *testing.T) {
+			oldTempl, _, _ := setup(t)
+			templ := newTemplate(t, oldTempl)
+			stateField := templ.WITs[0].Fields["state"]
+			stateField.Type = workitem.SimpleType{Kind: workitem.KindString}
+			templ.WITs[0].Fields["state"] = stateField
+			_, err := s.importerRepo.Import(s.Ctx, templ)
+			require.Error(t, err)
+		})
+		t.Run("field dropped that still exists", func(t *testing.T) {
+			oldTempl, _, _ := setup(t)
+			templ := newTemplate(t, oldTempl)
+			templ.WITs[0].Fields["priority"] = oldTempl.WITs[0].Fields["priority"]
+			_, err := s.importerRepo.Import(s.Ctx, templ)
+			require.Error(t, err)
+			isBadParameterError, _ := errors.IsBadParameterError(err)
+			require.True(t, isBadParameterError)
+		})
+		t.Run("unknown work item type", func(t *testing.T) {
+			oldTempl, _, _ := setup(t)
+			templ := newTemplate(t, oldTempl)
+			templ.FieldMigrations[0].WorkItemTypeID = uuid.NewV4()
+			_, err := s.importerRepo.Import(s.Ctx, templ)
+			require.Error(t, err)
+		})
+	})
+}
@@ -2,8 +2,10 @@ package spacetemplate
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/cache"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/log"
@@ -12,6 +14,19 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// CacheName is the name of the space template cache.
+const CacheName = "space_templates"
+
+// spaceTemplateCache is shared by all space template repositories and is
+// invalidated across all instances of the service.
+var spaceTemplateCache = cache.Register(cache.NewMemoryCache(CacheName, cache.DefaultTTL))
+
+// InvalidateCache removes the space template with the given ID from the cache
+// of all instances of the service.
+func InvalidateCache(ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	return cache.Invalidate(ctx, db, CacheName, id)
+}
+
 // Repository describes interactions with space templates
 type Repository interface {
 	repository.Exister
@@ -22,6 +37,16 @@ type Repository interface {
 	List(ctx context.Context) ([]SpaceTemplate, error)
 	// Load returns a single space template by a given ID
 	Load(ctx context.Context, templateID uuid.UUID) (*SpaceTemplate, error)
+	// Save updates the given space template and increments its version. A
+	// version conflict error is returned if the version of the given template
+	// doesn't match the stored one.
+	Save(ctx context.Context, template SpaceTemplate) (*SpaceTemplate, error)
+	// CreateVersion stores the definition of the given version of a space
+	// template.
+	CreateVersion(ctx context.Context, version SpaceTemplateVersion) error
+	// LoadVersion returns the definition of the given version of a space
+	// template.
+	LoadVersion(ctx context.Context, templateID uuid.UUID, version int) (*SpaceTemplateVersion, error)
 }
 
 // NewRepository creates a new space template repository
@@ -52,6 +77,10 @@ func (r *GormRepository) List(ctx context.Context) ([]SpaceTemplate, error) {
 
 // Load returns a single space template by a given ID
 func (r *GormRepository) Load(ctx context.Context, spaceTemplateID uuid.UUID) (*SpaceTemplate, error) {
+	if cached, ok := spaceTemplateCache.Get(spaceTemplateID); ok {
+		s := cached.(SpaceTemplate)
+		return &s, nil
+	}
 	var s SpaceTemplate
 	tx := r.db.Where("id = ?", spaceTemplateID).First(&s)
 	if tx.RecordNotFound() {
@@ -67,6 +96,7 @@ func (r *GormRepository) Load(ctx context.Context, spaceTemplateID uuid.UUID) (*
 		}, "failed to load space template")
 		return nil, errors.NewInternalError(ctx, errs.Wrap(tx.Error, "failed to load space template"))
 	}
+	spaceTemplateCache.Put(s.ID, s)
 	return &s, nil
 }
 
@@ -95,3 +125,63 @@ func (r *GormRepository) Create(ctx context.Context, s SpaceTemplate) (*SpaceTem
 	log.Debug(ctx, map[string]interface{}{"space_template_id": s.ID}, "space template created successfully")
 	return &s, nil
 }
+
+// Save updates the given space template and increments its version. A version
+// conflict error is returned if the version of the given template doesn't
+// match the stored one.
+func (r *GormRepository) Save(ctx context.Context, s SpaceTemplate) (*SpaceTemplate, error) {
+	if err := s.Validate(); err != nil {
+		return nil, errs.Wrap(err, "space template is invalid")
+	}
+	if _, err := r.Load(ctx, s.ID); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	oldVersion := s.Version
+	s.Version = oldVersion + 1
+	db := r.db.Where("version = ?", oldVersion).Save(&s)
+	if err := db.Error; err != nil {
+		log.Error(ctx, map[string]interface{}{"space_template_id": s.ID, "err": err}, "failed to save space template")
+		if gormsupport.IsUniqueViolation(err, "space_templates_name_uidx") {
+			return nil, errors.NewBadParameterError("name", s.Name).Expected("unique")
+		}
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to save space template"))
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	if err := InvalidateCache(ctx, r.db, s.ID); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	log.Debug(ctx, map[string]interface{}{"space_template_id": s.ID}, "space template saved successfully")
+	return &s, nil
+}
+
+// CreateVersion stores the definition of the given version of a space
+// template.
+func (r *GormRepository) CreateVersion(ctx context.Context, v SpaceTemplateVersion) error {
+	if v.Template == "" {
+		return errors.NewBadParameterError("template", v.Template).Expected("not empty")
+	}
+	if err := r.db.Create(&v).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{"space_template_id": v.SpaceTemplateID, "version": v.Version, "err": err}, "failed to create space template version")
+		if gormsupport.IsUniqueViolation(err, "space_template_versions_pkey") {
+			return errors.NewVersionConflictError("version conflict")
+		}
+		return errors.NewInternalError(ctx, errs.Wrap(err, "failed to create space template version"))
+	}
+	return nil
+}
+
+// LoadVersion returns the definition of the given version of a space
+// template.
+func (r *GormRepository) LoadVersion(ctx context.Context, spaceTemplateID uuid.UUID, version int) (*SpaceTemplateVersion, error) {
+	var v SpaceTemplateVersion
+	tx := r.db.Where("space_template_id = ? AND version = ?", spaceTemplateID, version).First(&v)
+	if tx.RecordNotFound() {
+		return nil, errors.NewNotFoundError("space_template_version", fmt.Sprintf("%s/%d", spaceTemplateID, version))
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(tx.Error, "failed to load space template version"))
+	}
+	return &v, nil
+}
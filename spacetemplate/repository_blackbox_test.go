@@ -4,12 +4,14 @@ import (
 	"testing"
 
 	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/spacetemplate"
 	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/stretchr/testify/assert"
@@ -124,3 +126,76 @@ func (s *repoSuite) TestRepository_List() {
 		require.Len(t, spaceTemplatesToBeFound, 0, "these space templates where not found", spaceTemplatesToBeFound)
 	})
 }
+
+func (s *repoSuite) TestSave() {
+	resource.Require(s.T(), resource.Database)
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.SpaceTemplates(1))
+		templ := *fxt.SpaceTemplates[0]
+		templ.Name = "renamed " + templ.Name
+		// when
+		actual, err := s.spaceTemplateRepo.Save(s.Ctx, templ)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, templ.Version+1, actual.Version)
+		loaded, err := s.spaceTemplateRepo.Load(s.Ctx, templ.ID)
+		require.NoError(t, err)
+		require.Equal(t, templ.Name, loaded.Name)
+		require.Equal(t, actual.Version, loaded.Version)
+	})
+
+	s.T().Run("version conflict", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.SpaceTemplates(1))
+		templ := *fxt.SpaceTemplates[0]
+		templ.Version = templ.Version + 1
+		// when
+		_, err := s.spaceTemplateRepo.Save(s.Ctx, templ)
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.VersionConflictError{}, err)
+	})
+
+	s.T().Run("not existing template", func(t *testing.T) {
+		// when
+		_, err := s.spaceTemplateRepo.Save(s.Ctx, spacetemplate.SpaceTemplate{ID: uuid.NewV4(), Name: "foo"})
+		// then
+		require.Error(t, err)
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
+func (s *repoSuite) TestVersions() {
+	resource.Require(s.T(), resource.Database)
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.SpaceTemplates(1), tf.Identities(1))
+		v := spacetemplate.SpaceTemplateVersion{
+			SpaceTemplateID: fxt.SpaceTemplates[0].ID,
+			Version:         fxt.SpaceTemplates[0].Version,
+			Template:        "space_template:\n  name: foo\n",
+			ModifierID:      &fxt.Identities[0].ID,
+		}
+		// when
+		err := s.spaceTemplateRepo.CreateVersion(s.Ctx, v)
+		// then
+		require.NoError(t, err)
+		loaded, err := s.spaceTemplateRepo.LoadVersion(s.Ctx, v.SpaceTemplateID, v.Version)
+		require.NoError(t, err)
+		require.Equal(t, v.Template, loaded.Template)
+		require.Equal(t, fxt.Identities[0].ID, *loaded.ModifierID)
+
+		t.Run("same version twice", func(t *testing.T) {
+			err := s.spaceTemplateRepo.CreateVersion(s.Ctx, v)
+			require.IsType(t, errors.VersionConflictError{}, err)
+		})
+	})
+
+	s.T().Run("not existing version", func(t *testing.T) {
+		_, err := s.spaceTemplateRepo.LoadVersion(s.Ctx, uuid.NewV4(), 0)
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
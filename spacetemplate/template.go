@@ -29,6 +29,9 @@ type SpaceTemplate struct {
 	Name                  string    `json:"name"`
 	Description           *string   `json:"description,omitempty"`
 	CanConstruct          bool      `gorm:"can_construct" json:"can_construct"`
+	// CreatorID is the identity that created a custom space template through
+	// the API. It is nil for the space templates that are imported on startup.
+	CreatorID *uuid.UUID `sql:"type:uuid" gorm:"column:creator_id" json:"creator_id,omitempty"`
 }
 
 // Validate ensures that all inner-document references of the given space
@@ -89,6 +92,9 @@ func (s SpaceTemplate) Equal(u convert.Equaler) bool {
 	if !reflect.DeepEqual(s.Description, other.Description) {
 		return false
 	}
+	if !reflect.DeepEqual(s.CreatorID, other.CreatorID) {
+		return false
+	}
 	return true
 }
 
@@ -102,3 +108,19 @@ func (s SpaceTemplate) EqualValue(u convert.Equaler) bool {
 	s.Lifecycle = other.Lifecycle
 	return s.Equal(u)
 }
+
+// A SpaceTemplateVersion holds the definition from which a space template was
+// created or updated to the given version.
+type SpaceTemplateVersion struct {
+	SpaceTemplateID uuid.UUID  `sql:"type:uuid" gorm:"primary_key" json:"space_template_id"`
+	Version         int        `gorm:"primary_key" json:"version"`
+	Template        string     `json:"template"`
+	ModifierID      *uuid.UUID `sql:"type:uuid" json:"modifier_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (v SpaceTemplateVersion) TableName() string {
+	return "space_template_versions"
+}
@@ -0,0 +1,90 @@
+package templatemigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/models"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+// DefaultBatchSize is the number of work items or links that are converted in
+// one transaction.
+const DefaultBatchSize = 100
+
+// Job periodically resumes all unfinished space template migrations.
+type Job struct {
+	db        *gorm.DB
+	cron      *cron.Cron
+	batchSize int
+}
+
+// NewJob creates a new job for the space template migrations.
+func NewJob(db *gorm.DB, batchSize int) *Job {
+	if batchSize < 1 {
+		batchSize = DefaultBatchSize
+	}
+	return &Job{db: db, cron: cron.New(), batchSize: batchSize}
+}
+
+// Start runs the job in the given interval until Stop is called.
+func (j *Job) Start(ctx context.Context, interval time.Duration) error {
+	err := j.cron.AddFunc("@every "+interval.String(), func() {
+		j.Run(ctx)
+	})
+	if err != nil {
+		return errs.Wrapf(err, "failed to schedule the space template migration job every %s", interval)
+	}
+	j.cron.Start()
+	return nil
+}
+
+// Stop stops the job.
+// This should be called only from main
+func (j *Job) Stop() {
+	j.cron.Stop()
+}
+
+// Run drives all unfinished migrations step by step until they are finished.
+// Every step runs in its own transaction, so a migration that is interrupted
+// (e.g. by an error or a restart) continues with the next batch when the job
+// runs again. The error of a failed step is recorded on the migration, which
+// fails after MaxAttempts failed steps in a row or on an error that retrying
+// won't fix. Such a migration has to be requested again (see
+// Repository.Create).
+func (j *Job) Run(ctx context.Context) {
+	migrations, err := NewRepository(j.db).ListUnfinished(ctx)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{"err": err}, "failed to list the unfinished space template migrations")
+		return
+	}
+	for _, m := range migrations {
+		for !m.State.Finished() {
+			err := models.Transactional(j.db, func(tx *gorm.DB) error {
+				updated, err := NewRepository(tx).Run(ctx, m.ID, j.batchSize)
+				if err != nil {
+					return err
+				}
+				m = *updated
+				return nil
+			})
+			if err != nil {
+				log.Error(ctx, map[string]interface{}{
+					"err":          err,
+					"migration_id": m.ID,
+					"space_id":     m.SpaceID,
+				}, "failed to run space template migration")
+				if err := NewRepository(j.db).RecordError(ctx, m.ID, err); err != nil {
+					log.Error(ctx, map[string]interface{}{
+						"err":          err,
+						"migration_id": m.ID,
+					}, "failed to record the error of the space template migration")
+				}
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Package templatemigration moves existing spaces from one space template to
+// another. Work items are converted to the work item types of the new
+// template, links get the link types of the new template and board columns are
+// remapped by name. The conversion runs in batches in a background job (see
+// Job) and can be tried out with a dry run first.
+package templatemigration
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// State tells how far a space template migration has progressed.
+type State string
+
+// The states of a space template migration
+const (
+	// StatePending is the state of a migration that was requested but hasn't
+	// been picked up by the job yet.
+	StatePending State = "pending"
+	// StateRunning is the state of a migration that has switched the space
+	// template of the space and is converting the work items and links.
+	StateRunning State = "running"
+	// StateCompleted is the final state of a successful migration or dry run.
+	StateCompleted State = "completed"
+	// StateFailed is the final state of a migration that found problems
+	// before it changed anything (see Report.Problems) or that was given up
+	// while running (see Error). A failed running migration leaves the space
+	// half-migrated until a migration to the same template is requested
+	// again (see Repository.Create).
+	StateFailed State = "failed"
+)
+
+// MaxAttempts is the number of failed attempts in a row after which a
+// migration is given up.
+const MaxAttempts = 5
+
+// Finished returns true if the state is a final one.
+func (s State) Finished() bool {
+	return s == StateCompleted || s == StateFailed
+}
+
+// TypeMapping describes how the work items of one work item type of the old
+// space template are converted.
+type TypeMapping struct {
+	// TypeID is the work item type of the new space template
+	TypeID uuid.UUID `json:"type_id"`
+	// Fields renames fields of the old type to fields of the new type. Fields
+	// with the same name in both types don't need to be listed.
+	Fields map[string]string `json:"fields,omitempty"`
+	// EnumValues maps values of enum fields (by field name in the new type)
+	// to values that are allowed in the new type. Values that are not mapped
+	// are kept if the new type allows them.
+	EnumValues map[string]map[string]string `json:"enum_values,omitempty"`
+}
+
+// TypeMappings maps the IDs of work item types of the old space template to
+// their conversion.
+type TypeMappings map[uuid.UUID]TypeMapping
+
+// Ensure TypeMappings implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*TypeMappings)(nil)
+var _ driver.Valuer = (*TypeMappings)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (m TypeMappings) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return toBytes(m)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (m *TypeMappings) Scan(src interface{}) error {
+	return fromBytes(src, m)
+}
+
+// LinkTypeMappings maps the IDs of link types of the old space template to
+// link types of the new space template.
+type LinkTypeMappings map[uuid.UUID]uuid.UUID
+
+// Ensure LinkTypeMappings implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*LinkTypeMappings)(nil)
+var _ driver.Valuer = (*LinkTypeMappings)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (m LinkTypeMappings) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return toBytes(m)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (m *LinkTypeMappings) Scan(src interface{}) error {
+	return fromBytes(src, m)
+}
+
+// TypeReport describes how the work items of one work item type are
+// converted.
+type TypeReport struct {
+	SourceTypeID   uuid.UUID  `json:"source_type_id"`
+	SourceTypeName string     `json:"source_type_name"`
+	TargetTypeID   *uuid.UUID `json:"target_type_id,omitempty"`
+	TargetTypeName string     `json:"target_type_name,omitempty"`
+	WorkItems      int        `json:"work_items"`
+	// MovedFields are the fields of the old type that have no counterpart in
+	// the new type. Their values are appended to the description.
+	MovedFields []string `json:"moved_fields,omitempty"`
+	// UnmappedValues lists the enum values (by field name in the new type)
+	// that are in use but not allowed by the new type. These fields are reset
+	// to their default and the old values are appended to the description.
+	UnmappedValues map[string][]string `json:"unmapped_values,omitempty"`
+}
+
+// LinkTypeReport describes how the links of one link type are converted.
+type LinkTypeReport struct {
+	SourceLinkTypeID   uuid.UUID  `json:"source_link_type_id"`
+	SourceLinkTypeName string     `json:"source_link_type_name"`
+	TargetLinkTypeID   *uuid.UUID `json:"target_link_type_id,omitempty"`
+	TargetLinkTypeName string     `json:"target_link_type_name,omitempty"`
+	Links              int        `json:"links"`
+}
+
+// Report summarizes what a migration changes. Problems prevent the migration
+// from running.
+type Report struct {
+	WorkItems     int              `json:"work_items"`
+	Links         int              `json:"links"`
+	Types         []TypeReport     `json:"types,omitempty"`
+	LinkTypes     []LinkTypeReport `json:"link_types,omitempty"`
+	RemovedBoards []string         `json:"removed_boards,omitempty"`
+	// Unplanned lists the types of work items that were created or moved
+	// into the space after the migration was planned.
+	Unplanned []TypeReport `json:"unplanned,omitempty"`
+	Problems  []string     `json:"problems,omitempty"`
+}
+
+// addUnplanned counts a work item of the given type that is converted
+// although it wasn't there when the migration was planned.
+func (r *Report) addUnplanned(oldType, newType workitem.WorkItemType) {
+	for _, tr := range r.Types {
+		if tr.SourceTypeID == oldType.ID {
+			return
+		}
+	}
+	for i := range r.Unplanned {
+		if r.Unplanned[i].SourceTypeID == oldType.ID {
+			r.Unplanned[i].WorkItems++
+			return
+		}
+	}
+	r.Unplanned = append(r.Unplanned, TypeReport{
+		SourceTypeID:   oldType.ID,
+		SourceTypeName: oldType.Name,
+		TargetTypeID:   &newType.ID,
+		TargetTypeName: newType.Name,
+		WorkItems:      1,
+	})
+}
+
+// Ensure Report implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*Report)(nil)
+var _ driver.Valuer = (*Report)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (r Report) Value() (driver.Value, error) {
+	return toBytes(r)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (r *Report) Scan(src interface{}) error {
+	return fromBytes(src, r)
+}
+
+// Migration moves a space from its space template to another one.
+type Migration struct {
+	gormsupport.Lifecycle
+	ID               uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	SpaceID          uuid.UUID `sql:"type:uuid"`
+	SourceTemplateID uuid.UUID `sql:"type:uuid"`
+	TargetTemplateID uuid.UUID `sql:"type:uuid"`
+	// TypeMapping tells how the work item types of the old template are
+	// converted. Types that are not mapped are converted to the type with
+	// the same name in the new template.
+	TypeMapping TypeMappings `sql:"type:jsonb"`
+	// LinkTypeMapping tells how the link types of the old template are
+	// converted. Link types that are not mapped are converted to the link
+	// type with the same name in the new template.
+	LinkTypeMapping LinkTypeMappings `sql:"type:jsonb"`
+	// DryRun migrations only compute the report and change nothing
+	DryRun bool
+	State  State
+	// MigratedWorkItems is the number of work items converted so far
+	MigratedWorkItems int
+	Report            Report `sql:"type:jsonb"`
+	// Error holds the last error that interrupted the migration. A running
+	// migration is retried by the job until it failed MaxAttempts times in a
+	// row or the error can't be fixed by trying again.
+	Error *string
+	// Attempts is the number of failed attempts in a row
+	Attempts  int
+	CreatorID uuid.UUID `sql:"type:uuid"`
+	Version   int
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (m Migration) TableName() string {
+	return "space_template_migrations"
+}
+
+func toBytes(j interface{}) (driver.Value, error) {
+	res, err := json.Marshal(j)
+	return res, err
+}
+
+func fromBytes(src interface{}, target interface{}) error {
+	if src == nil {
+		return nil
+	}
+	s, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("scan source was not a string")
+	}
+	return json.Unmarshal(s, target)
+}
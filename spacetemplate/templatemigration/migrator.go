@@ -0,0 +1,600 @@
+package templatemigration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Plan checks the given migration against the current content of its space
+// and returns what the migration would change. Unresolvable work item types
+// or link types are reported as problems.
+func (r *GormRepository) Plan(ctx context.Context, m Migration) (*Report, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplatemigration", "plan"}, time.Now())
+	report := Report{}
+	witRepo := workitem.NewWorkItemTypeRepository(r.db)
+	targetTypes, err := witRepo.List(ctx, m.TargetTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list work item types of space template %s", m.TargetTemplateID)
+	}
+
+	// work items by type
+	query := fmt.Sprintf(`
+		SELECT wi.type, count(wi.id)
+		FROM %[1]s wi JOIN %[2]s t ON t.id = wi.type
+		WHERE wi.space_id = $1 AND wi.deleted_at IS NULL AND t.space_template_id <> $2
+		GROUP BY wi.type`, workitem.WorkItemStorage{}.TableName(), workitem.WorkItemType{}.TableName())
+	counts, err := r.count(ctx, query, m.SpaceID, m.TargetTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	for typeID, count := range counts {
+		oldType, err := witRepo.Load(ctx, typeID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to load work item type %s", typeID)
+		}
+		report.WorkItems += count
+		tr := TypeReport{
+			SourceTypeID:   oldType.ID,
+			SourceTypeName: oldType.Name,
+			WorkItems:      count,
+		}
+		newType, err := resolveType(m, *oldType, targetTypes)
+		if err != nil {
+			report.Problems = append(report.Problems, err.Error())
+			report.Types = append(report.Types, tr)
+			continue
+		}
+		tr.TargetTypeID = &newType.ID
+		tr.TargetTypeName = newType.Name
+		problems, err := r.planType(ctx, m, &tr, *oldType, *newType)
+		if err != nil {
+			return nil, err
+		}
+		report.Problems = append(report.Problems, problems...)
+		report.Types = append(report.Types, tr)
+	}
+	sort.Slice(report.Types, func(i, j int) bool { return report.Types[i].SourceTypeName < report.Types[j].SourceTypeName })
+
+	// links by link type
+	linkTypeRepo := link.NewWorkItemLinkTypeRepository(r.db)
+	targetLinkTypes, err := linkTypeRepo.List(ctx, m.TargetTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list link types of space template %s", m.TargetTemplateID)
+	}
+	query = fmt.Sprintf(`
+		SELECT l.link_type_id, count(l.id)
+		FROM %[1]s l JOIN %[2]s t ON t.id = l.link_type_id JOIN %[3]s wi ON wi.id = l.source_id
+		WHERE wi.space_id = $1 AND t.space_template_id = $2 AND l.deleted_at IS NULL
+		GROUP BY l.link_type_id`, link.WorkItemLink{}.TableName(), link.WorkItemLinkType{}.TableName(), workitem.WorkItemStorage{}.TableName())
+	counts, err = r.count(ctx, query, m.SpaceID, m.SourceTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	for linkTypeID, count := range counts {
+		oldLinkType, err := linkTypeRepo.Load(ctx, linkTypeID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to load link type %s", linkTypeID)
+		}
+		report.Links += count
+		lr := LinkTypeReport{
+			SourceLinkTypeID:   oldLinkType.ID,
+			SourceLinkTypeName: oldLinkType.Name,
+			Links:              count,
+		}
+		newLinkType, err := resolveLinkType(m, *oldLinkType, targetLinkTypes)
+		if err != nil {
+			report.Problems = append(report.Problems, err.Error())
+		} else {
+			lr.TargetLinkTypeID = &newLinkType.ID
+			lr.TargetLinkTypeName = newLinkType.Name
+		}
+		report.LinkTypes = append(report.LinkTypes, lr)
+	}
+	sort.Slice(report.LinkTypes, func(i, j int) bool {
+		return report.LinkTypes[i].SourceLinkTypeName < report.LinkTypes[j].SourceLinkTypeName
+	})
+
+	// boards that were customized for the space
+	boards, err := workitem.NewBoardRepository(r.db).ListBySpace(ctx, m.SpaceID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list the boards of space %s", m.SpaceID)
+	}
+	for _, b := range boards {
+		if b.SpaceTemplateID != m.TargetTemplateID {
+			report.RemovedBoards = append(report.RemovedBoards, b.Name)
+		}
+	}
+	return &report, nil
+}
+
+// planType fills in the fields and enum values of the given type report that
+// cannot be converted and returns the problems of the field mapping.
+func (r *GormRepository) planType(ctx context.Context, m Migration, tr *TypeReport, oldType, newType workitem.WorkItemType) ([]string, error) {
+	var problems []string
+	mapping := m.TypeMapping[oldType.ID]
+	sourceNames := map[string]string{}
+	for from, to := range mapping.Fields {
+		if _, ok := oldType.Fields[from]; !ok {
+			problems = append(problems, fmt.Sprintf("field %q doesn't exist in work item type %q", from, oldType.Name))
+			continue
+		}
+		if _, ok := newType.Fields[to]; !ok {
+			problems = append(problems, fmt.Sprintf("field %q doesn't exist in work item type %q", to, newType.Name))
+			continue
+		}
+		sourceNames[to] = from
+	}
+	for name := range oldType.Fields {
+		if name == workitem.SystemMetaState {
+			continue
+		}
+		target := name
+		if to, ok := mapping.Fields[name]; ok {
+			target = to
+		}
+		if _, ok := newType.Fields[target]; !ok {
+			tr.MovedFields = append(tr.MovedFields, name)
+		}
+	}
+	sort.Strings(tr.MovedFields)
+
+	for name, def := range newType.Fields {
+		enumType, ok := def.Type.(workitem.EnumType)
+		if name == workitem.SystemMetaState || !ok || enumType.BaseType.Kind != workitem.KindString {
+			continue
+		}
+		sourceName := name
+		if from, ok := sourceNames[name]; ok {
+			sourceName = from
+		}
+		if _, ok := oldType.Fields[sourceName]; !ok {
+			continue
+		}
+		query := fmt.Sprintf(`
+			SELECT DISTINCT fields->>$1
+			FROM %s
+			WHERE space_id = $2 AND type = $3 AND deleted_at IS NULL AND fields->>$1 IS NOT NULL`, workitem.WorkItemStorage{}.TableName())
+		rows, err := r.db.Raw(query, sourceName, m.SpaceID, oldType.ID).Rows()
+		if err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		var unmapped []string
+		for rows.Next() {
+			var val string
+			if err := rows.Scan(&val); err != nil {
+				rows.Close()
+				return nil, errors.NewInternalError(ctx, err)
+			}
+			if _, err := def.Type.ConvertToModel(mapEnumValue(mapping, name, val)); err != nil {
+				unmapped = append(unmapped, val)
+			}
+		}
+		rows.Close()
+		if len(unmapped) > 0 {
+			sort.Strings(unmapped)
+			if tr.UnmappedValues == nil {
+				tr.UnmappedValues = map[string][]string{}
+			}
+			tr.UnmappedValues[name] = unmapped
+		}
+	}
+	return problems, nil
+}
+
+// count runs the given query that returns pairs of IDs and counts.
+func (r *GormRepository) count(ctx context.Context, query string, values ...interface{}) (map[uuid.UUID]int, error) {
+	rows, err := r.db.Raw(query, values...).Rows()
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	defer rows.Close()
+	res := map[uuid.UUID]int{}
+	for rows.Next() {
+		var id uuid.UUID
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		res[id] = count
+	}
+	return res, nil
+}
+
+// Run performs the next step of the migration with the given ID. A pending
+// migration is planned first. Dry runs and migrations with problems end there,
+// all others switch the space to the target template. A running migration
+// converts the next batch of work items and, once all work items are
+// converted, the next batch of links. When nothing is left, the boards that
+// were customized for the space under the old template are removed and the
+// migration is completed.
+//
+// The updated migration is returned. Run the steps until the migration is
+// finished, each in its own transaction.
+// returns NotFoundError, BadParameterError, VersionConflictError or InternalError
+func (r *GormRepository) Run(ctx context.Context, id uuid.UUID, batchSize int) (*Migration, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplatemigration", "run"}, time.Now())
+	var m Migration
+	tx := r.db.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&m)
+	if tx.RecordNotFound() {
+		return nil, errors.NewNotFoundError("space template migration", id.String())
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	var err error
+	switch m.State {
+	case StatePending:
+		err = r.start(ctx, &m)
+	case StateRunning:
+		err = r.step(ctx, &m, batchSize)
+	default:
+		return &m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Error = nil
+	m.Attempts = 0
+	if err := r.save(ctx, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// start plans the given migration and switches the space template of the
+// space unless the migration is a dry run or has problems. A migration that
+// retries a failed one finds the space switched already.
+func (r *GormRepository) start(ctx context.Context, m *Migration) error {
+	report, err := r.Plan(ctx, *m)
+	if err != nil {
+		return errs.Wrapf(err, "failed to plan space template migration %s", m.ID)
+	}
+	m.Report = *report
+	if len(report.Problems) > 0 {
+		m.State = StateFailed
+		return nil
+	}
+	if m.DryRun {
+		m.State = StateCompleted
+		return nil
+	}
+	s, err := space.NewRepository(r.db).Load(ctx, m.SpaceID)
+	if err != nil {
+		return errs.Wrapf(err, "failed to load space %s", m.SpaceID)
+	}
+	if s.SpaceTemplateID == m.TargetTemplateID {
+		m.State = StateRunning
+		log.Info(ctx, map[string]interface{}{
+			"space_id":           m.SpaceID,
+			"migration_id":       m.ID,
+			"target_template_id": m.TargetTemplateID,
+		}, "resuming failed space template migration")
+		return nil
+	}
+	db := r.db.Model(&space.Space{}).Where("id = ? AND space_template_id = ?", m.SpaceID, m.SourceTemplateID).Updates(map[string]interface{}{
+		"space_template_id": m.TargetTemplateID,
+		"version":           gorm.Expr("version + 1"),
+	})
+	if err := db.Error; err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to switch the space template of space %s", m.SpaceID))
+	}
+	if db.RowsAffected == 0 {
+		m.State = StateFailed
+		m.Report.Problems = append(m.Report.Problems, fmt.Sprintf("the space template of space %s is no longer %s", m.SpaceID, m.SourceTemplateID))
+		return nil
+	}
+	m.State = StateRunning
+	log.Info(ctx, map[string]interface{}{
+		"space_id":           m.SpaceID,
+		"migration_id":       m.ID,
+		"target_template_id": m.TargetTemplateID,
+	}, "switched space to new space template")
+	return nil
+}
+
+// step converts the next batch of work items or links of a running migration
+// and completes it when nothing is left.
+func (r *GormRepository) step(ctx context.Context, m *Migration, batchSize int) error {
+	n, err := r.migrateWorkItems(ctx, m, batchSize)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		m.MigratedWorkItems += n
+		return nil
+	}
+	n, err = r.migrateLinks(ctx, *m, batchSize)
+	if err != nil || n > 0 {
+		return err
+	}
+	boardRepo := workitem.NewBoardRepository(r.db)
+	boards, err := boardRepo.ListBySpace(ctx, m.SpaceID)
+	if err != nil {
+		return errs.Wrapf(err, "failed to list the boards of space %s", m.SpaceID)
+	}
+	for _, b := range boards {
+		if b.SpaceTemplateID == m.TargetTemplateID {
+			continue
+		}
+		if err := boardRepo.Delete(ctx, b.ID); err != nil {
+			return errs.Wrapf(err, "failed to delete board %s", b.ID)
+		}
+	}
+	m.State = StateCompleted
+	log.Info(ctx, map[string]interface{}{
+		"space_id":     m.SpaceID,
+		"migration_id": m.ID,
+		"work_items":   m.MigratedWorkItems,
+	}, "completed space template migration")
+	return nil
+}
+
+// migrateWorkItems converts the next batch of work items of the space that
+// still have a type of another space template and returns their number. The
+// target type is resolved for every work item, so items that were created or
+// moved into the space after the migration was planned are converted as
+// well; their types are added to the report.
+func (r *GormRepository) migrateWorkItems(ctx context.Context, m *Migration, batchSize int) (int, error) {
+	var items []workitem.WorkItemStorage
+	db := r.db.Where(fmt.Sprintf("space_id = ? AND type NOT IN (SELECT id FROM %s WHERE space_template_id = ?)", workitem.WorkItemType{}.TableName()), m.SpaceID, m.TargetTemplateID)
+	if err := db.Order("id").Limit(batchSize).Find(&items).Error; err != nil {
+		return 0, errors.NewInternalError(ctx, err)
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+	witRepo := workitem.NewWorkItemTypeRepository(r.db)
+	wiRepo := workitem.NewWorkItemRepository(r.db)
+	revisionRepo := workitem.NewRevisionRepository(r.db)
+	targetTypes, err := witRepo.List(ctx, m.TargetTemplateID)
+	if err != nil {
+		return 0, errs.Wrapf(err, "failed to list work item types of space template %s", m.TargetTemplateID)
+	}
+	columns, err := r.columnMapping(ctx, *m)
+	if err != nil {
+		return 0, err
+	}
+	oldTypes := map[uuid.UUID]*workitem.WorkItemType{}
+	for i := range items {
+		wi := &items[i]
+		oldType, ok := oldTypes[wi.Type]
+		if !ok {
+			oldType, err = witRepo.Load(ctx, wi.Type)
+			if err != nil {
+				return 0, errs.Wrapf(err, "failed to load work item type %s", wi.Type)
+			}
+			oldTypes[wi.Type] = oldType
+		}
+		newType, err := resolveType(*m, *oldType, targetTypes)
+		if err != nil {
+			return 0, err
+		}
+		m.Report.addUnplanned(*oldType, *newType)
+		applyTypeMapping(wi.Fields, *newType, m.TypeMapping[oldType.ID])
+		remapBoardColumns(wi.Fields, columns)
+		if err := wiRepo.ChangeWorkItemType(ctx, wi, oldType, newType, m.SpaceID); err != nil {
+			return 0, errs.Wrapf(err, "failed to change the type of work item %s from %s to %s", wi.ID, oldType.Name, newType.Name)
+		}
+		oldVersion := wi.Version
+		wi.Version++
+		tx := r.db.Where("version = ?", oldVersion).Save(wi)
+		if err := tx.Error; err != nil {
+			return 0, errors.NewInternalError(ctx, err)
+		}
+		if tx.RowsAffected == 0 {
+			return 0, errors.NewVersionConflictError("version conflict")
+		}
+		if _, err := revisionRepo.Create(ctx, m.CreatorID, workitem.RevisionTypeUpdate, *wi); err != nil {
+			return 0, errs.Wrapf(err, "failed to record revision of work item %s", wi.ID)
+		}
+	}
+	return len(items), nil
+}
+
+// migrateLinks converts the next batch of links of the space that still have
+// a link type of the source template and returns their number.
+func (r *GormRepository) migrateLinks(ctx context.Context, m Migration, batchSize int) (int, error) {
+	var links []link.WorkItemLink
+	db := r.db.Where(fmt.Sprintf("link_type_id IN (SELECT id FROM %s WHERE space_template_id = ?) AND source_id IN (SELECT id FROM %s WHERE space_id = ?)",
+		link.WorkItemLinkType{}.TableName(), workitem.WorkItemStorage{}.TableName()), m.SourceTemplateID, m.SpaceID)
+	if err := db.Order("id").Limit(batchSize).Find(&links).Error; err != nil {
+		return 0, errors.NewInternalError(ctx, err)
+	}
+	if len(links) == 0 {
+		return 0, nil
+	}
+	linkTypeRepo := link.NewWorkItemLinkTypeRepository(r.db)
+	revisionRepo := link.NewRevisionRepository(r.db)
+	targetLinkTypes, err := linkTypeRepo.List(ctx, m.TargetTemplateID)
+	if err != nil {
+		return 0, errs.Wrapf(err, "failed to list link types of space template %s", m.TargetTemplateID)
+	}
+	newLinkTypes := map[uuid.UUID]uuid.UUID{}
+	for i := range links {
+		l := &links[i]
+		newLinkTypeID, ok := newLinkTypes[l.LinkTypeID]
+		if !ok {
+			oldLinkType, err := linkTypeRepo.Load(ctx, l.LinkTypeID)
+			if err != nil {
+				return 0, errs.Wrapf(err, "failed to load link type %s", l.LinkTypeID)
+			}
+			newLinkType, err := resolveLinkType(m, *oldLinkType, targetLinkTypes)
+			if err != nil {
+				return 0, err
+			}
+			newLinkTypeID = newLinkType.ID
+			newLinkTypes[l.LinkTypeID] = newLinkTypeID
+		}
+		oldVersion := l.Version
+		l.LinkTypeID = newLinkTypeID
+		l.Version++
+		tx := r.db.Where("version = ?", oldVersion).Save(l)
+		if err := tx.Error; err != nil {
+			return 0, errors.NewInternalError(ctx, err)
+		}
+		if tx.RowsAffected == 0 {
+			return 0, errors.NewVersionConflictError("version conflict")
+		}
+		if err := revisionRepo.Create(ctx, m.CreatorID, link.RevisionTypeUpdate, *l); err != nil {
+			return 0, errs.Wrapf(err, "failed to record revision of link %s", l.ID)
+		}
+	}
+	return len(links), nil
+}
+
+// columnMapping maps the IDs of the board columns of the source template (and
+// of the boards customized for the space) to the IDs of the columns with the
+// same name on the boards of the target template.
+func (r *GormRepository) columnMapping(ctx context.Context, m Migration) (map[string]string, error) {
+	query := fmt.Sprintf(`
+		SELECT oc.id, nc.id
+		FROM %[1]s oc
+		JOIN %[2]s ob ON ob.id = oc.board_id AND ob.space_template_id = $1 AND (ob.space_id IS NULL OR ob.space_id = $2)
+		JOIN %[1]s nc ON nc.name = oc.name AND nc.deleted_at IS NULL
+		JOIN %[2]s nb ON nb.id = nc.board_id AND nb.space_template_id = $3 AND nb.space_id IS NULL AND nb.deleted_at IS NULL
+		WHERE oc.deleted_at IS NULL
+		ORDER BY nb.name, nc.column_order`, workitem.BoardColumn{}.TableName(), workitem.Board{}.TableName())
+	rows, err := r.db.Raw(query, m.SourceTemplateID, m.SpaceID, m.TargetTemplateID).Rows()
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	defer rows.Close()
+	res := map[string]string{}
+	for rows.Next() {
+		var oldID, newID uuid.UUID
+		if err := rows.Scan(&oldID, &newID); err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		// the first board of the target template wins
+		if _, ok := res[oldID.String()]; !ok {
+			res[oldID.String()] = newID.String()
+		}
+	}
+	return res, nil
+}
+
+// resolveType returns the work item type of the target template that items of
+// the given type are converted to.
+func resolveType(m Migration, oldType workitem.WorkItemType, targetTypes []workitem.WorkItemType) (*workitem.WorkItemType, error) {
+	if mapping, ok := m.TypeMapping[oldType.ID]; ok {
+		for i := range targetTypes {
+			if targetTypes[i].ID == mapping.TypeID && targetTypes[i].CanConstruct {
+				return &targetTypes[i], nil
+			}
+		}
+		return nil, errors.NewBadParameterError("type mapping", mapping.TypeID).Expected(fmt.Sprintf("a constructable work item type of space template %s", m.TargetTemplateID))
+	}
+	for i := range targetTypes {
+		if targetTypes[i].Name == oldType.Name && targetTypes[i].CanConstruct {
+			return &targetTypes[i], nil
+		}
+	}
+	return nil, errors.NewBadParameterErrorFromString(
+		fmt.Sprintf("work item type %q (ID: %s) has no counterpart in space template %s, please provide a type mapping", oldType.Name, oldType.ID, m.TargetTemplateID),
+	)
+}
+
+// resolveLinkType returns the link type of the target template (or of the
+// base template) that links of the given link type are converted to.
+func resolveLinkType(m Migration, oldLinkType link.WorkItemLinkType, targetLinkTypes []link.WorkItemLinkType) (*link.WorkItemLinkType, error) {
+	if mappedID, ok := m.LinkTypeMapping[oldLinkType.ID]; ok {
+		for i := range targetLinkTypes {
+			if targetLinkTypes[i].ID == mappedID {
+				return &targetLinkTypes[i], nil
+			}
+		}
+		return nil, errors.NewBadParameterError("link type mapping", mappedID).Expected(fmt.Sprintf("a link type of space template %s", m.TargetTemplateID))
+	}
+	for i := range targetLinkTypes {
+		if targetLinkTypes[i].Name == oldLinkType.Name {
+			return &targetLinkTypes[i], nil
+		}
+	}
+	return nil, errors.NewBadParameterErrorFromString(
+		fmt.Sprintf("link type %q (ID: %s) has no counterpart in space template %s, please provide a link type mapping", oldLinkType.Name, oldLinkType.ID, m.TargetTemplateID),
+	)
+}
+
+// applyTypeMapping renames the fields and maps the enum values of the given
+// work item fields as described by the type mapping. Renamed values that the
+// new field doesn't accept are left alone so that ChangeWorkItemType moves
+// them into the description.
+func applyTypeMapping(fields workitem.Fields, newType workitem.WorkItemType, mapping TypeMapping) {
+	renamed := map[string]struct{}{}
+	for from, to := range mapping.Fields {
+		val, ok := fields[from]
+		newField, exists := newType.Fields[to]
+		if !ok || !exists || from == to {
+			continue
+		}
+		converted, err := newField.ConvertToModel(to, mapEnumValue(mapping, to, val))
+		if err != nil {
+			continue
+		}
+		fields[to] = converted
+		delete(fields, from)
+		renamed[to] = struct{}{}
+	}
+	for name := range mapping.EnumValues {
+		if _, ok := renamed[name]; ok {
+			continue
+		}
+		if val, ok := fields[name]; ok {
+			fields[name] = mapEnumValue(mapping, name, val)
+		}
+	}
+}
+
+// mapEnumValue returns the value that the given value of the given field is
+// mapped to. Lists are mapped element by element.
+func mapEnumValue(mapping TypeMapping, field string, val interface{}) interface{} {
+	values, ok := mapping.EnumValues[field]
+	if !ok || val == nil {
+		return val
+	}
+	if list, ok := val.([]interface{}); ok {
+		res := make([]interface{}, len(list))
+		for i, v := range list {
+			res[i] = mapEnumValue(mapping, field, v)
+		}
+		return res
+	}
+	if newVal, ok := values[fmt.Sprint(val)]; ok {
+		return newVal
+	}
+	return val
+}
+
+// remapBoardColumns replaces the board columns of a work item with the
+// columns of the same name in the target template. Columns without
+// counterpart are dropped.
+func remapBoardColumns(fields workitem.Fields, columns map[string]string) {
+	val, ok := fields[workitem.SystemBoardcolumns]
+	if !ok {
+		return
+	}
+	list, _ := val.([]interface{})
+	res := []interface{}{}
+	for _, id := range list {
+		if newID, ok := columns[fmt.Sprint(id)]; ok {
+			res = append(res, newID)
+		}
+	}
+	if len(res) == 0 {
+		delete(fields, workitem.SystemBoardcolumns)
+		return
+	}
+	fields[workitem.SystemBoardcolumns] = res
+}
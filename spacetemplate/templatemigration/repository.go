@@ -0,0 +1,245 @@
+package templatemigration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository describes interactions with space template migrations
+type Repository interface {
+	Create(ctx context.Context, m Migration) (*Migration, error)
+	Load(ctx context.Context, id uuid.UUID) (*Migration, error)
+	List(ctx context.Context, spaceID uuid.UUID) ([]Migration, error)
+	ListUnfinished(ctx context.Context) ([]Migration, error)
+	Plan(ctx context.Context, m Migration) (*Report, error)
+	Run(ctx context.Context, id uuid.UUID, batchSize int) (*Migration, error)
+	RecordError(ctx context.Context, id uuid.UUID, cause error) error
+}
+
+// NewRepository creates a new space template migration repository.
+func NewRepository(db *gorm.DB) Repository {
+	return &GormRepository{db: db}
+}
+
+// GormRepository is the implementation of the storage interface for space
+// template migrations.
+type GormRepository struct {
+	db *gorm.DB
+}
+
+// Create requests the migration of a space to the target space template of
+// the given migration. The source template is taken from the space. Only one
+// unfinished migration per space is allowed.
+//
+// A migration that failed after it switched the space template leaves work
+// items or links of the old template behind. It is retried by requesting a
+// migration to the current space template of the space, which takes over the
+// source template of the failed migration.
+// returns NotFoundError, BadParameterError, DataConflictError or InternalError
+func (r *GormRepository) Create(ctx context.Context, m Migration) (*Migration, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplatemigration", "create"}, time.Now())
+	s, err := space.NewRepository(r.db).Load(ctx, m.SpaceID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to load space %s", m.SpaceID)
+	}
+	sourceTemplateID := s.SpaceTemplateID
+	if m.TargetTemplateID == s.SpaceTemplateID {
+		failed, err := r.unfinishedFailure(ctx, *s)
+		if err != nil {
+			return nil, err
+		}
+		if failed == nil {
+			return nil, errors.NewBadParameterError("target space template", m.TargetTemplateID).Expected("a space template different from the one of the space")
+		}
+		sourceTemplateID = failed.SourceTemplateID
+	}
+	target, err := spacetemplate.NewRepository(r.db).Load(ctx, m.TargetTemplateID)
+	if err != nil {
+		if notFound, _ := errors.IsNotFoundError(err); notFound {
+			return nil, errors.NewBadParameterError("target space template", m.TargetTemplateID).Expected("an existing space template")
+		}
+		return nil, errs.Wrapf(err, "failed to load space template %s", m.TargetTemplateID)
+	}
+	if !target.CanConstruct {
+		return nil, errors.NewBadParameterError("target space template", m.TargetTemplateID).Expected("a space template that spaces can be created from")
+	}
+	m.ID = uuid.NewV4()
+	m.SourceTemplateID = sourceTemplateID
+	m.State = StatePending
+	m.MigratedWorkItems = 0
+	m.Report = Report{}
+	m.Error = nil
+	m.Version = 0
+	if err := r.db.Create(&m).Error; err != nil {
+		if gormsupport.IsUniqueViolation(err, "space_template_migrations_unfinished_idx") {
+			return nil, errors.NewDataConflictError(fmt.Sprintf("space %s is already being migrated", m.SpaceID))
+		}
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to create space template migration"))
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_id":           m.SpaceID,
+		"migration_id":       m.ID,
+		"source_template_id": m.SourceTemplateID,
+		"target_template_id": m.TargetTemplateID,
+		"dry_run":            m.DryRun,
+	}, "requested space template migration")
+	return &m, nil
+}
+
+// unfinishedFailure returns the latest failed migration of the given space to
+// its current space template if work items or links of the source template
+// of that migration remain. Otherwise nil is returned.
+func (r *GormRepository) unfinishedFailure(ctx context.Context, s space.Space) (*Migration, error) {
+	var m Migration
+	tx := r.db.Where("space_id = ? AND target_template_id = ? AND state = ? AND NOT dry_run", s.ID, s.SpaceTemplateID, StateFailed).Order("created_at DESC").First(&m)
+	if tx.RecordNotFound() {
+		return nil, nil
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			(SELECT count(wi.id)
+			FROM %[1]s wi JOIN %[2]s t ON t.id = wi.type
+			WHERE wi.space_id = $1 AND wi.deleted_at IS NULL AND t.space_template_id <> $2)
+			+
+			(SELECT count(l.id)
+			FROM %[3]s l JOIN %[4]s t ON t.id = l.link_type_id JOIN %[1]s wi ON wi.id = l.source_id
+			WHERE wi.space_id = $1 AND t.space_template_id = $3 AND l.deleted_at IS NULL)`,
+		workitem.WorkItemStorage{}.TableName(), workitem.WorkItemType{}.TableName(), link.WorkItemLink{}.TableName(), link.WorkItemLinkType{}.TableName())
+	var remaining int
+	if err := r.db.CommonDB().QueryRow(query, s.ID, s.SpaceTemplateID, m.SourceTemplateID).Scan(&remaining); err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if remaining == 0 {
+		return nil, nil
+	}
+	return &m, nil
+}
+
+// Load returns the space template migration with the given ID.
+// returns NotFoundError or InternalError
+func (r *GormRepository) Load(ctx context.Context, id uuid.UUID) (*Migration, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplatemigration", "get"}, time.Now())
+	var res Migration
+	tx := r.db.Where("id = ?", id).First(&res)
+	if tx.RecordNotFound() {
+		return nil, errors.NewNotFoundError("space template migration", id.String())
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	return &res, nil
+}
+
+// List returns the space template migrations of the given space, newest
+// first.
+func (r *GormRepository) List(ctx context.Context, spaceID uuid.UUID) ([]Migration, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplatemigration", "query"}, time.Now())
+	var res []Migration
+	if err := r.db.Where("space_id = ?", spaceID).Order("created_at DESC").Find(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return res, nil
+}
+
+// ListUnfinished returns the pending and running migrations of all spaces,
+// oldest first.
+func (r *GormRepository) ListUnfinished(ctx context.Context) ([]Migration, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplatemigration", "query"}, time.Now())
+	var res []Migration
+	db := r.db.Where("state IN (?, ?)", StatePending, StateRunning).Order("created_at")
+	if err := db.Find(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return res, nil
+}
+
+// RecordError stores the error that interrupted the migration with the given
+// ID. The migration keeps its state so that the job resumes it later, unless
+// it failed MaxAttempts times in a row or the error is not temporary, in which
+// case the migration fails. A migration that fails while running can be
+// retried (see Create).
+func (r *GormRepository) RecordError(ctx context.Context, id uuid.UUID, cause error) error {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplatemigration", "save"}, time.Now())
+	var m Migration
+	tx := r.db.Where("id = ?", id).First(&m)
+	if tx.RecordNotFound() {
+		return errors.NewNotFoundError("space template migration", id.String())
+	}
+	if tx.Error != nil {
+		return errors.NewInternalError(ctx, tx.Error)
+	}
+	msg := cause.Error()
+	m.Error = &msg
+	m.Attempts++
+	if m.Attempts >= MaxAttempts || !isRetryable(cause) {
+		m.State = StateFailed
+		log.Error(ctx, map[string]interface{}{
+			"err":          cause,
+			"migration_id": id,
+			"attempts":     m.Attempts,
+		}, "giving up space template migration")
+	}
+	db := r.db.Model(&m).Updates(map[string]interface{}{
+		"error":    m.Error,
+		"attempts": m.Attempts,
+		"state":    m.State,
+	})
+	if err := db.Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	return nil
+}
+
+// isRetryable returns false for errors that running the migration again
+// won't fix, e.g. invalid mappings or missing types.
+func isRetryable(err error) bool {
+	for _, is := range []func(error) (bool, error){
+		errors.IsBadParameterError,
+		errors.IsNotFoundError,
+		errors.IsConversionError,
+		errors.IsForbiddenError,
+	} {
+		if ok, _ := is(err); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// save stores the progress of the given migration and increments its
+// version.
+func (r *GormRepository) save(ctx context.Context, m *Migration) error {
+	oldVersion := m.Version
+	m.Version++
+	db := r.db.Model(m).Where("version = ?", oldVersion).Updates(map[string]interface{}{
+		"state":               m.State,
+		"migrated_work_items": m.MigratedWorkItems,
+		"report":              m.Report,
+		"error":               m.Error,
+		"attempts":            m.Attempts,
+		"version":             m.Version,
+	})
+	if err := db.Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	if db.RowsAffected == 0 {
+		return errors.NewVersionConflictError("version conflict")
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+package templatemigration_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/spacetemplate/templatemigration"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestMigrationRepository struct {
+	gormtestsupport.DBTestSuite
+}
+
+func TestRunMigrationRepository(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestMigrationRepository{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+// createFixture creates a space with two "bug" and one "story" work items and
+// a link between them in the first of two space templates. The second
+// template only knows bugs. Both templates have a "related" link type.
+func (s *TestMigrationRepository) createFixture(t *testing.T) *tf.TestFixture {
+	return tf.NewTestFixture(t, s.DB,
+		tf.SpaceTemplates(2),
+		tf.Spaces(1),
+		tf.WorkItemTypes(3, tf.SetWorkItemTypeNames("bug", "story", "bug"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 2 {
+				fxt.WorkItemTypes[idx].SpaceTemplateID = fxt.SpaceTemplates[1].ID
+			}
+			return nil
+		}),
+		tf.WorkItemLinkTypes(2, tf.SetWorkItemLinkTypeNames("related", "related"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 1 {
+				fxt.WorkItemLinkTypes[idx].SpaceTemplateID = fxt.SpaceTemplates[1].ID
+			}
+			return nil
+		}),
+		tf.WorkItems(3, func(fxt *tf.TestFixture, idx int) error {
+			if idx == 2 {
+				fxt.WorkItems[idx].Type = fxt.WorkItemTypes[1].ID
+			}
+			return nil
+		}),
+		tf.WorkItemLinks(1),
+	)
+}
+
+func (s *TestMigrationRepository) newMigration(fxt *tf.TestFixture) templatemigration.Migration {
+	return templatemigration.Migration{
+		SpaceID:          fxt.Spaces[0].ID,
+		TargetTemplateID: fxt.SpaceTemplates[1].ID,
+		CreatorID:        fxt.Identities[0].ID,
+		TypeMapping: templatemigration.TypeMappings{
+			fxt.WorkItemTypes[1].ID: {TypeID: fxt.WorkItemTypes[2].ID},
+		},
+	}
+}
+
+// run runs the given migration until it is finished.
+func (s *TestMigrationRepository) run(t *testing.T, repo templatemigration.Repository, id uuid.UUID) *templatemigration.Migration {
+	for i := 0; i < 10; i++ {
+		m, err := repo.Run(s.Ctx, id, 2)
+		require.NoError(t, err)
+		if m.State.Finished() {
+			return m
+		}
+	}
+	require.Fail(t, "migration didn't finish")
+	return nil
+}
+
+func (s *TestMigrationRepository) TestCreate() {
+	repo := templatemigration.NewRepository(s.DB)
+
+	s.T().Run("ok", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		created, err := repo.Create(s.Ctx, s.newMigration(fxt))
+		require.NoError(t, err)
+		assert.Equal(t, templatemigration.StatePending, created.State)
+		assert.Equal(t, fxt.SpaceTemplates[0].ID, created.SourceTemplateID)
+		loaded, err := repo.Load(s.Ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.TypeMapping, loaded.TypeMapping)
+
+		t.Run("only one unfinished migration per space", func(t *testing.T) {
+			_, err := repo.Create(s.Ctx, s.newMigration(fxt))
+			require.Error(t, err)
+			assert.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+		})
+		t.Run("listed", func(t *testing.T) {
+			migrations, err := repo.List(s.Ctx, fxt.Spaces[0].ID)
+			require.NoError(t, err)
+			require.Len(t, migrations, 1)
+			assert.Equal(t, created.ID, migrations[0].ID)
+		})
+	})
+	s.T().Run("same space template", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		m := s.newMigration(fxt)
+		m.TargetTemplateID = fxt.SpaceTemplates[0].ID
+		_, err := repo.Create(s.Ctx, m)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+	s.T().Run("unknown space template", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		m := s.newMigration(fxt)
+		m.TargetTemplateID = uuid.NewV4()
+		_, err := repo.Create(s.Ctx, m)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+	s.T().Run("unknown space", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		m := s.newMigration(fxt)
+		m.SpaceID = uuid.NewV4()
+		_, err := repo.Create(s.Ctx, m)
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
+func (s *TestMigrationRepository) TestRun() {
+	repo := templatemigration.NewRepository(s.DB)
+
+	s.T().Run("dry run with unmapped type", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		m := s.newMigration(fxt)
+		m.TypeMapping = nil
+		m.DryRun = true
+		created, err := repo.Create(s.Ctx, m)
+		require.NoError(t, err)
+		// when
+		finished := s.run(t, repo, created.ID)
+		// then
+		assert.Equal(t, templatemigration.StateFailed, finished.State)
+		assert.Equal(t, 3, finished.Report.WorkItems)
+		require.Len(t, finished.Report.Types, 2)
+		assert.Equal(t, "bug", finished.Report.Types[0].SourceTypeName)
+		assert.Equal(t, 2, finished.Report.Types[0].WorkItems)
+		assert.Equal(t, fxt.WorkItemTypes[2].ID, *finished.Report.Types[0].TargetTypeID)
+		assert.Nil(t, finished.Report.Types[1].TargetTypeID)
+		require.Len(t, finished.Report.Problems, 1)
+		assert.Contains(t, finished.Report.Problems[0], "story")
+	})
+	s.T().Run("dry run", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		m := s.newMigration(fxt)
+		m.DryRun = true
+		created, err := repo.Create(s.Ctx, m)
+		require.NoError(t, err)
+		// when
+		finished := s.run(t, repo, created.ID)
+		// then
+		assert.Equal(t, templatemigration.StateCompleted, finished.State)
+		assert.Empty(t, finished.Report.Problems)
+		assert.Equal(t, 3, finished.Report.WorkItems)
+		assert.Equal(t, 1, finished.Report.Links)
+		require.Len(t, finished.Report.LinkTypes, 1)
+		assert.Equal(t, fxt.WorkItemLinkTypes[1].ID, *finished.Report.LinkTypes[0].TargetLinkTypeID)
+		// nothing changed
+		sp, err := space.NewRepository(s.DB).Load(s.Ctx, fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.SpaceTemplates[0].ID, sp.SpaceTemplateID)
+		assert.Equal(t, 0, finished.MigratedWorkItems)
+	})
+	s.T().Run("migrate", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		created, err := repo.Create(s.Ctx, s.newMigration(fxt))
+		require.NoError(t, err)
+		// when
+		finished := s.run(t, repo, created.ID)
+		// then
+		assert.Equal(t, templatemigration.StateCompleted, finished.State)
+		assert.Equal(t, 3, finished.MigratedWorkItems)
+		sp, err := space.NewRepository(s.DB).Load(s.Ctx, fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.SpaceTemplates[1].ID, sp.SpaceTemplateID)
+		wiRepo := workitem.NewWorkItemRepository(s.DB)
+		for _, wi := range fxt.WorkItems {
+			loaded, err := wiRepo.LoadByID(s.Ctx, wi.ID)
+			require.NoError(t, err)
+			assert.Equal(t, fxt.WorkItemTypes[2].ID, loaded.Type)
+			assert.Equal(t, wi.Version+1, loaded.Version)
+		}
+		l, err := link.NewWorkItemLinkRepository(s.DB).Load(s.Ctx, fxt.WorkItemLinks[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.WorkItemLinkTypes[1].ID, l.LinkTypeID)
+		unfinished, err := repo.ListUnfinished(s.Ctx)
+		require.NoError(t, err)
+		for _, u := range unfinished {
+			assert.NotEqual(t, finished.ID, u.ID)
+		}
+	})
+}
+
+func (s *TestMigrationRepository) TestRetry() {
+	repo := templatemigration.NewRepository(s.DB)
+	// given a migration that switched the space template and was given up
+	fxt := s.createFixture(s.T())
+	m := s.newMigration(fxt)
+	created, err := repo.Create(s.Ctx, m)
+	require.NoError(s.T(), err)
+	started, err := repo.Run(s.Ctx, created.ID, 2)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), templatemigration.StateRunning, started.State)
+	require.NoError(s.T(), repo.RecordError(s.Ctx, created.ID, errors.NewBadParameterError("mapping", "invalid")))
+	// and a work item of the old template that wasn't there when the
+	// migration was planned
+	late := workitem.WorkItemStorage{
+		ID:      uuid.NewV4(),
+		Number:  100,
+		Type:    fxt.WorkItemTypes[0].ID,
+		SpaceID: fxt.Spaces[0].ID,
+		Fields: workitem.Fields{
+			workitem.SystemTitle: "created after planning",
+			workitem.SystemState: fxt.WorkItems[0].Fields[workitem.SystemState],
+		},
+	}
+	require.NoError(s.T(), s.DB.Create(&late).Error)
+
+	s.T().Run("retried", func(t *testing.T) {
+		// when
+		retry, err := repo.Create(s.Ctx, m)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.SpaceTemplates[0].ID, retry.SourceTemplateID)
+		assert.Equal(t, fxt.SpaceTemplates[1].ID, retry.TargetTemplateID)
+		finished := s.run(t, repo, retry.ID)
+		// then
+		assert.Equal(t, templatemigration.StateCompleted, finished.State)
+		assert.Equal(t, 4, finished.MigratedWorkItems)
+		wiRepo := workitem.NewWorkItemRepository(s.DB)
+		for _, id := range []uuid.UUID{fxt.WorkItems[0].ID, fxt.WorkItems[1].ID, fxt.WorkItems[2].ID, late.ID} {
+			loaded, err := wiRepo.LoadByID(s.Ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, fxt.WorkItemTypes[2].ID, loaded.Type)
+		}
+		l, err := link.NewWorkItemLinkRepository(s.DB).Load(s.Ctx, fxt.WorkItemLinks[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.WorkItemLinkTypes[1].ID, l.LinkTypeID)
+	})
+	s.T().Run("nothing left to retry", func(t *testing.T) {
+		_, err := repo.Create(s.Ctx, m)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func (s *TestMigrationRepository) TestWorkItemCreatedAfterPlanning() {
+	repo := templatemigration.NewRepository(s.DB)
+	// given a started migration
+	fxt := s.createFixture(s.T())
+	created, err := repo.Create(s.Ctx, s.newMigration(fxt))
+	require.NoError(s.T(), err)
+	_, err = repo.Run(s.Ctx, created.ID, 2)
+	require.NoError(s.T(), err)
+	// and a work item of the old template that wasn't there when the
+	// migration was planned
+	late := workitem.WorkItemStorage{
+		ID:      uuid.NewV4(),
+		Number:  100,
+		Type:    fxt.WorkItemTypes[0].ID,
+		SpaceID: fxt.Spaces[0].ID,
+		Fields: workitem.Fields{
+			workitem.SystemTitle: "created after planning",
+			workitem.SystemState: fxt.WorkItems[0].Fields[workitem.SystemState],
+		},
+	}
+	require.NoError(s.T(), s.DB.Create(&late).Error)
+	// when
+	finished := s.run(s.T(), repo, created.ID)
+	// then
+	assert.Equal(s.T(), templatemigration.StateCompleted, finished.State)
+	assert.Equal(s.T(), 4, finished.MigratedWorkItems)
+	loaded, err := workitem.NewWorkItemRepository(s.DB).LoadByID(s.Ctx, late.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fxt.WorkItemTypes[2].ID, loaded.Type)
+}
+
+func (s *TestMigrationRepository) TestRecordError() {
+	repo := templatemigration.NewRepository(s.DB)
+	s.T().Run("temporary error", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		created, err := repo.Create(s.Ctx, s.newMigration(fxt))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.RecordError(s.Ctx, created.ID, errs.New("boom")))
+		loaded, err := repo.Load(s.Ctx, created.ID)
+		require.NoError(t, err)
+		require.NotNil(t, loaded.Error)
+		assert.Equal(t, "boom", *loaded.Error)
+		assert.Equal(t, 1, loaded.Attempts)
+		assert.Equal(t, templatemigration.StatePending, loaded.State)
+	})
+	s.T().Run("too many attempts", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		created, err := repo.Create(s.Ctx, s.newMigration(fxt))
+		require.NoError(t, err)
+
+		for i := 0; i < templatemigration.MaxAttempts; i++ {
+			require.NoError(t, repo.RecordError(s.Ctx, created.ID, errs.New("boom")))
+		}
+		loaded, err := repo.Load(s.Ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, templatemigration.MaxAttempts, loaded.Attempts)
+		assert.Equal(t, templatemigration.StateFailed, loaded.State)
+	})
+	s.T().Run("permanent error", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		created, err := repo.Create(s.Ctx, s.newMigration(fxt))
+		require.NoError(t, err)
+
+		cause := errors.NewBadParameterError("mapping", "invalid")
+		require.NoError(t, repo.RecordError(s.Ctx, created.ID, cause))
+		loaded, err := repo.Load(s.Ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, templatemigration.StateFailed, loaded.State)
+	})
+	s.T().Run("not found", func(t *testing.T) {
+		err := repo.RecordError(s.Ctx, uuid.NewV4(), errs.New("boom"))
+		assert.IsType(t, errors.NotFoundError{}, err)
+	})
+}
@@ -0,0 +1,55 @@
+package workitem
+
+import "fmt"
+
+// SystemBlocked is the name of a virtual field that can be used in filter
+// expressions but is never stored. It is true for a work item if at least one
+// of its predecessors in a "dependency" link topology is not closed. The
+// predecessors are followed transitively, so a work item is also blocked by
+// the open predecessors of its predecessors.
+const SystemBlocked = "system.blocked"
+
+// SystemMetaStateClosed is the meta-state of all closed work items.
+const SystemMetaStateClosed = "mClosed"
+
+// IsClosed returns true if the given field values (in model or storage
+// representation) belong to a closed work item.
+func IsClosed(fields map[string]interface{}) bool {
+	return fields[SystemState] == SystemStateClosed || fields[SystemMetaState] == SystemMetaStateClosed
+}
+
+// notClosedSQL returns an SQL condition that is true if the work item with
+// the given table alias is not closed.
+func notClosedSQL(alias string) string {
+	return fmt.Sprintf(`(COALESCE(%[1]s.fields->>'%[2]s', '') <> '%[3]s' AND COALESCE(%[1]s.fields->>'%[4]s', '') <> '%[5]s')`,
+		alias, SystemState, SystemStateClosed, SystemMetaState, SystemMetaStateClosed)
+}
+
+// blockedSQL is the SQL expression for the SystemBlocked field of the current
+// row of the work items table. The recursive query walks up all links of link
+// types with a "dependency" topology. Using UNION instead of UNION ALL stops
+// the recursion should there ever be a cycle across different link types.
+//
+// NOTE: importing the link package to get the table names and the topology
+// constant is not possible because of an import cycle.
+var blockedSQL = fmt.Sprintf(`(EXISTS (
+	WITH RECURSIVE blocking(id) AS (
+		SELECT blk_link.source_id
+		FROM work_item_links blk_link
+		JOIN work_item_link_types blk_type ON blk_type.id = blk_link.link_type_id
+		WHERE blk_link.target_id = %[1]s
+			AND blk_link.deleted_at IS NULL
+			AND blk_type.topology = 'dependency'
+	UNION
+		SELECT blk_link.source_id
+		FROM blocking
+		JOIN work_item_links blk_link ON blk_link.target_id = blocking.id
+		JOIN work_item_link_types blk_type ON blk_type.id = blk_link.link_type_id
+		WHERE blk_link.deleted_at IS NULL
+			AND blk_type.topology = 'dependency'
+	)
+	SELECT 1
+	FROM blocking
+	JOIN %[2]s blk_wi ON blk_wi.id = blocking.id
+	WHERE blk_wi.deleted_at IS NULL AND %[3]s
+))`, Column(WorkItemStorage{}.TableName(), "id"), WorkItemStorage{}.TableName(), notClosedSQL("blk_wi"))
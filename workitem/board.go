@@ -1,9 +1,15 @@
 package workitem
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/fabric8-services/fabric8-wit/convert"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	uuid "github.com/satori/go.uuid"
 )
@@ -18,6 +24,78 @@ type Board struct {
 	Columns               []BoardColumn `gorm:"-" json:"columns,omitempty"`
 	Context               string        `json:"context"`
 	ContextType           string        `json:"context_type"`
+	// SpaceID is only set for boards that were customized for a single space.
+	// Boards without a space belong to the space template.
+	SpaceID *uuid.UUID `sql:"type:uuid" json:"space_id,omitempty"`
+	// Swimlane determines how the cards on the board are grouped into rows.
+	Swimlane BoardSwimlane `json:"swimlane,omitempty"`
+	// Version for optimistic concurrency control
+	Version int `json:"-"`
+}
+
+// column returns the column with the given ID or nil if the board has no such
+// column.
+func (wib Board) column(id uuid.UUID) *BoardColumn {
+	for i := range wib.Columns {
+		if wib.Columns[i].ID == id {
+			return &wib.Columns[i]
+		}
+	}
+	return nil
+}
+
+// BoardSwimlane determines by which attribute of a work item the cards on a
+// board are grouped into horizontal lanes.
+type BoardSwimlane string
+
+// Valid swimlanes of a board. The empty swimlane shows all cards in a single
+// lane.
+const (
+	SwimlaneNone     BoardSwimlane = ""
+	SwimlaneAssignee BoardSwimlane = "assignee"
+	SwimlaneArea     BoardSwimlane = "area"
+	SwimlaneParent   BoardSwimlane = "parent"
+	SwimlaneLabel    BoardSwimlane = "label"
+)
+
+// CheckValid returns nil if the swimlane is known; otherwise a
+// BadParameterError is returned.
+func (s BoardSwimlane) CheckValid() error {
+	switch s {
+	case SwimlaneNone, SwimlaneAssignee, SwimlaneArea, SwimlaneParent, SwimlaneLabel:
+		return nil
+	}
+	return errors.NewBadParameterError("swimlane", s).Expected(fmt.Sprintf("one of %q, %q, %q or %q", SwimlaneAssignee, SwimlaneArea, SwimlaneParent, SwimlaneLabel))
+}
+
+// Validate checks that the board and its columns can be stored.
+func (wib Board) Validate() error {
+	if strings.TrimSpace(wib.Name) == "" {
+		return errors.NewBadParameterError("name", wib.Name).Expected("not empty")
+	}
+	if strings.TrimSpace(wib.Description) == "" {
+		return errors.NewBadParameterError("description", wib.Description).Expected("not empty")
+	}
+	if strings.TrimSpace(wib.Context) == "" || strings.TrimSpace(wib.ContextType) == "" {
+		return errors.NewBadParameterError("context", wib.Context).Expected("a context and a context type")
+	}
+	if err := wib.Swimlane.CheckValid(); err != nil {
+		return err
+	}
+	if len(wib.Columns) == 0 {
+		return errors.NewBadParameterError("columns", wib.Columns).Expected("not empty")
+	}
+	names := map[string]struct{}{}
+	for _, c := range wib.Columns {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		if _, ok := names[c.Name]; ok {
+			return errors.NewBadParameterError("columns", c.Name).Expected("unique column names")
+		}
+		names[c.Name] = struct{}{}
+	}
+	return nil
 }
 
 // TableName implements gorm.tabler
@@ -56,6 +134,15 @@ func (wib Board) Equal(u convert.Equaler) bool {
 	if wib.ContextType != other.ContextType {
 		return false
 	}
+	if !reflect.DeepEqual(wib.SpaceID, other.SpaceID) {
+		return false
+	}
+	if wib.Swimlane != other.Swimlane {
+		return false
+	}
+	if wib.Version != other.Version {
+		return false
+	}
 	if len(wib.Columns) != len(other.Columns) {
 		return false
 	}
@@ -74,6 +161,7 @@ func (wib Board) EqualValue(u convert.Equaler) bool {
 		return false
 	}
 	wib.Lifecycle = other.Lifecycle
+	wib.Version = other.Version
 	return wib.Equal(u)
 }
 
@@ -96,6 +184,66 @@ type BoardColumn struct {
 	Order                 int       `json:"order" gorm:"column:column_order"`
 	TransRuleKey          string    `json:"trans_rule_key"`
 	TransRuleArgument     string    `json:"trans_rule_argument"` // TODO: this is a JSON, not a string
+	// WIPLimit is the maximum number of work items in this column. No limit
+	// applies if it is nil.
+	WIPLimit     *int         `gorm:"column:wip_limit" json:"wip_limit,omitempty"`
+	WIPLimitMode WIPLimitMode `gorm:"column:wip_limit_mode" json:"wip_limit_mode,omitempty"`
+	// States lists the values of the "system.state" field that belong to
+	// this column. A state belongs to at most one column of a board and work
+	// items are kept in the column of their state (see
+	// syncStateAndBoardColumns).
+	States BoardColumnStates `sql:"type:jsonb" json:"states,omitempty"`
+}
+
+// WIPLimitMode determines what happens when a work item is moved into a column
+// that already holds as many work items as its WIP limit allows.
+type WIPLimitMode string
+
+// Valid WIP limit modes. An empty mode is treated like WIPLimitWarn.
+const (
+	// WIPLimitWarn allows the move but the board reports the exceeded limit.
+	WIPLimitWarn WIPLimitMode = "warn"
+	// WIPLimitBlock rejects the move.
+	WIPLimitBlock WIPLimitMode = "block"
+)
+
+// BoardColumnStates is the list of states mapped to a board column.
+type BoardColumnStates []string
+
+// Ensure BoardColumnStates implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*BoardColumnStates)(nil)
+var _ driver.Valuer = (*BoardColumnStates)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (s BoardColumnStates) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return toBytes(s)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (s *BoardColumnStates) Scan(src interface{}) error {
+	return fromBytes(src, s)
+}
+
+// Validate checks that the column can be stored.
+func (wibc BoardColumn) Validate() error {
+	if strings.TrimSpace(wibc.Name) == "" {
+		return errors.NewBadParameterError("columns.name", wibc.Name).Expected("not empty")
+	}
+	if strings.TrimSpace(wibc.TransRuleKey) == "" || strings.TrimSpace(wibc.TransRuleArgument) == "" {
+		return errors.NewBadParameterError("columns.trans_rule_key", wibc.TransRuleKey).Expected("a transition rule key and argument")
+	}
+	if wibc.WIPLimit != nil && *wibc.WIPLimit < 1 {
+		return errors.NewBadParameterError("columns.wip_limit", *wibc.WIPLimit).Expected("greater than zero")
+	}
+	switch wibc.WIPLimitMode {
+	case "", WIPLimitWarn, WIPLimitBlock:
+	default:
+		return errors.NewBadParameterError("columns.wip_limit_mode", wibc.WIPLimitMode).Expected(fmt.Sprintf("%q or %q", WIPLimitWarn, WIPLimitBlock))
+	}
+	return nil
 }
 
 // TableName implements gorm.tabler
@@ -134,6 +282,15 @@ func (wibc BoardColumn) Equal(u convert.Equaler) bool {
 	if wibc.TransRuleArgument != other.TransRuleArgument {
 		return false
 	}
+	if !reflect.DeepEqual(wibc.WIPLimit, other.WIPLimit) {
+		return false
+	}
+	if wibc.WIPLimitMode != other.WIPLimitMode {
+		return false
+	}
+	if !reflect.DeepEqual(wibc.States, other.States) {
+		return false
+	}
 	return true
 }
 
@@ -2,12 +2,17 @@ package workitem
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/fabric8-services/fabric8-wit/spacetemplate"
 
 	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/closeable"
 	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/jinzhu/gorm"
 	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
@@ -19,6 +24,10 @@ type BoardRepository interface {
 	Create(ctx context.Context, board Board) (*Board, error)
 	Load(ctx context.Context, groupID uuid.UUID) (*Board, error)
 	List(ctx context.Context, spaceTemplateID uuid.UUID) ([]*Board, error)
+	ListBySpace(ctx context.Context, spaceID uuid.UUID) ([]*Board, error)
+	Save(ctx context.Context, board Board) (*Board, error)
+	Delete(ctx context.Context, boardID uuid.UUID) error
+	CountColumnItems(ctx context.Context, boardID uuid.UUID) (map[uuid.UUID]int, error)
 }
 
 // NewBoardRepository creates a wi type group repository based on gorm.
@@ -66,7 +75,8 @@ func (r *GormBoardRepository) loadColumns(ctx context.Context, boardID uuid.UUID
 }
 
 // List returns all boards for the given space template ID
-// ordered by their position value.
+// ordered by their position value. Boards customized for a space are not
+// included.
 func (r *GormBoardRepository) List(ctx context.Context, spaceTemplateID uuid.UUID) ([]*Board, error) {
 	log.Debug(ctx, map[string]interface{}{"space_template_id": spaceTemplateID}, "loading work item boards for space template")
 	// check space template exists
@@ -74,7 +84,7 @@ func (r *GormBoardRepository) List(ctx context.Context, spaceTemplateID uuid.UUI
 		return nil, errors.NewNotFoundError("space template", spaceTemplateID.String())
 	}
 	res := []*Board{}
-	db := r.db.Model(&res).Where("space_template_id=?", spaceTemplateID).Order("name ASC").Find(&res)
+	db := r.db.Model(&res).Where("space_template_id=? AND space_id IS NULL", spaceTemplateID).Order("name ASC").Find(&res)
 	if db.RecordNotFound() {
 		log.Error(ctx, map[string]interface{}{"space_template_id": spaceTemplateID}, "work item boards not found")
 		return nil, errors.NewNotFoundError("work item boards for space template", spaceTemplateID.String())
@@ -100,27 +110,413 @@ func (r *GormBoardRepository) CheckExists(ctx context.Context, id uuid.UUID) err
 
 // Create creates a new work item board in the repository
 func (r *GormBoardRepository) Create(ctx context.Context, b Board) (*Board, error) {
-	if len(b.Columns) <= 0 {
-		return nil, errors.NewBadParameterError("columns", b.Columns).Expected("not empty")
+	if err := b.Validate(); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	if err := r.checkColumnStates(ctx, b); err != nil {
+		return nil, errs.WithStack(err)
 	}
 	if b.ID == uuid.Nil {
 		b.ID = uuid.NewV4()
 	}
 	db := r.db.Create(&b)
 	if db.Error != nil {
+		if gormsupport.IsUniqueViolation(db.Error, "work_item_boards_name_space_id_unique_idx") {
+			return nil, errors.NewDataConflictError(fmt.Sprintf("a board named %q already exists in the space", b.Name))
+		}
 		return nil, errors.NewInternalError(ctx, db.Error)
 	}
 	log.Debug(ctx, map[string]interface{}{"board_id": b.ID}, "created work item board")
 	// Create entries for each column in the column list
-	for _, column := range b.Columns {
+	for i := range b.Columns {
+		column := &b.Columns[i]
 		if column.ID == uuid.Nil {
 			column.ID = uuid.NewV4()
 		}
 		column.BoardID = b.ID
-		db = db.Create(&column)
+		db = db.Create(column)
 		if db.Error != nil {
 			return nil, errors.NewInternalError(ctx, db.Error)
 		}
 	}
 	return &b, nil
 }
+
+// ListBySpace returns all boards customized for the given space ordered by
+// their name.
+func (r *GormBoardRepository) ListBySpace(ctx context.Context, spaceID uuid.UUID) ([]*Board, error) {
+	log.Debug(ctx, map[string]interface{}{"space_id": spaceID}, "loading work item boards for space")
+	res := []*Board{}
+	db := r.db.Model(&res).Where("space_id=?", spaceID).Order("name ASC").Find(&res)
+	if err := db.Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	for _, board := range res {
+		columns, err := r.loadColumns(ctx, board.ID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to load columns for board with ID %s", board.ID)
+		}
+		board.Columns = columns
+	}
+	return res, nil
+}
+
+// Save updates the given board and replaces its columns with the given ones.
+// Columns are matched by their ID: existing columns are updated, columns
+// without an ID are added and columns that are no longer listed are removed.
+// Only columns without work items can be removed. The order of the columns is
+// taken from their position in the list.
+func (r *GormBoardRepository) Save(ctx context.Context, b Board) (*Board, error) {
+	existing, err := r.Load(ctx, b.ID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	if existing.Version != b.Version {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	if err := b.Validate(); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	keep := map[uuid.UUID]struct{}{}
+	for _, column := range b.Columns {
+		if column.ID != uuid.Nil && existing.column(column.ID) == nil {
+			return nil, errors.NewBadParameterError("columns.id", column.ID).Expected("the ID of a column of this board")
+		}
+		keep[column.ID] = struct{}{}
+	}
+	b.SpaceTemplateID = existing.SpaceTemplateID
+	b.SpaceID = existing.SpaceID
+	if err := r.checkColumnStates(ctx, b); err != nil {
+		return nil, errs.WithStack(err)
+	}
+	for _, column := range existing.Columns {
+		if _, ok := keep[column.ID]; ok {
+			continue
+		}
+		if err := r.checkColumnEmpty(ctx, column); err != nil {
+			return nil, errs.WithStack(err)
+		}
+	}
+	b.CreatedAt = existing.CreatedAt
+	b.Version = existing.Version + 1
+	db := r.db.Model(&b).Where("version=?", existing.Version).Updates(map[string]interface{}{
+		"name":         b.Name,
+		"description":  b.Description,
+		"context":      b.Context,
+		"context_type": b.ContextType,
+		"swimlane":     b.Swimlane,
+		"version":      b.Version,
+	})
+	if err := db.Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	// Columns are hard deleted so that their names and positions can be
+	// reused right away.
+	for _, column := range existing.Columns {
+		if _, ok := keep[column.ID]; ok {
+			continue
+		}
+		if err := r.db.Unscoped().Delete(&column).Error; err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+	}
+	// Move the remaining columns out of the way so that reordering them does
+	// not violate the unique position of a column on the board.
+	db = r.db.Model(&BoardColumn{}).Where("board_id=?", b.ID).UpdateColumn("column_order", gorm.Expr("-1 - column_order"))
+	if err := db.Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	for i := range b.Columns {
+		column := &b.Columns[i]
+		column.BoardID = b.ID
+		column.Order = i
+		if column.ID == uuid.Nil {
+			column.ID = uuid.NewV4()
+			if err := r.db.Create(column).Error; err != nil {
+				return nil, errors.NewInternalError(ctx, err)
+			}
+		} else {
+			column.CreatedAt = existing.column(column.ID).CreatedAt
+			if err := r.db.Save(column).Error; err != nil {
+				return nil, errors.NewInternalError(ctx, err)
+			}
+		}
+	}
+	log.Debug(ctx, map[string]interface{}{"board_id": b.ID}, "updated work item board")
+	return r.Load(ctx, b.ID)
+}
+
+// Delete deletes the board with the given ID and its columns. Only boards
+// customized for a space can be deleted.
+func (r *GormBoardRepository) Delete(ctx context.Context, boardID uuid.UUID) error {
+	b, err := r.Load(ctx, boardID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	if b.SpaceID == nil {
+		return errors.NewForbiddenError("boards of a space template cannot be deleted")
+	}
+	if err := r.db.Unscoped().Where("board_id=?", boardID).Delete(&BoardColumn{}).Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	if err := r.db.Delete(b).Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	log.Debug(ctx, map[string]interface{}{"board_id": boardID}, "deleted work item board")
+	return nil
+}
+
+// CountColumnItems returns the number of work items in each column of the
+// given space board.
+func (r *GormBoardRepository) CountColumnItems(ctx context.Context, boardID uuid.UUID) (map[uuid.UUID]int, error) {
+	b, err := r.Load(ctx, boardID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	res := map[uuid.UUID]int{}
+	if b.SpaceID == nil {
+		return res, nil
+	}
+	query := fmt.Sprintf(`
+		SELECT c.id, count(wi.id)
+		FROM %[1]s c
+		LEFT JOIN %[2]s wi ON wi.space_id = $1 AND wi.deleted_at IS NULL
+			AND wi.fields->'%[3]s' @> jsonb_build_array(c.id::text)
+		WHERE c.board_id = $2 AND c.deleted_at IS NULL
+		GROUP BY c.id`, BoardColumn{}.TableName(), WorkItemStorage{}.TableName(), SystemBoardcolumns)
+	rows, err := r.db.Raw(query, *b.SpaceID, boardID).Rows()
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	defer closeable.Close(ctx, rows)
+	for rows.Next() {
+		var id uuid.UUID
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		res[id] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return res, nil
+}
+
+// checkColumnEmpty returns a BadParameterError if a work item is in the given
+// column, which would be left with a reference to a removed column.
+func (r *GormBoardRepository) checkColumnEmpty(ctx context.Context, column BoardColumn) error {
+	var count int
+	db := r.db.Model(&WorkItemStorage{}).
+		Where(fmt.Sprintf("fields->'%s' @> jsonb_build_array(?::text)", SystemBoardcolumns), column.ID.String()).
+		Count(&count)
+	if err := db.Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	if count > 0 {
+		return errors.NewBadParameterError("columns", column.ID).Expected(fmt.Sprintf("column %q to be listed since it holds %d work items", column.Name, count))
+	}
+	return nil
+}
+
+// checkColumnStates returns a BadParameterError if a column of the given board
+// lists a state that no work item type of the board's space template knows or
+// a state that is already listed by another column.
+func (r *GormBoardRepository) checkColumnStates(ctx context.Context, b Board) error {
+	hasStates := false
+	for _, c := range b.Columns {
+		if len(c.States) > 0 {
+			hasStates = true
+			break
+		}
+	}
+	if !hasStates {
+		return nil
+	}
+	var wits []WorkItemType
+	if err := r.db.Where("space_template_id = ?", b.SpaceTemplateID).Find(&wits).Error; err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to load the work item types of space template %s", b.SpaceTemplateID))
+	}
+	known := map[string]struct{}{}
+	for _, wit := range wits {
+		fd, ok := wit.Fields[SystemState]
+		if !ok {
+			continue
+		}
+		if enumType, ok := fd.Type.(EnumType); ok {
+			for _, v := range enumType.Values {
+				known[fmt.Sprint(v)] = struct{}{}
+			}
+		}
+	}
+	mapped := map[string]string{}
+	for _, c := range b.Columns {
+		for _, state := range c.States {
+			if _, ok := known[state]; !ok {
+				return errors.NewBadParameterError("columns.states", state).Expected("a state of the work item types of the space template")
+			}
+			if other, ok := mapped[state]; ok {
+				return errors.NewBadParameterError("columns.states", state).Expected(fmt.Sprintf("a state that is not mapped to column %q already", other))
+			}
+			mapped[state] = c.Name
+		}
+	}
+	return nil
+}
+
+// syncStateAndBoardColumns keeps the state and the board columns of a work
+// item in line with the states that the columns of the space's boards are
+// mapped to. A work item that is moved into a column takes the first of the
+// column's states that its type allows, unless it is in one of them already.
+// A work item whose state changes is moved into the column that the new state
+// is mapped to on every board on which it wasn't moved explicitly. The old
+// values are nil for new work items.
+func syncStateAndBoardColumns(ctx context.Context, db *gorm.DB, spaceID uuid.UUID, wit WorkItemType, oldState, oldColumns interface{}, fields Fields) error {
+	if _, ok := wit.Fields[SystemState]; !ok {
+		return nil
+	}
+	if _, ok := wit.Fields[SystemBoardcolumns]; !ok {
+		return nil
+	}
+	stateChanged := fields[SystemState] != nil && fmt.Sprint(fields[SystemState]) != fmt.Sprint(oldState)
+	added := addedBoardColumns(oldColumns, fields[SystemBoardcolumns])
+	if !stateChanged && len(added) == 0 {
+		return nil
+	}
+	var columns []BoardColumn
+	err := db.Joins(fmt.Sprintf("JOIN %[1]s b ON b.id = %[2]s.board_id AND b.deleted_at IS NULL", Board{}.TableName(), BoardColumn{}.TableName())).
+		Where(fmt.Sprintf("b.space_id = ? OR (b.space_id IS NULL AND b.space_template_id = (SELECT space_template_id FROM %s WHERE id = ?))", space.Space{}.TableName()), spaceID, spaceID).
+		Order("column_order").
+		Find(&columns).Error
+	if err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to load the board columns of space %s", spaceID))
+	}
+	columnsByID := make(map[string]BoardColumn, len(columns))
+	for _, c := range columns {
+		columnsByID[c.ID.String()] = c
+	}
+	// the columns the work item was moved into determine its state
+	moved := map[uuid.UUID]struct{}{}
+	for _, id := range added {
+		c, ok := columnsByID[id]
+		if !ok {
+			continue
+		}
+		moved[c.BoardID] = struct{}{}
+		if len(c.States) == 0 || containsState(c.States, fmt.Sprint(fields[SystemState])) {
+			continue
+		}
+		if stateChanged {
+			return errors.NewBadParameterError(SystemState, fields[SystemState]).Expected(fmt.Sprintf("one of the states %v of column %q", c.States, c.Name))
+		}
+		for _, state := range c.States {
+			if _, err := wit.Fields[SystemState].ConvertToModel(SystemState, state); err == nil {
+				fields[SystemState] = state
+				break
+			}
+		}
+	}
+	// the state determines the column on all other boards
+	state := fmt.Sprint(fields[SystemState])
+	targets := map[uuid.UUID]string{}
+	for _, c := range columns {
+		if _, ok := moved[c.BoardID]; !ok && containsState(c.States, state) {
+			targets[c.BoardID] = c.ID.String()
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	var res []interface{}
+	if l, ok := fields[SystemBoardcolumns].([]interface{}); ok {
+		for _, v := range l {
+			if c, ok := columnsByID[fmt.Sprint(v)]; ok {
+				if _, ok := targets[c.BoardID]; ok {
+					continue
+				}
+			}
+			res = append(res, v)
+		}
+	}
+	for _, c := range columns {
+		if targets[c.BoardID] == c.ID.String() {
+			res = append(res, c.ID.String())
+		}
+	}
+	fields[SystemBoardcolumns] = res
+	return nil
+}
+
+// containsState returns true if the given states contain the given state.
+func containsState(states BoardColumnStates, state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWIPLimits returns a BadParameterError if moving the given work item
+// into one of the given columns would exceed a blocking WIP limit. The column
+// rows are locked until the surrounding transaction ends so that concurrent
+// moves into the same column are counted one after the other.
+func checkWIPLimits(ctx context.Context, db *gorm.DB, spaceID, workItemID uuid.UUID, columnIDs []string) error {
+	// lock the columns in a stable order to avoid deadlocks
+	columnIDs = append([]string{}, columnIDs...)
+	sort.Strings(columnIDs)
+	for _, columnID := range columnIDs {
+		id, err := uuid.FromString(columnID)
+		if err != nil {
+			// not a column we know about, so no limit applies
+			continue
+		}
+		column := BoardColumn{}
+		// SELECT ... FOR UPDATE serializes the counting for this column
+		tx := db.Set("gorm:query_option", "FOR UPDATE").Where("id=?", id).First(&column)
+		if tx.RecordNotFound() {
+			continue
+		}
+		if err := tx.Error; err != nil {
+			return errors.NewInternalError(ctx, err)
+		}
+		if column.WIPLimit == nil || column.WIPLimitMode != WIPLimitBlock {
+			continue
+		}
+		var count int
+		tx = db.Model(&WorkItemStorage{}).
+			Where("space_id = ? AND id != ?", spaceID, workItemID).
+			Where(fmt.Sprintf("fields->'%s' @> jsonb_build_array(?::text)", SystemBoardcolumns), columnID).
+			Count(&count)
+		if err := tx.Error; err != nil {
+			return errors.NewInternalError(ctx, err)
+		}
+		if count >= *column.WIPLimit {
+			return errors.NewBadParameterError(SystemBoardcolumns, columnID).Expected(fmt.Sprintf("a column with less than %d work items since column %q is at its WIP limit", *column.WIPLimit, column.Name))
+		}
+	}
+	return nil
+}
+
+// addedBoardColumns returns the column IDs in the new value of the
+// "system.boardcolumns" field that are not in the old value.
+func addedBoardColumns(oldValue, newValue interface{}) []string {
+	old := map[string]struct{}{}
+	if l, ok := oldValue.([]interface{}); ok {
+		for _, v := range l {
+			old[fmt.Sprint(v)] = struct{}{}
+		}
+	}
+	var res []string
+	if l, ok := newValue.([]interface{}); ok {
+		for _, v := range l {
+			if _, ok := old[fmt.Sprint(v)]; !ok {
+				res = append(res, fmt.Sprint(v))
+			}
+		}
+	}
+	return res
+}
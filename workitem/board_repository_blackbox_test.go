@@ -8,6 +8,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
 	"github.com/fabric8-services/fabric8-wit/workitem"
@@ -176,6 +177,324 @@ func (s *workItemBoardRepoTest) TestList() {
 	})
 }
 
+// spaceBoard turns the fixture's board into a board customized for the
+// fixture's space.
+func spaceBoard(fxt *tf.TestFixture, idx int) error {
+	fxt.WorkItemBoards[idx].SpaceID = ptr.UUID(fxt.Spaces[0].ID)
+	return nil
+}
+
+func (s *workItemBoardRepoTest) TestListBySpace() {
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1), tf.WorkItemBoards(2, spaceBoard))
+		// when
+		actual, err := s.repo.ListBySpace(s.Ctx, fxt.Spaces[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		for _, b := range actual {
+			require.Equal(t, fxt.Spaces[0].ID, *b.SpaceID)
+			require.Len(t, b.Columns, 4)
+		}
+		t.Run("space boards are not listed for the space template", func(t *testing.T) {
+			actual, err := s.repo.List(s.Ctx, fxt.SpaceTemplates[0].ID)
+			require.NoError(t, err)
+			require.Empty(t, actual)
+		})
+	})
+	s.T().Run("no boards", func(t *testing.T) {
+		// when
+		actual, err := s.repo.ListBySpace(s.Ctx, uuid.NewV4())
+		// then
+		require.NoError(t, err)
+		require.Empty(t, actual)
+	})
+}
+
+func (s *workItemBoardRepoTest) TestSave() {
+	s.T().Run("reorder, add and remove columns", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1), tf.WorkItemTypes(1), tf.WorkItemBoards(1, spaceBoard))
+		b := *fxt.WorkItemBoards[0]
+		old := b.Columns
+		b.Name = "renamed board"
+		b.Swimlane = workitem.SwimlaneAssignee
+		b.Columns = []workitem.BoardColumn{
+			old[2],
+			old[0],
+			{
+				Name:              "Review",
+				TransRuleKey:      "updateStateFromColumnMove",
+				TransRuleArgument: "{ 'metastate': 'mInprogress' }",
+				WIPLimit:          ptr.Int(3),
+				WIPLimitMode:      workitem.WIPLimitBlock,
+				States:            workitem.BoardColumnStates{"in progress"},
+			},
+		}
+		// when
+		actual, err := s.repo.Save(s.Ctx, b)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, "renamed board", actual.Name)
+		require.Equal(t, workitem.SwimlaneAssignee, actual.Swimlane)
+		require.Equal(t, b.Version+1, actual.Version)
+		require.Len(t, actual.Columns, 3)
+		require.Equal(t, old[2].ID, actual.Columns[0].ID)
+		require.Equal(t, old[0].ID, actual.Columns[1].ID)
+		require.Equal(t, "Review", actual.Columns[2].Name)
+		require.Equal(t, 2, actual.Columns[2].Order)
+		require.Equal(t, 3, *actual.Columns[2].WIPLimit)
+		require.Equal(t, workitem.WIPLimitBlock, actual.Columns[2].WIPLimitMode)
+		require.Equal(t, workitem.BoardColumnStates{"in progress"}, actual.Columns[2].States)
+	})
+	s.T().Run("version conflict", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1), tf.WorkItemBoards(1, spaceBoard))
+		b := *fxt.WorkItemBoards[0]
+		b.Version++
+		// when
+		_, err := s.repo.Save(s.Ctx, b)
+		// then
+		require.IsType(t, errors.VersionConflictError{}, errs.Cause(err))
+	})
+	s.T().Run("invalid states", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1), tf.WorkItemTypes(1), tf.WorkItemBoards(1, spaceBoard))
+		for name, states := range map[string][]workitem.BoardColumnStates{
+			"unknown state":            {{"in review"}, nil},
+			"state in several columns": {{"open"}, {"new", "open"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				b := *fxt.WorkItemBoards[0]
+				b.Columns = append([]workitem.BoardColumn{}, b.Columns...)
+				b.Columns[0].States = states[0]
+				b.Columns[1].States = states[1]
+				// when
+				_, err := s.repo.Save(s.Ctx, b)
+				// then
+				require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+			})
+		}
+	})
+	s.T().Run("unknown column", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1), tf.WorkItemBoards(1, spaceBoard))
+		b := *fxt.WorkItemBoards[0]
+		b.Columns[0].ID = uuid.NewV4()
+		// when
+		_, err := s.repo.Save(s.Ctx, b)
+		// then
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func (s *workItemBoardRepoTest) TestDelete() {
+	s.T().Run("space board", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1), tf.WorkItemBoards(1, spaceBoard))
+		// when
+		err := s.repo.Delete(s.Ctx, fxt.WorkItemBoards[0].ID)
+		// then
+		require.NoError(t, err)
+		_, err = s.repo.Load(s.Ctx, fxt.WorkItemBoards[0].ID)
+		require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+	s.T().Run("template board", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemBoards(1))
+		// when
+		err := s.repo.Delete(s.Ctx, fxt.WorkItemBoards[0].ID)
+		// then
+		require.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+	})
+}
+
+func (s *workItemBoardRepoTest) TestWIPLimits() {
+	// given a space board whose first column holds at most one work item
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItemBoards(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemBoards[idx].SpaceID = ptr.UUID(fxt.Spaces[0].ID)
+			fxt.WorkItemBoards[idx].Columns[0].WIPLimit = ptr.Int(1)
+			return nil
+		}),
+		tf.WorkItems(2, func(fxt *tf.TestFixture, idx int) error {
+			if idx == 0 {
+				fxt.WorkItems[idx].Fields[workitem.SystemBoardcolumns] = []interface{}{fxt.WorkItemBoards[0].Columns[0].ID.String()}
+			}
+			return nil
+		}),
+	)
+	column := fxt.WorkItemBoards[0].Columns[0]
+	wiRepo := workitem.NewWorkItemRepository(s.DB)
+
+	s.T().Run("count column items", func(t *testing.T) {
+		counts, err := s.repo.CountColumnItems(s.Ctx, fxt.WorkItemBoards[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, counts[column.ID])
+		require.Equal(t, 0, counts[fxt.WorkItemBoards[0].Columns[1].ID])
+	})
+	s.T().Run("warn mode allows exceeding the limit", func(t *testing.T) {
+		// when
+		wi := *fxt.WorkItems[1]
+		wi.Fields[workitem.SystemBoardcolumns] = []interface{}{column.ID.String()}
+		saved, _, err := wiRepo.Save(s.Ctx, fxt.Spaces[0].ID, wi, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		fxt.WorkItems[1] = saved
+		// move it back out of the column
+		wi = *saved
+		wi.Fields[workitem.SystemBoardcolumns] = []interface{}{}
+		saved, _, err = wiRepo.Save(s.Ctx, fxt.Spaces[0].ID, wi, fxt.Identities[0].ID)
+		require.NoError(t, err)
+		fxt.WorkItems[1] = saved
+	})
+	s.T().Run("block mode rejects exceeding the limit", func(t *testing.T) {
+		// given
+		b := *fxt.WorkItemBoards[0]
+		b.Columns[0].WIPLimitMode = workitem.WIPLimitBlock
+		_, err := s.repo.Save(s.Ctx, b)
+		require.NoError(t, err)
+		// when
+		wi := *fxt.WorkItems[1]
+		wi.Fields[workitem.SystemBoardcolumns] = []interface{}{column.ID.String()}
+		_, _, err = wiRepo.Save(s.Ctx, fxt.Spaces[0].ID, wi, fxt.Identities[0].ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		t.Run("items already in the column can still be saved", func(t *testing.T) {
+			wi := *fxt.WorkItems[0]
+			wi.Fields[workitem.SystemTitle] = "changed title"
+			_, _, err := wiRepo.Save(s.Ctx, fxt.Spaces[0].ID, wi, fxt.Identities[0].ID)
+			require.NoError(t, err)
+		})
+		t.Run("new items are rejected as well", func(t *testing.T) {
+			fields := map[string]interface{}{
+				workitem.SystemTitle:        "new item",
+				workitem.SystemState:        fxt.WorkItems[1].Fields[workitem.SystemState],
+				workitem.SystemBoardcolumns: []interface{}{column.ID.String()},
+			}
+			_, _, err := wiRepo.Create(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemTypes[0].ID, fields, fxt.Identities[0].ID)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
+	})
+	s.T().Run("columns with work items cannot be removed", func(t *testing.T) {
+		// given
+		b, err := s.repo.Load(s.Ctx, fxt.WorkItemBoards[0].ID)
+		require.NoError(t, err)
+		b.Columns = b.Columns[1:]
+		// when
+		_, err = s.repo.Save(s.Ctx, *b)
+		// then
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		loaded, err := s.repo.Load(s.Ctx, b.ID)
+		require.NoError(t, err)
+		require.Equal(t, column.ID, loaded.Columns[0].ID)
+	})
+}
+
+func (s *workItemBoardRepoTest) TestStateSync() {
+	// given a space board whose first two columns are mapped to states
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItemBoards(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemBoards[idx].SpaceID = ptr.UUID(fxt.Spaces[0].ID)
+			fxt.WorkItemBoards[idx].Columns[0].States = workitem.BoardColumnStates{workitem.SystemStateNew}
+			fxt.WorkItemBoards[idx].Columns[1].States = workitem.BoardColumnStates{workitem.SystemStateInProgress, workitem.SystemStateOpen}
+			return nil
+		}),
+		tf.WorkItems(1, tf.SetWorkItemField(workitem.SystemState, workitem.SystemStateNew)),
+	)
+	columns := fxt.WorkItemBoards[0].Columns
+	wiRepo := workitem.NewWorkItemRepository(s.DB)
+
+	s.T().Run("created work item is in the column of its state", func(t *testing.T) {
+		require.Equal(t, []interface{}{columns[0].ID.String()}, fxt.WorkItems[0].Fields[workitem.SystemBoardcolumns])
+	})
+	s.T().Run("changing the state moves the work item", func(t *testing.T) {
+		// when
+		wi := *fxt.WorkItems[0]
+		wi.Fields[workitem.SystemState] = workitem.SystemStateOpen
+		saved, _, err := wiRepo.Save(s.Ctx, fxt.Spaces[0].ID, wi, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{columns[1].ID.String()}, saved.Fields[workitem.SystemBoardcolumns])
+		fxt.WorkItems[0] = saved
+	})
+	s.T().Run("moving the work item changes its state", func(t *testing.T) {
+		// when
+		wi := *fxt.WorkItems[0]
+		wi.Fields[workitem.SystemBoardcolumns] = []interface{}{columns[0].ID.String()}
+		saved, _, err := wiRepo.Save(s.Ctx, fxt.Spaces[0].ID, wi, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, workitem.SystemStateNew, saved.Fields[workitem.SystemState])
+		fxt.WorkItems[0] = saved
+	})
+	s.T().Run("state and column must match", func(t *testing.T) {
+		// when
+		wi := *fxt.WorkItems[0]
+		wi.Fields[workitem.SystemState] = workitem.SystemStateClosed
+		wi.Fields[workitem.SystemBoardcolumns] = []interface{}{columns[1].ID.String()}
+		_, _, err := wiRepo.Save(s.Ctx, fxt.Spaces[0].ID, wi, fxt.Identities[0].ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func TestWorkItemBoard_Validate(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	// given
+	a := workitem.Board{
+		Name:        "Some Board Name",
+		Description: "Some Board Description",
+		ContextType: "TypeLevelContext",
+		Context:     uuid.NewV4().String(),
+		Swimlane:    workitem.SwimlaneArea,
+		Columns: []workitem.BoardColumn{
+			{
+				Name:              "New",
+				TransRuleKey:      "updateStateFromColumnMove",
+				TransRuleArgument: "{ 'metastate': 'mNew' }",
+				WIPLimit:          ptr.Int(5),
+				WIPLimitMode:      workitem.WIPLimitWarn,
+			},
+		},
+	}
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, a.Validate())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]func(b *workitem.Board){
+			"empty name":        func(b *workitem.Board) { b.Name = " " },
+			"empty description": func(b *workitem.Board) { b.Description = "" },
+			"unknown swimlane":  func(b *workitem.Board) { b.Swimlane = "foo" },
+			"no columns":        func(b *workitem.Board) { b.Columns = nil },
+			"duplicate column names": func(b *workitem.Board) {
+				b.Columns = append([]workitem.BoardColumn{}, a.Columns[0], a.Columns[0])
+			},
+			"zero WIP limit": func(b *workitem.Board) {
+				b.Columns = []workitem.BoardColumn{a.Columns[0]}
+				b.Columns[0].WIPLimit = ptr.Int(0)
+			},
+			"unknown WIP limit mode": func(b *workitem.Board) {
+				b.Columns = []workitem.BoardColumn{a.Columns[0]}
+				b.Columns[0].WIPLimitMode = "foo"
+			},
+		}
+		for name, fn := range tests {
+			t.Run(name, func(t *testing.T) {
+				b := a
+				fn(&b)
+				require.IsType(t, errors.BadParameterError{}, b.Validate())
+			})
+		}
+	})
+}
+
 func TestWorkItemBoard_EqualAndEqualValue(t *testing.T) {
 	t.Parallel()
 	resource.Require(t, resource.UnitTest)
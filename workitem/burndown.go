@@ -0,0 +1,147 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Burndown holds the day by day progress of the work items of an iteration.
+// It serves both as a burndown (open work) and a burnup (closed work and total
+// scope) chart.
+type Burndown struct {
+	Start time.Time
+	End   time.Time
+	// Field is the name of the numeric field whose values are summed up, e.g.
+	// the story points. It is empty if only work items are counted.
+	Field string
+	Days  []BurndownDay
+}
+
+// BurndownDay describes the work items of an iteration at the end of a day.
+type BurndownDay struct {
+	Date        time.Time
+	Open        int
+	Closed      int
+	OpenValue   float64
+	ClosedValue float64
+	// Added and Removed are the numbers of work items that were moved into or
+	// out of the iteration during the day.
+	Added   int
+	Removed int
+}
+
+// numericValue returns the given field value as a number or 0 if it is not
+// numeric.
+func numericValue(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+	return 0
+}
+
+// ComputeBurndown computes the burndown of the iteration(s) with the given
+// IDs for every day from start to end from the revisions of the work items.
+// The revisions of each work item must be ordered by their time. A work item
+// belongs to the iteration as long as its "system.iteration" field refers to
+// one of the given iterations.
+func ComputeBurndown(revisions []Revision, iterationIDs []uuid.UUID, field string, start, end time.Time) Burndown {
+	start = start.UTC()
+	end = end.UTC()
+	res := Burndown{Start: start, End: end, Field: field}
+	iterations := map[string]struct{}{}
+	for _, id := range iterationIDs {
+		iterations[id.String()] = struct{}{}
+	}
+	inScope := func(rev *Revision) bool {
+		if rev == nil || rev.Type == RevisionTypeDelete {
+			return false
+		}
+		_, ok := iterations[fmt.Sprint(rev.WorkItemFields[SystemIteration])]
+		return ok
+	}
+
+	dates := days(start, end)
+	res.Days = make([]BurndownDay, len(dates))
+	for d, day := range dates {
+		res.Days[d].Date = day
+	}
+	itemIDs, histories := groupRevisions(revisions)
+	for _, id := range itemIDs {
+		history := histories[id]
+		next := 0
+		var current *Revision
+		// the scope at the start of the iteration is the baseline for the
+		// changes on the first day
+		for next < len(history) && !history[next].Time.After(start) {
+			current = &history[next]
+			next++
+		}
+		wasInScope := inScope(current)
+		for d, day := range dates {
+			dayEnd := day.Add(24 * time.Hour)
+			for next < len(history) && history[next].Time.Before(dayEnd) {
+				current = &history[next]
+				next++
+			}
+			isInScope := inScope(current)
+			switch {
+			case isInScope && !wasInScope:
+				res.Days[d].Added++
+			case !isInScope && wasInScope:
+				res.Days[d].Removed++
+			}
+			wasInScope = isInScope
+			if !isInScope {
+				continue
+			}
+			var value float64
+			if field != "" {
+				value = numericValue(current.WorkItemFields[field])
+			}
+			if IsClosed(current.WorkItemFields) {
+				res.Days[d].Closed++
+				res.Days[d].ClosedValue += value
+			} else {
+				res.Days[d].Open++
+				res.Days[d].OpenValue += value
+			}
+		}
+	}
+	return res
+}
+
+// Burndown computes the burndown of the iteration(s) with the given IDs from
+// start to end. See ComputeBurndown for details.
+func (r *GormRevisionRepository) Burndown(ctx context.Context, iterationIDs []uuid.UUID, field string, start, end time.Time) (*Burndown, error) {
+	if err := checkDateRange(start, end); err != nil {
+		return nil, err
+	}
+	log.Debug(ctx, map[string]interface{}{"iteration_ids": iterationIDs, "field": field}, "computing burndown")
+	ids := make([]string, len(iterationIDs))
+	for i, id := range iterationIDs {
+		ids[i] = id.String()
+	}
+	// all work items that were part of the iteration(s) at some point
+	itemsQuery := fmt.Sprintf("SELECT DISTINCT work_item_id FROM %s WHERE work_item_fields->>'%s' IN (?)", revisionTableName, SystemIteration)
+	var revisions []Revision
+	db := r.db.Where(fmt.Sprintf("work_item_id IN (%s) AND revision_time <= ?", itemsQuery), ids, end).
+		Order("work_item_id, revision_time, work_item_version").
+		Find(&revisions)
+	if err := db.Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	res := ComputeBurndown(revisions, iterationIDs, field, start, end)
+	return &res, nil
+}
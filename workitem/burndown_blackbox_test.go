@@ -0,0 +1,75 @@
+package workitem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeBurndown(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	// given
+	day := func(d int, h int) time.Time {
+		return time.Date(2018, time.April, d, h, 0, 0, 0, time.UTC)
+	}
+	sprint, child, other := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	rev := func(id uuid.UUID, t time.Time, iterationID uuid.UUID, state string, points float64) workitem.Revision {
+		return workitem.Revision{
+			WorkItemID: id,
+			Time:       t,
+			Type:       workitem.RevisionTypeUpdate,
+			WorkItemFields: workitem.Fields{
+				workitem.SystemIteration: iterationID.String(),
+				workitem.SystemState:     state,
+				"storypoints":            points,
+			},
+		}
+	}
+	a, b, c := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	revisions := []workitem.Revision{
+		// a is planned before the sprint and closed on the 2nd
+		rev(a, day(1, 0), sprint, workitem.SystemStateNew, 3),
+		rev(a, day(2, 12), sprint, workitem.SystemStateClosed, 3),
+		// b is planned into the child iteration before the sprint and moved
+		// out of the sprint on the 3rd
+		rev(b, day(1, 0), child, workitem.SystemStateNew, 5),
+		rev(b, day(3, 12), other, workitem.SystemStateNew, 5),
+		// c is added on the 2nd and deleted on the 4th
+		rev(c, day(1, 0), other, workitem.SystemStateNew, 8),
+		rev(c, day(2, 8), sprint, workitem.SystemStateInProgress, 8),
+		{WorkItemID: c, Time: day(4, 8), Type: workitem.RevisionTypeDelete, WorkItemFields: workitem.Fields{}},
+	}
+
+	t.Run("with field", func(t *testing.T) {
+		t.Parallel()
+		// when
+		res := workitem.ComputeBurndown(revisions, []uuid.UUID{sprint, child}, "storypoints", day(1, 6), day(4, 18))
+		// then
+		require.Len(t, res.Days, 4)
+		expected := []workitem.BurndownDay{
+			{Date: day(1, 0), Open: 2, Closed: 0, OpenValue: 8, ClosedValue: 0},
+			{Date: day(2, 0), Open: 2, Closed: 1, OpenValue: 13, ClosedValue: 3, Added: 1},
+			{Date: day(3, 0), Open: 1, Closed: 1, OpenValue: 8, ClosedValue: 3, Removed: 1},
+			{Date: day(4, 0), Open: 0, Closed: 1, OpenValue: 0, ClosedValue: 3, Removed: 1},
+		}
+		assert.Equal(t, expected, res.Days)
+	})
+	t.Run("without field", func(t *testing.T) {
+		t.Parallel()
+		// when
+		res := workitem.ComputeBurndown(revisions, []uuid.UUID{sprint}, "", day(1, 6), day(2, 18))
+		// then
+		require.Len(t, res.Days, 2)
+		assert.Equal(t, 1, res.Days[0].Open)
+		assert.Equal(t, 0.0, res.Days[0].OpenValue)
+		assert.Equal(t, 1, res.Days[1].Open)
+		assert.Equal(t, 1, res.Days[1].Closed)
+	})
+}
@@ -0,0 +1,576 @@
+package workitem
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	errs "github.com/pkg/errors"
+)
+
+// A computed field expression is a small arithmetic language that operates on
+// the numeric fields of a work item and on aggregates over the fields of its
+// direct children in the parent-child tree. The grammar looks like this:
+//
+//   expr      = term { ("+" | "-") term }
+//   term      = factor { ("*" | "/") factor }
+//   factor    = number | field | call | "(" expr ")" | "-" factor
+//   call      = ("sum" | "min" | "max" | "avg") "(" "children." field ")"
+//             | "count" "(" "children" ")"
+//             | "days_since" "(" field ")"
+//
+// Examples:
+//
+//   sum(children.effort) - effort_spent
+//   days_since(system.created_at)
+//
+// An expression yields no value (nil in Go and NULL in SQL) if any of its
+// operands has no value or if it divides by zero. Aggregates only consider
+// the stored field values of the children and ignore children without a
+// value. The sum and count of an item without children is 0 while min, max
+// and avg have no value then.
+
+// functions that can be used in computed field expressions
+const (
+	computedFuncSum       = "sum"
+	computedFuncMin       = "min"
+	computedFuncMax       = "max"
+	computedFuncAvg       = "avg"
+	computedFuncCount     = "count"
+	computedFuncDaysSince = "days_since"
+
+	// computedChildrenPrefix is the prefix with which to refer to the fields
+	// of a work item's children inside an aggregate function.
+	computedChildrenPrefix = "children"
+)
+
+// ComputedExpression is the parsed form of the expression of a computed field.
+type ComputedExpression struct {
+	root computedNode
+}
+
+// computedEnv holds everything that is needed to evaluate an expression for
+// a single work item.
+type computedEnv struct {
+	fields   map[string]interface{}
+	children []map[string]interface{}
+	now      time.Time
+}
+
+// computedNode is a node in the syntax tree of a computed field expression.
+type computedNode interface {
+	// eval returns the value of the node or nil if it has no value.
+	eval(env computedEnv) *float64
+	// sql returns an SQL expression that computes the same value as eval
+	// for the current row of the work items table.
+	sql() string
+	// walk calls the given function for this node and all its descendants.
+	walk(fn func(n computedNode))
+}
+
+// ParseComputedExpression parses the given string and returns an error if it
+// is not a valid computed field expression.
+func ParseComputedExpression(s string) (*ComputedExpression, error) {
+	tokens, err := tokenizeComputedExpression(s)
+	if err != nil {
+		return nil, errs.Wrapf(err, `failed to tokenize expression "%s"`, s)
+	}
+	p := computedParser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, errs.Wrapf(err, `failed to parse expression "%s"`, s)
+	}
+	if !p.done() {
+		return nil, errs.Errorf(`failed to parse expression "%s": unexpected "%s" at position %d`, s, p.peek().text, p.peek().pos)
+	}
+	return &ComputedExpression{root: root}, nil
+}
+
+// Evaluate computes the value of the expression using the given field values
+// of a work item and of its children. The result is nil if the expression
+// yields no value.
+func (e ComputedExpression) Evaluate(fields map[string]interface{}, children []map[string]interface{}, now time.Time) *float64 {
+	return e.root.eval(computedEnv{fields: fields, children: children, now: now})
+}
+
+// SQL returns an SQL expression that computes the value of the expression
+// for the current row of the work items table.
+func (e ComputedExpression) SQL() string {
+	return e.root.sql()
+}
+
+// UsesChildren returns true if the expression aggregates over the children
+// of a work item.
+func (e ComputedExpression) UsesChildren() bool {
+	res := false
+	e.root.walk(func(n computedNode) {
+		if _, ok := n.(*computedAggregateNode); ok {
+			res = true
+		}
+	})
+	return res
+}
+
+// Fields returns the names of the work item's own fields that are referenced
+// by the expression.
+func (e ComputedExpression) Fields() []string {
+	res := []string{}
+	seen := map[string]struct{}{}
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			res = append(res, name)
+		}
+	}
+	e.root.walk(func(n computedNode) {
+		switch t := n.(type) {
+		case *computedFieldNode:
+			add(t.name)
+		case *computedDaysSinceNode:
+			add(t.field)
+		}
+	})
+	return res
+}
+
+// ----------------------------------------------------------------------------
+// syntax tree
+// ----------------------------------------------------------------------------
+
+type computedNumberNode struct {
+	value float64
+}
+
+func (n *computedNumberNode) eval(env computedEnv) *float64 {
+	v := n.value
+	return &v
+}
+
+func (n *computedNumberNode) sql() string {
+	return strconv.FormatFloat(n.value, 'f', -1, 64)
+}
+
+func (n *computedNumberNode) walk(fn func(n computedNode)) {
+	fn(n)
+}
+
+type computedFieldNode struct {
+	name string
+}
+
+func (n *computedFieldNode) eval(env computedEnv) *float64 {
+	return toComputedNumber(env.fields[n.name])
+}
+
+func (n *computedFieldNode) sql() string {
+	return computedJSONNumber(WorkItemStorage{}.TableName(), n.name)
+}
+
+func (n *computedFieldNode) walk(fn func(n computedNode)) {
+	fn(n)
+}
+
+type computedNegateNode struct {
+	operand computedNode
+}
+
+func (n *computedNegateNode) eval(env computedEnv) *float64 {
+	v := n.operand.eval(env)
+	if v == nil {
+		return nil
+	}
+	res := -*v
+	return &res
+}
+
+func (n *computedNegateNode) sql() string {
+	return "(-" + n.operand.sql() + ")"
+}
+
+func (n *computedNegateNode) walk(fn func(n computedNode)) {
+	fn(n)
+	n.operand.walk(fn)
+}
+
+type computedBinaryNode struct {
+	op          string
+	left, right computedNode
+}
+
+func (n *computedBinaryNode) eval(env computedEnv) *float64 {
+	l := n.left.eval(env)
+	r := n.right.eval(env)
+	if l == nil || r == nil {
+		return nil
+	}
+	var res float64
+	switch n.op {
+	case "+":
+		res = *l + *r
+	case "-":
+		res = *l - *r
+	case "*":
+		res = *l * *r
+	case "/":
+		if *r == 0 {
+			return nil
+		}
+		res = *l / *r
+	}
+	return &res
+}
+
+func (n *computedBinaryNode) sql() string {
+	if n.op == "/" {
+		return "(" + n.left.sql() + " / NULLIF(" + n.right.sql() + ", 0))"
+	}
+	return "(" + n.left.sql() + " " + n.op + " " + n.right.sql() + ")"
+}
+
+func (n *computedBinaryNode) walk(fn func(n computedNode)) {
+	fn(n)
+	n.left.walk(fn)
+	n.right.walk(fn)
+}
+
+type computedAggregateNode struct {
+	fn    string
+	field string // empty for count
+}
+
+func (n *computedAggregateNode) eval(env computedEnv) *float64 {
+	if n.fn == computedFuncCount {
+		res := float64(len(env.children))
+		return &res
+	}
+	var res *float64
+	count := 0
+	for _, child := range env.children {
+		v := toComputedNumber(child[n.field])
+		if v == nil {
+			continue
+		}
+		count++
+		if res == nil {
+			res = v
+			continue
+		}
+		switch n.fn {
+		case computedFuncSum, computedFuncAvg:
+			*res += *v
+		case computedFuncMin:
+			*res = math.Min(*res, *v)
+		case computedFuncMax:
+			*res = math.Max(*res, *v)
+		}
+	}
+	switch n.fn {
+	case computedFuncSum:
+		if res == nil {
+			zero := float64(0)
+			return &zero
+		}
+	case computedFuncAvg:
+		if res != nil {
+			*res = *res / float64(count)
+		}
+	}
+	return res
+}
+
+func (n *computedAggregateNode) sql() string {
+	var agg string
+	switch n.fn {
+	case computedFuncCount:
+		agg = "COUNT(*)"
+	case computedFuncSum:
+		agg = "COALESCE(SUM(" + computedJSONNumber("computed_child", n.field) + "), 0)"
+	default:
+		agg = strings.ToUpper(n.fn) + "(" + computedJSONNumber("computed_child", n.field) + ")"
+	}
+	return fmt.Sprintf(`(SELECT %[1]s FROM work_item_links computed_link
+		JOIN %[2]s computed_child ON computed_child.id = computed_link.target_id AND computed_child.deleted_at IS NULL
+		WHERE computed_link.source_id = "%[2]s"."id"
+		AND computed_link.link_type_id = '%[3]s'
+		AND computed_link.deleted_at IS NULL)`, agg, WorkItemStorage{}.TableName(), parentChildLinkTypeID)
+}
+
+func (n *computedAggregateNode) walk(fn func(n computedNode)) {
+	fn(n)
+}
+
+type computedDaysSinceNode struct {
+	field string
+}
+
+func (n *computedDaysSinceNode) eval(env computedEnv) *float64 {
+	var t time.Time
+	switch v := env.fields[n.field].(type) {
+	case time.Time:
+		t = v
+	case nil:
+		return nil
+	default:
+		// instants are stored as nano seconds since the epoch
+		nanos := toComputedNumber(v)
+		if nanos == nil {
+			return nil
+		}
+		t = time.Unix(0, int64(*nanos))
+	}
+	res := math.Floor(env.now.Sub(t).Hours() / 24)
+	return &res
+}
+
+func (n *computedDaysSinceNode) sql() string {
+	var ts string
+	switch n.field {
+	case SystemCreatedAt:
+		ts = Column(WorkItemStorage{}.TableName(), "created_at")
+	case SystemUpdatedAt:
+		ts = Column(WorkItemStorage{}.TableName(), "updated_at")
+	default:
+		ts = "to_timestamp(" + computedJSONNumber(WorkItemStorage{}.TableName(), n.field) + " / 1000000000)"
+	}
+	return "floor(EXTRACT(EPOCH FROM (now() - " + ts + ")) / 86400)"
+}
+
+func (n *computedDaysSinceNode) walk(fn func(n computedNode)) {
+	fn(n)
+}
+
+// computedJSONNumber returns an SQL expression that reads the given field
+// from the jsonb fields column of the given table as a number.
+func computedJSONNumber(table, field string) string {
+	return "(" + Column(table, "fields") + "->>'" + field + "')::numeric"
+}
+
+// toComputedNumber converts the given field value to a number or returns nil
+// if that is not possible.
+func toComputedNumber(v interface{}) *float64 {
+	var res float64
+	switch t := v.(type) {
+	case float64:
+		res = t
+	case float32:
+		res = float64(t)
+	case int:
+		res = float64(t)
+	case int32:
+		res = float64(t)
+	case int64:
+		res = float64(t)
+	default:
+		return nil
+	}
+	return &res
+}
+
+// ----------------------------------------------------------------------------
+// tokenizer and parser
+// ----------------------------------------------------------------------------
+
+type computedTokenKind int
+
+const (
+	computedTokenEOF computedTokenKind = iota
+	computedTokenNumber
+	computedTokenIdent
+	computedTokenSymbol
+)
+
+type computedToken struct {
+	kind computedTokenKind
+	text string
+	pos  int
+}
+
+func isComputedIdentRune(r rune, first bool) bool {
+	if r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+	return !first && (r == '.' || unicode.IsDigit(r))
+}
+
+func tokenizeComputedExpression(s string) ([]computedToken, error) {
+	runes := []rune(s)
+	tokens := []computedToken{}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+-*/(),", r):
+			tokens = append(tokens, computedToken{kind: computedTokenSymbol, text: string(r), pos: i})
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, computedToken{kind: computedTokenNumber, text: string(runes[start:i]), pos: start})
+		case isComputedIdentRune(r, true):
+			start := i
+			for i < len(runes) && isComputedIdentRune(runes[i], false) {
+				i++
+			}
+			tokens = append(tokens, computedToken{kind: computedTokenIdent, text: string(runes[start:i]), pos: start})
+		default:
+			return nil, errs.Errorf(`invalid character "%c" at position %d`, r, i)
+		}
+	}
+	return append(tokens, computedToken{kind: computedTokenEOF, pos: len(runes)}), nil
+}
+
+type computedParser struct {
+	tokens []computedToken
+	pos    int
+}
+
+func (p *computedParser) peek() computedToken {
+	return p.tokens[p.pos]
+}
+
+func (p *computedParser) next() computedToken {
+	t := p.tokens[p.pos]
+	if t.kind != computedTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *computedParser) done() bool {
+	return p.peek().kind == computedTokenEOF
+}
+
+func (p *computedParser) isSymbol(symbols ...string) bool {
+	t := p.peek()
+	if t.kind != computedTokenSymbol {
+		return false
+	}
+	for _, s := range symbols {
+		if t.text == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *computedParser) expect(symbol string) error {
+	t := p.next()
+	if t.kind != computedTokenSymbol || t.text != symbol {
+		return errs.Errorf(`expected "%s" at position %d but got "%s"`, symbol, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *computedParser) parseExpr() (computedNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.isSymbol("+", "-") {
+		op := p.next().text
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &computedBinaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *computedParser) parseTerm() (computedNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.isSymbol("*", "/") {
+		op := p.next().text
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &computedBinaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *computedParser) parseFactor() (computedNode, error) {
+	t := p.next()
+	switch t.kind {
+	case computedTokenNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errs.Errorf(`invalid number "%s" at position %d`, t.text, t.pos)
+		}
+		return &computedNumberNode{value: v}, nil
+	case computedTokenIdent:
+		if p.isSymbol("(") {
+			return p.parseCall(t)
+		}
+		if t.text == computedChildrenPrefix || strings.HasPrefix(t.text, computedChildrenPrefix+".") {
+			return nil, errs.Errorf(`"%s" at position %d can only be used inside an aggregate function`, t.text, t.pos)
+		}
+		return &computedFieldNode{name: t.text}, nil
+	case computedTokenSymbol:
+		switch t.text {
+		case "(":
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "-":
+			n, err := p.parseFactor()
+			if err != nil {
+				return nil, err
+			}
+			return &computedNegateNode{operand: n}, nil
+		}
+	case computedTokenEOF:
+		return nil, errs.Errorf("unexpected end of expression")
+	}
+	return nil, errs.Errorf(`unexpected "%s" at position %d`, t.text, t.pos)
+}
+
+func (p *computedParser) parseCall(fn computedToken) (computedNode, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	arg := p.next()
+	if arg.kind != computedTokenIdent {
+		return nil, errs.Errorf(`expected a field as argument of "%s" at position %d but got "%s"`, fn.text, arg.pos, arg.text)
+	}
+	var n computedNode
+	switch fn.text {
+	case computedFuncSum, computedFuncMin, computedFuncMax, computedFuncAvg:
+		prefix := computedChildrenPrefix + "."
+		if !strings.HasPrefix(arg.text, prefix) || len(arg.text) == len(prefix) {
+			return nil, errs.Errorf(`argument of "%s" at position %d must look like "%s<field>" but is "%s"`, fn.text, arg.pos, prefix, arg.text)
+		}
+		n = &computedAggregateNode{fn: fn.text, field: strings.TrimPrefix(arg.text, prefix)}
+	case computedFuncCount:
+		if arg.text != computedChildrenPrefix {
+			return nil, errs.Errorf(`argument of "%s" at position %d must be "%s" but is "%s"`, fn.text, arg.pos, computedChildrenPrefix, arg.text)
+		}
+		n = &computedAggregateNode{fn: fn.text}
+	case computedFuncDaysSince:
+		if arg.text == computedChildrenPrefix || strings.HasPrefix(arg.text, computedChildrenPrefix+".") {
+			return nil, errs.Errorf(`argument of "%s" at position %d must be a field of the work item itself but is "%s"`, fn.text, arg.pos, arg.text)
+		}
+		n = &computedDaysSinceNode{field: arg.text}
+	default:
+		return nil, errs.Errorf(`unknown function "%s" at position %d`, fn.text, fn.pos)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
@@ -0,0 +1,93 @@
+package workitem
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/cache"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ComputedFieldCacheName is the name of the global cache of computed field
+// values.
+const ComputedFieldCacheName = "computed_fields"
+
+// computedFieldCacheEntry holds the computed field values of a work item in a
+// certain version. Because expressions can depend on the current time (e.g.
+// "days_since"), an entry is only valid on the day it was computed.
+type computedFieldCacheEntry struct {
+	version int
+	day     time.Time
+	values  map[string]interface{}
+}
+
+// computedFieldCache holds the computed field values per work item. The values
+// of a parent are invalidated across all instances of the service whenever one
+// of its children or the links to them change.
+var computedFieldCache = cache.Register(cache.NewMemoryCache(ComputedFieldCacheName, cache.DefaultTTL))
+
+// getComputedFields returns the cached computed field values of the work item
+// with the given ID and version as they were computed on the day of the given
+// time.
+func getComputedFields(id uuid.UUID, version int, now time.Time) (map[string]interface{}, bool) {
+	v, ok := computedFieldCache.Get(id)
+	if !ok {
+		return nil, false
+	}
+	e, ok := v.(computedFieldCacheEntry)
+	if !ok || e.version != version || !e.day.Equal(computedFieldCacheDay(now)) {
+		return nil, false
+	}
+	res := make(map[string]interface{}, len(e.values))
+	for k, v := range e.values {
+		res[k] = v
+	}
+	return res, true
+}
+
+// putComputedFields caches the computed field values of the work item with
+// the given ID and version.
+func putComputedFields(id uuid.UUID, version int, now time.Time, values map[string]interface{}) {
+	e := computedFieldCacheEntry{
+		version: version,
+		day:     computedFieldCacheDay(now),
+		values:  make(map[string]interface{}, len(values)),
+	}
+	for k, v := range values {
+		e.values[k] = v
+	}
+	computedFieldCache.Put(id, e)
+}
+
+func computedFieldCacheDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// InvalidateComputedFields removes the cached computed field values of the
+// given work items from the caches of all instances of the service. Call this
+// whenever something changes that a computed field of these work items can
+// depend upon, e.g. one of their children. uuid.Nil clears the whole cache.
+func InvalidateComputedFields(ctx context.Context, db *gorm.DB, ids ...uuid.UUID) error {
+	for _, id := range ids {
+		if err := cache.Invalidate(ctx, db, ComputedFieldCacheName, id); err != nil {
+			return errs.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// invalidateComputedFieldsOfParents removes the cached computed field values
+// of the parents of the given work item, which may aggregate over it.
+func (r *GormWorkItemRepository) invalidateComputedFieldsOfParents(ctx context.Context, id uuid.UUID) error {
+	var parentIDs []uuid.UUID
+	db := r.db.Table("work_item_links").
+		Where("target_id = ? AND link_type_id = ? AND deleted_at IS NULL", id, parentChildLinkTypeID).
+		Pluck("source_id", &parentIDs)
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, db.Error)
+	}
+	return InvalidateComputedFields(ctx, r.db, parentIDs...)
+}
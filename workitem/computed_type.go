@@ -0,0 +1,154 @@
+package workitem
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/convert"
+	errs "github.com/pkg/errors"
+)
+
+// ComputedType describes a field whose value is not stored but derived from
+// other fields of the work item and from its children whenever the work item
+// is read. The Expression is evaluated as described in computed_expression.go
+// and its result is converted to the ResultKind which can either be
+// "integer" (the result is truncated) or "float".
+//
+// In a space template a computed field is declared like this:
+//
+//	remaining_effort:
+//	  label: Remaining effort
+//	  description: The sum of the effort of all children
+//	  type:
+//	    kind: computed
+//	    result_kind: float
+//	    expression: sum(children.effort)
+type ComputedType struct {
+	Kind       Kind   `json:"kind"`
+	ResultKind Kind   `json:"result_kind"`
+	Expression string `json:"expression"`
+}
+
+// Ensure ComputedType implements the FieldType interface
+var _ FieldType = ComputedType{}
+var _ FieldType = (*ComputedType)(nil)
+
+// Ensure ComputedType implements the Equaler interface
+var _ convert.Equaler = ComputedType{}
+var _ convert.Equaler = (*ComputedType)(nil)
+
+// Validate checks that the kind is "computed", that the result kind is a
+// number and that the expression can be parsed.
+func (t ComputedType) Validate() error {
+	if t.Kind != KindComputed {
+		return errs.Errorf(`computed type has kind "%s" but needs "%s"`, t.Kind, KindComputed)
+	}
+	if t.ResultKind != KindInteger && t.ResultKind != KindFloat {
+		return errs.Errorf(`computed type must have a result kind of "%s" or "%s" and not "%s"`, KindInteger, KindFloat, t.ResultKind)
+	}
+	if _, err := ParseComputedExpression(t.Expression); err != nil {
+		return errs.Wrap(err, "failed to validate expression of computed type")
+	}
+	return nil
+}
+
+// ParsedExpression returns the parsed expression of the computed type.
+func (t ComputedType) ParsedExpression() (*ComputedExpression, error) {
+	return ParseComputedExpression(t.Expression)
+}
+
+// Evaluate computes the value of the field from the given field values of a
+// work item and of its children. The result is nil, an int or a float64
+// depending on the result kind.
+func (t ComputedType) Evaluate(fields map[string]interface{}, children []map[string]interface{}, now time.Time) (interface{}, error) {
+	expr, err := t.ParsedExpression()
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	v := expr.Evaluate(fields, children, now)
+	if v == nil || math.IsNaN(*v) || math.IsInf(*v, 0) {
+		return nil, nil
+	}
+	if t.ResultKind == KindInteger {
+		return int(math.Trunc(*v)), nil
+	}
+	return *v, nil
+}
+
+// SQL returns an SQL expression that computes the value of the field for the
+// current row of the work items table.
+func (t ComputedType) SQL() (string, error) {
+	expr, err := t.ParsedExpression()
+	if err != nil {
+		return "", errs.WithStack(err)
+	}
+	if t.ResultKind == KindInteger {
+		return "trunc(" + expr.SQL() + ")", nil
+	}
+	return expr.SQL(), nil
+}
+
+// SetDefaultValue implements FieldType
+func (t ComputedType) SetDefaultValue(v interface{}) (FieldType, error) {
+	if v != nil {
+		return nil, errs.Errorf("a computed type cannot have a default value: %+v", v)
+	}
+	return t, nil
+}
+
+// GetDefaultValue implements FieldType
+func (t ComputedType) GetDefaultValue() interface{} {
+	return nil
+}
+
+// Equal returns true if two ComputedType objects are equal; otherwise false is returned.
+func (t ComputedType) Equal(u convert.Equaler) bool {
+	other, ok := u.(ComputedType)
+	if !ok {
+		return false
+	}
+	return t.Kind == other.Kind && t.ResultKind == other.ResultKind && t.Expression == other.Expression
+}
+
+// EqualValue implements convert.Equaler interface
+func (t ComputedType) EqualValue(u convert.Equaler) bool {
+	return t.Equal(u)
+}
+
+// GetKind implements FieldType
+func (t ComputedType) GetKind() Kind {
+	return t.Kind
+}
+
+// ConvertToModel implements the FieldType interface. The value of a computed
+// field is never stored, so only nil is accepted.
+func (t ComputedType) ConvertToModel(value interface{}) (interface{}, error) {
+	if value != nil {
+		return nil, errs.Errorf("value %v (%[1]T) cannot be set because the field is computed", value)
+	}
+	return nil, nil
+}
+
+// ConvertFromModel implements the FieldType interface
+func (t ComputedType) ConvertFromModel(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return value, nil
+	}
+	return nil, errs.Errorf("value %v (%[1]T) of a computed field should be a number", value)
+}
+
+// ConvertToModelWithType implements FieldType
+func (t ComputedType) ConvertToModelWithType(newFieldType FieldType, v interface{}) (interface{}, error) {
+	// there is no stored value to convert
+	return nil, nil
+}
+
+// ConvertToStringSlice implements the FieldType interface
+func (t ComputedType) ConvertToStringSlice(value interface{}) ([]string, error) {
+	return SimpleType{Kind: t.ResultKind}.ConvertToStringSlice(value)
+}
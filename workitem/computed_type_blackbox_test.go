@@ -0,0 +1,162 @@
+package workitem_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComputedExpression(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		for _, s := range []string{
+			"42",
+			"effort",
+			"-effort * 2.5",
+			"(effort - effort_spent) / 8",
+			"sum(children.effort) - effort_spent",
+			"min(children.effort) + max(children.effort) + avg(children.effort)",
+			"count(children)",
+			"days_since(system.created_at)",
+		} {
+			_, err := workitem.ParseComputedExpression(s)
+			require.NoError(t, err, s)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, s := range []string{
+			"",
+			"1 +",
+			"(1 + 2",
+			"1 2",
+			"effort $ 2",
+			"1.2.3",
+			"children.effort",
+			"sum(effort)",
+			"sum(children.)",
+			"count(effort)",
+			"days_since(children.effort)",
+			"median(children.effort)",
+		} {
+			_, err := workitem.ParseComputedExpression(s)
+			require.Error(t, err, s)
+		}
+	})
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+		e, err := workitem.ParseComputedExpression("effort + sum(children.effort) + days_since(system.created_at) - effort")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"effort", "system.created_at"}, e.Fields())
+		assert.True(t, e.UsesChildren())
+	})
+}
+
+func TestComputedExpression_Evaluate(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	now := time.Date(2018, 5, 10, 12, 0, 0, 0, time.UTC)
+	fields := map[string]interface{}{
+		"effort":                 8.0,
+		"effort_spent":           3,
+		workitem.SystemCreatedAt: time.Date(2018, 5, 1, 18, 0, 0, 0, time.UTC),
+	}
+	children := []map[string]interface{}{
+		{"effort": 2.0},
+		{"effort": 4.0},
+		{"effort": nil},
+	}
+	testData := []struct {
+		expr     string
+		children []map[string]interface{}
+		expected *float64
+	}{
+		{"effort - effort_spent", nil, ptr.Float64(5)},
+		{"(effort + 2) * 2 / 4", nil, ptr.Float64(5)},
+		{"-effort", nil, ptr.Float64(-8)},
+		{"effort / 0", nil, nil},
+		{"unknown + 1", nil, nil},
+		{"sum(children.effort)", children, ptr.Float64(6)},
+		{"min(children.effort)", children, ptr.Float64(2)},
+		{"max(children.effort)", children, ptr.Float64(4)},
+		{"avg(children.effort)", children, ptr.Float64(3)},
+		{"count(children)", children, ptr.Float64(3)},
+		{"sum(children.effort)", nil, ptr.Float64(0)},
+		{"count(children)", nil, ptr.Float64(0)},
+		{"avg(children.effort)", nil, nil},
+		{"days_since(system.created_at)", nil, ptr.Float64(8)},
+		{"days_since(system.updated_at)", nil, nil},
+	}
+	for _, d := range testData {
+		t.Run(d.expr, func(t *testing.T) {
+			e, err := workitem.ParseComputedExpression(d.expr)
+			require.NoError(t, err)
+			actual := e.Evaluate(fields, d.children, now)
+			if d.expected == nil {
+				assert.Nil(t, actual)
+				return
+			}
+			require.NotNil(t, actual)
+			assert.Equal(t, *d.expected, *actual)
+		})
+	}
+}
+
+func TestComputedType(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	t.Run("validate", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindFloat, Expression: "sum(children.effort)"}.Validate())
+		require.Error(t, workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindString, Expression: "sum(children.effort)"}.Validate())
+		require.Error(t, workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindFloat, Expression: "sum("}.Validate())
+		require.Error(t, workitem.FieldDefinition{
+			Label:    "Remaining",
+			Required: true,
+			Type:     workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindFloat, Expression: "1"},
+		}.Validate())
+		// computed fields cannot be system fields
+		fields := workitem.FieldDefinitions{
+			"system.remaining": {
+				Label: "Remaining",
+				Type:  workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindFloat, Expression: "1"},
+			},
+		}
+		require.Error(t, fields.Validate())
+	})
+	t.Run("evaluate integer", func(t *testing.T) {
+		t.Parallel()
+		ct := workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindInteger, Expression: "effort / 3"}
+		v, err := ct.Evaluate(map[string]interface{}{"effort": 8.0}, nil, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 2, v)
+	})
+	t.Run("value cannot be set", func(t *testing.T) {
+		t.Parallel()
+		ct := workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindInteger, Expression: "1"}
+		_, err := ct.ConvertToModel(1)
+		require.Error(t, err)
+	})
+	t.Run("unmarshal forces read-only", func(t *testing.T) {
+		t.Parallel()
+		def := workitem.FieldDefinition{}
+		err := json.Unmarshal([]byte(`{
+			"label": "Remaining effort",
+			"type": {"kind": "computed", "result_kind": "float", "expression": "sum(children.effort)"}
+		}`), &def)
+		require.NoError(t, err)
+		assert.True(t, def.ReadOnly)
+		assert.Equal(t, workitem.ComputedType{Kind: workitem.KindComputed, ResultKind: workitem.KindFloat, Expression: "sum(children.effort)"}, def.Type)
+	})
+}
@@ -6,6 +6,14 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// Names of the events of the comments of a work item
+const (
+	CommentResolvedEvent        = "comment.resolved"
+	CommentReopenedEvent        = "comment.reopened"
+	CommentReactionAddedEvent   = "comment.reaction.added"
+	CommentReactionRemovedEvent = "comment.reaction.removed"
+)
+
 // Event represents work item event
 type Event struct {
 	RevisionID     uuid.UUID
@@ -15,6 +23,14 @@ type Event struct {
 	Modifier       uuid.UUID
 	Old            interface{}
 	New            interface{}
+	// CommentID is set for the events of the comments of the work item
+	CommentID uuid.UUID
+}
+
+// IsCommentEvent returns true if the event happened on a comment of the work
+// item rather than on the work item itself.
+func (e Event) IsCommentEvent() bool {
+	return e.CommentID != uuid.Nil
 }
 
 // GetETagData returns the field values to use to generate the ETag
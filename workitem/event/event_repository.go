@@ -3,12 +3,15 @@ package event
 import (
 	"context"
 	"reflect"
+	"sort"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 )
@@ -33,6 +36,7 @@ func NewEventRepository(db *gorm.DB) *GormEventRepository {
 		wiRevisionRepo:   workitem.NewRevisionRepository(db),
 		workItemTypeRepo: workitem.NewWorkItemTypeRepository(db),
 		identityRepo:     account.NewIdentityRepository(db),
+		commentRevRepo:   comment.NewRevisionRepository(db),
 	}
 }
 
@@ -43,6 +47,7 @@ type GormEventRepository struct {
 	wiRevisionRepo   *workitem.GormRevisionRepository
 	workItemTypeRepo *workitem.GormWorkItemTypeRepository
 	identityRepo     *account.GormIdentityRepository
+	commentRevRepo   *comment.GormCommentRevisionRepository
 }
 
 // List implements Repository interface
@@ -180,5 +185,73 @@ func (r *GormEventRepository) List(ctx context.Context, wiID uuid.UUID) (List, e
 		}
 	}
 
+	commentEvents, err := r.listCommentEvents(ctx, wiID, revisionList)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list comment events for work item %s", wiID)
+	}
+	if len(commentEvents) > 0 {
+		eventList = append(eventList, commentEvents...)
+		sort.SliceStable(eventList, func(i, j int) bool {
+			return eventList[i].Timestamp.Before(eventList[j].Timestamp)
+		})
+	}
+	return eventList, nil
+}
+
+// listCommentEvents returns the events for resolved and reopened threads and
+// for added and removed reactions on the comments of the given work item.
+func (r *GormEventRepository) listCommentEvents(ctx context.Context, wiID uuid.UUID, revisionList []workitem.Revision) (List, error) {
+	commentRevisions, err := r.commentRevRepo.ListByParent(ctx, wiID,
+		comment.RevisionTypeResolve,
+		comment.RevisionTypeReopen,
+		comment.RevisionTypeReactionAdd,
+		comment.RevisionTypeReactionRemove)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	eventList := List{}
+	for _, rev := range commentRevisions {
+		event := Event{
+			RevisionID:     rev.ID,
+			WorkItemTypeID: workItemTypeAt(revisionList, rev.Time),
+			Timestamp:      rev.Time,
+			Modifier:       rev.ModifierIdentity,
+			CommentID:      rev.CommentID,
+		}
+		switch rev.Type {
+		case comment.RevisionTypeResolve:
+			event.Name = CommentResolvedEvent
+			event.Old = false
+			event.New = true
+		case comment.RevisionTypeReopen:
+			event.Name = CommentReopenedEvent
+			event.Old = true
+			event.New = false
+		case comment.RevisionTypeReactionAdd:
+			event.Name = CommentReactionAddedEvent
+			if rev.CommentReaction != nil {
+				event.New = *rev.CommentReaction
+			}
+		case comment.RevisionTypeReactionRemove:
+			event.Name = CommentReactionRemovedEvent
+			if rev.CommentReaction != nil {
+				event.Old = *rev.CommentReaction
+			}
+		}
+		eventList = append(eventList, event)
+	}
 	return eventList, nil
 }
+
+// workItemTypeAt returns the type of the work item at the given point in time
+// based on its revisions (in chronological order).
+func workItemTypeAt(revisionList []workitem.Revision, t time.Time) uuid.UUID {
+	var witID uuid.UUID
+	for _, rev := range revisionList {
+		if rev.Time.After(t) && witID != uuid.Nil {
+			break
+		}
+		witID = rev.WorkItemTypeID
+	}
+	return witID
+}
@@ -3,6 +3,7 @@ package event_test
 import (
 	"testing"
 
+	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/rendering"
 	"github.com/fabric8-services/fabric8-wit/resource"
@@ -414,4 +415,32 @@ func (s *eventRepoBlackBoxTest) TestList() {
 		assert.Equal(t, fxt.WorkItemTypes[0].ID, eventList[0].Old)
 		assert.Equal(t, fxt.WorkItemTypes[1].ID, eventList[0].New)
 	})
+
+	s.T().Run("comment events", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1), tf.Identities(2), tf.Comments(1))
+		commentRepo := comment.NewRepository(s.DB)
+		c := fxt.Comments[0]
+		_, err := commentRepo.Resolve(s.Ctx, c.ID, fxt.Identities[1].ID)
+		require.NoError(t, err)
+		require.NoError(t, commentRepo.AddReaction(s.Ctx, c.ID, fxt.Identities[0].ID, "thumbsup"))
+		require.NoError(t, commentRepo.RemoveReaction(s.Ctx, c.ID, fxt.Identities[0].ID, "thumbsup"))
+		_, err = commentRepo.Reopen(s.Ctx, c.ID, fxt.Identities[0].ID)
+		require.NoError(t, err)
+
+		eventList, err := s.wiEventRepo.List(s.Ctx, fxt.WorkItems[0].ID)
+		require.NoError(t, err)
+		require.Len(t, eventList, 4)
+		for _, e := range eventList {
+			assert.True(t, e.IsCommentEvent())
+			assert.Equal(t, c.ID, e.CommentID)
+			assert.Equal(t, fxt.WorkItems[0].Type, e.WorkItemTypeID)
+		}
+		assert.Equal(t, event.CommentResolvedEvent, eventList[0].Name)
+		assert.Equal(t, fxt.Identities[1].ID, eventList[0].Modifier)
+		assert.Equal(t, event.CommentReactionAddedEvent, eventList[1].Name)
+		assert.Equal(t, "thumbsup", eventList[1].New)
+		assert.Equal(t, event.CommentReactionRemovedEvent, eventList[2].Name)
+		assert.Equal(t, "thumbsup", eventList[2].Old)
+		assert.Equal(t, event.CommentReopenedEvent, eventList[3].Name)
+	})
 }
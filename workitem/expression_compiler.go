@@ -2,6 +2,7 @@ package workitem
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -18,7 +19,22 @@ const (
 // gorm.DB.Where(). Returns the number of expected parameters for the query and a
 // slice of errors if something goes wrong.
 func Compile(where criteria.Expression) (whereClause string, parameters []interface{}, joins []*TableJoin, err []error) {
+	return CompileWithComputedFields(where, nil)
+}
+
+// CompileWithComputedFields works like Compile but additionally resolves
+// references to the given computed fields by evaluating their expressions in
+// SQL.
+func CompileWithComputedFields(where criteria.Expression, computed ComputedFieldMap) (whereClause string, parameters []interface{}, joins []*TableJoin, err []error) {
 	compiler := newExpressionCompiler()
+	for name, defs := range computed {
+		sql, e := computedFieldSQL(defs)
+		if e != nil {
+			compiler.err = append(compiler.err, errs.Wrapf(e, `failed to compile computed field "%s"`, name))
+			continue
+		}
+		compiler.computed[name] = sql
+	}
 
 	criteria.IteratePostOrder(where, bubbleUpJSONContext(&compiler))
 
@@ -76,6 +92,50 @@ func bubbleUpJSONContext(c *expressionCompiler) func(exp criteria.Expression) bo
 	}
 }
 
+// computedFieldSQL returns an SQL expression that evaluates the computed field
+// definition that belongs to the type of the current work item row.
+func computedFieldSQL(defs map[uuid.UUID]ComputedType) (string, error) {
+	witIDs := make([]string, 0, len(defs))
+	for witID := range defs {
+		witIDs = append(witIDs, witID.String())
+	}
+	// sort the types to produce a stable query
+	sort.Strings(witIDs)
+	res := "(CASE " + Column(WorkItemStorage{}.TableName(), "type")
+	for _, witID := range witIDs {
+		sql, err := defs[uuid.FromStringOrNil(witID)].SQL()
+		if err != nil {
+			return "", errs.WithStack(err)
+		}
+		res += " WHEN '" + witID + "' THEN " + sql
+	}
+	return res + " END)", nil
+}
+
+// FieldNames returns the names of all fields that are referenced in the given
+// expression.
+func FieldNames(exp criteria.Expression) []string {
+	res := []string{}
+	seen := map[string]struct{}{}
+	criteria.IteratePostOrder(exp, func(exp criteria.Expression) bool {
+		var name string
+		switch t := exp.(type) {
+		case *criteria.FieldExpression:
+			name = t.FieldName
+		case *criteria.IsNullExpression:
+			name = t.FieldName
+		default:
+			return true
+		}
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			res = append(res, name)
+		}
+		return true
+	})
+	return res
+}
+
 // Column returns a proper column name from the given column name in the given
 // table.
 func Column(table, column string) string {
@@ -98,6 +158,11 @@ var fieldMap = map[string]string{
 // SpaceID -> space_id) and tells if the field is stored inside the jsonb column
 // (last result is true then) or as a normal column.
 func (c *expressionCompiler) getFieldName(fieldName string) (mappedFieldName string, isJSONField bool) {
+	// Computed fields are not stored at all but evaluated in SQL.
+	if sql, ok := c.computed[fieldName]; ok {
+		return sql, false
+	}
+
 	// If this field name references a joinable table, we will not say that it
 	// is a JSON field even though it might contain a dot.
 	for _, j := range c.joins {
@@ -248,6 +313,10 @@ var DefaultTableJoins = func() TableJoinMap {
 func newExpressionCompiler() expressionCompiler {
 	return expressionCompiler{
 		parameters: []interface{}{},
+		// virtual fields are resolved just like computed fields
+		computed: map[string]string{
+			SystemBlocked: blockedSQL,
+		},
 		// Define all possible join scenarios here
 		joins: DefaultTableJoins(),
 	}
@@ -256,9 +325,10 @@ func newExpressionCompiler() expressionCompiler {
 // expressionCompiler takes an expression and compiles it to a where clause for our gorm models
 // implements criteria.ExpressionVisitor
 type expressionCompiler struct {
-	parameters []interface{} // records the number of parameter expressions encountered
-	err        []error       // record any errors found in the expression
-	joins      TableJoinMap  // map of table joins keyed by table name
+	parameters []interface{}     // records the number of parameter expressions encountered
+	err        []error           // record any errors found in the expression
+	joins      TableJoinMap      // map of table joins keyed by table name
+	computed   map[string]string // SQL expressions of computed and virtual fields keyed by field name
 }
 
 // Ensure expressionCompiler implements the ExpressionVisitor interface
@@ -296,6 +366,9 @@ func (c *expressionCompiler) Field(f *criteria.FieldExpression) interface{} {
 		c.err = append(c.err, errs.Errorf("field name must not contain single quotes: %s", f.FieldName))
 		return nil
 	}
+	if sql, ok := c.computed[f.FieldName]; ok {
+		return sql
+	}
 
 	mappedFieldName, isJSONField := c.getFieldName(f.FieldName)
 
@@ -366,6 +439,12 @@ func (c *expressionCompiler) Equals(e *criteria.EqualsExpression) interface{} {
 }
 
 func (c *expressionCompiler) Substring(e *criteria.SubstringExpression) interface{} {
+	if f, ok := e.Left().(*criteria.FieldExpression); ok {
+		if _, isComputed := c.computed[f.FieldName]; isComputed {
+			c.err = append(c.err, errs.Errorf("substring search is not supported on computed field: %s", f.FieldName))
+			return nil
+		}
+	}
 	inJSONContext := isInJSONContext(e.Left())
 	join, isJoinedRef := c.expressionRefersToJoinedData(e.Left())
 	if inJSONContext || isJoinedRef {
@@ -490,6 +569,66 @@ func (c *expressionCompiler) Child(e *criteria.ChildExpression) interface{} {
 
 }
 
+// Descendant matches all work items below the given ancestor by following the
+// links of all link types with a "tree" topology. The recursive query is the
+// reverse of the one used to find the ancestors of a work item.
+func (c *expressionCompiler) Descendant(e *criteria.DescendantExpression) interface{} {
+	left, ok := e.Left().(*criteria.FieldExpression)
+	if !ok {
+		c.err = append(c.err, errs.Errorf("invalid left expression (not a field expression): %+v", e.Left()))
+		return nil
+	}
+	col, isJSONField := c.getFieldName(left.FieldName)
+	if isJSONField {
+		c.err = append(c.err, errs.Errorf("invalid field name for descendant expression: %s", left.FieldName))
+		return nil
+	}
+	litExp, ok := e.Right().(*criteria.LiteralExpression)
+	if !ok {
+		c.err = append(c.err, errs.Errorf("failed to convert right expression to literal expression: %+v", e.Right()))
+		return nil
+	}
+	r, ok := litExp.Value.(string)
+	if !ok {
+		c.err = append(c.err, errs.Errorf("failed to convert value of right literal expression to string: %+v", litExp.Value))
+		return nil
+	}
+	if _, err := uuid.FromString(r); err != nil {
+		c.err = append(c.err, errs.Wrapf(err, "descendant expression requires the ID of a work item: %s", r))
+		return nil
+	}
+	if e.MaxDepth < 0 {
+		c.err = append(c.err, errs.Errorf("maximum depth of descendant expression must not be negative: %d", e.MaxDepth))
+		return nil
+	}
+	depthLimitation := ""
+	if e.MaxDepth > 0 {
+		depthLimitation = fmt.Sprintf("AND array_length(d.already_visited, 1) < %d", e.MaxDepth)
+	}
+	c.parameters = append(c.parameters, r)
+
+	// NOTE: importing the link package to get the table names and the
+	// topology constant is not possible because of an import cycle.
+	return fmt.Sprintf(`(%[1]s IN (
+		WITH RECURSIVE descendants(id, already_visited, cycle) AS (
+			SELECT dsc_link.target_id, ARRAY[dsc_link.id], false
+			FROM work_item_links dsc_link
+			WHERE dsc_link.source_id = ?
+				AND dsc_link.deleted_at IS NULL
+				AND dsc_link.link_type_id IN (SELECT id FROM work_item_link_types WHERE topology = 'tree')
+		UNION
+			SELECT dsc_link.target_id, d.already_visited || dsc_link.id, dsc_link.id = ANY(d.already_visited)
+			FROM descendants d, work_item_links dsc_link
+			WHERE dsc_link.source_id = d.id
+				AND dsc_link.deleted_at IS NULL
+				AND dsc_link.link_type_id IN (SELECT id FROM work_item_link_types WHERE topology = 'tree')
+				AND NOT d.cycle
+				%[2]s
+		)
+		SELECT id FROM descendants
+	))`, col, depthLimitation)
+}
+
 func (c *expressionCompiler) Parameter(v *criteria.ParameterExpression) interface{} {
 	c.err = append(c.err, errs.Errorf("parameter expression not supported"))
 	return nil
@@ -1,6 +1,7 @@
 package workitem_test
 
 import (
+	"strings"
 	"testing"
 
 	c "github.com/fabric8-services/fabric8-wit/criteria"
@@ -177,6 +178,40 @@ func TestField(t *testing.T) {
 	t.Run("underscore", func(t *testing.T) {
 		expect(t, c.Equals(c.Field("foo_bar"), c.Literal(23)), `(`+workitem.Column(wiTbl, "fields")+` @> '{"foo_bar" : 23}')`, []interface{}{}, nil)
 	})
+	t.Run("blocked", func(t *testing.T) {
+		where, params, joins, compileErrors := workitem.Compile(c.Equals(c.Field(workitem.SystemBlocked), c.Literal(true)))
+		require.Empty(t, compileErrors)
+		assert.True(t, strings.HasPrefix(where, "((EXISTS ("), where)
+		assert.Contains(t, where, "WITH RECURSIVE")
+		assert.True(t, strings.HasSuffix(where, ") = ?)"), where)
+		assert.Equal(t, []interface{}{true}, params)
+		assert.Empty(t, joins)
+	})
+	t.Run("descendant", func(t *testing.T) {
+		ancestorID := "c20882bd-3a70-48a4-9784-3d6735992a43"
+		t.Run("all levels", func(t *testing.T) {
+			where, params, joins, compileErrors := workitem.Compile(c.Descendant(c.Field("ID"), c.Literal(ancestorID), 0))
+			require.Empty(t, compileErrors)
+			assert.True(t, strings.HasPrefix(where, "("+workitem.Column(wiTbl, "id")+" IN ("), where)
+			assert.Contains(t, where, "WITH RECURSIVE")
+			assert.NotContains(t, where, "array_length")
+			assert.Equal(t, []interface{}{ancestorID}, params)
+			assert.Empty(t, joins)
+		})
+		t.Run("limited depth", func(t *testing.T) {
+			where, _, _, compileErrors := workitem.Compile(c.Descendant(c.Field("ID"), c.Literal(ancestorID), 2))
+			require.Empty(t, compileErrors)
+			assert.Contains(t, where, "array_length(d.already_visited, 1) < 2")
+		})
+		t.Run("invalid ID", func(t *testing.T) {
+			_, _, _, compileErrors := workitem.Compile(c.Descendant(c.Field("ID"), c.Literal("foo'bar"), 0))
+			require.NotEmpty(t, compileErrors)
+		})
+		t.Run("negative depth", func(t *testing.T) {
+			_, _, _, compileErrors := workitem.Compile(c.Descendant(c.Field("ID"), c.Literal(ancestorID), -1))
+			require.NotEmpty(t, compileErrors)
+		})
+	})
 }
 
 func TestAndOr(t *testing.T) {
@@ -32,14 +32,17 @@ const (
 	// composite
 	KindEnum Kind = "enum"
 	KindList Kind = "list"
+	// derived
+	KindComputed Kind = "computed"
 )
 
 // Kind is the kind of field type
 type Kind string
 
-// IsSimpleType returns 'true' if the kind is simple, i.e., not a list nor an enum
+// IsSimpleType returns 'true' if the kind is simple, i.e., not a list, an enum
+// nor a computed field
 func (k Kind) IsSimpleType() bool {
-	return k != KindEnum && k != KindList
+	return k != KindEnum && k != KindList && k != KindComputed
 }
 
 // IsRelational returns 'true' if the kind must be represented with a
@@ -106,6 +109,8 @@ type FieldDefinition struct {
 	Label       string    `json:"label"`
 	Description string    `json:"description"`
 	Type        FieldType `json:"type"`
+	// Rules holds optional validation rules for the field's value.
+	Rules *FieldRules `json:"rules,omitempty"`
 }
 
 // Ensure FieldDefinition implements the Equaler interface
@@ -120,6 +125,14 @@ func (f FieldDefinition) Validate() error {
 	if strings.TrimSpace(f.Label) == "" {
 		return errs.Errorf(`field label is empty "%s" when trimmed`, f.Label)
 	}
+	if f.Required && f.Type.GetKind() == KindComputed {
+		return errs.Errorf(`computed field "%s" cannot be required`, f.Label)
+	}
+	if f.Rules != nil {
+		if err := f.Rules.Validate(f.Type); err != nil {
+			return errs.Wrapf(err, `failed to validate rules of field "%s"`, f.Label)
+		}
+	}
 	return f.Type.Validate()
 }
 
@@ -141,6 +154,12 @@ func (f FieldDefinition) Equal(u convert.Equaler) bool {
 	if f.Description != other.Description {
 		return false
 	}
+	if (f.Rules == nil) != (other.Rules == nil) {
+		return false
+	}
+	if f.Rules != nil && !f.Rules.Equal(*other.Rules) {
+		return false
+	}
 	return convert.CascadeEqual(f.Type, other.Type)
 }
 
@@ -187,6 +206,7 @@ type rawFieldDef struct {
 	Label       string           `json:"label"`
 	Description string           `json:"description"`
 	Type        *json.RawMessage `json:"type"`
+	Rules       *FieldRules      `json:"rules,omitempty"`
 }
 
 // Ensure rawFieldDef implements the Equaler interface
@@ -214,6 +234,9 @@ func (f rawFieldDef) Equal(u convert.Equaler) bool {
 	if !reflect.DeepEqual(f.Type, other.Type) {
 		return false
 	}
+	if !reflect.DeepEqual(f.Rules, other.Rules) {
+		return false
+	}
 	return true
 }
 
@@ -259,21 +282,29 @@ func (f *FieldDefinition) UnmarshalJSON(bytes []byte) error {
 		if err != nil {
 			return errs.WithStack(err)
 		}
-		*f = FieldDefinition{Type: theType, Required: temp.Required, ReadOnly: temp.ReadOnly, Label: temp.Label, Description: temp.Description}
+		*f = FieldDefinition{Type: theType, Required: temp.Required, ReadOnly: temp.ReadOnly, Label: temp.Label, Description: temp.Description, Rules: temp.Rules}
 	case KindEnum:
 		theType := EnumType{}
 		err = json.Unmarshal(*temp.Type, &theType)
 		if err != nil {
 			return errs.WithStack(err)
 		}
-		*f = FieldDefinition{Type: theType, Required: temp.Required, ReadOnly: temp.ReadOnly, Label: temp.Label, Description: temp.Description}
+		*f = FieldDefinition{Type: theType, Required: temp.Required, ReadOnly: temp.ReadOnly, Label: temp.Label, Description: temp.Description, Rules: temp.Rules}
+	case KindComputed:
+		theType := ComputedType{}
+		err = json.Unmarshal(*temp.Type, &theType)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		// the value of a computed field can never be set
+		*f = FieldDefinition{Type: theType, Required: temp.Required, ReadOnly: true, Label: temp.Label, Description: temp.Description, Rules: temp.Rules}
 	default:
 		theType := SimpleType{}
 		err = json.Unmarshal(*temp.Type, &theType)
 		if err != nil {
 			return errs.WithStack(err)
 		}
-		*f = FieldDefinition{Type: theType, Required: temp.Required, ReadOnly: temp.ReadOnly, Label: temp.Label, Description: temp.Description}
+		*f = FieldDefinition{Type: theType, Required: temp.Required, ReadOnly: temp.ReadOnly, Label: temp.Label, Description: temp.Description, Rules: temp.Rules}
 	}
 	return nil
 }
@@ -290,7 +321,7 @@ func ConvertAnyToKind(any interface{}) (*Kind, error) {
 func ConvertStringToKind(k string) (*Kind, error) {
 	kind := Kind(k)
 	switch kind {
-	case KindString, KindInteger, KindFloat, KindInstant, KindURL, KindUser, KindEnum, KindList, KindIteration, KindMarkup, KindArea, KindCodebase, KindLabel, KindBoardColumn, KindBoolean, KindRemoteTracker:
+	case KindString, KindInteger, KindFloat, KindInstant, KindURL, KindUser, KindEnum, KindList, KindIteration, KindMarkup, KindArea, KindCodebase, KindLabel, KindBoardColumn, KindBoolean, KindRemoteTracker, KindComputed:
 		return &kind, nil
 	}
 	return nil, errs.Errorf("kind '%s' is not a simple type", k)
@@ -0,0 +1,323 @@
+package workitem
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	errs "github.com/pkg/errors"
+)
+
+// FieldRules holds declarative validation rules for the value of a field that
+// go beyond the plain type check of the field's kind. All rules are optional
+// and for list fields they apply to each element of the list.
+//
+// In a space template the rules are declared next to the field type:
+//
+//	effort:
+//	  label: Effort
+//	  type:
+//	    kind: float
+//	  rules:
+//	    min: 0
+//	    max: 100
+//	resolution:
+//	  label: Resolution
+//	  type:
+//	    kind: string
+//	  rules:
+//	    max_length: 200
+//	    pattern: "^[A-Z].*"
+//	    required_when:
+//	    - field: system.state
+//	      values: [ closed ]
+type FieldRules struct {
+	// Min and Max restrict the value of integer and float fields (inclusive).
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// Pattern is a regular expression that the value of a string, URL or
+	// markup field must match.
+	Pattern *string `json:"pattern,omitempty"`
+	// MaxLength is the maximum number of characters of the value of a string,
+	// URL or markup field.
+	MaxLength *int `json:"max_length,omitempty"`
+	// NotBefore and NotAfter restrict the value of instant fields (inclusive).
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+	// RequiredWhen makes the field required as soon as one of the conditions
+	// is met.
+	RequiredWhen []FieldCondition `json:"required_when,omitempty"`
+}
+
+// FieldCondition is met when the value of the field with the given name equals
+// one of the given values. For list fields it is enough if one element of the
+// list equals one of the values.
+type FieldCondition struct {
+	Field  string        `json:"field"`
+	Values []interface{} `json:"values"`
+}
+
+// valueKind returns the kind of the individual values of the given field
+// type, e.g. the component kind for a list or the base kind for an enum.
+func valueKind(t FieldType) Kind {
+	switch v := t.(type) {
+	case ListType:
+		return valueKind(v.ComponentType)
+	case EnumType:
+		return valueKind(v.BaseType)
+	case ComputedType:
+		return v.ResultKind
+	}
+	return t.GetKind()
+}
+
+// Validate checks that the rules make sense for the given field type.
+func (r FieldRules) Validate(t FieldType) error {
+	kind := valueKind(t)
+	if t.GetKind() == KindComputed {
+		return errs.New("a computed field cannot have rules")
+	}
+	if r.Min != nil || r.Max != nil {
+		if kind != KindInteger && kind != KindFloat {
+			return errs.Errorf(`min and max rules are only allowed for "%s" and "%s" fields and not for "%s"`, KindInteger, KindFloat, kind)
+		}
+		if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return errs.Errorf("min rule %v is greater than max rule %v", *r.Min, *r.Max)
+		}
+	}
+	if r.Pattern != nil || r.MaxLength != nil {
+		if kind != KindString && kind != KindURL && kind != KindMarkup {
+			return errs.Errorf(`pattern and max_length rules are only allowed for "%s", "%s" and "%s" fields and not for "%s"`, KindString, KindURL, KindMarkup, kind)
+		}
+		if r.Pattern != nil {
+			if _, err := regexp.Compile(*r.Pattern); err != nil {
+				return errs.Wrapf(err, "failed to compile pattern rule %q", *r.Pattern)
+			}
+		}
+		if r.MaxLength != nil && *r.MaxLength <= 0 {
+			return errs.Errorf("max_length rule must be greater than zero and not %d", *r.MaxLength)
+		}
+	}
+	if r.NotBefore != nil || r.NotAfter != nil {
+		if kind != KindInstant {
+			return errs.Errorf(`not_before and not_after rules are only allowed for "%s" fields and not for "%s"`, KindInstant, kind)
+		}
+		if r.NotBefore != nil && r.NotAfter != nil && r.NotBefore.After(*r.NotAfter) {
+			return errs.Errorf("not_before rule %s is after not_after rule %s", r.NotBefore, r.NotAfter)
+		}
+	}
+	for _, c := range r.RequiredWhen {
+		if strings.TrimSpace(c.Field) == "" {
+			return errs.New("the field of a required_when rule must not be empty")
+		}
+		if len(c.Values) == 0 {
+			return errs.Errorf("the required_when rule for field %q has no values", c.Field)
+		}
+	}
+	return nil
+}
+
+// Equal returns true if two FieldRules objects are equal; otherwise false is
+// returned.
+func (r FieldRules) Equal(other FieldRules) bool {
+	if !reflect.DeepEqual(r.Min, other.Min) || !reflect.DeepEqual(r.Max, other.Max) {
+		return false
+	}
+	if !reflect.DeepEqual(r.Pattern, other.Pattern) || !reflect.DeepEqual(r.MaxLength, other.MaxLength) {
+		return false
+	}
+	if !timePtrEqual(r.NotBefore, other.NotBefore) || !timePtrEqual(r.NotAfter, other.NotAfter) {
+		return false
+	}
+	return reflect.DeepEqual(r.RequiredWhen, other.RequiredWhen)
+}
+
+func timePtrEqual(l, r *time.Time) bool {
+	if l == nil || r == nil {
+		return l == r
+	}
+	return l.Equal(*r)
+}
+
+// check returns a description of what was expected if the given value in
+// model representation violates one of the value rules; otherwise an empty
+// string is returned.
+func (r FieldRules) check(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if list, ok := value.([]interface{}); ok {
+		for _, v := range list {
+			if expected := r.check(v); expected != "" {
+				return expected
+			}
+		}
+		return ""
+	}
+	if r.Min != nil || r.Max != nil {
+		if f, ok := toFloat64(value); ok {
+			if r.Min != nil && f < *r.Min {
+				return fmt.Sprintf("a value not less than %v", *r.Min)
+			}
+			if r.Max != nil && f > *r.Max {
+				return fmt.Sprintf("a value not greater than %v", *r.Max)
+			}
+		}
+	}
+	if r.Pattern != nil || r.MaxLength != nil {
+		if s, ok := toText(value); ok {
+			if r.MaxLength != nil && utf8.RuneCountInString(s) > *r.MaxLength {
+				return fmt.Sprintf("at most %d characters", *r.MaxLength)
+			}
+			if r.Pattern != nil && !regexp.MustCompile(*r.Pattern).MatchString(s) {
+				return fmt.Sprintf("a value matching %q", *r.Pattern)
+			}
+		}
+	}
+	if r.NotBefore != nil || r.NotAfter != nil {
+		if f, ok := toFloat64(value); ok {
+			t := time.Unix(0, int64(f)).UTC()
+			if r.NotBefore != nil && t.Before(*r.NotBefore) {
+				return fmt.Sprintf("a date not before %s", r.NotBefore.UTC().Format(time.RFC3339))
+			}
+			if r.NotAfter != nil && t.After(*r.NotAfter) {
+				return fmt.Sprintf("a date not after %s", r.NotAfter.UTC().Format(time.RFC3339))
+			}
+		}
+	}
+	return ""
+}
+
+// met returns true if the given condition is met by the given field values in
+// model representation.
+func (c FieldCondition) met(fields FieldDefinitions, values map[string]interface{}) bool {
+	def, ok := fields[c.Field]
+	if !ok {
+		return false
+	}
+	actual := values[c.Field]
+	if actual == nil {
+		return false
+	}
+	candidates := []interface{}{actual}
+	if list, ok := actual.([]interface{}); ok {
+		candidates = list
+	}
+	for _, expected := range c.Values {
+		// bring the expected value into the same representation as the
+		// stored value (e.g. a float64 from JSON into an int).
+		if lt, ok := def.Type.(ListType); ok {
+			if v, err := lt.ComponentType.ConvertToModel(expected); err == nil {
+				expected = v
+			}
+		} else if v, err := def.Type.ConvertToModel(expected); err == nil {
+			expected = v
+		}
+		for _, candidate := range candidates {
+			if reflect.DeepEqual(candidate, expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateFieldValues checks the given field values in model representation
+// against the rules of the work item type's fields. The returned error is a
+// BadParameterError that points to the first offending field in alphabetical
+// order.
+func (wit WorkItemType) ValidateFieldValues(values map[string]interface{}) error {
+	return wit.Fields.ValidateValues(values, workItemAttributesPointer)
+}
+
+// ValidateValues checks the given values in model representation against the
+// rules of the field definitions. The returned error is a BadParameterError
+// with a JSON pointer made up of the given prefix and the name of the first
+// offending field in alphabetical order.
+func (j FieldDefinitions) ValidateValues(values map[string]interface{}, pointerPrefix string) error {
+	names := make([]string, 0, len(j))
+	for name, def := range j {
+		if def.Rules != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		def := j[name]
+		value := values[name]
+		if expected := def.Rules.check(value); expected != "" {
+			return errors.NewBadParameterError(name, value).Expected(expected).Pointer(FieldPointer(pointerPrefix, name))
+		}
+		if !isEmptyValue(value) {
+			continue
+		}
+		for _, c := range def.Rules.RequiredWhen {
+			if c.met(j, values) {
+				return errors.NewBadParameterError(name, value).Expected(fmt.Sprintf("a value when %s is %v", c.Field, c.Values)).Pointer(FieldPointer(pointerPrefix, name))
+			}
+		}
+	}
+	return nil
+}
+
+// workItemAttributesPointer is the JSON pointer to the attributes in a
+// JSON-API work item document.
+const workItemAttributesPointer = "/data/attributes/"
+
+// fieldPointer returns a JSON pointer to the attribute of the given field in a
+// JSON-API work item document.
+func fieldPointer(name string) string {
+	return FieldPointer(workItemAttributesPointer, name)
+}
+
+// FieldPointer returns a JSON pointer to the field with the given name below
+// the given prefix. The name is escaped according to RFC 6901.
+func FieldPointer(prefix, name string) string {
+	return prefix + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// isEmptyValue returns true if the given value in model representation is
+// nil, a blank string, an empty list or a markup without content.
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if s, ok := toText(value); ok {
+		return strings.TrimSpace(s) == ""
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		return v.Len() == 0
+	}
+	return false
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// toText returns the textual content of a string or markup value.
+func toText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case map[string]interface{}:
+		s, ok := v[rendering.ContentKey].(string)
+		return s, ok
+	}
+	return "", false
+}
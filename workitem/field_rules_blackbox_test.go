@@ -0,0 +1,170 @@
+package workitem_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldRules_Validate(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	date := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	floatType := workitem.SimpleType{Kind: workitem.KindFloat}
+	stringType := workitem.SimpleType{Kind: workitem.KindString}
+	instantType := workitem.SimpleType{Kind: workitem.KindInstant}
+	listType := workitem.ListType{
+		SimpleType:    workitem.SimpleType{Kind: workitem.KindList},
+		ComponentType: stringType,
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, workitem.FieldRules{Min: ptr.Float64(0), Max: ptr.Float64(10)}.Validate(floatType))
+		require.NoError(t, workitem.FieldRules{Pattern: ptr.String("^[a-z]+$"), MaxLength: ptr.Int(5)}.Validate(stringType))
+		require.NoError(t, workitem.FieldRules{MaxLength: ptr.Int(5)}.Validate(listType))
+		require.NoError(t, workitem.FieldRules{NotBefore: &date}.Validate(instantType))
+		require.NoError(t, workitem.FieldRules{RequiredWhen: []workitem.FieldCondition{{Field: workitem.SystemState, Values: []interface{}{"closed"}}}}.Validate(stringType))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		require.Error(t, workitem.FieldRules{Min: ptr.Float64(10), Max: ptr.Float64(0)}.Validate(floatType))
+		require.Error(t, workitem.FieldRules{Min: ptr.Float64(0)}.Validate(stringType))
+		require.Error(t, workitem.FieldRules{Pattern: ptr.String("(")}.Validate(stringType))
+		require.Error(t, workitem.FieldRules{MaxLength: ptr.Int(0)}.Validate(stringType))
+		require.Error(t, workitem.FieldRules{MaxLength: ptr.Int(5)}.Validate(floatType))
+		require.Error(t, workitem.FieldRules{NotBefore: &date}.Validate(stringType))
+		require.Error(t, workitem.FieldRules{NotBefore: ptr.Time(date.Add(time.Hour)), NotAfter: &date}.Validate(instantType))
+		require.Error(t, workitem.FieldRules{RequiredWhen: []workitem.FieldCondition{{Field: workitem.SystemState}}}.Validate(stringType))
+	})
+	t.Run("unknown field in required_when", func(t *testing.T) {
+		t.Parallel()
+		fields := workitem.FieldDefinitions{
+			"resolution": {
+				Label: "Resolution",
+				Type:  stringType,
+				Rules: &workitem.FieldRules{
+					RequiredWhen: []workitem.FieldCondition{{Field: "unknown", Values: []interface{}{"closed"}}},
+				},
+			},
+		}
+		require.Error(t, fields.Validate())
+	})
+}
+
+func TestFieldRules_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	def := workitem.FieldDefinition{}
+	err := json.Unmarshal([]byte(`{
+		"label": "Effort",
+		"type": {"kind": "float"},
+		"rules": {"min": 0, "max": 100, "required_when": [{"field": "system.state", "values": ["closed"]}]}
+	}`), &def)
+	require.NoError(t, err)
+	require.NotNil(t, def.Rules)
+	assert.Equal(t, workitem.FieldRules{
+		Min:          ptr.Float64(0),
+		Max:          ptr.Float64(100),
+		RequiredWhen: []workitem.FieldCondition{{Field: workitem.SystemState, Values: []interface{}{"closed"}}},
+	}, *def.Rules)
+}
+
+func TestWorkItemType_ValidateFieldValues(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	date := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	wit := workitem.WorkItemType{
+		Fields: workitem.FieldDefinitions{
+			workitem.SystemState: {
+				Label: "State",
+				Type: workitem.EnumType{
+					SimpleType: workitem.SimpleType{Kind: workitem.KindEnum},
+					BaseType:   workitem.SimpleType{Kind: workitem.KindString},
+					Values:     []interface{}{"open", "closed"},
+				},
+			},
+			"effort": {
+				Label: "Effort",
+				Type:  workitem.SimpleType{Kind: workitem.KindInteger},
+				Rules: &workitem.FieldRules{Min: ptr.Float64(0), Max: ptr.Float64(10)},
+			},
+			"code": {
+				Label: "Code",
+				Type:  workitem.SimpleType{Kind: workitem.KindString},
+				Rules: &workitem.FieldRules{Pattern: ptr.String("^[A-Z]+$"), MaxLength: ptr.Int(3)},
+			},
+			"notes": {
+				Label: "Notes",
+				Type:  workitem.SimpleType{Kind: workitem.KindMarkup},
+				Rules: &workitem.FieldRules{MaxLength: ptr.Int(5)},
+			},
+			"due": {
+				Label: "Due",
+				Type:  workitem.SimpleType{Kind: workitem.KindInstant},
+				Rules: &workitem.FieldRules{NotBefore: &date},
+			},
+			"resolution": {
+				Label: "Resolution",
+				Type:  workitem.SimpleType{Kind: workitem.KindString},
+				Rules: &workitem.FieldRules{
+					RequiredWhen: []workitem.FieldCondition{{Field: workitem.SystemState, Values: []interface{}{"closed"}}},
+				},
+			},
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, wit.ValidateFieldValues(map[string]interface{}{
+			workitem.SystemState: "open",
+			"effort":             10,
+			"code":               "ABC",
+			"notes":              rendering.NewMarkupContentFromLegacy("short").ToMap(),
+			"due":                date.UnixNano(),
+		}))
+		require.NoError(t, wit.ValidateFieldValues(map[string]interface{}{
+			workitem.SystemState: "closed",
+			"resolution":         "fixed",
+		}))
+	})
+
+	testData := []struct {
+		name   string
+		field  string
+		values map[string]interface{}
+	}{
+		{"below min", "effort", map[string]interface{}{"effort": -1}},
+		{"above max", "effort", map[string]interface{}{"effort": 11}},
+		{"pattern mismatch", "code", map[string]interface{}{"code": "abc"}},
+		{"too long", "code", map[string]interface{}{"code": "ABCD"}},
+		{"markup too long", "notes", map[string]interface{}{"notes": rendering.NewMarkupContentFromLegacy("too long").ToMap()}},
+		{"date too early", "due", map[string]interface{}{"due": date.Add(-time.Second).UnixNano()}},
+		{"required when closed", "resolution", map[string]interface{}{workitem.SystemState: "closed"}},
+		{"blank when closed", "resolution", map[string]interface{}{workitem.SystemState: "closed", "resolution": "  "}},
+	}
+	for _, d := range testData {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			err := wit.ValidateFieldValues(d.values)
+			require.Error(t, err)
+			ok, cause := errors.IsBadParameterError(err)
+			require.True(t, ok)
+			pointer := cause.(errors.BadParameterError).SourcePointer()
+			require.NotNil(t, pointer)
+			assert.Equal(t, "/data/attributes/"+d.field, *pointer)
+		})
+	}
+}
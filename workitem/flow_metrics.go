@@ -0,0 +1,341 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SystemMetaStateNew is the meta-state of all work items on which no work has
+// started yet.
+const SystemMetaStateNew = "mNew"
+
+// MaxFlowMetricsDays is the longest date range (in days) for which flow
+// metrics can be computed.
+const MaxFlowMetricsDays = 366
+
+// FlowMetrics holds the cumulative flow as well as the lead and cycle times
+// of the work items of a space (or an iteration) within a date range. All of
+// it is computed from the revisions of the work items.
+type FlowMetrics struct {
+	Start time.Time
+	End   time.Time
+	// Dates are the days at whose end the cumulative flow was sampled.
+	Dates []time.Time
+	// CumulativeFlow contains one series per board column or, if no board
+	// was given, per state.
+	CumulativeFlow []FlowSeries
+	// LeadTimes measure the days from the creation of a work item until it
+	// was closed.
+	LeadTimes []FlowTimeStats
+	// CycleTimes measure the days from the start of the work on a work item
+	// until it was closed.
+	CycleTimes []FlowTimeStats
+}
+
+// FlowSeries holds the number of work items in a board column (or state) at
+// the end of each day of the flow metrics' date range.
+type FlowSeries struct {
+	// Key is the ID of the board column or the state.
+	Key    string
+	Name   string
+	Counts []int
+}
+
+// FlowTimeStats describes the distribution of the lead or cycle times (in
+// days) of the work items of one type that were closed within the date range.
+type FlowTimeStats struct {
+	WorkItemTypeID uuid.UUID
+	Count          int
+	Mean           float64
+	P50            float64
+	P75            float64
+	P85            float64
+	P95            float64
+}
+
+// isStarted returns true if work on the work item with the given field
+// values (in storage representation) has started.
+func isStarted(fields Fields) bool {
+	if IsClosed(fields) {
+		return true
+	}
+	if metaState, ok := fields[SystemMetaState].(string); ok && metaState != "" {
+		return metaState != SystemMetaStateNew
+	}
+	switch fields[SystemState] {
+	case nil, "", SystemStateNew, SystemStateOpen:
+		return false
+	}
+	return true
+}
+
+// days returns the beginning of every day (in UTC) from the given start up to
+// and including the given end.
+func days(start, end time.Time) []time.Time {
+	var res []time.Time
+	for day := start.UTC().Truncate(24 * time.Hour); !day.After(end); day = day.Add(24 * time.Hour) {
+		res = append(res, day)
+	}
+	return res
+}
+
+// groupRevisions groups the given revisions by work item. The IDs of the work
+// items are returned in the order of their first revision.
+func groupRevisions(revisions []Revision) ([]uuid.UUID, map[uuid.UUID][]Revision) {
+	var ids []uuid.UUID
+	histories := map[uuid.UUID][]Revision{}
+	for _, rev := range revisions {
+		if _, ok := histories[rev.WorkItemID]; !ok {
+			ids = append(ids, rev.WorkItemID)
+		}
+		histories[rev.WorkItemID] = append(histories[rev.WorkItemID], rev)
+	}
+	return ids, histories
+}
+
+// percentile returns the p-th percentile of the given sorted values using the
+// nearest-rank method.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// newFlowTimeStats returns the distributions of the given durations (in days)
+// per work item type ordered by the work item type ID.
+func newFlowTimeStats(durations map[uuid.UUID][]float64) []FlowTimeStats {
+	res := make([]FlowTimeStats, 0, len(durations))
+	for typeID, values := range durations {
+		sort.Float64s(values)
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		res = append(res, FlowTimeStats{
+			WorkItemTypeID: typeID,
+			Count:          len(values),
+			Mean:           sum / float64(len(values)),
+			P50:            percentile(values, 50),
+			P75:            percentile(values, 75),
+			P85:            percentile(values, 85),
+			P95:            percentile(values, 95),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].WorkItemTypeID.String() < res[j].WorkItemTypeID.String()
+	})
+	return res
+}
+
+// ComputeFlowMetrics computes the flow metrics for the given date range from
+// the revisions of the work items. The revisions of each work item must be
+// ordered by their time. If board columns are given, the cumulative flow is
+// computed per column in the order of the columns; otherwise it is computed
+// per state in the order in which the states first appear in the revisions.
+// If an iteration is given, a work item only counts as long as its revision
+// belongs to that iteration.
+func ComputeFlowMetrics(revisions []Revision, iterationID *uuid.UUID, columns []BoardColumn, start, end time.Time) FlowMetrics {
+	start = start.UTC()
+	end = end.UTC()
+	res := FlowMetrics{Start: start, End: end, Dates: days(start, end)}
+	inScope := func(rev *Revision) bool {
+		if rev == nil || rev.Type == RevisionTypeDelete {
+			return false
+		}
+		return iterationID == nil || fmt.Sprint(rev.WorkItemFields[SystemIteration]) == iterationID.String()
+	}
+
+	itemIDs, histories := groupRevisions(revisions)
+
+	// the keys of the series
+	index := map[string]int{}
+	for _, c := range columns {
+		index[c.ID.String()] = len(res.CumulativeFlow)
+		res.CumulativeFlow = append(res.CumulativeFlow, FlowSeries{Key: c.ID.String(), Name: c.Name})
+	}
+	if len(columns) == 0 {
+		for _, rev := range revisions {
+			state, ok := rev.WorkItemFields[SystemState].(string)
+			if !ok || state == "" {
+				continue
+			}
+			if _, ok := index[state]; !ok {
+				index[state] = len(res.CumulativeFlow)
+				res.CumulativeFlow = append(res.CumulativeFlow, FlowSeries{Key: state, Name: state})
+			}
+		}
+	}
+	for i := range res.CumulativeFlow {
+		res.CumulativeFlow[i].Counts = make([]int, len(res.Dates))
+	}
+
+	leadTimes := map[uuid.UUID][]float64{}
+	cycleTimes := map[uuid.UUID][]float64{}
+	for _, id := range itemIDs {
+		history := histories[id]
+		// cumulative flow
+		next := 0
+		var current *Revision
+		for d, day := range res.Dates {
+			dayEnd := day.Add(24 * time.Hour)
+			for next < len(history) && history[next].Time.Before(dayEnd) {
+				current = &history[next]
+				next++
+			}
+			if !inScope(current) {
+				continue
+			}
+			if len(columns) > 0 {
+				if ids, ok := current.WorkItemFields[SystemBoardcolumns].([]interface{}); ok {
+					for _, columnID := range ids {
+						if i, ok := index[fmt.Sprint(columnID)]; ok {
+							res.CumulativeFlow[i].Counts[d]++
+						}
+					}
+				}
+			} else if state, ok := current.WorkItemFields[SystemState].(string); ok {
+				if i, ok := index[state]; ok {
+					res.CumulativeFlow[i].Counts[d]++
+				}
+			}
+		}
+
+		// lead and cycle time
+		last := history[len(history)-1]
+		if !inScope(&last) {
+			continue
+		}
+		var startedAt, closedAt time.Time
+		closed := false
+		for _, rev := range history {
+			if startedAt.IsZero() && isStarted(rev.WorkItemFields) {
+				startedAt = rev.Time
+			}
+			if IsClosed(rev.WorkItemFields) {
+				if !closed {
+					closedAt = rev.Time
+				}
+				closed = true
+			} else {
+				closed = false
+			}
+		}
+		if !closed || closedAt.Before(start) || closedAt.After(end) {
+			continue
+		}
+		leadTimes[last.WorkItemTypeID] = append(leadTimes[last.WorkItemTypeID], closedAt.Sub(history[0].Time).Hours()/24)
+		cycleTimes[last.WorkItemTypeID] = append(cycleTimes[last.WorkItemTypeID], closedAt.Sub(startedAt).Hours()/24)
+	}
+	res.LeadTimes = newFlowTimeStats(leadTimes)
+	res.CycleTimes = newFlowTimeStats(cycleTimes)
+	return res
+}
+
+// checkDateRange returns a BadParameterError if the given date range is empty
+// or too long to compute metrics for.
+func checkDateRange(start, end time.Time) error {
+	if !start.Before(end) {
+		return errors.NewBadParameterError("start", start).Expected("a date before the end date")
+	}
+	if end.Sub(start) > MaxFlowMetricsDays*24*time.Hour {
+		return errors.NewBadParameterError("end", end).Expected(fmt.Sprintf("a date at most %d days after the start date", MaxFlowMetricsDays))
+	}
+	return nil
+}
+
+// closedWithin returns the IDs of the work items that have a closed revision
+// within the given date range.
+func closedWithin(revisions []Revision, start, end time.Time) []uuid.UUID {
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]struct{}{}
+	for _, rev := range revisions {
+		if rev.Time.Before(start) || rev.Time.After(end) || !IsClosed(rev.WorkItemFields) {
+			continue
+		}
+		if _, ok := seen[rev.WorkItemID]; !ok {
+			seen[rev.WorkItemID] = struct{}{}
+			ids = append(ids, rev.WorkItemID)
+		}
+	}
+	return ids
+}
+
+// FlowMetrics computes the flow metrics of the work items in the given space
+// (or only those in the given iteration) for the given date range. If board
+// columns are given, the cumulative flow is computed per column.
+//
+// Only the revisions within the date range and the last revision before it
+// are loaded, except for the work items closed within the date range whose
+// whole history is needed for their lead and cycle times.
+func (r *GormRevisionRepository) FlowMetrics(ctx context.Context, spaceID uuid.UUID, iterationID *uuid.UUID, columns []BoardColumn, start, end time.Time) (*FlowMetrics, error) {
+	if err := checkDateRange(start, end); err != nil {
+		return nil, err
+	}
+	log.Debug(ctx, map[string]interface{}{"space_id": spaceID, "iteration_id": iterationID}, "computing flow metrics")
+	scope := "wi.space_id = ?"
+	scopeArgs := []interface{}{spaceID}
+	if iterationID != nil {
+		// all work items that were part of the iteration at some point
+		scope += fmt.Sprintf(" AND r.work_item_id IN (SELECT work_item_id FROM %s WHERE work_item_fields->>'%s' = ? AND revision_time <= ?)", revisionTableName, SystemIteration)
+		scopeArgs = append(scopeArgs, iterationID.String(), end)
+	}
+	query := fmt.Sprintf(`SELECT * FROM (
+			SELECT DISTINCT ON (r.work_item_id) r.* FROM %[1]s r JOIN %[2]s wi ON wi.id = r.work_item_id
+			WHERE %[3]s AND r.revision_time < ?
+			ORDER BY r.work_item_id, r.revision_time DESC, r.work_item_version DESC
+		) baseline
+		UNION ALL
+		SELECT r.* FROM %[1]s r JOIN %[2]s wi ON wi.id = r.work_item_id
+		WHERE %[3]s AND r.revision_time >= ? AND r.revision_time <= ?
+		ORDER BY work_item_id, revision_time, work_item_version`,
+		revisionTableName, WorkItemStorage{}.TableName(), scope)
+	args := append(append([]interface{}{}, scopeArgs...), start)
+	args = append(append(args, scopeArgs...), start, end)
+	var revisions []Revision
+	if err := r.db.Raw(query, args...).Scan(&revisions).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if ids := closedWithin(revisions, start, end); len(ids) > 0 {
+		var earlier []Revision
+		db := r.db.Where("work_item_id IN (?) AND revision_time < ?", ids, start).
+			Order("work_item_id, revision_time, work_item_version").
+			Find(&earlier)
+		if err := db.Error; err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		revisions = withEarlierRevisions(revisions, earlier, start)
+	}
+	res := ComputeFlowMetrics(revisions, iterationID, columns, start, end)
+	return &res, nil
+}
+
+// withEarlierRevisions replaces the last revision before the start of the
+// work items for which earlier revisions are given with those revisions.
+func withEarlierRevisions(revisions, earlier []Revision, start time.Time) []Revision {
+	_, earlierHistories := groupRevisions(earlier)
+	itemIDs, histories := groupRevisions(revisions)
+	res := make([]Revision, 0, len(revisions)+len(earlier))
+	for _, id := range itemIDs {
+		history := histories[id]
+		if e, ok := earlierHistories[id]; ok {
+			res = append(res, e...)
+			for len(history) > 0 && history[0].Time.Before(start) {
+				history = history[1:]
+			}
+		}
+		res = append(res, history...)
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package workitem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeFlowMetrics(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	// given
+	day := func(d int, h int) time.Time {
+		return time.Date(2018, time.March, d, h, 0, 0, 0, time.UTC)
+	}
+	typeID := uuid.NewV4()
+	todo := workitem.BoardColumn{ID: uuid.NewV4(), Name: "To Do"}
+	doing := workitem.BoardColumn{ID: uuid.NewV4(), Name: "Doing"}
+	done := workitem.BoardColumn{ID: uuid.NewV4(), Name: "Done"}
+	rev := func(id uuid.UUID, t time.Time, revType workitem.RevisionType, state string, column workitem.BoardColumn) workitem.Revision {
+		return workitem.Revision{
+			WorkItemID:     id,
+			WorkItemTypeID: typeID,
+			Time:           t,
+			Type:           revType,
+			WorkItemFields: workitem.Fields{
+				workitem.SystemState:        state,
+				workitem.SystemBoardcolumns: []interface{}{column.ID.String()},
+			},
+		}
+	}
+	a, b, c := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	revisions := []workitem.Revision{
+		// a is created on the 1st, started on the 2nd and closed on the 4th
+		rev(a, day(1, 12), workitem.RevisionTypeCreate, workitem.SystemStateNew, todo),
+		rev(a, day(2, 12), workitem.RevisionTypeUpdate, workitem.SystemStateInProgress, doing),
+		rev(a, day(4, 12), workitem.RevisionTypeUpdate, workitem.SystemStateClosed, done),
+		// b is created on the 1st and closed on the 2nd without being started
+		rev(b, day(1, 12), workitem.RevisionTypeCreate, workitem.SystemStateNew, todo),
+		rev(b, day(3, 12), workitem.RevisionTypeUpdate, workitem.SystemStateClosed, done),
+		// c is created on the 2nd, closed on the 3rd, reopened on the 4th and
+		// deleted on the 5th
+		rev(c, day(2, 12), workitem.RevisionTypeCreate, workitem.SystemStateNew, todo),
+		rev(c, day(3, 6), workitem.RevisionTypeUpdate, workitem.SystemStateClosed, done),
+		rev(c, day(4, 6), workitem.RevisionTypeUpdate, workitem.SystemStateInProgress, doing),
+		{WorkItemID: c, WorkItemTypeID: typeID, Time: day(5, 6), Type: workitem.RevisionTypeDelete, WorkItemFields: workitem.Fields{}},
+	}
+
+	t.Run("per board column", func(t *testing.T) {
+		t.Parallel()
+		// when
+		m := workitem.ComputeFlowMetrics(revisions, nil, []workitem.BoardColumn{todo, doing, done}, day(1, 0), day(5, 23))
+		// then
+		require.Len(t, m.Dates, 5)
+		assert.Equal(t, day(1, 0), m.Dates[0])
+		require.Len(t, m.CumulativeFlow, 3)
+		assert.Equal(t, todo.ID.String(), m.CumulativeFlow[0].Key)
+		assert.Equal(t, "To Do", m.CumulativeFlow[0].Name)
+		assert.Equal(t, []int{2, 2, 0, 0, 0}, m.CumulativeFlow[0].Counts)
+		assert.Equal(t, []int{0, 1, 1, 1, 0}, m.CumulativeFlow[1].Counts)
+		assert.Equal(t, []int{0, 0, 2, 2, 2}, m.CumulativeFlow[2].Counts)
+
+		require.Len(t, m.LeadTimes, 1)
+		lead := m.LeadTimes[0]
+		assert.Equal(t, typeID, lead.WorkItemTypeID)
+		assert.Equal(t, 2, lead.Count)
+		assert.Equal(t, 2.5, lead.Mean)
+		assert.Equal(t, 2.0, lead.P50)
+		assert.Equal(t, 3.0, lead.P95)
+
+		require.Len(t, m.CycleTimes, 1)
+		cycle := m.CycleTimes[0]
+		assert.Equal(t, 2, cycle.Count)
+		assert.Equal(t, 0.0, cycle.P50)
+		assert.Equal(t, 2.0, cycle.P95)
+	})
+	t.Run("per state", func(t *testing.T) {
+		t.Parallel()
+		// when
+		m := workitem.ComputeFlowMetrics(revisions, nil, nil, day(1, 0), day(5, 23))
+		// then
+		require.Len(t, m.CumulativeFlow, 3)
+		assert.Equal(t, workitem.SystemStateNew, m.CumulativeFlow[0].Key)
+		assert.Equal(t, workitem.SystemStateInProgress, m.CumulativeFlow[1].Key)
+		assert.Equal(t, workitem.SystemStateClosed, m.CumulativeFlow[2].Key)
+		assert.Equal(t, []int{0, 0, 2, 2, 2}, m.CumulativeFlow[2].Counts)
+	})
+	t.Run("items closed outside of the range are ignored", func(t *testing.T) {
+		t.Parallel()
+		// when
+		m := workitem.ComputeFlowMetrics(revisions, nil, nil, day(4, 0), day(5, 23))
+		// then
+		require.Len(t, m.LeadTimes, 1)
+		assert.Equal(t, 1, m.LeadTimes[0].Count)
+		assert.Equal(t, 3.0, m.LeadTimes[0].Mean)
+	})
+	t.Run("per iteration", func(t *testing.T) {
+		t.Parallel()
+		// given d is moved into the iteration on the 2nd and out of it on the
+		// 4th
+		iterationID := uuid.NewV4()
+		d := uuid.NewV4()
+		inIteration := func(r workitem.Revision, id uuid.UUID) workitem.Revision {
+			fields := workitem.Fields{workitem.SystemIteration: id.String()}
+			for k, v := range r.WorkItemFields {
+				fields[k] = v
+			}
+			r.WorkItemFields = fields
+			return r
+		}
+		revisions := []workitem.Revision{
+			rev(d, day(1, 12), workitem.RevisionTypeCreate, workitem.SystemStateNew, todo),
+			inIteration(rev(d, day(2, 12), workitem.RevisionTypeUpdate, workitem.SystemStateNew, todo), iterationID),
+			inIteration(rev(d, day(4, 12), workitem.RevisionTypeUpdate, workitem.SystemStateNew, todo), uuid.NewV4()),
+		}
+		// when
+		m := workitem.ComputeFlowMetrics(revisions, &iterationID, nil, day(1, 0), day(5, 23))
+		// then
+		require.Len(t, m.CumulativeFlow, 1)
+		assert.Equal(t, []int{0, 1, 1, 0, 0}, m.CumulativeFlow[0].Counts)
+	})
+}
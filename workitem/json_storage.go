@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"reflect"
+	"strings"
 
 	"github.com/fabric8-services/fabric8-wit/convert"
 	errs "github.com/pkg/errors"
@@ -78,6 +79,16 @@ func (j *FieldDefinitions) Validate() error {
 		if err := field.Validate(); err != nil {
 			return errs.Wrapf(err, "failed to validate field %s", name)
 		}
+		if field.Type.GetKind() == KindComputed && strings.HasPrefix(name, SystemFieldPrefix) {
+			return errs.Errorf(`computed field %s must not use the "%s" prefix of system fields`, name, SystemFieldPrefix)
+		}
+		if field.Rules != nil {
+			for _, c := range field.Rules.RequiredWhen {
+				if _, ok := (*j)[c.Field]; !ok {
+					return errs.Errorf("field %s is required when field %s has certain values but there is no such field", name, c.Field)
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -0,0 +1,47 @@
+package link
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+)
+
+// AttributesPointer is the JSON pointer to the attribute values in a JSON-API
+// work item link document.
+const AttributesPointer = "/data/attributes/fields/"
+
+// ConvertAttributesToModel checks the given attribute values against the
+// fields defined by the link type and returns them in model representation.
+// Unknown attributes and values that violate the field definition or its
+// rules result in a BadParameterError.
+func (t WorkItemLinkType) ConvertAttributesToModel(values map[string]interface{}) (workitem.Fields, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := t.Fields[name]; !ok {
+			return nil, errors.NewBadParameterError(name, values[name]).Expected(fmt.Sprintf("one of the attributes of link type %q", t.Name)).Pointer(workitem.FieldPointer(AttributesPointer, name))
+		}
+	}
+	res := workitem.Fields{}
+	for name, def := range t.Fields {
+		v, err := def.ConvertToModel(name, values[name])
+		if err != nil {
+			return nil, errors.NewBadParameterError(name, values[name]).Expected(err.Error()).Pointer(workitem.FieldPointer(AttributesPointer, name))
+		}
+		if v != nil {
+			res[name] = v
+		}
+	}
+	if err := t.Fields.ValidateValues(res, AttributesPointer); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res, nil
+}
@@ -0,0 +1,76 @@
+package link_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	errs "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkItemLinkType_ConvertAttributesToModel(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	lt := link.WorkItemLinkType{
+		Name: "duplicates",
+		Fields: workitem.FieldDefinitions{
+			"reason": {
+				Label: "Reason",
+				Type:  workitem.SimpleType{Kind: workitem.KindString},
+				Rules: &workitem.FieldRules{MaxLength: ptr.Int(10)},
+			},
+			"confidence": {
+				Label:    "Confidence",
+				Required: true,
+				Type:     workitem.SimpleType{Kind: workitem.KindFloat},
+				Rules:    &workitem.FieldRules{Min: ptr.Float64(0), Max: ptr.Float64(1)},
+			},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		res, err := lt.ConvertAttributesToModel(map[string]interface{}{"reason": "same bug", "confidence": 0.8})
+		require.NoError(t, err)
+		require.Equal(t, workitem.Fields{"reason": "same bug", "confidence": 0.8}, res)
+	})
+	t.Run("no fields defined", func(t *testing.T) {
+		t.Parallel()
+		res, err := link.WorkItemLinkType{}.ConvertAttributesToModel(nil)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+	t.Run("unknown attribute", func(t *testing.T) {
+		t.Parallel()
+		_, err := lt.ConvertAttributesToModel(map[string]interface{}{"confidence": 0.8, "foo": "bar"})
+		require.Error(t, err)
+		badParamErr, ok := errs.Cause(err).(errors.BadParameterError)
+		require.True(t, ok, "expected a BadParameterError but got %T", errs.Cause(err))
+		require.Equal(t, ptr.String(link.AttributesPointer+"foo"), badParamErr.SourcePointer())
+	})
+	t.Run("missing required attribute", func(t *testing.T) {
+		t.Parallel()
+		_, err := lt.ConvertAttributesToModel(map[string]interface{}{"reason": "same bug"})
+		require.Error(t, err)
+		_, ok := errs.Cause(err).(errors.BadParameterError)
+		require.True(t, ok, "expected a BadParameterError but got %T", errs.Cause(err))
+	})
+	t.Run("wrong type", func(t *testing.T) {
+		t.Parallel()
+		_, err := lt.ConvertAttributesToModel(map[string]interface{}{"confidence": "high"})
+		require.Error(t, err)
+	})
+	t.Run("rule violation", func(t *testing.T) {
+		t.Parallel()
+		_, err := lt.ConvertAttributesToModel(map[string]interface{}{"confidence": 1.5})
+		require.Error(t, err)
+		badParamErr, ok := errs.Cause(err).(errors.BadParameterError)
+		require.True(t, ok, "expected a BadParameterError but got %T", errs.Cause(err))
+		require.Equal(t, ptr.String(link.AttributesPointer+"confidence"), badParamErr.SourcePointer())
+	})
+}
@@ -0,0 +1,152 @@
+package link
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Constraints restrict which work items can be connected with links of a
+// certain type. A work item satisfies the source (or target) restriction if
+// its type is listed in the types or is a member of one of the listed type
+// groups. Empty lists impose no restriction.
+//
+// In a space template the constraints are declared on the link type:
+//
+//	constraints:
+//	  source_types: [ 71171e90-6d35-498f-a6a7-2083b5267c18 ]
+//	  target_type_groups: [ 1fc4c4f5-3f30-4ae4-8b6d-5c1e7f0a6b0e ]
+//	  max_children: 10
+type Constraints struct {
+	SourceTypes      []uuid.UUID `json:"source_types,omitempty"`
+	SourceTypeGroups []uuid.UUID `json:"source_type_groups,omitempty"`
+	TargetTypes      []uuid.UUID `json:"target_types,omitempty"`
+	TargetTypeGroups []uuid.UUID `json:"target_type_groups,omitempty"`
+	// MaxChildren is the maximum number of links of this type that can
+	// start at the same source work item. No limit applies if it is nil.
+	MaxChildren *int `json:"max_children,omitempty"`
+}
+
+// Ensure Constraints implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*Constraints)(nil)
+var _ driver.Valuer = (*Constraints)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (c Constraints) Value() (driver.Value, error) {
+	if c.IsEmpty() {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (c *Constraints) Scan(src interface{}) error {
+	if src == nil {
+		*c = Constraints{}
+		return nil
+	}
+	b, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("scan source was not a byte array but %T", src)
+	}
+	return json.Unmarshal(b, c)
+}
+
+// IsEmpty returns true if the constraints impose no restriction at all.
+func (c Constraints) IsEmpty() bool {
+	return len(c.SourceTypes) == 0 && len(c.SourceTypeGroups) == 0 &&
+		len(c.TargetTypes) == 0 && len(c.TargetTypeGroups) == 0 &&
+		c.MaxChildren == nil
+}
+
+// Equal returns true if both constraints impose the same restrictions.
+func (c Constraints) Equal(other Constraints) bool {
+	if c.IsEmpty() && other.IsEmpty() {
+		return true
+	}
+	return reflect.DeepEqual(c, other)
+}
+
+// Validate checks that the constraints make sense.
+func (c Constraints) Validate() error {
+	if c.MaxChildren != nil && *c.MaxChildren < 1 {
+		return errs.Errorf("max_children must be greater than zero and not %d", *c.MaxChildren)
+	}
+	for _, ids := range [][]uuid.UUID{c.SourceTypes, c.SourceTypeGroups, c.TargetTypes, c.TargetTypeGroups} {
+		for _, id := range ids {
+			if id == uuid.Nil {
+				return errs.New("constraints must not refer to a nil ID")
+			}
+		}
+	}
+	return nil
+}
+
+// checkConstraints returns a BadParameterError if a link of the given type
+// from the source to the target work item violates the link type's
+// constraints.
+func (r *GormWorkItemLinkRepository) checkConstraints(ctx context.Context, linkType WorkItemLinkType, source, target workitem.WorkItem) error {
+	c := linkType.Constraints
+	if c.IsEmpty() {
+		return nil
+	}
+	ok, err := r.typeAllowed(ctx, source.Type, c.SourceTypes, c.SourceTypeGroups)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	if !ok {
+		return errors.NewBadParameterError("data.relationships.source", source.ID).Expected(fmt.Sprintf("a work item of one of the source types allowed by link type %q", linkType.Name))
+	}
+	ok, err = r.typeAllowed(ctx, target.Type, c.TargetTypes, c.TargetTypeGroups)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	if !ok {
+		return errors.NewBadParameterError("data.relationships.target", target.ID).Expected(fmt.Sprintf("a work item of one of the target types allowed by link type %q", linkType.Name))
+	}
+	if c.MaxChildren != nil {
+		var count int
+		db := r.db.Model(&WorkItemLink{}).Where("source_id = ? AND link_type_id = ?", source.ID, linkType.ID).Count(&count)
+		if db.Error != nil {
+			return errors.NewInternalError(ctx, db.Error)
+		}
+		if count >= *c.MaxChildren {
+			return errors.NewBadParameterError("data.relationships.source", source.ID).Expected(fmt.Sprintf("a work item with less than %d %q links", *c.MaxChildren, linkType.ForwardName))
+		}
+	}
+	return nil
+}
+
+// typeAllowed returns true if no types and groups are given or if the given
+// work item type is one of the types or a member of one of the groups.
+func (r *GormWorkItemLinkRepository) typeAllowed(ctx context.Context, typeID uuid.UUID, typeIDs, groupIDs []uuid.UUID) (bool, error) {
+	if len(typeIDs) == 0 && len(groupIDs) == 0 {
+		return true, nil
+	}
+	for _, id := range typeIDs {
+		if id == typeID {
+			return true, nil
+		}
+	}
+	groupRepo := workitem.NewWorkItemTypeGroupRepository(r.db)
+	for _, groupID := range groupIDs {
+		group, err := groupRepo.Load(ctx, groupID)
+		if err != nil {
+			return false, errs.Wrapf(err, "failed to load work item type group %s", groupID)
+		}
+		for _, id := range group.TypeList {
+			if id == typeID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
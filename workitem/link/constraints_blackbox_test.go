@@ -0,0 +1,52 @@
+package link_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraints(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	c := link.Constraints{
+		SourceTypes:      []uuid.UUID{uuid.NewV4()},
+		TargetTypeGroups: []uuid.UUID{uuid.NewV4()},
+		MaxChildren:      ptr.Int(3),
+	}
+
+	t.Run("validate", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, c.Validate())
+		require.NoError(t, link.Constraints{}.Validate())
+		require.Error(t, link.Constraints{MaxChildren: ptr.Int(0)}.Validate())
+		require.Error(t, link.Constraints{TargetTypes: []uuid.UUID{uuid.Nil}}.Validate())
+	})
+	t.Run("equal", func(t *testing.T) {
+		t.Parallel()
+		require.True(t, c.Equal(c))
+		require.True(t, link.Constraints{}.Equal(link.Constraints{SourceTypes: []uuid.UUID{}}))
+		b := c
+		b.MaxChildren = ptr.Int(4)
+		require.False(t, c.Equal(b))
+	})
+	t.Run("value and scan", func(t *testing.T) {
+		t.Parallel()
+		v, err := c.Value()
+		require.NoError(t, err)
+		var scanned link.Constraints
+		require.NoError(t, scanned.Scan(v))
+		require.True(t, c.Equal(scanned))
+
+		v, err = link.Constraints{}.Value()
+		require.NoError(t, err)
+		require.Nil(t, v)
+		require.NoError(t, scanned.Scan(nil))
+		require.True(t, scanned.IsEmpty())
+	})
+}
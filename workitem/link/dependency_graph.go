@@ -0,0 +1,248 @@
+package link
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// DependencyNode is a work item in a dependency graph.
+type DependencyNode struct {
+	ID     uuid.UUID
+	Number int
+	Title  string
+	State  string
+	// Weight is the value of the weight field of the work item (or 1 if no
+	// weight field was requested). Closed work items have a weight of 0.
+	Weight float64
+	// Blocked is true if at least one of the (transitive) predecessors of the
+	// work item is not closed.
+	Blocked bool
+	// BlockedBy holds the IDs of all (transitive) predecessors that are not
+	// closed.
+	BlockedBy []uuid.UUID
+}
+
+// DependencyEdge is a link in a dependency graph. The source work item must be
+// done before the target work item.
+type DependencyEdge struct {
+	LinkID     uuid.UUID
+	LinkTypeID uuid.UUID
+	SourceID   uuid.UUID
+	TargetID   uuid.UUID
+}
+
+// DependencyGraph is the graph spanned by the links of all link types with a
+// "dependency" topology.
+type DependencyGraph struct {
+	Nodes []DependencyNode
+	Edges []DependencyEdge
+	// CriticalPath is the path through the graph with the highest sum of node
+	// weights, starting with the first predecessor.
+	CriticalPath       []uuid.UUID
+	CriticalPathWeight float64
+}
+
+// DependencyGraph returns the dependency graph of the work items in the given
+// space, optionally restricted to the work items in the given iteration. The
+// graph also contains all transitive predecessors of those work items, even if
+// they are located in another iteration. The critical path is weighted by the
+// numeric field with the given name; if the name is empty, every work item
+// weighs 1.
+func (r *GormWorkItemLinkRepository) DependencyGraph(ctx context.Context, spaceID uuid.UUID, iterationID *uuid.UUID, weightField string) (*DependencyGraph, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "dependencygraph"}, time.Now())
+	var items []workitem.WorkItemStorage
+	db := r.db.Where("space_id = ?", spaceID)
+	if iterationID != nil {
+		db = db.Where(fmt.Sprintf("fields->>'%s' = ?", workitem.SystemIteration), iterationID.String())
+	}
+	if err := db.Find(&items).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to list work items of space %s", spaceID))
+	}
+	itemByID := make(map[uuid.UUID]workitem.WorkItemStorage, len(items))
+	frontier := make([]uuid.UUID, 0, len(items))
+	for _, wi := range items {
+		itemByID[wi.ID] = wi
+		frontier = append(frontier, wi.ID)
+	}
+
+	// walk up the dependency links until all predecessors are known
+	var links []WorkItemLink
+	var missing []uuid.UUID
+	seenLinks := map[uuid.UUID]struct{}{}
+	for len(frontier) > 0 {
+		var found []WorkItemLink
+		db := r.db.Where(fmt.Sprintf("target_id IN (?) AND link_type_id IN (SELECT id FROM %s WHERE topology = ? AND deleted_at IS NULL)", WorkItemLinkType{}.TableName()), frontier, TopologyDependency).Find(&found)
+		if db.Error != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to list dependency links"))
+		}
+		frontier = nil
+		for _, l := range found {
+			if _, ok := seenLinks[l.ID]; ok {
+				continue
+			}
+			seenLinks[l.ID] = struct{}{}
+			links = append(links, l)
+			if _, ok := itemByID[l.SourceID]; ok {
+				continue
+			}
+			itemByID[l.SourceID] = workitem.WorkItemStorage{}
+			missing = append(missing, l.SourceID)
+			frontier = append(frontier, l.SourceID)
+		}
+	}
+	if len(missing) > 0 {
+		var predecessors []workitem.WorkItemStorage
+		if err := r.db.Where("id IN (?)", missing).Find(&predecessors).Error; err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to load predecessor work items"))
+		}
+		for _, wi := range predecessors {
+			itemByID[wi.ID] = wi
+		}
+	}
+
+	// deleted predecessors are dropped together with their links
+	nodes := make([]DependencyNode, 0, len(itemByID))
+	for id, wi := range itemByID {
+		if wi.ID != id {
+			delete(itemByID, id)
+			continue
+		}
+		nodes = append(nodes, newDependencyNode(wi, weightField))
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Number < nodes[j].Number })
+	numberOf := make(map[uuid.UUID]int, len(nodes))
+	for _, n := range nodes {
+		numberOf[n.ID] = n.Number
+	}
+	edges := make([]DependencyEdge, 0, len(links))
+	for _, l := range links {
+		if _, ok := itemByID[l.SourceID]; !ok {
+			continue
+		}
+		edges = append(edges, DependencyEdge{LinkID: l.ID, LinkTypeID: l.LinkTypeID, SourceID: l.SourceID, TargetID: l.TargetID})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if numberOf[edges[i].SourceID] != numberOf[edges[j].SourceID] {
+			return numberOf[edges[i].SourceID] < numberOf[edges[j].SourceID]
+		}
+		return numberOf[edges[i].TargetID] < numberOf[edges[j].TargetID]
+	})
+
+	g := &DependencyGraph{Nodes: nodes, Edges: edges}
+	g.markBlocked(itemByID)
+	g.CriticalPath, g.CriticalPathWeight = g.criticalPath()
+	return g, nil
+}
+
+// newDependencyNode converts the given work item into a graph node.
+func newDependencyNode(wi workitem.WorkItemStorage, weightField string) DependencyNode {
+	n := DependencyNode{ID: wi.ID, Number: wi.Number, Weight: 1}
+	n.Title, _ = wi.Fields[workitem.SystemTitle].(string)
+	n.State, _ = wi.Fields[workitem.SystemState].(string)
+	if weightField != "" {
+		switch v := wi.Fields[weightField].(type) {
+		case float64:
+			n.Weight = v
+		case int:
+			n.Weight = float64(v)
+		default:
+			n.Weight = 0
+		}
+	}
+	if workitem.IsClosed(wi.Fields) {
+		n.Weight = 0
+	}
+	return n
+}
+
+// predecessors returns the direct predecessors of every node.
+func (g DependencyGraph) predecessors() map[uuid.UUID][]uuid.UUID {
+	res := map[uuid.UUID][]uuid.UUID{}
+	for _, e := range g.Edges {
+		res[e.TargetID] = append(res[e.TargetID], e.SourceID)
+	}
+	return res
+}
+
+// markBlocked sets the Blocked and BlockedBy fields of all nodes.
+func (g *DependencyGraph) markBlocked(items map[uuid.UUID]workitem.WorkItemStorage) {
+	preds := g.predecessors()
+	for i, n := range g.Nodes {
+		visited := map[uuid.UUID]struct{}{n.ID: {}}
+		queue := append([]uuid.UUID{}, preds[n.ID]...)
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if _, ok := visited[id]; ok {
+				continue
+			}
+			visited[id] = struct{}{}
+			if !workitem.IsClosed(items[id].Fields) {
+				g.Nodes[i].BlockedBy = append(g.Nodes[i].BlockedBy, id)
+			}
+			queue = append(queue, preds[id]...)
+		}
+		g.Nodes[i].Blocked = len(g.Nodes[i].BlockedBy) > 0
+	}
+}
+
+// criticalPath returns the path with the highest sum of node weights. The
+// nodes are visited in topological order, nodes that are part of a cycle are
+// ignored.
+func (g DependencyGraph) criticalPath() ([]uuid.UUID, float64) {
+	weight := make(map[uuid.UUID]float64, len(g.Nodes))
+	inDegree := make(map[uuid.UUID]int, len(g.Nodes))
+	successors := map[uuid.UUID][]uuid.UUID{}
+	for _, n := range g.Nodes {
+		weight[n.ID] = n.Weight
+	}
+	for _, e := range g.Edges {
+		inDegree[e.TargetID]++
+		successors[e.SourceID] = append(successors[e.SourceID], e.TargetID)
+	}
+	var queue []uuid.UUID
+	for _, n := range g.Nodes {
+		if inDegree[n.ID] == 0 {
+			queue = append(queue, n.ID)
+		}
+	}
+	dist := make(map[uuid.UUID]float64, len(g.Nodes))
+	prev := map[uuid.UUID]uuid.UUID{}
+	var end uuid.UUID
+	var best float64
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		dist[id] += weight[id]
+		if dist[id] > best {
+			best = dist[id]
+			end = id
+		}
+		for _, s := range successors[id] {
+			if dist[id] > dist[s] {
+				dist[s] = dist[id]
+				prev[s] = id
+			}
+			inDegree[s]--
+			if inDegree[s] == 0 {
+				queue = append(queue, s)
+			}
+		}
+	}
+	if best == 0 {
+		return []uuid.UUID{}, 0
+	}
+	path := []uuid.UUID{end}
+	for id, ok := prev[end]; ok; id, ok = prev[id] {
+		path = append([]uuid.UUID{id}, path...)
+	}
+	return path, best
+}
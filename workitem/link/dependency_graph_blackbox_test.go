@@ -0,0 +1,123 @@
+package link_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type dependencyGraphBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	linkRepo *link.GormWorkItemLinkRepository
+}
+
+func TestRunDependencyGraphBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &dependencyGraphBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *dependencyGraphBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.linkRepo = link.NewWorkItemLinkRepository(s.DB)
+}
+
+// createFixture creates the dependencies A->C, B->C, C->D and E->B where E is
+// closed. D is in the second iteration, all others are in the first one.
+func (s *dependencyGraphBlackBoxTest) createFixture(t *testing.T) *tf.TestFixture {
+	efforts := map[string]float64{"A": 3, "B": 2, "C": 4, "D": 1, "E": 10}
+	return tf.NewTestFixture(t, s.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.Iterations(2),
+		tf.WorkItemTypes(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemTypes[idx].Fields["effort"] = workitem.FieldDefinition{
+				Label: "Effort",
+				Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+			}
+			return nil
+		}),
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyDependency)),
+		tf.WorkItems(5, tf.SetWorkItemTitles("A", "B", "C", "D", "E"), func(fxt *tf.TestFixture, idx int) error {
+			wi := fxt.WorkItems[idx]
+			title := wi.Fields[workitem.SystemTitle].(string)
+			wi.Fields["effort"] = efforts[title]
+			wi.Fields[workitem.SystemIteration] = fxt.Iterations[0].ID.String()
+			switch title {
+			case "D":
+				wi.Fields[workitem.SystemIteration] = fxt.Iterations[1].ID.String()
+			case "E":
+				wi.Fields[workitem.SystemState] = workitem.SystemStateClosed
+			}
+			return nil
+		}),
+		tf.WorkItemLinksCustom(4, tf.BuildLinks(tf.L("A", "C"), tf.L("B", "C"), tf.L("C", "D"), tf.L("E", "B"))),
+	)
+}
+
+func (s *dependencyGraphBlackBoxTest) TestDependencyGraph() {
+	s.T().Run("space", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		id := func(title string) uuid.UUID { return fxt.WorkItemByTitle(title).ID }
+		// when
+		g, err := s.linkRepo.DependencyGraph(s.Ctx, fxt.Spaces[0].ID, nil, "effort")
+		// then
+		require.NoError(t, err)
+		require.Len(t, g.Nodes, 5)
+		require.Len(t, g.Edges, 4)
+		blockedBy := map[uuid.UUID][]uuid.UUID{}
+		for _, n := range g.Nodes {
+			assert.Equal(t, len(n.BlockedBy) > 0, n.Blocked)
+			blockedBy[n.ID] = n.BlockedBy
+		}
+		assert.Empty(t, blockedBy[id("A")])
+		assert.Empty(t, blockedBy[id("B")], "B is only blocked by the closed E")
+		assert.Empty(t, blockedBy[id("E")])
+		assert.Len(t, blockedBy[id("C")], 2)
+		assert.Contains(t, blockedBy[id("C")], id("A"))
+		assert.Contains(t, blockedBy[id("C")], id("B"))
+		assert.Len(t, blockedBy[id("D")], 3)
+		assert.Equal(t, []uuid.UUID{id("A"), id("C"), id("D")}, g.CriticalPath)
+		assert.Equal(t, 8.0, g.CriticalPathWeight)
+	})
+	s.T().Run("iteration includes predecessors", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		// when
+		g, err := s.linkRepo.DependencyGraph(s.Ctx, fxt.Spaces[0].ID, &fxt.Iterations[1].ID, "")
+		// then
+		require.NoError(t, err)
+		require.Len(t, g.Nodes, 5)
+		require.Len(t, g.Edges, 4)
+		require.Len(t, g.CriticalPath, 3)
+		assert.Equal(t, fxt.WorkItemByTitle("D").ID, g.CriticalPath[2])
+		assert.Equal(t, 3.0, g.CriticalPathWeight)
+	})
+	s.T().Run("iteration without dependencies", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.CreateWorkItemEnvironment(),
+			tf.Iterations(1),
+			tf.WorkItems(1, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.Iterations[0].ID.String()
+				return nil
+			}),
+		)
+		// when
+		g, err := s.linkRepo.DependencyGraph(s.Ctx, fxt.Spaces[0].ID, &fxt.Iterations[0].ID, "")
+		// then
+		require.NoError(t, err)
+		require.Len(t, g.Nodes, 1)
+		assert.False(t, g.Nodes[0].Blocked)
+		require.Empty(t, g.Edges)
+		assert.Equal(t, []uuid.UUID{fxt.WorkItems[0].ID}, g.CriticalPath)
+		assert.Equal(t, 1.0, g.CriticalPathWeight)
+	})
+}
@@ -0,0 +1,150 @@
+package link
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// GraphNode is a work item in a link graph.
+type GraphNode struct {
+	ID     uuid.UUID `json:"id"`
+	Number int       `json:"number"`
+	Title  string    `json:"title"`
+	State  string    `json:"state"`
+}
+
+// GraphEdge is a work item link in a link graph.
+type GraphEdge struct {
+	ID           uuid.UUID `json:"id"`
+	SourceID     uuid.UUID `json:"source"`
+	TargetID     uuid.UUID `json:"target"`
+	LinkTypeID   uuid.UUID `json:"link_type_id"`
+	LinkTypeName string    `json:"link_type"`
+	// ForwardName describes the link when reading it from source to target
+	// (e.g. "parent of").
+	ForwardName string `json:"forward_name"`
+}
+
+// Graph is the part of the graph of work items and their links that was
+// reached by walking the links from a set of start work items.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// WalkGraph follows the links of the given work items in both directions up
+// to the given depth and returns all work items and links it came across. If
+// link type IDs are given, only links of those types are followed. A depth of
+// 0 returns just the start work items.
+func (r *GormWorkItemLinkRepository) WalkGraph(ctx context.Context, startIDs []uuid.UUID, depth int, linkTypeIDs ...uuid.UUID) (*Graph, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "walkgraph"}, time.Now())
+	if depth < 0 {
+		return nil, errors.NewBadParameterError("depth", depth).Expected(">= 0")
+	}
+	visited := make(map[uuid.UUID]struct{}, len(startIDs))
+	nodeIDs := make([]uuid.UUID, 0, len(startIDs))
+	for _, id := range startIDs {
+		if _, ok := visited[id]; ok {
+			continue
+		}
+		visited[id] = struct{}{}
+		nodeIDs = append(nodeIDs, id)
+	}
+	var links []WorkItemLink
+	seenLinks := map[uuid.UUID]struct{}{}
+	frontier := nodeIDs
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		var found []WorkItemLink
+		db := r.db.Where("(source_id IN (?) OR target_id IN (?))", frontier, frontier)
+		if len(linkTypeIDs) > 0 {
+			db = db.Where("link_type_id IN (?)", linkTypeIDs)
+		}
+		if err := db.Find(&found).Error; err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to list work item links"))
+		}
+		frontier = nil
+		for _, l := range found {
+			if _, ok := seenLinks[l.ID]; ok {
+				continue
+			}
+			seenLinks[l.ID] = struct{}{}
+			links = append(links, l)
+			for _, id := range []uuid.UUID{l.SourceID, l.TargetID} {
+				if _, ok := visited[id]; ok {
+					continue
+				}
+				visited[id] = struct{}{}
+				nodeIDs = append(nodeIDs, id)
+				frontier = append(frontier, id)
+			}
+		}
+	}
+
+	g := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	if len(nodeIDs) == 0 {
+		return g, nil
+	}
+	var items []workitem.WorkItemStorage
+	if err := r.db.Where("id IN (?)", nodeIDs).Find(&items).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to load work items of the link graph"))
+	}
+	numberOf := make(map[uuid.UUID]int, len(items))
+	for _, wi := range items {
+		n := GraphNode{ID: wi.ID, Number: wi.Number}
+		n.Title, _ = wi.Fields[workitem.SystemTitle].(string)
+		n.State, _ = wi.Fields[workitem.SystemState].(string)
+		g.Nodes = append(g.Nodes, n)
+		numberOf[wi.ID] = wi.Number
+	}
+	// numbers are only unique within a space
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		if g.Nodes[i].Number != g.Nodes[j].Number {
+			return g.Nodes[i].Number < g.Nodes[j].Number
+		}
+		return g.Nodes[i].ID.String() < g.Nodes[j].ID.String()
+	})
+
+	linkTypes := map[uuid.UUID]*WorkItemLinkType{}
+	for _, l := range links {
+		_, sourceFound := numberOf[l.SourceID]
+		_, targetFound := numberOf[l.TargetID]
+		if !sourceFound || !targetFound {
+			continue
+		}
+		lt, ok := linkTypes[l.LinkTypeID]
+		if !ok {
+			var err error
+			lt, err = r.workItemLinkTypeRepo.Load(ctx, l.LinkTypeID)
+			if err != nil {
+				return nil, errs.Wrapf(err, "failed to load link type %s", l.LinkTypeID)
+			}
+			linkTypes[l.LinkTypeID] = lt
+		}
+		g.Edges = append(g.Edges, GraphEdge{
+			ID:           l.ID,
+			SourceID:     l.SourceID,
+			TargetID:     l.TargetID,
+			LinkTypeID:   l.LinkTypeID,
+			LinkTypeName: lt.Name,
+			ForwardName:  lt.ForwardName,
+		})
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if numberOf[a.SourceID] != numberOf[b.SourceID] {
+			return numberOf[a.SourceID] < numberOf[b.SourceID]
+		}
+		if numberOf[a.TargetID] != numberOf[b.TargetID] {
+			return numberOf[a.TargetID] < numberOf[b.TargetID]
+		}
+		return a.LinkTypeName < b.LinkTypeName
+	})
+	return g, nil
+}
@@ -0,0 +1,77 @@
+package link_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type graphBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	linkRepo *link.GormWorkItemLinkRepository
+}
+
+func TestRunGraphBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &graphBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *graphBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.linkRepo = link.NewWorkItemLinkRepository(s.DB)
+}
+
+func (s *graphBlackBoxTest) TestWalkGraph() {
+	// A->B->C is a tree, D depends on B
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItemLinkTypes(2, tf.SetTopologies(link.TopologyTree, link.TopologyDependency)),
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D")),
+		tf.WorkItemLinksCustom(3, tf.BuildLinks(tf.L("A", "B"), tf.L("B", "C"), tf.L("D", "B")), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemLinks[idx].LinkTypeID = fxt.WorkItemLinkTypes[0].ID
+			if idx == 2 {
+				fxt.WorkItemLinks[idx].LinkTypeID = fxt.WorkItemLinkTypes[1].ID
+			}
+			return nil
+		}),
+	)
+	titles := func(g *link.Graph) []string {
+		res := make([]string, len(g.Nodes))
+		for i, n := range g.Nodes {
+			res[i] = n.Title
+		}
+		return res
+	}
+	b := fxt.WorkItemByTitle("B").ID
+
+	s.T().Run("depth 0", func(t *testing.T) {
+		g, err := s.linkRepo.WalkGraph(s.Ctx, []uuid.UUID{b}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"B"}, titles(g))
+		assert.Empty(t, g.Edges)
+	})
+	s.T().Run("depth 1 follows both directions", func(t *testing.T) {
+		g, err := s.linkRepo.WalkGraph(s.Ctx, []uuid.UUID{b}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B", "C", "D"}, titles(g))
+		require.Len(t, g.Edges, 3)
+		assert.Equal(t, fxt.WorkItemLinkTypes[0].Name, g.Edges[0].LinkTypeName)
+		assert.Equal(t, fxt.WorkItemLinkTypes[0].ForwardName, g.Edges[0].ForwardName)
+	})
+	s.T().Run("restricted to link type", func(t *testing.T) {
+		g, err := s.linkRepo.WalkGraph(s.Ctx, []uuid.UUID{fxt.WorkItemByTitle("A").ID}, 5, fxt.WorkItemLinkTypes[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B", "C"}, titles(g))
+		require.Len(t, g.Edges, 2)
+	})
+	s.T().Run("negative depth", func(t *testing.T) {
+		_, err := s.linkRepo.WalkGraph(s.Ctx, []uuid.UUID{b}, -1)
+		require.Error(t, err)
+	})
+}
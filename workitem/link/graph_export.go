@@ -0,0 +1,166 @@
+package link
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	errs "github.com/pkg/errors"
+)
+
+// GraphFormat is the format in which a link graph is exported.
+type GraphFormat string
+
+// The supported link graph export formats
+const (
+	GraphFormatJSON    GraphFormat = "json"
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatGraphML GraphFormat = "graphml"
+)
+
+// ContentType returns the MIME type of documents in the format.
+func (f GraphFormat) ContentType() string {
+	switch f {
+	case GraphFormatDOT:
+		return "text/vnd.graphviz"
+	case GraphFormatGraphML:
+		return "application/graphml+xml"
+	default:
+		return "application/json"
+	}
+}
+
+// Export writes the graph in the given format.
+func (g Graph) Export(w io.Writer, format GraphFormat) error {
+	switch format {
+	case GraphFormatJSON:
+		return errs.WithStack(json.NewEncoder(w).Encode(g))
+	case GraphFormatDOT:
+		return g.writeDOT(w)
+	case GraphFormatGraphML:
+		return g.writeGraphML(w)
+	default:
+		return errors.NewBadParameterError("format", format).Expected(fmt.Sprintf("one of %q, %q or %q", GraphFormatJSON, GraphFormatDOT, GraphFormatGraphML))
+	}
+}
+
+// label returns the label under which a node is displayed.
+func (n GraphNode) label() string {
+	return fmt.Sprintf("#%d %s\n(%s)", n.Number, n.Title, n.State)
+}
+
+// quoteDOT returns the given string as a quoted DOT identifier.
+func quoteDOT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// writeDOT writes the graph as a Graphviz digraph.
+func (g Graph) writeDOT(w io.Writer) error {
+	var b bytes.Buffer
+	b.WriteString("digraph workitems {\n")
+	b.WriteString("\tnode [shape=box];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "\t%s [label=%s];\n", quoteDOT(n.ID.String()), quoteDOT(n.label()))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", quoteDOT(e.SourceID.String()), quoteDOT(e.TargetID.String()), quoteDOT(e.ForwardName))
+	}
+	b.WriteString("}\n")
+	_, err := b.WriteTo(w)
+	return errs.WithStack(err)
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// writeGraphML writes the graph as a GraphML document (see
+// http://graphml.graphdrawing.org/).
+func (g Graph) writeGraphML(w io.Writer) error {
+	doc := graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "number", For: "node", AttrName: "number", AttrType: "int"},
+			{ID: "title", For: "node", AttrName: "title", AttrType: "string"},
+			{ID: "state", For: "node", AttrName: "state", AttrType: "string"},
+			{ID: "link_type", For: "edge", AttrName: "link_type", AttrType: "string"},
+			{ID: "forward_name", For: "edge", AttrName: "forward_name", AttrType: "string"},
+		},
+		Graph: graphMLGraph{
+			ID:          "workitems",
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, len(g.Nodes)),
+			Edges:       make([]graphMLEdge, len(g.Edges)),
+		},
+	}
+	for i, n := range g.Nodes {
+		doc.Graph.Nodes[i] = graphMLNode{
+			ID: n.ID.String(),
+			Data: []graphMLData{
+				{Key: "number", Value: strconv.Itoa(n.Number)},
+				{Key: "title", Value: n.Title},
+				{Key: "state", Value: n.State},
+			},
+		}
+	}
+	for i, e := range g.Edges {
+		doc.Graph.Edges[i] = graphMLEdge{
+			ID:     e.ID.String(),
+			Source: e.SourceID.String(),
+			Target: e.TargetID.String(),
+			Data: []graphMLData{
+				{Key: "link_type", Value: e.LinkTypeName},
+				{Key: "forward_name", Value: e.ForwardName},
+			},
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return errs.WithStack(err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return errs.Wrap(err, "failed to encode graph as GraphML")
+	}
+	_, err := io.WriteString(w, "\n")
+	return errs.WithStack(err)
+}
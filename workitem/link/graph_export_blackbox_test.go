@@ -0,0 +1,93 @@
+package link_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestGraph() link.Graph {
+	a := uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000a")
+	b := uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000b")
+	return link.Graph{
+		Nodes: []link.GraphNode{
+			{ID: a, Number: 1, Title: `Epic "one"`, State: "new"},
+			{ID: b, Number: 2, Title: `Story \ two`, State: "closed"},
+		},
+		Edges: []link.GraphEdge{
+			{
+				ID:           uuid.FromStringOrNil("00000000-0000-0000-0000-0000000000ab"),
+				SourceID:     a,
+				TargetID:     b,
+				LinkTypeID:   link.SystemWorkItemLinkTypeParentChildID,
+				LinkTypeName: "Parenting",
+				ForwardName:  "parent of",
+			},
+		},
+	}
+}
+
+func TestGraph_Export(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	g := exportTestGraph()
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.NoError(t, g.Export(&buf, link.GraphFormatJSON))
+		var actual link.Graph
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+		assert.Equal(t, g, actual)
+	})
+	t.Run("dot", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.NoError(t, g.Export(&buf, link.GraphFormatDOT))
+		expected := "digraph workitems {\n" +
+			"\tnode [shape=box];\n" +
+			"\t\"00000000-0000-0000-0000-00000000000a\" [label=\"#1 Epic \\\"one\\\"\\n(new)\"];\n" +
+			"\t\"00000000-0000-0000-0000-00000000000b\" [label=\"#2 Story \\\\ two\\n(closed)\"];\n" +
+			"\t\"00000000-0000-0000-0000-00000000000a\" -> \"00000000-0000-0000-0000-00000000000b\" [label=\"parent of\"];\n" +
+			"}\n"
+		assert.Equal(t, expected, buf.String())
+	})
+	t.Run("graphml", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.NoError(t, g.Export(&buf, link.GraphFormatGraphML))
+		var doc struct {
+			Nodes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"graph>node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+				Data   []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"graph>edge"`
+		}
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+		require.Len(t, doc.Nodes, 2)
+		require.Len(t, doc.Edges, 1)
+		assert.Equal(t, g.Nodes[0].ID.String(), doc.Edges[0].Source)
+		assert.Equal(t, g.Nodes[1].ID.String(), doc.Edges[0].Target)
+		require.NotEmpty(t, doc.Edges[0].Data)
+		assert.Equal(t, "link_type", doc.Edges[0].Data[0].Key)
+		assert.Equal(t, "Parenting", doc.Edges[0].Data[0].Value)
+	})
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.Error(t, g.Export(&buf, link.GraphFormat("svg")))
+	})
+}
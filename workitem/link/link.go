@@ -7,6 +7,7 @@ import (
 	convert "github.com/fabric8-services/fabric8-wit/convert"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -20,6 +21,9 @@ type WorkItemLink struct {
 	SourceID   uuid.UUID `sql:"type:uuid"`
 	TargetID   uuid.UUID `sql:"type:uuid"`
 	LinkTypeID uuid.UUID `sql:"type:uuid"`
+	// Attributes holds the values of the attributes defined by the link
+	// type in model representation.
+	Attributes workitem.Fields `sql:"type:jsonb"`
 }
 
 // Ensure Fields implements the Equaler interface
@@ -50,6 +54,11 @@ func (l WorkItemLink) Equal(u convert.Equaler) bool {
 	if l.LinkTypeID != other.LinkTypeID {
 		return false
 	}
+	if len(l.Attributes) != 0 || len(other.Attributes) != 0 {
+		if !l.Attributes.Equal(other.Attributes) {
+			return false
+		}
+	}
 	return true
 }
 
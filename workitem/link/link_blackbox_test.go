@@ -9,6 +9,7 @@ import (
 	"github.com/fabric8-services/fabric8-common/id"
 	"github.com/fabric8-services/fabric8-wit/convert"
 	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/require"
@@ -93,6 +94,14 @@ func TestWorkItemLink_EqualAndEqualValue(t *testing.T) {
 		require.False(t, a.Equal(b))
 		require.False(t, a.EqualValue(b))
 	})
+
+	t.Run("attributes", func(t *testing.T) {
+		t.Parallel()
+		b := a
+		b.Attributes = workitem.Fields{"reason": "waiting for review"}
+		require.False(t, a.Equal(b))
+		require.False(t, a.EqualValue(b))
+	})
 }
 
 func TestWorkItemLinkCheckValidForCreation(t *testing.T) {
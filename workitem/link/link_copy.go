@@ -0,0 +1,164 @@
+package link
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CloneTree clones the work item with the given ID together with all of its
+// descendants (following parent-child links) into the target space specified
+// in the options. Links between the cloned work items are recreated between
+// the clones as long as their link type is available in the target space.
+// The clones are returned with the clone of the root work item first.
+func (r *GormWorkItemLinkRepository) CloneTree(ctx context.Context, rootID uuid.UUID, opts workitem.CopyOptions, creatorID uuid.UUID) ([]workitem.WorkItem, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "clonetree"}, time.Now())
+	ids, err := r.listTree(ctx, rootID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to collect the tree of work item %s", rootID)
+	}
+	clones := make([]workitem.WorkItem, 0, len(ids))
+	cloneIDs := make(map[uuid.UUID]uuid.UUID, len(ids))
+	for _, id := range ids {
+		clone, _, err := r.workItemRepo.Clone(ctx, id, opts, creatorID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to clone work item %s", id)
+		}
+		clones = append(clones, *clone)
+		cloneIDs[id] = clone.ID
+	}
+	allowed, err := r.allowedLinkTypes(ctx, opts.TargetSpaceID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := r.listLinksWithin(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range links {
+		if _, ok := allowed[l.LinkTypeID]; !ok {
+			log.Info(ctx, map[string]interface{}{
+				"wil_id":          l.ID,
+				"wilt_id":         l.LinkTypeID,
+				"target_space_id": opts.TargetSpaceID,
+			}, "not cloning link because its type is not available in the target space")
+			continue
+		}
+		if _, err := r.Create(ctx, cloneIDs[l.SourceID], cloneIDs[l.TargetID], l.LinkTypeID, creatorID); err != nil {
+			return nil, errs.Wrapf(err, "failed to clone link %s", l.ID)
+		}
+	}
+	return clones, nil
+}
+
+// MoveTree moves the work item with the given ID together with all of its
+// descendants (following parent-child links) into the target space specified
+// in the options. Links that would cross the space boundary after the move,
+// and links whose type isn't available in the target space, are deleted. The
+// moved work items are returned with the root work item first.
+func (r *GormWorkItemLinkRepository) MoveTree(ctx context.Context, rootID uuid.UUID, opts workitem.CopyOptions, modifierID uuid.UUID) ([]workitem.WorkItem, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "movetree"}, time.Now())
+	root, err := r.workItemRepo.LoadFromDB(ctx, rootID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to load work item %s", rootID)
+	}
+	if err := r.acquireLock(root.SpaceID); err != nil {
+		return nil, errs.Wrap(err, "failed to acquire lock during tree move")
+	}
+	ids, err := r.listTree(ctx, rootID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to collect the tree of work item %s", rootID)
+	}
+	inTree := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		inTree[id] = struct{}{}
+	}
+	allowed, err := r.allowedLinkTypes(ctx, opts.TargetSpaceID)
+	if err != nil {
+		return nil, err
+	}
+	var links []WorkItemLink
+	db := r.db.Where("source_id IN (?) OR target_id IN (?)", ids, ids).Find(&links)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to list links of work item tree %s", rootID))
+	}
+	for _, l := range links {
+		_, sourceInTree := inTree[l.SourceID]
+		_, targetInTree := inTree[l.TargetID]
+		_, typeAllowed := allowed[l.LinkTypeID]
+		if sourceInTree && targetInTree && typeAllowed {
+			continue
+		}
+		if err := r.deleteLink(ctx, l, modifierID); err != nil {
+			return nil, errs.Wrapf(err, "failed to delete link %s before moving work item tree %s", l.ID, rootID)
+		}
+	}
+	moved := make([]workitem.WorkItem, 0, len(ids))
+	for _, id := range ids {
+		wi, _, err := r.workItemRepo.Move(ctx, id, opts, modifierID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to move work item %s", id)
+		}
+		moved = append(moved, *wi)
+	}
+	return moved, nil
+}
+
+// listTree returns the ID of the given work item followed by the IDs of all of
+// its descendants in breadth-first order.
+func (r *GormWorkItemLinkRepository) listTree(ctx context.Context, rootID uuid.UUID) ([]uuid.UUID, error) {
+	result := []uuid.UUID{rootID}
+	visited := map[uuid.UUID]struct{}{rootID: {}}
+	parents := []uuid.UUID{rootID}
+	for len(parents) > 0 {
+		childLinks, err := r.ListChildLinks(ctx, SystemWorkItemLinkTypeParentChildID, parents...)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		parents = nil
+		for _, l := range childLinks {
+			if _, ok := visited[l.TargetID]; ok {
+				continue
+			}
+			visited[l.TargetID] = struct{}{}
+			result = append(result, l.TargetID)
+			parents = append(parents, l.TargetID)
+		}
+	}
+	return result, nil
+}
+
+// listLinksWithin returns all links whose source and target are both among
+// the given work items.
+func (r *GormWorkItemLinkRepository) listLinksWithin(ctx context.Context, ids []uuid.UUID) ([]WorkItemLink, error) {
+	var links []WorkItemLink
+	db := r.db.Where("source_id IN (?) AND target_id IN (?)", ids, ids).Find(&links)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to list links between work items"))
+	}
+	return links, nil
+}
+
+// allowedLinkTypes returns the set of link type IDs usable in the given space.
+func (r *GormWorkItemLinkRepository) allowedLinkTypes(ctx context.Context, spaceID uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	s, err := space.NewRepository(r.db).Load(ctx, spaceID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to load space %s", spaceID)
+	}
+	types, err := r.workItemLinkTypeRepo.List(ctx, s.SpaceTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list link types of space template %s", s.SpaceTemplateID)
+	}
+	result := make(map[uuid.UUID]struct{}, len(types))
+	for _, t := range types {
+		result[t.ID] = struct{}{}
+	}
+	return result, nil
+}
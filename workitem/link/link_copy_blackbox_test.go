@@ -0,0 +1,112 @@
+package link_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type linkCopyBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	linkRepo *link.GormWorkItemLinkRepository
+}
+
+func TestRunLinkCopyBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &linkCopyBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *linkCopyBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.linkRepo = link.NewWorkItemLinkRepository(s.DB)
+}
+
+// createFixture creates two spaces and the tree A->B->C in the first space.
+// Work item D in the first space depends on B.
+func (s *linkCopyBlackBoxTest) createFixture(t *testing.T) *tf.TestFixture {
+	return tf.NewTestFixture(t, s.DB,
+		tf.Spaces(2),
+		tf.Iterations(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Iterations[idx].SpaceID = fxt.Spaces[idx].ID
+			return nil
+		}),
+		tf.Areas(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Areas[idx].SpaceID = fxt.Spaces[idx].ID
+			return nil
+		}),
+		tf.WorkItemLinkTypes(2, tf.SetTopologies(link.TopologyTree, link.TopologyDependency)),
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D")),
+		tf.WorkItemLinksCustom(3, func(fxt *tf.TestFixture, idx int) error {
+			l := fxt.WorkItemLinks[idx]
+			switch idx {
+			case 0:
+				l.SourceID, l.TargetID = fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID
+				l.LinkTypeID = link.SystemWorkItemLinkTypeParentChildID
+			case 1:
+				l.SourceID, l.TargetID = fxt.WorkItemByTitle("B").ID, fxt.WorkItemByTitle("C").ID
+				l.LinkTypeID = link.SystemWorkItemLinkTypeParentChildID
+			case 2:
+				l.SourceID, l.TargetID = fxt.WorkItemByTitle("D").ID, fxt.WorkItemByTitle("B").ID
+				l.LinkTypeID = fxt.WorkItemLinkTypes[1].ID
+			}
+			return nil
+		}),
+	)
+}
+
+func (s *linkCopyBlackBoxTest) TestCloneTree() {
+	s.T().Run("ok - clones descendants and their links", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		opts := workitem.CopyOptions{TargetSpaceID: fxt.Spaces[1].ID}
+		// when
+		clones, err := s.linkRepo.CloneTree(s.Ctx, fxt.WorkItemByTitle("A").ID, opts, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, clones, 3)
+		for i, title := range []string{"A", "B", "C"} {
+			assert.Equal(t, fxt.WorkItemByTitle(title).Fields[workitem.SystemTitle], clones[i].Fields[workitem.SystemTitle])
+			assert.Equal(t, fxt.Spaces[1].ID, clones[i].SpaceID)
+		}
+		childLinks, err := s.linkRepo.ListChildLinks(s.Ctx, link.SystemWorkItemLinkTypeParentChildID, clones[0].ID, clones[1].ID)
+		require.NoError(t, err)
+		require.Len(t, childLinks, 2)
+		// the dependency from D stays with the original tree
+		links, err := s.linkRepo.ListByWorkItem(s.Ctx, clones[1].ID)
+		require.NoError(t, err)
+		assert.Len(t, links, 2)
+		links, err = s.linkRepo.ListByWorkItem(s.Ctx, fxt.WorkItemByTitle("B").ID)
+		require.NoError(t, err)
+		assert.Len(t, links, 3)
+	})
+}
+
+func (s *linkCopyBlackBoxTest) TestMoveTree() {
+	s.T().Run("ok - moves descendants and drops cross-space links", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		opts := workitem.CopyOptions{TargetSpaceID: fxt.Spaces[1].ID}
+		// when
+		moved, err := s.linkRepo.MoveTree(s.Ctx, fxt.WorkItemByTitle("A").ID, opts, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, moved, 3)
+		for i, title := range []string{"A", "B", "C"} {
+			assert.Equal(t, fxt.WorkItemByTitle(title).ID, moved[i].ID)
+			assert.Equal(t, fxt.Spaces[1].ID, moved[i].SpaceID)
+		}
+		links, err := s.linkRepo.ListByWorkItem(s.Ctx, fxt.WorkItemByTitle("B").ID)
+		require.NoError(t, err)
+		assert.Len(t, links, 2)
+		links, err = s.linkRepo.ListByWorkItem(s.Ctx, fxt.WorkItemByTitle("D").ID)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+}
@@ -35,7 +35,9 @@ const (
 type WorkItemLinkRepository interface {
 	repository.Exister
 	Create(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID, creatorID uuid.UUID) (*WorkItemLink, error)
+	CreateWithAttributes(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID, attributes map[string]interface{}, creatorID uuid.UUID) (*WorkItemLink, error)
 	Load(ctx context.Context, ID uuid.UUID) (*WorkItemLink, error)
+	UpdateAttributes(ctx context.Context, ID uuid.UUID, version int, attributes map[string]interface{}, modifierID uuid.UUID) (*WorkItemLink, error)
 	List(ctx context.Context) ([]WorkItemLink, error)
 	ListByWorkItem(ctx context.Context, wiID uuid.UUID) ([]WorkItemLink, error)
 	DeleteRelatedLinks(ctx context.Context, wiID uuid.UUID, suppressorID uuid.UUID) error
@@ -45,6 +47,10 @@ type WorkItemLinkRepository interface {
 	WorkItemHasChildren(ctx context.Context, parentID uuid.UUID) (bool, error)
 	// GetAncestors returns all ancestors for the given work items.
 	GetAncestors(ctx context.Context, linkTypeID uuid.UUID, upToLevel int, workItemIDs ...uuid.UUID) (ancestors AncestorList, err error)
+	CloneTree(ctx context.Context, rootID uuid.UUID, opts workitem.CopyOptions, creatorID uuid.UUID) ([]workitem.WorkItem, error)
+	MoveTree(ctx context.Context, rootID uuid.UUID, opts workitem.CopyOptions, modifierID uuid.UUID) ([]workitem.WorkItem, error)
+	DependencyGraph(ctx context.Context, spaceID uuid.UUID, iterationID *uuid.UUID, weightField string) (*DependencyGraph, error)
+	WalkGraph(ctx context.Context, startIDs []uuid.UUID, depth int, linkTypeIDs ...uuid.UUID) (*Graph, error)
 }
 
 // NewWorkItemLinkRepository creates a work item link repository based on gorm
@@ -208,6 +214,13 @@ func (r *GormWorkItemLinkRepository) acquireLock(spaceID uuid.UUID) error {
 // Create creates a new work item link in the repository.
 // Returns BadParameterError, ConversionError or InternalError
 func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID, creatorID uuid.UUID) (*WorkItemLink, error) {
+	return r.CreateWithAttributes(ctx, sourceID, targetID, linkTypeID, nil, creatorID)
+}
+
+// CreateWithAttributes creates a new work item link just like Create but also
+// stores the given attribute values on the link. The values are checked
+// against the fields defined by the link type.
+func (r *GormWorkItemLinkRepository) CreateWithAttributes(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID, attributes map[string]interface{}, creatorID uuid.UUID) (*WorkItemLink, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "create"}, time.Now())
 	link := &WorkItemLink{
 		SourceID:   sourceID,
@@ -250,6 +263,26 @@ func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targe
 		return nil, errs.Wrapf(err, "failed to create work item due to topology violation")
 	}
 
+	// Make sure the types of the source and target work items are allowed by
+	// the link type.
+	var source, target workitem.WorkItem
+	for _, item := range items {
+		if item.ID == sourceID {
+			source = *item
+		}
+		if item.ID == targetID {
+			target = *item
+		}
+	}
+	if err := r.checkConstraints(ctx, *linkType, source, target); err != nil {
+		return nil, errs.Wrapf(err, "failed to create work item link due to constraint violation")
+	}
+
+	link.Attributes, err = linkType.ConvertAttributesToModel(attributes)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to convert attributes of work item link")
+	}
+
 	db := r.db.Create(link)
 	if db.Error != nil {
 		if gormsupport.IsUniqueViolation(db.Error, "work_item_links_unique_idx") {
@@ -271,6 +304,10 @@ func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targe
 	if err := r.revisionRepo.Create(ctx, creatorID, RevisionTypeCreate, *link); err != nil {
 		return nil, errs.Wrapf(err, "error while creating work item")
 	}
+	// the computed fields of the source may aggregate over its children
+	if err := workitem.InvalidateComputedFields(ctx, r.db, sourceID); err != nil {
+		return nil, errs.Wrapf(err, "failed to invalidate computed fields")
+	}
 	return link, nil
 }
 
@@ -353,6 +390,45 @@ func (r *GormWorkItemLinkRepository) Delete(ctx context.Context, linkID uuid.UUI
 	return nil
 }
 
+// UpdateAttributes replaces the attribute values of the work item link with
+// the given ID and version. The values are checked against the attributes
+// defined by the link type.
+// returns NotFoundError, BadParameterError, VersionConflictError or InternalError
+func (r *GormWorkItemLinkRepository) UpdateAttributes(ctx context.Context, linkID uuid.UUID, version int, attributes map[string]interface{}, modifierID uuid.UUID) (*WorkItemLink, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "update"}, time.Now())
+	lnk, err := r.Load(ctx, linkID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	if lnk.Version != version {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	linkType, err := r.workItemLinkTypeRepo.Load(ctx, lnk.LinkTypeID)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to load link type")
+	}
+	lnk.Attributes, err = linkType.ConvertAttributesToModel(attributes)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to convert attributes of work item link")
+	}
+	lnk.Version = version + 1
+	db := r.db.Model(lnk).Where("version = ?", version).Updates(map[string]interface{}{
+		"attributes": lnk.Attributes,
+		"version":    lnk.Version,
+	})
+	if err := db.Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	if err := r.revisionRepo.Create(ctx, modifierID, RevisionTypeUpdate, *lnk); err != nil {
+		return nil, errs.Wrapf(err, "error while updating work item link")
+	}
+	log.Debug(ctx, map[string]interface{}{"wil_id": linkID}, "updated the attributes of the work item link")
+	return lnk, nil
+}
+
 // DeleteRelatedLinks deletes all links in which the source or target equals the
 // given work item ID.
 func (r *GormWorkItemLinkRepository) DeleteRelatedLinks(ctx context.Context, wiID uuid.UUID, suppressorID uuid.UUID) error {
@@ -403,6 +479,10 @@ func (r *GormWorkItemLinkRepository) deleteLink(ctx context.Context, lnk WorkIte
 	if err := r.revisionRepo.Create(ctx, suppressorID, RevisionTypeDelete, lnk); err != nil {
 		return errs.Wrapf(err, "error while deleting work item")
 	}
+	// the computed fields of the source may aggregate over its children
+	if err := workitem.InvalidateComputedFields(ctx, r.db, lnk.SourceID); err != nil {
+		return errs.Wrapf(err, "failed to invalidate computed fields")
+	}
 	return nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	_ "github.com/lib/pq" // need to import postgres driver
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -318,9 +319,100 @@ func (s *linkRepoBlackBoxTest) TestCreate() {
 			// then
 			require.NoError(t, err)
 		})
+		t.Run("with attributes", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+				tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+					fxt.WorkItemLinkTypes[idx].Fields = workitem.FieldDefinitions{
+						"reason": {Label: "Reason", Type: workitem.SimpleType{Kind: workitem.KindString}},
+					}
+					return nil
+				}),
+			)
+			// when
+			l, err := s.workitemLinkRepo.CreateWithAttributes(s.Ctx, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID, fxt.WorkItemLinkTypes[0].ID, map[string]interface{}{"reason": "waiting for review"}, fxt.Identities[0].ID)
+			// then
+			require.NoError(t, err)
+			loaded, err := s.workitemLinkRepo.Load(s.Ctx, l.ID)
+			require.NoError(t, err)
+			require.Equal(t, workitem.Fields{"reason": "waiting for review"}, loaded.Attributes)
+		})
 	})
 
 	s.T().Run("fail", func(t *testing.T) {
+		t.Run("unknown attribute", func(t *testing.T) {
+			// given a link type without any attributes
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.WorkItems(2, tf.SetWorkItemTitles("A", "B")),
+				tf.WorkItemLinkTypes(1),
+			)
+			// when
+			_, err := s.workitemLinkRepo.CreateWithAttributes(s.Ctx, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID, fxt.WorkItemLinkTypes[0].ID, map[string]interface{}{"reason": "foo"}, fxt.Identities[0].ID)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
+		t.Run("constraints", func(t *testing.T) {
+			// given an "epic" and a "story" work item and a link type that
+			// only allows epics as source
+			fxt := tf.NewTestFixture(t, s.DB,
+				tf.WorkItemTypes(2, tf.SetWorkItemTypeNames("epic", "story")),
+				tf.WorkItems(3, tf.SetWorkItemTitles("epic", "story1", "story2"), func(fxt *tf.TestFixture, idx int) error {
+					fxt.WorkItems[idx].Type = fxt.WorkItemTypeByName("story").ID
+					if idx == 0 {
+						fxt.WorkItems[idx].Type = fxt.WorkItemTypeByName("epic").ID
+					}
+					return nil
+				}),
+				tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyNetwork), func(fxt *tf.TestFixture, idx int) error {
+					fxt.WorkItemLinkTypes[idx].Constraints = link.Constraints{
+						SourceTypes: []uuid.UUID{fxt.WorkItemTypeByName("epic").ID},
+						TargetTypes: []uuid.UUID{fxt.WorkItemTypeByName("story").ID},
+						MaxChildren: ptr.Int(1),
+					}
+					return nil
+				}),
+			)
+			epicID := fxt.WorkItemByTitle("epic").ID
+			story1ID := fxt.WorkItemByTitle("story1").ID
+			story2ID := fxt.WorkItemByTitle("story2").ID
+			linkTypeID := fxt.WorkItemLinkTypes[0].ID
+			t.Run("source type not allowed", func(t *testing.T) {
+				_, err := s.workitemLinkRepo.Create(s.Ctx, story1ID, story2ID, linkTypeID, fxt.Identities[0].ID)
+				require.Error(t, err)
+				require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+			})
+			t.Run("target type not allowed", func(t *testing.T) {
+				_, err := s.workitemLinkRepo.Create(s.Ctx, story1ID, epicID, linkTypeID, fxt.Identities[0].ID)
+				require.Error(t, err)
+				require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+			})
+			t.Run("max children exceeded", func(t *testing.T) {
+				_, err := s.workitemLinkRepo.Create(s.Ctx, epicID, story1ID, linkTypeID, fxt.Identities[0].ID)
+				require.NoError(t, err)
+				_, err = s.workitemLinkRepo.Create(s.Ctx, epicID, story2ID, linkTypeID, fxt.Identities[0].ID)
+				require.Error(t, err)
+				require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+			})
+			t.Run("target type group", func(t *testing.T) {
+				// given a group that contains the epic type as allowed targets
+				groupFxt := tf.NewTestFixture(t, s.DB, tf.WorkItemTypeGroups(1, func(gfxt *tf.TestFixture, idx int) error {
+					gfxt.WorkItemTypeGroups[idx].TypeList = []uuid.UUID{fxt.WorkItemTypeByName("epic").ID}
+					return nil
+				}))
+				lt := *fxt.WorkItemLinkTypes[0]
+				lt.Constraints = link.Constraints{TargetTypeGroups: []uuid.UUID{groupFxt.WorkItemTypeGroups[0].ID}}
+				require.NoError(t, s.DB.Save(&lt).Error)
+				// when
+				_, err := s.workitemLinkRepo.Create(s.Ctx, story2ID, story1ID, linkTypeID, fxt.Identities[0].ID)
+				// then
+				require.Error(t, err)
+				require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+				_, err = s.workitemLinkRepo.Create(s.Ctx, story2ID, epicID, linkTypeID, fxt.Identities[0].ID)
+				require.NoError(t, err)
+			})
+		})
 		t.Run("single-parent violation in tree topology", func(t *testing.T) {
 			// given 2 work items linked with one tree-topology link type
 			fxt := tf.NewTestFixture(t, s.DB,
@@ -594,6 +686,60 @@ func (s *linkRepoBlackBoxTest) TestCreate() {
 	})
 }
 
+func (s *linkRepoBlackBoxTest) TestUpdateAttributes() {
+	reasonLinkType := tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+		fxt.WorkItemLinkTypes[idx].Fields = workitem.FieldDefinitions{
+			"reason": {Label: "Reason", Type: workitem.SimpleType{Kind: workitem.KindString}},
+		}
+		return nil
+	})
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1), reasonLinkType, tf.Identities(2))
+		l := fxt.WorkItemLinks[0]
+		// when
+		updated, err := s.workitemLinkRepo.UpdateAttributes(s.Ctx, l.ID, l.Version, map[string]interface{}{"reason": "waiting for review"}, fxt.Identities[1].ID)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, l.Version+1, updated.Version)
+		loaded, err := s.workitemLinkRepo.Load(s.Ctx, l.ID)
+		require.NoError(t, err)
+		require.Equal(t, workitem.Fields{"reason": "waiting for review"}, loaded.Attributes)
+		require.Equal(t, l.Version+1, loaded.Version)
+	})
+
+	s.T().Run("fail", func(t *testing.T) {
+		t.Run("unknown attribute", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1), reasonLinkType)
+			l := fxt.WorkItemLinks[0]
+			// when
+			_, err := s.workitemLinkRepo.UpdateAttributes(s.Ctx, l.ID, l.Version, map[string]interface{}{"foo": "bar"}, fxt.Identities[0].ID)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		})
+		t.Run("version conflict", func(t *testing.T) {
+			// given
+			fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1), reasonLinkType)
+			l := fxt.WorkItemLinks[0]
+			// when
+			_, err := s.workitemLinkRepo.UpdateAttributes(s.Ctx, l.ID, l.Version+1, map[string]interface{}{"reason": "foo"}, fxt.Identities[0].ID)
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.VersionConflictError{}, errs.Cause(err))
+		})
+		t.Run("link doesn't exist", func(t *testing.T) {
+			// when
+			_, err := s.workitemLinkRepo.UpdateAttributes(s.Ctx, uuid.NewV4(), 0, nil, uuid.NewV4())
+			// then
+			require.Error(t, err)
+			require.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+		})
+	})
+}
+
 func (s *linkRepoBlackBoxTest) TestExistsLink() {
 	s.T().Run("link exists", func(t *testing.T) {
 		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1))
@@ -3,6 +3,7 @@ package link
 import (
 	"time"
 
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -16,8 +17,7 @@ const (
 	// RevisionTypeDelete a work item link deletion
 	RevisionTypeDelete // 2
 	_                  // ignore 3rd value
-	// RevisionTypeUpdate a work item link update
-	// TODO(kwk): can we remove this "update" revsion type? We no longer support updating a link.
+	// RevisionTypeUpdate a work item link update (e.g. of its attributes)
 	RevisionTypeUpdate // 4
 
 )
@@ -41,6 +41,8 @@ type Revision struct {
 	WorkItemLinkTargetID uuid.UUID `sql:"type:uuid"`
 	// the ID of the type of the work item link that changed
 	WorkItemLinkTypeID uuid.UUID `sql:"type:uuid"`
+	// the attribute values of the work item link that changed
+	WorkItemLinkAttributes workitem.Fields `sql:"type:jsonb"`
 }
 
 const (
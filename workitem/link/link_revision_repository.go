@@ -39,14 +39,15 @@ func (r *GormWorkItemLinkRevisionRepository) Create(ctx context.Context, modifie
 	}, "Storing a revision after operation on work item link.")
 	tx := r.db
 	revision := &Revision{
-		ModifierIdentity:     modifierID,
-		Time:                 time.Now(),
-		Type:                 revisionType,
-		WorkItemLinkID:       l.ID,
-		WorkItemLinkVersion:  l.Version,
-		WorkItemLinkSourceID: l.SourceID,
-		WorkItemLinkTargetID: l.TargetID,
-		WorkItemLinkTypeID:   l.LinkTypeID,
+		ModifierIdentity:       modifierID,
+		Time:                   time.Now(),
+		Type:                   revisionType,
+		WorkItemLinkID:         l.ID,
+		WorkItemLinkVersion:    l.Version,
+		WorkItemLinkSourceID:   l.SourceID,
+		WorkItemLinkTargetID:   l.TargetID,
+		WorkItemLinkTypeID:     l.LinkTypeID,
+		WorkItemLinkAttributes: l.Attributes,
 	}
 	if err := tx.Create(&revision).Error; err != nil {
 		return errors.NewInternalError(ctx, errs.Wrap(err, "failed to create new work item link revision"))
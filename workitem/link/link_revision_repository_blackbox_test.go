@@ -6,6 +6,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,4 +54,29 @@ func (s *revisionRepositoryBlackBoxTest) TestList() {
 		assert.Equal(t, fxt.WorkItemLinks[0].TargetID, revision2.WorkItemLinkTargetID)
 		assert.Equal(t, fxt.WorkItemLinkTypes[0].ID, revision2.WorkItemLinkTypeID)
 	})
+
+	s.T().Run("ok - when updating the attributes of a work item link", func(t *testing.T) {
+		// given a work item link whose type defines a "reason" attribute
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItemLinks(1), tf.Identities(2),
+			tf.WorkItemLinkTypes(1, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemLinkTypes[idx].Fields = workitem.FieldDefinitions{
+					"reason": {Label: "Reason", Type: workitem.SimpleType{Kind: workitem.KindString}},
+				}
+				return nil
+			}),
+		)
+		linkRepository := link.NewWorkItemLinkRepository(s.DB)
+		_, err := linkRepository.UpdateAttributes(s.Ctx, fxt.WorkItemLinks[0].ID, fxt.WorkItemLinks[0].Version, map[string]interface{}{"reason": "blocked"}, fxt.Identities[1].ID)
+		require.NoError(t, err)
+		// when
+		workitemLinkRevisions, err := revRepo.List(s.Ctx, fxt.WorkItemLinks[0].ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, workitemLinkRevisions, 2)
+		revision2 := workitemLinkRevisions[1]
+		assert.Equal(t, link.RevisionTypeUpdate, revision2.Type)
+		assert.Equal(t, fxt.Identities[1].ID, revision2.ModifierIdentity)
+		assert.Equal(t, fxt.WorkItemLinks[0].Version+1, revision2.WorkItemLinkVersion)
+		assert.Equal(t, workitem.Fields{"reason": "blocked"}, revision2.WorkItemLinkAttributes)
+	})
 }
@@ -7,6 +7,7 @@ import (
 	convert "github.com/fabric8-services/fabric8-wit/convert"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	errs "github.com/pkg/errors"
 
 	uuid "github.com/satori/go.uuid"
@@ -33,6 +34,12 @@ type WorkItemLinkType struct {
 	ReverseName           string    `json:"reverse_name"`
 	ReverseDescription    *string   `json:"reverse_description,omitempty"`
 	SpaceTemplateID       uuid.UUID `sql:"type:uuid" json:"space_template_id"` // Reference to a space template
+	// Fields defines the optional attributes that links of this type can
+	// carry (e.g. a "reason" for a "blocks" link).
+	Fields workitem.FieldDefinitions `sql:"type:jsonb" json:"fields,omitempty"`
+	// Constraints restrict the types of work items that can be linked with
+	// this link type.
+	Constraints Constraints `sql:"type:jsonb" json:"constraints,omitempty"`
 }
 
 // Ensure WorkItemLinkType implements the Equaler interface
@@ -78,6 +85,18 @@ func (t WorkItemLinkType) Equal(u convert.Equaler) bool {
 	if t.SpaceTemplateID != other.SpaceTemplateID {
 		return false
 	}
+	if len(t.Fields) != len(other.Fields) {
+		return false
+	}
+	for name, def := range t.Fields {
+		otherDef, ok := other.Fields[name]
+		if !ok || !def.Equal(otherDef) {
+			return false
+		}
+	}
+	if !t.Constraints.Equal(other.Constraints) {
+		return false
+	}
 	return true
 }
 
@@ -110,6 +129,14 @@ func (t *WorkItemLinkType) CheckValidForCreation() error {
 	if t.SpaceTemplateID == uuid.Nil {
 		return errors.NewBadParameterError("space_template_id", t.SpaceTemplateID)
 	}
+	if len(t.Fields) > 0 {
+		if err := t.Fields.Validate(); err != nil {
+			return errors.NewBadParameterError("fields", t.Fields).Expected(err.Error())
+		}
+	}
+	if err := t.Constraints.Validate(); err != nil {
+		return errors.NewBadParameterError("constraints", t.Constraints).Expected(err.Error())
+	}
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/convert"
 	"github.com/fabric8-services/fabric8-wit/ptr"
 	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/require"
@@ -140,6 +141,24 @@ func TestWorkItemLinkType_EqualAndEqualValue(t *testing.T) {
 		require.False(t, a.Equal(b))
 		require.False(t, a.EqualValue(b))
 	})
+
+	t.Run("constraints", func(t *testing.T) {
+		t.Parallel()
+		b := a
+		b.Constraints = link.Constraints{MaxChildren: ptr.Int(1)}
+		require.False(t, a.Equal(b))
+		require.False(t, a.EqualValue(b))
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+		b := a
+		b.Fields = workitem.FieldDefinitions{
+			"reason": {Label: "Reason", Type: workitem.SimpleType{Kind: workitem.KindString}},
+		}
+		require.False(t, a.Equal(b))
+		require.False(t, a.EqualValue(b))
+	})
 }
 
 func TestWorkItemLinkTypeCheckValidForCreation(t *testing.T) {
@@ -192,4 +211,18 @@ func TestWorkItemLinkTypeCheckValidForCreation(t *testing.T) {
 		b.SpaceTemplateID = uuid.Nil
 		require.NotNil(t, b.CheckValidForCreation())
 	})
+
+	t.Run("invalid constraints", func(t *testing.T) {
+		b := a
+		b.Constraints = link.Constraints{MaxChildren: ptr.Int(-1)}
+		require.NotNil(t, b.CheckValidForCreation())
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		b := a
+		b.Fields = workitem.FieldDefinitions{
+			"reason": {Label: " ", Type: workitem.SimpleType{Kind: workitem.KindString}},
+		}
+		require.NotNil(t, b.CheckValidForCreation())
+	})
 }
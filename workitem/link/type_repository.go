@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/cache"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/log"
@@ -16,6 +17,19 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// WorkItemLinkTypeCacheName is the name of the work item link type cache.
+const WorkItemLinkTypeCacheName = "work_item_link_types"
+
+// linkTypeCache is shared by all work item link type repositories and is
+// invalidated across all instances of the service.
+var linkTypeCache = cache.Register(cache.NewMemoryCache(WorkItemLinkTypeCacheName, cache.DefaultTTL))
+
+// InvalidateWorkItemLinkTypeCache removes the work item link type with the
+// given ID from the cache of all instances of the service.
+func InvalidateWorkItemLinkTypeCache(ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	return cache.Invalidate(ctx, db, WorkItemLinkTypeCacheName, id)
+}
+
 // WorkItemLinkTypeRepository encapsulates storage & retrieval of work item link types
 type WorkItemLinkTypeRepository interface {
 	repository.Exister
@@ -75,6 +89,10 @@ func (r *GormWorkItemLinkTypeRepository) Load(ctx context.Context, ID uuid.UUID)
 	log.Info(ctx, map[string]interface{}{
 		"wilt_id": ID,
 	}, "loading work item link type")
+	if cached, ok := linkTypeCache.Get(ID); ok {
+		modelLinkType := cached.(WorkItemLinkType)
+		return &modelLinkType, nil
+	}
 	modelLinkType := WorkItemLinkType{}
 	db := r.db.Model(&modelLinkType).Where("id=?", ID).First(&modelLinkType)
 	if db.RecordNotFound() {
@@ -90,6 +108,7 @@ func (r *GormWorkItemLinkTypeRepository) Load(ctx context.Context, ID uuid.UUID)
 		}, "failed to create work item link type")
 		return nil, errors.NewInternalError(ctx, db.Error)
 	}
+	linkTypeCache.Put(modelLinkType.ID, modelLinkType)
 	return &modelLinkType, nil
 }
 
@@ -188,6 +207,9 @@ func (r *GormWorkItemLinkTypeRepository) Save(ctx context.Context, modelToSave W
 		}, "unable to save work item link type repository")
 		return nil, errors.NewInternalError(ctx, db.Error)
 	}
+	if err := InvalidateWorkItemLinkTypeCache(ctx, r.db, modelToSave.ID); err != nil {
+		return nil, errs.WithStack(err)
+	}
 	log.Info(ctx, map[string]interface{}{
 		"wilt_id": existingModel.ID,
 		"wilt":    existingModel,
@@ -0,0 +1,201 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository encapsulates storage & retrieval of work item templates
+type Repository interface {
+	repository.Exister
+	Create(ctx context.Context, t *Template) error
+	Load(ctx context.Context, id uuid.UUID) (*Template, error)
+	List(ctx context.Context, spaceID uuid.UUID) ([]Template, error)
+	Instantiate(ctx context.Context, id uuid.UUID, overrides map[string]interface{}, creatorID uuid.UUID) (*workitem.WorkItem, []workitem.WorkItem, error)
+}
+
+// NewRepository creates a work item template repository based on gorm
+func NewRepository(db *gorm.DB) *GormRepository {
+	return &GormRepository{
+		db:       db,
+		wiRepo:   workitem.NewWorkItemRepository(db),
+		witRepo:  workitem.NewWorkItemTypeRepository(db),
+		linkRepo: link.NewWorkItemLinkRepository(db),
+	}
+}
+
+// GormRepository implements Repository using gorm
+type GormRepository struct {
+	db       *gorm.DB
+	wiRepo   *workitem.GormWorkItemRepository
+	witRepo  *workitem.GormWorkItemTypeRepository
+	linkRepo *link.GormWorkItemLinkRepository
+}
+
+// CheckExists returns nil if the given ID exists otherwise returns an error
+func (r *GormRepository) CheckExists(ctx context.Context, id uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "exists"}, time.Now())
+	return repository.CheckExists(ctx, r.db, Template{}.TableName(), id)
+}
+
+// Create validates and stores the given work item template. The type of the
+// template and of all of its children must belong to the space template of
+// the template's space and all preset field values must be valid for their
+// type.
+func (r *GormRepository) Create(ctx context.Context, t *Template) error {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "create"}, time.Now())
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.NewBadParameterError("name", t.Name).Expected("non empty string")
+	}
+	if err := r.validateNode(ctx, t.SpaceID, t.WorkItemTypeID, t.Fields, t.Children, "fields"); err != nil {
+		return err
+	}
+	t.ID = uuid.NewV4()
+	if err := r.db.Create(t).Error; err != nil {
+		if gormsupport.IsUniqueViolation(err, "work_item_templates_name_space_id_unique_idx") {
+			log.Error(ctx, map[string]interface{}{
+				"err":      err,
+				"name":     t.Name,
+				"space_id": t.SpaceID,
+			}, "unable to create work item template because a template with the same name already exists in the space")
+			return errors.NewDataConflictError(fmt.Sprintf("work item template already exists with name = %s , space_id = %s", t.Name, t.SpaceID))
+		}
+		return errors.NewInternalError(ctx, errs.Wrap(err, "failed to create work item template"))
+	}
+	log.Debug(ctx, map[string]interface{}{"wit_template_id": t.ID}, "created work item template")
+	return nil
+}
+
+// validateNode checks that the given type can be used in the space and that
+// the presets are valid values for the fields of that type. The children are
+// validated recursively. The path is used to point to the offending field in
+// error messages.
+func (r *GormRepository) validateNode(ctx context.Context, spaceID, typeID uuid.UUID, presets Presets, children Children, path string) error {
+	wit, err := r.witRepo.Load(ctx, typeID)
+	if err != nil {
+		return errors.NewBadParameterError(path+".type", typeID).Expected("existing work item type")
+	}
+	if _, err := r.wiRepo.CheckTypeAndSpaceShareTemplate(ctx, wit, spaceID); err != nil {
+		return errs.Wrapf(err, "invalid work item type at %s", path)
+	}
+	for name, value := range presets {
+		fieldDef, ok := wit.Fields[name]
+		if !ok {
+			return errors.NewBadParameterError(path+"."+name, value).Expected(fmt.Sprintf("a field of work item type %q", wit.Name))
+		}
+		if fieldDef.ReadOnly {
+			return errors.NewBadParameterError(path+"."+name, value).Expected("a field that is not read-only")
+		}
+		if _, err := fieldDef.ConvertToModel(name, value); err != nil {
+			return errors.NewBadParameterError(path+"."+name, value).Expected(fieldDef.Type.GetKind().String())
+		}
+	}
+	for i, child := range children {
+		if err := r.validateNode(ctx, spaceID, child.TypeID, child.Fields, child.Children, fmt.Sprintf("%s.children[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load returns the work item template for the given ID.
+func (r *GormRepository) Load(ctx context.Context, id uuid.UUID) (*Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "load"}, time.Now())
+	res := Template{}
+	db := r.db.Model(&res).Where("id = ?", id).First(&res)
+	if db.RecordNotFound() {
+		log.Error(ctx, map[string]interface{}{"wit_template_id": id}, "work item template not found")
+		return nil, errors.NewNotFoundError("work item template", id.String())
+	}
+	if err := db.Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return &res, nil
+}
+
+// List returns all work item templates of the given space ordered by name.
+func (r *GormRepository) List(ctx context.Context, spaceID uuid.UUID) ([]Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "list"}, time.Now())
+	res := []Template{}
+	if err := r.db.Where("space_id = ?", spaceID).Order("name ASC").Find(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to list work item templates of space %s", spaceID))
+	}
+	return res, nil
+}
+
+// Instantiate creates the work item described by the template with the given
+// ID, applying the given field values on top of the presets of the root work
+// item. All children in the template are created as well and are linked to
+// their parent with a parent-child link. The root work item is returned
+// together with all created children in depth-first order.
+//
+// NOTE: Run this inside a transaction so that a failure while creating a
+// child doesn't leave a partially instantiated tree behind.
+func (r *GormRepository) Instantiate(ctx context.Context, id uuid.UUID, overrides map[string]interface{}, creatorID uuid.UUID) (*workitem.WorkItem, []workitem.WorkItem, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "instantiate"}, time.Now())
+	t, err := r.Load(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := map[string]interface{}{}
+	for k, v := range t.Fields {
+		fields[k] = v
+	}
+	for k, v := range overrides {
+		fields[k] = v
+	}
+	root, _, err := r.wiRepo.Create(ctx, t.SpaceID, t.WorkItemTypeID, fields, creatorID)
+	if err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to create work item from template %s", id)
+	}
+	children := make([]workitem.WorkItem, 0, t.Children.Count())
+	if err := r.instantiateChildren(ctx, *root, t.Children, creatorID, &children); err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to create children from template %s", id)
+	}
+	log.Info(ctx, map[string]interface{}{
+		"wit_template_id": id,
+		"wi_id":           root.ID,
+		"children":        len(children),
+	}, "instantiated work item template")
+	return root, children, nil
+}
+
+func (r *GormRepository) instantiateChildren(ctx context.Context, parent workitem.WorkItem, children Children, creatorID uuid.UUID, result *[]workitem.WorkItem) error {
+	for _, child := range children {
+		fields := map[string]interface{}{}
+		for k, v := range child.Fields {
+			fields[k] = v
+		}
+		// children inherit the iteration and area of their parent
+		for _, name := range []string{workitem.SystemIteration, workitem.SystemArea} {
+			if _, ok := fields[name]; !ok && parent.Fields[name] != nil {
+				fields[name] = parent.Fields[name]
+			}
+		}
+		wi, _, err := r.wiRepo.Create(ctx, parent.SpaceID, child.TypeID, fields, creatorID)
+		if err != nil {
+			return errs.Wrapf(err, "failed to create child of work item %s", parent.ID)
+		}
+		if _, err := r.linkRepo.Create(ctx, parent.ID, wi.ID, link.SystemWorkItemLinkTypeParentChildID, creatorID); err != nil {
+			return errs.Wrapf(err, "failed to link work item %s to its parent %s", wi.ID, parent.ID)
+		}
+		*result = append(*result, *wi)
+		if err := r.instantiateChildren(ctx, *wi, child.Children, creatorID, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
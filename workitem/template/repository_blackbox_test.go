@@ -0,0 +1,140 @@
+package template_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type templateRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo *template.GormRepository
+}
+
+func TestRunTemplateRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &templateRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *templateRepoBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.repo = template.NewRepository(s.DB)
+}
+
+func (s *templateRepoBlackBoxTest) newTemplate(fxt *tf.TestFixture) template.Template {
+	return template.Template{
+		SpaceID:        fxt.Spaces[0].ID,
+		Name:           "release checklist",
+		WorkItemTypeID: fxt.WorkItemTypes[0].ID,
+		Fields:         template.Presets{workitem.SystemTitle: "Release"},
+		Children: template.Children{
+			{
+				TypeID: fxt.WorkItemTypes[0].ID,
+				Fields: template.Presets{workitem.SystemTitle: "Update changelog"},
+				Children: template.Children{
+					{TypeID: fxt.WorkItemTypes[0].ID, Fields: template.Presets{workitem.SystemTitle: "Collect commits"}},
+				},
+			},
+			{TypeID: fxt.WorkItemTypes[0].ID, Fields: template.Presets{workitem.SystemTitle: "Tag release"}},
+		},
+		CreatorID: fxt.Identities[0].ID,
+	}
+}
+
+func (s *templateRepoBlackBoxTest) TestCreate() {
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment())
+		tmpl := s.newTemplate(fxt)
+		// when
+		err := s.repo.Create(s.Ctx, &tmpl)
+		// then
+		require.NoError(t, err)
+		loaded, err := s.repo.Load(s.Ctx, tmpl.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tmpl.Name, loaded.Name)
+		assert.Equal(t, "Release", loaded.Fields[workitem.SystemTitle])
+		require.Len(t, loaded.Children, 2)
+		assert.Equal(t, 3, loaded.Children.Count())
+		list, err := s.repo.List(s.Ctx, fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, tmpl.ID, list[0].ID)
+	})
+
+	s.T().Run("fail - unknown field", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment())
+		tmpl := s.newTemplate(fxt)
+		tmpl.Children[0].Children[0].Fields["foo"] = "bar"
+		err := s.repo.Create(s.Ctx, &tmpl)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+		assert.Contains(t, err.Error(), "fields.children[0].children[0].foo")
+	})
+
+	s.T().Run("fail - type from another space template", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment())
+		other := tf.NewTestFixture(t, s.DB, tf.WorkItemTypes(1))
+		tmpl := s.newTemplate(fxt)
+		tmpl.WorkItemTypeID = other.WorkItemTypes[0].ID
+		err := s.repo.Create(s.Ctx, &tmpl)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("fail - duplicate name", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment())
+		tmpl := s.newTemplate(fxt)
+		require.NoError(t, s.repo.Create(s.Ctx, &tmpl))
+		dup := s.newTemplate(fxt)
+		err := s.repo.Create(s.Ctx, &dup)
+		require.Error(t, err)
+		assert.IsType(t, errors.DataConflictError{}, errs.Cause(err))
+	})
+}
+
+func (s *templateRepoBlackBoxTest) TestLoad() {
+	s.T().Run("not found", func(t *testing.T) {
+		_, err := s.repo.Load(s.Ctx, uuid.NewV4())
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
+func (s *templateRepoBlackBoxTest) TestInstantiate() {
+	s.T().Run("ok - creates work item tree", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment())
+		tmpl := s.newTemplate(fxt)
+		require.NoError(t, s.repo.Create(s.Ctx, &tmpl))
+		// when
+		root, children, err := s.repo.Instantiate(s.Ctx, tmpl.ID, map[string]interface{}{workitem.SystemTitle: "Release 1.2"}, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Release 1.2", root.Fields[workitem.SystemTitle])
+		assert.Equal(t, fxt.Spaces[0].ID, root.SpaceID)
+		require.Len(t, children, 3)
+		assert.Equal(t, "Update changelog", children[0].Fields[workitem.SystemTitle])
+		assert.Equal(t, "Collect commits", children[1].Fields[workitem.SystemTitle])
+		assert.Equal(t, "Tag release", children[2].Fields[workitem.SystemTitle])
+		linkRepo := link.NewWorkItemLinkRepository(s.DB)
+		rootLinks, err := linkRepo.ListChildLinks(s.Ctx, link.SystemWorkItemLinkTypeParentChildID, root.ID)
+		require.NoError(t, err)
+		assert.Len(t, rootLinks, 2)
+		grandChildLinks, err := linkRepo.ListChildLinks(s.Ctx, link.SystemWorkItemLinkTypeParentChildID, children[0].ID)
+		require.NoError(t, err)
+		require.Len(t, grandChildLinks, 1)
+		assert.Equal(t, children[1].ID, grandChildLinks[0].TargetID)
+	})
+}
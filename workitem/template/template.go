@@ -0,0 +1,119 @@
+// Package template contains the code to manage work item templates. A work
+// item template describes a work item of a given type with preset field values
+// and an optional tree of child work items that are all created at once when
+// the template is instantiated.
+package template
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeWorkItemTemplates helps to avoid string literal
+const APIStringTypeWorkItemTemplates = "workitemtemplates"
+
+// Template describes a work item of a certain type with preset field values
+// and an optional list of children that are created along with it.
+type Template struct {
+	gormsupport.Lifecycle
+	ID             uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	SpaceID        uuid.UUID `sql:"type:uuid"`
+	Name           string
+	Description    *string
+	WorkItemTypeID uuid.UUID `sql:"type:uuid" gorm:"column:work_item_type_id"`
+	Fields         Presets   `sql:"type:jsonb"`
+	Children       Children  `sql:"type:jsonb"`
+	CreatorID      uuid.UUID `sql:"type:uuid" gorm:"column:creator_id"`
+	Version        int
+}
+
+// TableName implements gorm.tabler
+func (t Template) TableName() string {
+	return "work_item_templates"
+}
+
+// GetETagData returns the field values to use to generate the ETag
+func (t Template) GetETagData() []interface{} {
+	return []interface{}{t.ID, t.Version}
+}
+
+// GetLastModified returns the last modification time
+func (t Template) GetLastModified() time.Time {
+	return t.UpdatedAt.Truncate(time.Second)
+}
+
+// Presets maps a field name to the value that a work item gets when it is
+// created from a template.
+type Presets map[string]interface{}
+
+// Ensure Presets implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*Presets)(nil)
+var _ driver.Valuer = (*Presets)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (p Presets) Value() (driver.Value, error) {
+	return toBytes(p)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (p *Presets) Scan(src interface{}) error {
+	return fromBytes(src, p)
+}
+
+// Child describes a work item that is created as a child of the work item
+// created from the parent template node.
+type Child struct {
+	TypeID   uuid.UUID `json:"type_id"`
+	Fields   Presets   `json:"fields,omitempty"`
+	Children Children  `json:"children,omitempty"`
+}
+
+// Children is the list of child nodes in a template.
+type Children []Child
+
+// Ensure Children implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*Children)(nil)
+var _ driver.Valuer = (*Children)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (c Children) Value() (driver.Value, error) {
+	return toBytes(c)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (c *Children) Scan(src interface{}) error {
+	return fromBytes(src, c)
+}
+
+// Count returns the number of nodes in the children tree.
+func (c Children) Count() int {
+	n := len(c)
+	for _, child := range c {
+		n += child.Children.Count()
+	}
+	return n
+}
+
+func toBytes(j interface{}) (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return json.Marshal(j)
+}
+
+func fromBytes(src interface{}, target interface{}) error {
+	if src == nil {
+		return nil
+	}
+	s, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("scan source was not a string")
+	}
+	return json.Unmarshal(s, target)
+}
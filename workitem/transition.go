@@ -0,0 +1,197 @@
+package workitem
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	errs "github.com/pkg/errors"
+)
+
+// TransitionFromAny can be used as the "from" state of a transition to allow
+// the transition from every state.
+const TransitionFromAny = "*"
+
+// Transition describes an allowed change of the "system.state" field of a work
+// item from one state to another. A transition may require fields to be set
+// and guards to be met before it can be taken.
+//
+// In a space template the transitions are declared for each work item type:
+//
+//	transitions:
+//	- from: new
+//	  to: in progress
+//	- from: in progress
+//	  to: resolved
+//	  required_fields: [ system.assignees ]
+//	- from: "*"
+//	  to: closed
+//	  guards:
+//	  - field: resolution
+//	    values: [ fixed, wontfix ]
+type Transition struct {
+	From           string           `json:"from"`
+	To             string           `json:"to"`
+	RequiredFields []string         `json:"required_fields,omitempty"`
+	Guards         []FieldCondition `json:"guards,omitempty"`
+}
+
+// matches returns true if the transition leads from the given state to the
+// other given state.
+func (t Transition) matches(from, to string) bool {
+	return (t.From == TransitionFromAny || t.From == from) && t.To == to
+}
+
+// check returns an error if the transition cannot be taken with the given field
+// values in model representation.
+func (t Transition) check(fields FieldDefinitions, from, to string, values map[string]interface{}) error {
+	for _, name := range t.RequiredFields {
+		if isEmptyValue(values[name]) {
+			return errors.NewBadParameterError(name, values[name]).Expected(fmt.Sprintf("a value when changing the state from %q to %q", from, to)).Pointer(fieldPointer(name))
+		}
+	}
+	for _, g := range t.Guards {
+		if !g.met(fields, values) {
+			return errors.NewBadParameterError(g.Field, values[g.Field]).Expected(fmt.Sprintf("one of %v when changing the state from %q to %q", g.Values, from, to)).Pointer(fieldPointer(g.Field))
+		}
+	}
+	return nil
+}
+
+// Transitions is the list of allowed state transitions of a work item type.
+// An empty list allows all transitions.
+type Transitions []Transition
+
+// Ensure Transitions implements the Scanner and Valuer interfaces
+var _ sql.Scanner = (*Transitions)(nil)
+var _ driver.Valuer = (*Transitions)(nil)
+
+// Value implements the https://golang.org/pkg/database/sql/driver/#Valuer interface
+func (t Transitions) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return toBytes(t)
+}
+
+// Scan implements the https://golang.org/pkg/database/sql/#Scanner interface
+func (t *Transitions) Scan(src interface{}) error {
+	return fromBytes(src, t)
+}
+
+// Equal returns true if both lists contain the same transitions in the same
+// order.
+func (t Transitions) Equal(other Transitions) bool {
+	if len(t) != len(other) {
+		return false
+	}
+	for i := range t {
+		if !reflect.DeepEqual(t[i], other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that the transitions only refer to existing fields and, if
+// the state field is an enum, to existing states.
+func (t Transitions) Validate(fields FieldDefinitions) error {
+	var states []interface{}
+	if def, ok := fields[SystemState]; ok {
+		if enum, ok := def.Type.(EnumType); ok {
+			states = enum.Values
+		}
+	}
+	isState := func(s string) bool {
+		if states == nil {
+			return true
+		}
+		for _, v := range states {
+			if v == s {
+				return true
+			}
+		}
+		return false
+	}
+	for i, tr := range t {
+		if strings.TrimSpace(tr.From) == "" || strings.TrimSpace(tr.To) == "" {
+			return errs.Errorf("transition %d must have a from and a to state", i)
+		}
+		if tr.From != TransitionFromAny && !isState(tr.From) {
+			return errs.Errorf("transition %d starts from unknown state %q", i, tr.From)
+		}
+		if !isState(tr.To) {
+			return errs.Errorf("transition %d leads to unknown state %q", i, tr.To)
+		}
+		for _, name := range tr.RequiredFields {
+			if _, ok := fields[name]; !ok {
+				return errs.Errorf("transition %d requires unknown field %q", i, name)
+			}
+		}
+		for _, g := range tr.Guards {
+			if _, ok := fields[g.Field]; !ok {
+				return errs.Errorf("transition %d has a guard on unknown field %q", i, g.Field)
+			}
+			if len(g.Values) == 0 {
+				return errs.Errorf("transition %d has a guard on field %q without values", i, g.Field)
+			}
+		}
+	}
+	return nil
+}
+
+// NextStates returns the states that a work item of this type can be moved
+// to from the given state, ignoring required fields and guards. If no
+// transitions are defined, nil is returned which means that all states are
+// allowed.
+func (wit WorkItemType) NextStates(from string) []string {
+	if len(wit.Transitions) == 0 {
+		return nil
+	}
+	res := []string{}
+	seen := map[string]struct{}{}
+	for _, t := range wit.Transitions {
+		if t.From != TransitionFromAny && t.From != from {
+			continue
+		}
+		if _, ok := seen[t.To]; ok {
+			continue
+		}
+		seen[t.To] = struct{}{}
+		res = append(res, t.To)
+	}
+	return res
+}
+
+// CheckTransition returns a BadParameterError if a work item of this type
+// cannot change its state from the given old state to the state contained in
+// the given field values (in model representation). A work item whose state
+// doesn't change or whose type defines no transitions always passes.
+func (wit WorkItemType) CheckTransition(oldState interface{}, values map[string]interface{}) error {
+	if len(wit.Transitions) == 0 {
+		return nil
+	}
+	from, _ := oldState.(string)
+	to, _ := values[SystemState].(string)
+	if from == to {
+		return nil
+	}
+	var err error
+	found := false
+	for _, t := range wit.Transitions {
+		if !t.matches(from, to) {
+			continue
+		}
+		found = true
+		if err = t.check(wit.Fields, from, to, values); err == nil {
+			return nil
+		}
+	}
+	if !found {
+		return errors.NewBadParameterError(SystemState, to).Expected(fmt.Sprintf("one of the states %v that can follow %q", wit.NextStates(from), from)).Pointer(fieldPointer(SystemState))
+	}
+	return err
+}
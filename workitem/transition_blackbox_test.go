@@ -0,0 +1,115 @@
+package workitem_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func transitionTestType() workitem.WorkItemType {
+	stringType := workitem.SimpleType{Kind: workitem.KindString}
+	return workitem.WorkItemType{
+		Fields: workitem.FieldDefinitions{
+			workitem.SystemState: {
+				Label: "State",
+				Type: workitem.EnumType{
+					SimpleType: workitem.SimpleType{Kind: workitem.KindEnum},
+					BaseType:   stringType,
+					Values:     []interface{}{"new", "in progress", "resolved", "closed"},
+				},
+			},
+			"resolution": {Label: "Resolution", Type: stringType},
+			"assignee":   {Label: "Assignee", Type: stringType},
+		},
+		Transitions: workitem.Transitions{
+			{From: "new", To: "in progress"},
+			{From: "in progress", To: "resolved", RequiredFields: []string{"assignee"}},
+			{From: workitem.TransitionFromAny, To: "closed", Guards: []workitem.FieldCondition{{Field: "resolution", Values: []interface{}{"fixed", "wontfix"}}}},
+		},
+	}
+}
+
+func TestTransitions_Validate(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	wit := transitionTestType()
+	require.NoError(t, wit.Transitions.Validate(wit.Fields))
+
+	testData := map[string]workitem.Transition{
+		"empty to state":   {From: "new"},
+		"unknown from":     {From: "foo", To: "new"},
+		"unknown to":       {From: "new", To: "foo"},
+		"unknown required": {From: "new", To: "closed", RequiredFields: []string{"foo"}},
+		"unknown guard":    {From: "new", To: "closed", Guards: []workitem.FieldCondition{{Field: "foo", Values: []interface{}{"bar"}}}},
+		"guard w/o values": {From: "new", To: "closed", Guards: []workitem.FieldCondition{{Field: "resolution"}}},
+	}
+	for name, tr := range testData {
+		tr := tr
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Error(t, workitem.Transitions{tr}.Validate(wit.Fields))
+		})
+	}
+}
+
+func TestWorkItemType_NextStates(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	wit := transitionTestType()
+	assert.Equal(t, []string{"in progress", "closed"}, wit.NextStates("new"))
+	assert.Equal(t, []string{"resolved", "closed"}, wit.NextStates("in progress"))
+	assert.Equal(t, []string{"closed"}, wit.NextStates("resolved"))
+	assert.Nil(t, workitem.WorkItemType{}.NextStates("new"))
+}
+
+func TestWorkItemType_CheckTransition(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	wit := transitionTestType()
+	requirePointer := func(t *testing.T, err error, field string) {
+		require.Error(t, err)
+		ok, cause := errors.IsBadParameterError(err)
+		require.True(t, ok)
+		pointer := cause.(errors.BadParameterError).SourcePointer()
+		require.NotNil(t, pointer)
+		assert.Equal(t, "/data/attributes/"+field, *pointer)
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, wit.CheckTransition("new", map[string]interface{}{workitem.SystemState: "in progress"}))
+		require.NoError(t, wit.CheckTransition("in progress", map[string]interface{}{workitem.SystemState: "resolved", "assignee": "me"}))
+		require.NoError(t, wit.CheckTransition("resolved", map[string]interface{}{workitem.SystemState: "closed", "resolution": "fixed"}))
+	})
+	t.Run("unchanged state", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, wit.CheckTransition("resolved", map[string]interface{}{workitem.SystemState: "resolved"}))
+	})
+	t.Run("no transitions defined", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, workitem.WorkItemType{}.CheckTransition("closed", map[string]interface{}{workitem.SystemState: "new"}))
+	})
+	t.Run("not allowed", func(t *testing.T) {
+		t.Parallel()
+		err := wit.CheckTransition("new", map[string]interface{}{workitem.SystemState: "resolved"})
+		requirePointer(t, err, workitem.SystemState)
+		assert.Contains(t, err.Error(), "[in progress closed]")
+	})
+	t.Run("missing required field", func(t *testing.T) {
+		t.Parallel()
+		err := wit.CheckTransition("in progress", map[string]interface{}{workitem.SystemState: "resolved"})
+		requirePointer(t, err, "assignee")
+	})
+	t.Run("guard not met", func(t *testing.T) {
+		t.Parallel()
+		err := wit.CheckTransition("resolved", map[string]interface{}{workitem.SystemState: "closed", "resolution": "unknown"})
+		requirePointer(t, err, "resolution")
+	})
+}
@@ -0,0 +1,263 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Velocity holds the amount of work that was completed in past iterations.
+// The work of a work item is the value of a numeric field (e.g. the story
+// points) or 1 if only work items are counted. The work of a work item with
+// several assignees is split evenly among them.
+type Velocity struct {
+	// Field is the name of the numeric field whose values are summed up. It
+	// is empty if only work items are counted.
+	Field      string
+	Iterations []IterationVelocity
+	// Average is the work completed per iteration on average.
+	Average float64
+	// AveragePerAssignee is the work each assignee completed per iteration
+	// on average.
+	AveragePerAssignee map[uuid.UUID]float64
+}
+
+// IterationVelocity describes the work that was completed in a single
+// iteration.
+type IterationVelocity struct {
+	IterationID uuid.UUID
+	Name        string
+	StartAt     *time.Time
+	EndAt       *time.Time
+	Closed      int
+	Value       float64
+	PerAssignee map[uuid.UUID]float64
+}
+
+// IterationPlan compares the work committed to an iteration with the capacity
+// of its assignees and the velocity of past iterations.
+type IterationPlan struct {
+	IterationID  uuid.UUID
+	Field        string
+	CapacityUnit iteration.CapacityUnit
+	// Capacity is the capacity of all assignees together.
+	Capacity float64
+	// Committed is the work of all open work items in the iteration.
+	Committed float64
+	// Unassigned is the part of the committed work that has no assignee.
+	Unassigned float64
+	// Velocity is the average velocity of past iterations.
+	Velocity  float64
+	Assignees []AssigneeLoad
+	Warnings  []string
+}
+
+// AssigneeLoad compares the work committed by a single assignee with their
+// capacity and velocity.
+type AssigneeLoad struct {
+	AssigneeID uuid.UUID
+	// Capacity is nil if no capacity was set for the assignee.
+	Capacity      *float64
+	Committed     float64
+	Velocity      float64
+	Overcommitted bool
+}
+
+// workValue returns the work of the work item with the given field values
+// (in storage representation): the numeric value of the given field or 1 if
+// no field is given.
+func workValue(fields Fields, field string) float64 {
+	if field == "" {
+		return 1
+	}
+	return numericValue(fields[field])
+}
+
+// assigneeShares splits the given work evenly among the assignees of the work
+// item with the given field values (in storage representation). An empty map
+// is returned if the work item has no assignee.
+func assigneeShares(fields Fields, value float64) map[uuid.UUID]float64 {
+	var assignees []uuid.UUID
+	if ids, ok := fields[SystemAssignees].([]interface{}); ok {
+		for _, id := range ids {
+			if assigneeID, err := uuid.FromString(fmt.Sprint(id)); err == nil {
+				assignees = append(assignees, assigneeID)
+			}
+		}
+	}
+	res := make(map[uuid.UUID]float64, len(assignees))
+	for _, id := range assignees {
+		res[id] += value / float64(len(assignees))
+	}
+	return res
+}
+
+// ComputeVelocity computes the velocity of the given iterations from the
+// closed work items among the given ones. A work item counts for the
+// iteration its "system.iteration" field refers to. The iterations are
+// returned in the given order.
+func ComputeVelocity(iterations []iteration.Iteration, items []WorkItemStorage, field string) Velocity {
+	res := Velocity{
+		Field:              field,
+		Iterations:         make([]IterationVelocity, len(iterations)),
+		AveragePerAssignee: map[uuid.UUID]float64{},
+	}
+	index := map[string]int{}
+	for i, itr := range iterations {
+		index[itr.ID.String()] = i
+		res.Iterations[i] = IterationVelocity{
+			IterationID: itr.ID,
+			Name:        itr.Name,
+			StartAt:     itr.StartAt,
+			EndAt:       itr.EndAt,
+			PerAssignee: map[uuid.UUID]float64{},
+		}
+	}
+	for _, wi := range items {
+		i, ok := index[fmt.Sprint(wi.Fields[SystemIteration])]
+		if !ok || !IsClosed(wi.Fields) {
+			continue
+		}
+		value := workValue(wi.Fields, field)
+		res.Iterations[i].Closed++
+		res.Iterations[i].Value += value
+		for id, share := range assigneeShares(wi.Fields, value) {
+			res.Iterations[i].PerAssignee[id] += share
+		}
+	}
+	if len(iterations) == 0 {
+		return res
+	}
+	for _, itr := range res.Iterations {
+		res.Average += itr.Value
+		for id, v := range itr.PerAssignee {
+			res.AveragePerAssignee[id] += v
+		}
+	}
+	res.Average /= float64(len(iterations))
+	for id := range res.AveragePerAssignee {
+		res.AveragePerAssignee[id] /= float64(len(iterations))
+	}
+	return res
+}
+
+// ComputeIterationPlan compares the work of the open work items among the
+// given ones with the capacity of the given iteration and the given velocity.
+// Warnings are issued for every assignee whose committed work exceeds their
+// capacity or who has no capacity at all, and if the committed work exceeds
+// the capacity of the iteration or the velocity of past iterations. The
+// assignees are ordered by their ID.
+func ComputeIterationPlan(itr iteration.Iteration, items []WorkItemStorage, velocity Velocity) IterationPlan {
+	res := IterationPlan{
+		IterationID:  itr.ID,
+		Field:        velocity.Field,
+		CapacityUnit: itr.CapacityUnit,
+		Capacity:     itr.Capacity.Total(),
+		Velocity:     velocity.Average,
+	}
+	committed := map[uuid.UUID]float64{}
+	for _, wi := range items {
+		if IsClosed(wi.Fields) {
+			continue
+		}
+		value := workValue(wi.Fields, velocity.Field)
+		res.Committed += value
+		shares := assigneeShares(wi.Fields, value)
+		if len(shares) == 0 {
+			res.Unassigned += value
+		}
+		for id, share := range shares {
+			committed[id] += share
+		}
+	}
+	for id := range itr.Capacity {
+		if _, ok := committed[id]; !ok {
+			committed[id] = 0
+		}
+	}
+	for id, load := range committed {
+		a := AssigneeLoad{
+			AssigneeID: id,
+			Committed:  load,
+			Velocity:   velocity.AveragePerAssignee[id],
+		}
+		if capacity, ok := itr.Capacity[id]; ok {
+			a.Capacity = &capacity
+			a.Overcommitted = load > capacity
+		}
+		res.Assignees = append(res.Assignees, a)
+	}
+	sort.Slice(res.Assignees, func(i, j int) bool {
+		return res.Assignees[i].AssigneeID.String() < res.Assignees[j].AssigneeID.String()
+	})
+	for _, a := range res.Assignees {
+		switch {
+		case a.Capacity == nil:
+			res.Warnings = append(res.Warnings, fmt.Sprintf("assignee %s has %g committed but no capacity", a.AssigneeID, a.Committed))
+		case a.Overcommitted:
+			res.Warnings = append(res.Warnings, fmt.Sprintf("assignee %s is overcommitted: %g committed, %g %s capacity", a.AssigneeID, a.Committed, *a.Capacity, res.CapacityUnit))
+		}
+	}
+	if len(itr.Capacity) > 0 && res.Committed > res.Capacity {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("iteration is overcommitted: %g committed, %g %s capacity", res.Committed, res.Capacity, res.CapacityUnit))
+	}
+	if len(velocity.Iterations) > 0 && res.Committed > res.Velocity {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("committed work (%g) exceeds the average velocity of the last %d iteration(s) (%g)", res.Committed, len(velocity.Iterations), res.Velocity))
+	}
+	return res
+}
+
+// loadByIterations returns the work items that belong to one of the given
+// iterations in storage representation.
+func (r *GormWorkItemRepository) loadByIterations(ctx context.Context, iterationIDs []uuid.UUID) ([]WorkItemStorage, error) {
+	var res []WorkItemStorage
+	if len(iterationIDs) == 0 {
+		return res, nil
+	}
+	ids := make([]string, len(iterationIDs))
+	for i, id := range iterationIDs {
+		ids[i] = id.String()
+	}
+	db := r.db.Model(WorkItemStorage{}).Where(fmt.Sprintf("fields->>'%s' IN (?)", SystemIteration), ids).Find(&res)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return res, nil
+}
+
+// Velocity computes the velocity of the given (past) iterations. See
+// ComputeVelocity for details.
+func (r *GormWorkItemRepository) Velocity(ctx context.Context, iterations []iteration.Iteration, field string) (*Velocity, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "velocity"}, time.Now())
+	log.Debug(ctx, map[string]interface{}{"iterations": len(iterations), "field": field}, "computing velocity")
+	ids := make([]uuid.UUID, len(iterations))
+	for i, itr := range iterations {
+		ids[i] = itr.ID
+	}
+	items, err := r.loadByIterations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := ComputeVelocity(iterations, items, field)
+	return &res, nil
+}
+
+// PlanIteration compares the work committed to the given iteration with its
+// capacity and the given velocity. See ComputeIterationPlan for details.
+func (r *GormWorkItemRepository) PlanIteration(ctx context.Context, itr iteration.Iteration, velocity Velocity) (*IterationPlan, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "planiteration"}, time.Now())
+	log.Debug(ctx, map[string]interface{}{"iteration_id": itr.ID, "field": velocity.Field}, "planning iteration")
+	items, err := r.loadByIterations(ctx, []uuid.UUID{itr.ID})
+	if err != nil {
+		return nil, err
+	}
+	res := ComputeIterationPlan(itr, items, velocity)
+	return &res, nil
+}
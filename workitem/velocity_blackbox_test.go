@@ -0,0 +1,114 @@
+package workitem_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeVelocity(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	// given
+	sprint1 := iteration.Iteration{ID: uuid.NewV4(), Name: "sprint 1"}
+	sprint2 := iteration.Iteration{ID: uuid.NewV4(), Name: "sprint 2"}
+	alice, bob := uuid.NewV4(), uuid.NewV4()
+	item := func(itr iteration.Iteration, state string, points float64, assignees ...uuid.UUID) workitem.WorkItemStorage {
+		ids := []interface{}{}
+		for _, id := range assignees {
+			ids = append(ids, id.String())
+		}
+		return workitem.WorkItemStorage{
+			ID: uuid.NewV4(),
+			Fields: workitem.Fields{
+				workitem.SystemIteration: itr.ID.String(),
+				workitem.SystemState:     state,
+				workitem.SystemAssignees: ids,
+				"storypoints":            points,
+			},
+		}
+	}
+	items := []workitem.WorkItemStorage{
+		item(sprint1, workitem.SystemStateClosed, 5, alice),
+		item(sprint1, workitem.SystemStateClosed, 4, alice, bob),
+		item(sprint1, workitem.SystemStateOpen, 8, bob),
+		item(sprint2, workitem.SystemStateClosed, 3),
+	}
+
+	t.Run("with field", func(t *testing.T) {
+		t.Parallel()
+		// when
+		v := workitem.ComputeVelocity([]iteration.Iteration{sprint2, sprint1}, items, "storypoints")
+		// then
+		require.Len(t, v.Iterations, 2)
+		assert.Equal(t, sprint2.ID, v.Iterations[0].IterationID)
+		assert.Equal(t, 1, v.Iterations[0].Closed)
+		assert.Equal(t, 3.0, v.Iterations[0].Value)
+		assert.Empty(t, v.Iterations[0].PerAssignee)
+		assert.Equal(t, 2, v.Iterations[1].Closed)
+		assert.Equal(t, 9.0, v.Iterations[1].Value)
+		assert.Equal(t, map[uuid.UUID]float64{alice: 7, bob: 2}, v.Iterations[1].PerAssignee)
+		assert.Equal(t, 6.0, v.Average)
+		assert.Equal(t, map[uuid.UUID]float64{alice: 3.5, bob: 1}, v.AveragePerAssignee)
+	})
+	t.Run("without field", func(t *testing.T) {
+		t.Parallel()
+		// when
+		v := workitem.ComputeVelocity([]iteration.Iteration{sprint1}, items, "")
+		// then
+		require.Len(t, v.Iterations, 1)
+		assert.Equal(t, 2.0, v.Iterations[0].Value)
+		assert.Equal(t, 2.0, v.Average)
+	})
+	t.Run("without iterations", func(t *testing.T) {
+		t.Parallel()
+		v := workitem.ComputeVelocity(nil, items, "storypoints")
+		assert.Empty(t, v.Iterations)
+		assert.Equal(t, 0.0, v.Average)
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		t.Parallel()
+		// given
+		carol := uuid.NewV4()
+		next := iteration.Iteration{
+			ID:           uuid.NewV4(),
+			Capacity:     iteration.Capacity{alice: 5, bob: 10},
+			CapacityUnit: iteration.CapacityUnitPoints,
+		}
+		planned := []workitem.WorkItemStorage{
+			item(next, workitem.SystemStateNew, 6, alice),
+			item(next, workitem.SystemStateInProgress, 2, bob),
+			item(next, workitem.SystemStateClosed, 20, bob),
+			item(next, workitem.SystemStateNew, 1, carol),
+			item(next, workitem.SystemStateNew, 3),
+		}
+		velocity := workitem.ComputeVelocity([]iteration.Iteration{sprint2, sprint1}, items, "storypoints")
+		// when
+		plan := workitem.ComputeIterationPlan(next, planned, velocity)
+		// then
+		assert.Equal(t, next.ID, plan.IterationID)
+		assert.Equal(t, "storypoints", plan.Field)
+		assert.Equal(t, 15.0, plan.Capacity)
+		assert.Equal(t, 12.0, plan.Committed)
+		assert.Equal(t, 3.0, plan.Unassigned)
+		assert.Equal(t, 6.0, plan.Velocity)
+		require.Len(t, plan.Assignees, 3)
+		loads := map[uuid.UUID]workitem.AssigneeLoad{}
+		for _, a := range plan.Assignees {
+			loads[a.AssigneeID] = a
+		}
+		assert.Equal(t, workitem.AssigneeLoad{AssigneeID: alice, Capacity: ptr.Float64(5), Committed: 6, Velocity: 3.5, Overcommitted: true}, loads[alice])
+		assert.Equal(t, workitem.AssigneeLoad{AssigneeID: bob, Capacity: ptr.Float64(10), Committed: 2, Velocity: 1}, loads[bob])
+		assert.Equal(t, workitem.AssigneeLoad{AssigneeID: carol, Committed: 1}, loads[carol])
+		// one warning for alice, one for carol and one for exceeding the velocity
+		assert.Len(t, plan.Warnings, 3)
+	})
+}
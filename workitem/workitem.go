@@ -29,6 +29,11 @@ type WorkItem struct {
 	// optional, private timestamp of the latest addition/removal of a relationship with this workitem
 	// this field is used to generate the `ETag` and `Last-Modified` values in the HTTP responses and conditional requests processing
 	relationShipsChangedAt *time.Time
+	// ChildrenChangedAt is the latest change of a child of this work item (or
+	// of a link to one). It is only set when computed fields aggregating over
+	// the children are returned so that the `ETag` and `Last-Modified` values
+	// change along with them.
+	ChildrenChangedAt *time.Time
 }
 
 // WICountsPerIteration counting work item states by iteration
@@ -40,7 +45,11 @@ type WICountsPerIteration struct {
 
 // GetETagData returns the field values to use to generate the ETag
 func (wi WorkItem) GetETagData() []interface{} {
-	return []interface{}{wi.ID, wi.Version, wi.relationShipsChangedAt}
+	res := []interface{}{wi.ID, wi.Version, wi.relationShipsChangedAt}
+	if wi.ChildrenChangedAt != nil {
+		res = append(res, *wi.ChildrenChangedAt)
+	}
+	return res
 }
 
 // GetLastModified returns the last modification time
@@ -53,6 +62,9 @@ func (wi WorkItem) GetLastModified() time.Time {
 	if wi.relationShipsChangedAt != nil && (lastModified == nil || wi.relationShipsChangedAt.After(*lastModified)) {
 		lastModified = wi.relationShipsChangedAt
 	}
+	if wi.ChildrenChangedAt != nil && (lastModified == nil || wi.ChildrenChangedAt.After(*lastModified)) {
+		lastModified = wi.ChildrenChangedAt
+	}
 
 	log.Debug(nil, map[string]interface{}{"wi_id": wi.ID}, "Last modified value: %v", lastModified)
 	return *lastModified
@@ -70,6 +82,13 @@ func (wi WorkItem) ChangeSet(older change.Detector) (change.Set, error) {
 				OldValue:      nil,
 			},
 		}
+		if wi.Fields[SystemArea] != nil {
+			changeSet = append(changeSet, change.Change{
+				AttributeName: SystemArea,
+				NewValue:      wi.Fields[SystemArea],
+				OldValue:      nil,
+			})
+		}
 		if wi.Fields[SystemBoardcolumns] != nil && len(wi.Fields[SystemBoardcolumns].([]interface{})) != 0 {
 			changeSet = append(changeSet, change.Change{
 				AttributeName: SystemBoardcolumns,
@@ -87,8 +106,8 @@ func (wi WorkItem) ChangeSet(older change.Detector) (change.Set, error) {
 		return nil, errs.New("Other entity has not the same ID: " + olderWorkItem.ID.String())
 	}
 	changes := []change.Change{}
-	// CAUTION: we're only supporting changes to the system.state and to the
-	// board position relationship for now. If we need to support more
+	// CAUTION: we're only supporting changes to the system.state, the
+	// system.area and to the board position relationship for now. If we need to support more
 	// attribute changes, this has to be added here. This will be likely
 	// necessary when adding new Actions.
 	// compare system.state
@@ -99,6 +118,14 @@ func (wi WorkItem) ChangeSet(older change.Detector) (change.Set, error) {
 			OldValue:      olderWorkItem.Fields[SystemState],
 		})
 	}
+	// compare system.area
+	if wi.Fields[SystemArea] != olderWorkItem.Fields[SystemArea] {
+		changes = append(changes, change.Change{
+			AttributeName: SystemArea,
+			NewValue:      wi.Fields[SystemArea],
+			OldValue:      olderWorkItem.Fields[SystemArea],
+		})
+	}
 	// compare system.boardcolumns
 	// this field looks like this:
 	// system.boardcolumns": ["43f9e838-3b4b-45e8-85eb-dd402e8324b5", "69699af8-cb28-4b90-b829-24c1aad12797"]
@@ -0,0 +1,203 @@
+package workitem
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/closeable"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/goadesign/goa"
+	"github.com/lib/pq"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// importing the link package here to get the link type is currently not
+// possible because of an import cycle
+var parentChildLinkTypeID = uuid.FromStringOrNil("25C326A7-6D03-4F5A-B23B-86A9EE4171E9")
+
+// ComputeFields evaluates all computed fields of the given work item and
+// returns their values keyed by field name.
+func (r *GormWorkItemRepository) ComputeFields(ctx context.Context, wit WorkItemType, wi WorkItem) (map[string]interface{}, error) {
+	res, err := r.ComputeFieldsList(ctx, []WorkItemType{wit}, []WorkItem{wi})
+	if err != nil {
+		return nil, err
+	}
+	if values, ok := res[wi.ID]; ok {
+		return values, nil
+	}
+	return map[string]interface{}{}, nil
+}
+
+// ComputeFieldsList evaluates the computed fields of all given work items
+// and returns their values keyed by work item ID and field name. The given
+// work item types must contain the types of all work items. Results are
+// cached until the work item or one of its children changes. The children of
+// all uncached work items whose fields aggregate over them are loaded at once.
+func (r *GormWorkItemRepository) ComputeFieldsList(ctx context.Context, wits []WorkItemType, wis []WorkItem) (map[uuid.UUID]map[string]interface{}, error) {
+	res := make(map[uuid.UUID]map[string]interface{}, len(wis))
+	computed := map[uuid.UUID]map[string]ComputedType{}
+	usesChildren := map[uuid.UUID]bool{}
+	for _, wit := range wits {
+		if _, ok := computed[wit.ID]; ok {
+			continue
+		}
+		fields := wit.ComputedFields()
+		if len(fields) == 0 {
+			continue
+		}
+		computed[wit.ID] = fields
+		for _, t := range fields {
+			expr, err := t.ParsedExpression()
+			if err != nil {
+				return nil, errs.WithStack(err)
+			}
+			if expr.UsesChildren() {
+				usesChildren[wit.ID] = true
+				break
+			}
+		}
+	}
+	if len(computed) == 0 {
+		return res, nil
+	}
+	now := time.Now()
+	uncached := make([]WorkItem, 0, len(wis))
+	parentIDs := []uuid.UUID{}
+	for _, wi := range wis {
+		if _, ok := computed[wi.Type]; !ok {
+			continue
+		}
+		if values, ok := getComputedFields(wi.ID, wi.Version, now); ok {
+			res[wi.ID] = values
+			continue
+		}
+		uncached = append(uncached, wi)
+		if usesChildren[wi.Type] {
+			parentIDs = append(parentIDs, wi.ID)
+		}
+	}
+	if len(uncached) == 0 {
+		return res, nil
+	}
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "computefields"}, time.Now())
+	children, err := r.loadChildFields(ctx, parentIDs...)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	for _, wi := range uncached {
+		fields := computed[wi.Type]
+		values := make(map[string]interface{}, len(fields))
+		for name, t := range fields {
+			v, err := t.Evaluate(wi.Fields, children[wi.ID], now)
+			if err != nil {
+				return nil, errs.Wrapf(err, `failed to evaluate computed field "%s" of work item %s`, name, wi.ID)
+			}
+			values[name] = v
+		}
+		putComputedFields(wi.ID, wi.Version, now, values)
+		res[wi.ID] = values
+	}
+	return res, nil
+}
+
+// loadChildFields returns the stored field values of all children of the
+// given work items keyed by the ID of their parent.
+func (r *GormWorkItemRepository) loadChildFields(ctx context.Context, ids ...uuid.UUID) (map[uuid.UUID][]map[string]interface{}, error) {
+	res := map[uuid.UUID][]map[string]interface{}{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+	rows, err := r.db.Model(&WorkItemStorage{}).
+		Select("work_item_links.source_id, "+Column(WorkItemStorage{}.TableName(), "fields")).
+		Joins("JOIN work_item_links ON work_item_links.target_id = "+Column(WorkItemStorage{}.TableName(), "id")).
+		Where("work_item_links.source_id IN (?) AND work_item_links.link_type_id = ? AND work_item_links.deleted_at IS NULL", ids, parentChildLinkTypeID).
+		Rows()
+	defer closeable.Close(ctx, rows)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_ids": ids,
+			"err":    err,
+		}, "unable to load the children of the work items")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	for rows.Next() {
+		var parentID uuid.UUID
+		var fields Fields
+		if err := rows.Scan(&parentID, &fields); err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		res[parentID] = append(res[parentID], fields)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return res, nil
+}
+
+// ChildrenChangedAt returns the latest time at which a child of the given
+// work item or a link to one of its children was changed, created or deleted.
+// It returns nil if the work item never had any children.
+func (r *GormWorkItemRepository) ChildrenChangedAt(ctx context.Context, id uuid.UUID) (*time.Time, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "childrenchangedat"}, time.Now())
+	var res pq.NullTime
+	err := r.db.Raw(`SELECT max(GREATEST(l.updated_at, l.deleted_at, wi.updated_at, wi.deleted_at))
+		FROM work_item_links l JOIN `+WorkItemStorage{}.TableName()+` wi ON wi.id = l.target_id
+		WHERE l.source_id = ? AND l.link_type_id = ?`, id, parentChildLinkTypeID).Row().Scan(&res)
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to load the latest change of the children of work item %s", id))
+	}
+	if !res.Valid {
+		return nil, nil
+	}
+	return &res.Time, nil
+}
+
+// ComputedFieldMap maps a field name to the computed field definitions with
+// that name keyed by the ID of the work item type that defines them.
+type ComputedFieldMap map[string]map[uuid.UUID]ComputedType
+
+// ListComputedFields returns all computed field definitions with one of the
+// given names. System fields are never computed, so no query is needed when
+// only system fields are given.
+func (r *GormWorkItemTypeRepository) ListComputedFields(ctx context.Context, names ...string) (ComputedFieldMap, error) {
+	res := ComputedFieldMap{}
+	custom := make([]string, 0, len(names))
+	for _, name := range names {
+		if !strings.HasPrefix(name, SystemFieldPrefix) {
+			custom = append(custom, name)
+		}
+	}
+	if len(custom) == 0 {
+		return res, nil
+	}
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtype", "listcomputedfields"}, time.Now())
+	rows, err := r.db.Raw(`SELECT wit.id, n.name, wit.fields->n.name->'type'
+		FROM `+WorkItemType{}.TableName()+` wit, unnest(?::text[]) n(name)
+		WHERE wit.deleted_at IS NULL
+		AND wit.fields->n.name->'type'->>'kind' = ?`, pq.Array(custom), KindComputed.String()).Rows()
+	defer closeable.Close(ctx, rows)
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	for rows.Next() {
+		var witID uuid.UUID
+		var name string
+		var rawType []byte
+		if err := rows.Scan(&witID, &name, &rawType); err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		var t ComputedType
+		if err := json.Unmarshal(rawType, &t); err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrapf(err, `failed to unmarshal computed field "%s" of work item type %s`, name, witID))
+		}
+		if _, ok := res[name]; !ok {
+			res[name] = map[uuid.UUID]ComputedType{}
+		}
+		res[name][witID] = t
+	}
+	return res, nil
+}
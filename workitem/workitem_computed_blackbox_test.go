@@ -0,0 +1,197 @@
+package workitem_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/criteria"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type workItemComputedBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo *workitem.GormWorkItemRepository
+}
+
+func TestRunWorkItemComputedBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &workItemComputedBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *workItemComputedBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.repo = workitem.NewWorkItemRepository(s.DB)
+}
+
+// createFixture creates a work item type with a stored "effort" field and a
+// computed "remaining" field that sums up the effort of all children. The
+// "parent" work item has the two children "child1" and "child2".
+func (s *workItemComputedBlackBoxTest) createFixture(t *testing.T) *tf.TestFixture {
+	return tf.NewTestFixture(t, s.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItemTypes(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemTypes[idx].Fields["effort"] = workitem.FieldDefinition{
+				Label: "Effort",
+				Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+			}
+			fxt.WorkItemTypes[idx].Fields["remaining"] = workitem.FieldDefinition{
+				Label:    "Remaining effort",
+				ReadOnly: true,
+				Type: workitem.ComputedType{
+					Kind:       workitem.KindComputed,
+					ResultKind: workitem.KindFloat,
+					Expression: "sum(children.effort) - effort",
+				},
+			}
+			return nil
+		}),
+		tf.WorkItems(3, tf.SetWorkItemTitles("parent", "child1", "child2"), func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItems[idx].Fields["effort"] = float64(idx + 1)
+			return nil
+		}),
+		tf.WorkItemLinksCustom(2, func(fxt *tf.TestFixture, idx int) error {
+			l := fxt.WorkItemLinks[idx]
+			l.LinkTypeID = link.SystemWorkItemLinkTypeParentChildID
+			l.SourceID = fxt.WorkItemByTitle("parent").ID
+			l.TargetID = fxt.WorkItems[idx+1].ID
+			return nil
+		}),
+	)
+}
+
+func (s *workItemComputedBlackBoxTest) TestComputeFields() {
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		parent := fxt.WorkItemByTitle("parent")
+		// when
+		values, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *parent)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"remaining": 4.0}, values)
+	})
+
+	s.T().Run("cached", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		parent := fxt.WorkItemByTitle("parent")
+		_, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *parent)
+		require.NoError(t, err)
+		// when the child is changed behind the repository's back
+		db := s.DB.Exec(`UPDATE work_items SET fields = jsonb_set(fields, '{effort}', '10') WHERE id = ?`, fxt.WorkItemByTitle("child1").ID)
+		require.NoError(t, db.Error)
+		// then
+		values, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *parent)
+		require.NoError(t, err)
+		assert.Equal(t, 4.0, values["remaining"])
+	})
+
+	s.T().Run("invalidated when child changes", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		parent := fxt.WorkItemByTitle("parent")
+		_, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *parent)
+		require.NoError(t, err)
+		// when
+		child := fxt.WorkItemByTitle("child1")
+		child.Fields["effort"] = 10.0
+		_, _, err = s.repo.Save(s.Ctx, child.SpaceID, *child, fxt.Identities[0].ID)
+		require.NoError(t, err)
+		// then
+		values, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *parent)
+		require.NoError(t, err)
+		assert.Equal(t, 12.0, values["remaining"])
+	})
+
+	s.T().Run("invalidated when link is deleted", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		parent := fxt.WorkItemByTitle("parent")
+		_, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *parent)
+		require.NoError(t, err)
+		// when
+		err = link.NewWorkItemLinkRepository(s.DB).Delete(s.Ctx, fxt.WorkItemLinks[0].ID, fxt.Identities[0].ID)
+		require.NoError(t, err)
+		// then
+		values, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *parent)
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, values["remaining"])
+	})
+
+	s.T().Run("list", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		other := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(1))
+		wis := []workitem.WorkItem{*fxt.WorkItemByTitle("parent"), *fxt.WorkItemByTitle("child1"), *other.WorkItems[0]}
+		// when
+		values, err := s.repo.ComputeFieldsList(s.Ctx, []workitem.WorkItemType{*fxt.WorkItemTypes[0], *other.WorkItemTypes[0]}, wis)
+		// then
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		assert.Equal(t, map[string]interface{}{"remaining": 4.0}, values[wis[0].ID])
+		assert.Equal(t, map[string]interface{}{"remaining": -2.0}, values[wis[1].ID])
+	})
+
+	s.T().Run("no computed fields", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(1))
+		values, err := s.repo.ComputeFields(s.Ctx, *fxt.WorkItemTypes[0], *fxt.WorkItems[0])
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+}
+
+func (s *workItemComputedBlackBoxTest) TestChildrenChangedAt() {
+	s.T().Run("with children", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		before, err := s.repo.ChildrenChangedAt(s.Ctx, fxt.WorkItemByTitle("parent").ID)
+		require.NoError(t, err)
+		require.NotNil(t, before)
+		// when
+		child := fxt.WorkItemByTitle("child1")
+		child.Fields["effort"] = 10.0
+		_, _, err = s.repo.Save(s.Ctx, child.SpaceID, *child, fxt.Identities[0].ID)
+		require.NoError(t, err)
+		// then
+		after, err := s.repo.ChildrenChangedAt(s.Ctx, fxt.WorkItemByTitle("parent").ID)
+		require.NoError(t, err)
+		require.NotNil(t, after)
+		assert.False(t, after.Before(*before))
+	})
+
+	s.T().Run("without children", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.CreateWorkItemEnvironment(), tf.WorkItems(1))
+		changedAt, err := s.repo.ChildrenChangedAt(s.Ctx, fxt.WorkItems[0].ID)
+		require.NoError(t, err)
+		assert.Nil(t, changedAt)
+	})
+}
+
+func (s *workItemComputedBlackBoxTest) TestFilterByComputedField() {
+	s.T().Run("equals", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		// when
+		res, count, err := s.repo.List(s.Ctx, fxt.Spaces[0].ID, criteria.Equals(criteria.Field("remaining"), criteria.Literal(4.0)), nil, nil, nil, workitem.SortWorkItemsByDefault)
+		// then
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		assert.Equal(t, fxt.WorkItemByTitle("parent").ID, res[0].ID)
+	})
+
+	s.T().Run("count", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		// when
+		count, err := s.repo.Count(s.Ctx, fxt.Spaces[0].ID, criteria.Not(criteria.Field("remaining"), criteria.Literal(4.0)))
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
@@ -0,0 +1,419 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/label"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Possible values for the operation that created an origin record.
+const (
+	OriginOperationClone = "clone"
+	OriginOperationMove  = "move"
+)
+
+// CopyOptions describes how a work item is transferred to another space when
+// it is cloned or moved.
+//
+// All mappings translate an ID from the source space into an ID of the target
+// space. Iterations and areas that are not mapped fall back to the root
+// iteration and root area of the target space. Labels that are not mapped are
+// matched by name in the target space and dropped if no such label exists. A
+// work item type that doesn't belong to the target space template is looked up
+// in TypeMapping and then by name in the target space template.
+type CopyOptions struct {
+	TargetSpaceID    uuid.UUID
+	TypeMapping      map[uuid.UUID]uuid.UUID
+	IterationMapping map[uuid.UUID]uuid.UUID
+	AreaMapping      map[uuid.UUID]uuid.UUID
+	LabelMapping     map[uuid.UUID]uuid.UUID
+}
+
+// Origin links a work item back to the work item it was cloned from or to the
+// space and number it had before it was moved.
+type Origin struct {
+	gormsupport.Lifecycle
+	ID               uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	WorkItemID       uuid.UUID `sql:"type:uuid" gorm:"column:work_item_id"`
+	SourceWorkItemID uuid.UUID `sql:"type:uuid" gorm:"column:source_work_item_id"`
+	SourceSpaceID    uuid.UUID `sql:"type:uuid" gorm:"column:source_space_id"`
+	SourceNumber     int       `gorm:"column:source_number"`
+	Operation        string    `gorm:"column:operation"`
+	CreatorID        uuid.UUID `sql:"type:uuid" gorm:"column:creator_id"`
+}
+
+// TableName implements gorm.tabler
+func (o Origin) TableName() string {
+	return "work_item_origins"
+}
+
+// Clone creates a copy of the work item with the given ID in the target space
+// specified in the options. The copy gets a new number from the target space's
+// sequence, its iteration, area and labels are remapped and its type is
+// converted if needed. The copy has to pass the same checks as a newly created
+// work item. An origin record pointing back to the source is stored alongside
+// the copy.
+// returns NotFoundError, BadParameterError or InternalError
+func (r *GormWorkItemRepository) Clone(ctx context.Context, sourceID uuid.UUID, opts CopyOptions, creatorID uuid.UUID) (*WorkItem, *Revision, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "clone"}, time.Now())
+	source, err := r.LoadFromDB(ctx, sourceID)
+	if err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to load work item to clone: %s", sourceID)
+	}
+	targetSpace, err := r.space.Load(ctx, opts.TargetSpaceID)
+	if err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to load target space: %s", opts.TargetSpaceID)
+	}
+	wi := WorkItemStorage{
+		Type:    source.Type,
+		Fields:  Fields{},
+		SpaceID: targetSpace.ID,
+	}
+	for k, v := range source.Fields {
+		wi.Fields[k] = v
+	}
+	wi.Fields[SystemCreator] = creatorID.String()
+	wiType, err := r.transferToSpace(ctx, &wi, source.SpaceID, *targetSpace, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	pos, err := r.LoadHighestOrder(ctx, targetSpace.ID)
+	if err != nil {
+		return nil, nil, errors.NewInternalError(ctx, err)
+	}
+	wi.ExecutionOrder = pos + orderValue
+	number, err := r.winr.NextVal(ctx, targetSpace.ID)
+	if err != nil {
+		return nil, nil, errors.NewInternalError(ctx, err)
+	}
+	wi.Number = *number
+	if err := r.checkTransferredFields(ctx, *wiType, targetSpace.ID, wi.ID, nil, wi.Fields); err != nil {
+		return nil, nil, err
+	}
+	if err := r.db.Create(&wi).Error; err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to create clone of work item %s", sourceID)
+	}
+	rev, err := r.wirr.Create(context.Background(), creatorID, RevisionTypeCreate, wi)
+	if err != nil {
+		return nil, nil, errs.Wrapf(err, "error while cloning work item")
+	}
+	if err := r.createOrigin(ctx, wi.ID, *source, OriginOperationClone, creatorID); err != nil {
+		return nil, nil, err
+	}
+	log.Debug(ctx, map[string]interface{}{"wi_id": wi.ID, "source_id": sourceID, "number": wi.Number}, "Work item cloned successfully!")
+	witem, err := ConvertWorkItemStorageToModel(wiType, &wi)
+	if err != nil {
+		return nil, nil, err
+	}
+	return witem, &rev, nil
+}
+
+// Move transfers the work item with the given ID to the target space
+// specified in the options. The work item keeps its ID but gets a new number
+// from the target space's sequence. Its iteration, area and labels are
+// remapped and its type is converted if needed. The moved work item has to
+// pass the same checks as an updated one. An origin record remembers the
+// space and number the work item had before.
+//
+// NOTE: links are not touched by this method. Use the link repository's
+// MoveTree to move a work item together with its links.
+// returns NotFoundError, BadParameterError or InternalError
+func (r *GormWorkItemRepository) Move(ctx context.Context, id uuid.UUID, opts CopyOptions, modifierID uuid.UUID) (*WorkItem, *Revision, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "move"}, time.Now())
+	wi, err := r.LoadFromDB(ctx, id)
+	if err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to load work item to move: %s", id)
+	}
+	if wi.SpaceID == opts.TargetSpaceID {
+		return nil, nil, errors.NewBadParameterError("target space", opts.TargetSpaceID).Expected("a space different from the current one")
+	}
+	targetSpace, err := r.space.Load(ctx, opts.TargetSpaceID)
+	if err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to load target space: %s", opts.TargetSpaceID)
+	}
+	source := *wi
+	oldState := wi.Fields[SystemState]
+	wiType, err := r.transferToSpace(ctx, wi, source.SpaceID, *targetSpace, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := r.checkTransferredFields(ctx, *wiType, targetSpace.ID, wi.ID, oldState, wi.Fields); err != nil {
+		return nil, nil, err
+	}
+	if err := wiType.CheckTransition(oldState, wi.Fields); err != nil {
+		return nil, nil, err
+	}
+	pos, err := r.LoadHighestOrder(ctx, targetSpace.ID)
+	if err != nil {
+		return nil, nil, errors.NewInternalError(ctx, err)
+	}
+	number, err := r.winr.NextVal(ctx, targetSpace.ID)
+	if err != nil {
+		return nil, nil, errors.NewInternalError(ctx, err)
+	}
+	wi.SpaceID = targetSpace.ID
+	wi.Number = *number
+	wi.ExecutionOrder = pos + orderValue
+	wi.Version = source.Version + 1
+	tx := r.db.Where("Version = ?", source.Version).Save(wi)
+	if err := tx.Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_id":           id,
+			"target_space_id": targetSpace.ID,
+			"err":             err,
+		}, "unable to move the work item")
+		return nil, nil, errors.NewInternalError(ctx, err)
+	}
+	if tx.RowsAffected == 0 {
+		return nil, nil, errors.NewVersionConflictError("version conflict")
+	}
+	rev, err := r.wirr.Create(context.Background(), modifierID, RevisionTypeUpdate, *wi)
+	if err != nil {
+		return nil, nil, errs.Wrapf(err, "error while moving work item")
+	}
+	if err := r.createOrigin(ctx, wi.ID, source, OriginOperationMove, modifierID); err != nil {
+		return nil, nil, err
+	}
+	log.Info(ctx, map[string]interface{}{
+		"wi_id":           id,
+		"source_space_id": source.SpaceID,
+		"target_space_id": targetSpace.ID,
+	}, "Moved work item")
+	witem, err := ConvertWorkItemStorageToModel(wiType, wi)
+	if err != nil {
+		return nil, nil, errs.WithStack(err)
+	}
+	return witem, &rev, nil
+}
+
+// ListOrigins returns the origin records of the given work item, oldest first.
+func (r *GormWorkItemRepository) ListOrigins(ctx context.Context, id uuid.UUID) ([]Origin, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "origins"}, time.Now())
+	var origins []Origin
+	if err := r.db.Where("work_item_id = ?", id).Order("created_at asc").Find(&origins).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to list origins of work item %s", id))
+	}
+	return origins, nil
+}
+
+func (r *GormWorkItemRepository) createOrigin(ctx context.Context, workItemID uuid.UUID, source WorkItemStorage, operation string, creatorID uuid.UUID) error {
+	origin := Origin{
+		WorkItemID:       workItemID,
+		SourceWorkItemID: source.ID,
+		SourceSpaceID:    source.SpaceID,
+		SourceNumber:     source.Number,
+		Operation:        operation,
+		CreatorID:        creatorID,
+	}
+	if err := r.db.Create(&origin).Error; err != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(err, "failed to record %s origin of work item %s", operation, workItemID))
+	}
+	return nil
+}
+
+// checkTransferredFields runs the checks of Create and Save on the fields of a
+// work item that is cloned or moved into the given space: the field rules of
+// its type, the states of the board columns it is in and the WIP limits of
+// these columns. All of its columns count as new in the target space. The old
+// state is nil for clones.
+func (r *GormWorkItemRepository) checkTransferredFields(ctx context.Context, wiType WorkItemType, spaceID, workItemID uuid.UUID, oldState interface{}, fields Fields) error {
+	if err := wiType.ValidateFieldValues(fields); err != nil {
+		return err
+	}
+	if err := syncStateAndBoardColumns(ctx, r.db, spaceID, wiType, oldState, nil, fields); err != nil {
+		return err
+	}
+	if columns := addedBoardColumns(nil, fields[SystemBoardcolumns]); len(columns) > 0 {
+		if err := checkWIPLimits(ctx, r.db, spaceID, workItemID, columns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferToSpace rewrites the type and fields of the given work item so that
+// they are valid in the target space. The work item type to use with the
+// rewritten work item is returned.
+func (r *GormWorkItemRepository) transferToSpace(ctx context.Context, wi *WorkItemStorage, sourceSpaceID uuid.UUID, targetSpace space.Space, opts CopyOptions) (*WorkItemType, error) {
+	oldType, err := r.witr.Load(ctx, wi.Type)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to load work item type %s", wi.Type)
+	}
+	newType, err := r.resolveTargetType(ctx, *oldType, targetSpace, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.remapSpaceFields(ctx, wi.Fields, sourceSpaceID, targetSpace.ID, opts); err != nil {
+		return nil, err
+	}
+	if oldType.SpaceTemplateID != newType.SpaceTemplateID {
+		// board columns are defined per space template
+		delete(wi.Fields, SystemBoardcolumns)
+	}
+	if oldType.ID != newType.ID {
+		if err := r.ChangeWorkItemType(ctx, wi, oldType, newType, targetSpace.ID); err != nil {
+			return nil, errs.Wrapf(err, "unable to change workitem type from %s (ID: %s) to %s (ID: %s)", oldType.Name, oldType.ID, newType.Name, newType.ID)
+		}
+	}
+	return newType, nil
+}
+
+// resolveTargetType returns the work item type that an item of the given type
+// gets in the target space.
+func (r *GormWorkItemRepository) resolveTargetType(ctx context.Context, wit WorkItemType, targetSpace space.Space, opts CopyOptions) (*WorkItemType, error) {
+	if mappedID, ok := opts.TypeMapping[wit.ID]; ok {
+		newType, err := r.witr.Load(ctx, mappedID)
+		if err != nil {
+			return nil, errors.NewBadParameterError("type mapping", mappedID)
+		}
+		if newType.SpaceTemplateID != targetSpace.SpaceTemplateID {
+			return nil, errors.NewBadParameterError("type mapping", mappedID).Expected(fmt.Sprintf("a work item type of space template %s", targetSpace.SpaceTemplateID))
+		}
+		return newType, nil
+	}
+	if wit.SpaceTemplateID == targetSpace.SpaceTemplateID {
+		return &wit, nil
+	}
+	types, err := r.witr.List(ctx, targetSpace.SpaceTemplateID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list work item types of space template %s", targetSpace.SpaceTemplateID)
+	}
+	for _, t := range types {
+		if t.Name == wit.Name && t.CanConstruct {
+			return &t, nil
+		}
+	}
+	return nil, errors.NewBadParameterErrorFromString(
+		fmt.Sprintf("work item type %q (ID: %s) has no counterpart in the space template of space %s, please provide a type mapping", wit.Name, wit.ID, targetSpace.ID),
+	)
+}
+
+// remapSpaceFields replaces all space specific references (iteration, area,
+// labels and codebase) in the given fields with references that are valid in
+// the target space.
+func (r *GormWorkItemRepository) remapSpaceFields(ctx context.Context, fields Fields, sourceSpaceID, targetSpaceID uuid.UUID, opts CopyOptions) error {
+	if sourceSpaceID == targetSpaceID {
+		return nil
+	}
+	// iteration
+	if _, ok := fields[SystemIteration]; ok {
+		newID, err := r.remapIteration(ctx, fields[SystemIteration], targetSpaceID, opts.IterationMapping)
+		if err != nil {
+			return err
+		}
+		fields[SystemIteration] = newID.String()
+	}
+	// area
+	if _, ok := fields[SystemArea]; ok {
+		newID, err := r.remapArea(ctx, fields[SystemArea], targetSpaceID, opts.AreaMapping)
+		if err != nil {
+			return err
+		}
+		fields[SystemArea] = newID.String()
+	}
+	// labels
+	if val, ok := fields[SystemLabels]; ok {
+		labels, err := r.remapLabels(ctx, val, targetSpaceID, opts.LabelMapping)
+		if err != nil {
+			return err
+		}
+		if len(labels) == 0 {
+			delete(fields, SystemLabels)
+		} else {
+			fields[SystemLabels] = labels
+		}
+	}
+	// codebases belong to a single space
+	delete(fields, SystemCodebase)
+	return nil
+}
+
+func (r *GormWorkItemRepository) remapIteration(ctx context.Context, val interface{}, targetSpaceID uuid.UUID, mapping map[uuid.UUID]uuid.UUID) (uuid.UUID, error) {
+	if newID, ok := mapping[uuid.FromStringOrNil(fmt.Sprint(val))]; ok {
+		itr, err := iteration.NewIterationRepository(r.db).Load(ctx, newID)
+		if err != nil || itr.SpaceID != targetSpaceID {
+			return uuid.Nil, errors.NewBadParameterError("iteration mapping", newID).Expected(fmt.Sprintf("an iteration of space %s", targetSpaceID))
+		}
+		return newID, nil
+	}
+	root, err := iteration.NewIterationRepository(r.db).Root(ctx, targetSpaceID)
+	if err != nil {
+		return uuid.Nil, errs.Wrapf(err, "failed to load root iteration of space %s", targetSpaceID)
+	}
+	return root.ID, nil
+}
+
+func (r *GormWorkItemRepository) remapArea(ctx context.Context, val interface{}, targetSpaceID uuid.UUID, mapping map[uuid.UUID]uuid.UUID) (uuid.UUID, error) {
+	if newID, ok := mapping[uuid.FromStringOrNil(fmt.Sprint(val))]; ok {
+		a, err := area.NewAreaRepository(r.db).Load(ctx, newID)
+		if err != nil || a.SpaceID != targetSpaceID {
+			return uuid.Nil, errors.NewBadParameterError("area mapping", newID).Expected(fmt.Sprintf("an area of space %s", targetSpaceID))
+		}
+		return newID, nil
+	}
+	root, err := area.NewAreaRepository(r.db).Root(ctx, targetSpaceID)
+	if err != nil {
+		return uuid.Nil, errs.Wrapf(err, "failed to load root area of space %s", targetSpaceID)
+	}
+	return root.ID, nil
+}
+
+func (r *GormWorkItemRepository) remapLabels(ctx context.Context, val interface{}, targetSpaceID uuid.UUID, mapping map[uuid.UUID]uuid.UUID) ([]interface{}, error) {
+	labelRepo := label.NewLabelRepository(r.db)
+	targetLabels, err := labelRepo.List(ctx, targetSpaceID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to list labels of space %s", targetSpaceID)
+	}
+	targetByID := map[uuid.UUID]label.Label{}
+	targetByName := map[string]uuid.UUID{}
+	for _, l := range targetLabels {
+		targetByID[l.ID] = l
+		targetByName[l.Name] = l.ID
+	}
+	var values []interface{}
+	switch v := val.(type) {
+	case []interface{}:
+		values = v
+	case []string:
+		for _, s := range v {
+			values = append(values, s)
+		}
+	}
+	result := []interface{}{}
+	seen := map[uuid.UUID]struct{}{}
+	for _, v := range values {
+		oldID := uuid.FromStringOrNil(fmt.Sprint(v))
+		newID, ok := mapping[oldID]
+		if ok {
+			if _, exists := targetByID[newID]; !exists {
+				return nil, errors.NewBadParameterError("label mapping", newID).Expected(fmt.Sprintf("a label of space %s", targetSpaceID))
+			}
+		} else {
+			oldLabel, err := labelRepo.Load(ctx, oldID)
+			if err != nil {
+				log.Info(ctx, map[string]interface{}{"label_id": oldID, "err": err}, "dropping unknown label")
+				continue
+			}
+			if newID, ok = targetByName[oldLabel.Name]; !ok {
+				continue
+			}
+		}
+		if _, dup := seen[newID]; dup {
+			continue
+		}
+		seen[newID] = struct{}{}
+		result = append(result, newID.String())
+	}
+	return result, nil
+}
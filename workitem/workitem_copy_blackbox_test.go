@@ -0,0 +1,208 @@
+package workitem_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type workItemCopyBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo *workitem.GormWorkItemRepository
+}
+
+func TestRunWorkItemCopyBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &workItemCopyBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *workItemCopyBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.repo = workitem.NewWorkItemRepository(s.DB)
+}
+
+// createFixture creates two spaces with a root iteration, a root area and a
+// child iteration each. Both spaces have a label named "foo" and the first
+// space has an additional label "bar". The single work item lives in the first
+// space and references the child iteration and both labels.
+func (s *workItemCopyBlackBoxTest) createFixture(t *testing.T) *tf.TestFixture {
+	return tf.NewTestFixture(t, s.DB,
+		tf.Spaces(2),
+		tf.Iterations(4, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Iterations[idx].SpaceID = fxt.Spaces[idx%2].ID
+			if idx > 1 {
+				fxt.Iterations[idx].MakeChildOf(*fxt.Iterations[idx-2])
+			}
+			return nil
+		}),
+		tf.Areas(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Areas[idx].SpaceID = fxt.Spaces[idx].ID
+			return nil
+		}),
+		tf.Labels(3, tf.SetLabelNames("foo", "bar", "foo"), func(fxt *tf.TestFixture, idx int) error {
+			if idx == 2 {
+				fxt.Labels[idx].SpaceID = fxt.Spaces[1].ID
+			}
+			return nil
+		}),
+		tf.WorkItems(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.Iterations[2].ID.String()
+			fxt.WorkItems[idx].Fields[workitem.SystemArea] = fxt.Areas[0].ID.String()
+			fxt.WorkItems[idx].Fields[workitem.SystemLabels] = []string{fxt.Labels[0].ID.String(), fxt.Labels[1].ID.String()}
+			return nil
+		}),
+	)
+}
+
+func (s *workItemCopyBlackBoxTest) TestClone() {
+	s.T().Run("ok - remaps iteration, area and labels", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		source := fxt.WorkItems[0]
+		// when
+		clone, rev, err := s.repo.Clone(s.Ctx, source.ID, workitem.CopyOptions{TargetSpaceID: fxt.Spaces[1].ID}, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, rev)
+		assert.NotEqual(t, source.ID, clone.ID)
+		assert.Equal(t, fxt.Spaces[1].ID, clone.SpaceID)
+		assert.Equal(t, 1, clone.Number)
+		assert.Equal(t, source.Fields[workitem.SystemTitle], clone.Fields[workitem.SystemTitle])
+		assert.Equal(t, fxt.Iterations[1].ID.String(), clone.Fields[workitem.SystemIteration])
+		assert.Equal(t, fxt.Areas[1].ID.String(), clone.Fields[workitem.SystemArea])
+		assert.Equal(t, []interface{}{fxt.Labels[2].ID.String()}, clone.Fields[workitem.SystemLabels])
+		// the source is left untouched
+		loaded, err := s.repo.LoadByID(s.Ctx, source.ID)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.Spaces[0].ID, loaded.SpaceID)
+		// the origin points back to the source
+		origins, err := s.repo.ListOrigins(s.Ctx, clone.ID)
+		require.NoError(t, err)
+		require.Len(t, origins, 1)
+		assert.Equal(t, source.ID, origins[0].SourceWorkItemID)
+		assert.Equal(t, fxt.Spaces[0].ID, origins[0].SourceSpaceID)
+		assert.Equal(t, source.Number, origins[0].SourceNumber)
+		assert.Equal(t, workitem.OriginOperationClone, origins[0].Operation)
+	})
+
+	s.T().Run("ok - uses explicit mappings", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		opts := workitem.CopyOptions{
+			TargetSpaceID:    fxt.Spaces[1].ID,
+			IterationMapping: map[uuid.UUID]uuid.UUID{fxt.Iterations[2].ID: fxt.Iterations[3].ID},
+			LabelMapping:     map[uuid.UUID]uuid.UUID{fxt.Labels[1].ID: fxt.Labels[2].ID},
+		}
+		// when
+		clone, _, err := s.repo.Clone(s.Ctx, fxt.WorkItems[0].ID, opts, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, fxt.Iterations[3].ID.String(), clone.Fields[workitem.SystemIteration])
+		assert.Equal(t, []interface{}{fxt.Labels[2].ID.String()}, clone.Fields[workitem.SystemLabels])
+	})
+
+	s.T().Run("fail - mapped iteration from another space", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		opts := workitem.CopyOptions{
+			TargetSpaceID:    fxt.Spaces[1].ID,
+			IterationMapping: map[uuid.UUID]uuid.UUID{fxt.Iterations[2].ID: fxt.Iterations[0].ID},
+		}
+		// when
+		_, _, err := s.repo.Clone(s.Ctx, fxt.WorkItems[0].ID, opts, fxt.Identities[0].ID)
+		// then
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("fail - unknown work item", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		_, _, err := s.repo.Clone(s.Ctx, uuid.NewV4(), workitem.CopyOptions{TargetSpaceID: fxt.Spaces[0].ID}, fxt.Identities[0].ID)
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
+func (s *workItemCopyBlackBoxTest) TestMove() {
+	s.T().Run("ok - keeps ID and gets new number", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		source := fxt.WorkItems[0]
+		// when
+		moved, rev, err := s.repo.Move(s.Ctx, source.ID, workitem.CopyOptions{TargetSpaceID: fxt.Spaces[1].ID}, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, rev)
+		assert.Equal(t, source.ID, moved.ID)
+		assert.Equal(t, fxt.Spaces[1].ID, moved.SpaceID)
+		assert.Equal(t, 1, moved.Number)
+		assert.Equal(t, source.Version+1, moved.Version)
+		assert.Equal(t, fxt.Iterations[1].ID.String(), moved.Fields[workitem.SystemIteration])
+		_, err = s.repo.Load(s.Ctx, fxt.Spaces[0].ID, source.Number)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+		origins, err := s.repo.ListOrigins(s.Ctx, source.ID)
+		require.NoError(t, err)
+		require.Len(t, origins, 1)
+		assert.Equal(t, fxt.Spaces[0].ID, origins[0].SourceSpaceID)
+		assert.Equal(t, source.Number, origins[0].SourceNumber)
+		assert.Equal(t, workitem.OriginOperationMove, origins[0].Operation)
+	})
+
+	s.T().Run("fail - same space", func(t *testing.T) {
+		fxt := s.createFixture(t)
+		_, _, err := s.repo.Move(s.Ctx, fxt.WorkItems[0].ID, workitem.CopyOptions{TargetSpaceID: fxt.Spaces[0].ID}, fxt.Identities[0].ID)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func (s *workItemCopyBlackBoxTest) TestWIPLimits() {
+	// given a board column that holds at most one work item per space and is
+	// full in the second space
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Spaces(2),
+		tf.Iterations(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Iterations[idx].SpaceID = fxt.Spaces[idx].ID
+			return nil
+		}),
+		tf.Areas(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.Areas[idx].SpaceID = fxt.Spaces[idx].ID
+			return nil
+		}),
+		tf.WorkItemBoards(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemBoards[idx].Columns[0].WIPLimit = ptr.Int(1)
+			fxt.WorkItemBoards[idx].Columns[0].WIPLimitMode = workitem.WIPLimitBlock
+			return nil
+		}),
+		tf.WorkItems(2, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItems[idx].SpaceID = fxt.Spaces[idx].ID
+			fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.Iterations[idx].ID.String()
+			fxt.WorkItems[idx].Fields[workitem.SystemArea] = fxt.Areas[idx].ID.String()
+			fxt.WorkItems[idx].Fields[workitem.SystemBoardcolumns] = []interface{}{fxt.WorkItemBoards[0].Columns[0].ID.String()}
+			return nil
+		}),
+	)
+	opts := workitem.CopyOptions{TargetSpaceID: fxt.Spaces[1].ID}
+
+	s.T().Run("clone", func(t *testing.T) {
+		_, _, err := s.repo.Clone(s.Ctx, fxt.WorkItems[0].ID, opts, fxt.Identities[0].ID)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("move", func(t *testing.T) {
+		_, _, err := s.repo.Move(s.Ctx, fxt.WorkItems[0].ID, opts, fxt.Identities[0].ID)
+		require.Error(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
@@ -0,0 +1,143 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/closeable"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/label"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// LabelUsage holds the number of work items a label is attached to.
+type LabelUsage struct {
+	// Count is the number of work items the label itself is attached to
+	Count int
+	// TotalCount is the number of work items the label or any of its
+	// descendant labels is attached to
+	TotalCount int
+}
+
+// ReplaceLabels removes the labels with the given IDs from all work items of
+// the given space and attaches the given replacement label instead, unless it
+// is nil. A revision attributed to the given modifier is recorded for every
+// updated work item. It returns these revisions so that the caller can notify
+// about the updated work items once the transaction is committed.
+func (r *GormWorkItemRepository) ReplaceLabels(ctx context.Context, spaceID uuid.UUID, labelIDs []uuid.UUID, replacementID *uuid.UUID, modifierID uuid.UUID) ([]Revision, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "replacelabels"}, time.Now())
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+	replaced := map[string]struct{}{}
+	conditions := make([]string, 0, len(labelIDs))
+	args := make([]interface{}, 0, len(labelIDs))
+	for _, id := range labelIDs {
+		replaced[id.String()] = struct{}{}
+		conditions = append(conditions, fmt.Sprintf("fields->'%s' @> jsonb_build_array(?::text)", SystemLabels))
+		args = append(args, id.String())
+	}
+	var items []WorkItemStorage
+	db := r.db.Where("space_id = ?", spaceID).Where("("+strings.Join(conditions, " OR ")+")", args...).Order("id").Find(&items)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to load the work items with labels %v", labelIDs))
+	}
+	revisions := make([]Revision, 0, len(items))
+	for i := range items {
+		wi := &items[i]
+		wi.Fields[SystemLabels] = replaceLabelIDs(wi.Fields[SystemLabels], replaced, replacementID)
+		if labels, ok := wi.Fields[SystemLabels].([]interface{}); ok && len(labels) == 0 {
+			delete(wi.Fields, SystemLabels)
+		}
+		oldVersion := wi.Version
+		wi.Version++
+		tx := r.db.Where("version = ?", oldVersion).Save(wi)
+		if err := tx.Error; err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to update the labels of work item %s", wi.ID))
+		}
+		if tx.RowsAffected == 0 {
+			return nil, errors.NewVersionConflictError("version conflict")
+		}
+		rev, err := r.wirr.Create(ctx, modifierID, RevisionTypeUpdate, *wi)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to record revision of work item %s", wi.ID)
+		}
+		if err := r.invalidateComputedFieldsOfParents(ctx, wi.ID); err != nil {
+			return nil, errs.Wrapf(err, "failed to invalidate computed fields")
+		}
+		revisions = append(revisions, rev)
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_id":   spaceID,
+		"label_ids":  labelIDs,
+		"work_items": len(items),
+	}, "replaced labels of work items")
+	return revisions, nil
+}
+
+// replaceLabelIDs returns the given list of label IDs without the replaced
+// ones. The replacement takes the position of the first replaced label unless
+// it is already in the list.
+func replaceLabelIDs(val interface{}, replaced map[string]struct{}, replacementID *uuid.UUID) []interface{} {
+	labels, _ := val.([]interface{})
+	res := make([]interface{}, 0, len(labels))
+	seen := map[string]struct{}{}
+	for _, l := range labels {
+		id := fmt.Sprint(l)
+		if _, ok := replaced[id]; ok {
+			if replacementID == nil {
+				continue
+			}
+			id = replacementID.String()
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+	return res
+}
+
+// LabelUsage returns how many work items of the given space each label of
+// the space is attached to.
+func (r *GormWorkItemRepository) LabelUsage(ctx context.Context, spaceID uuid.UUID) (map[uuid.UUID]LabelUsage, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "labelusage"}, time.Now())
+	query := fmt.Sprintf(`
+		WITH RECURSIVE tree(root, id) AS (
+			SELECT id, id FROM %[1]s WHERE space_id = $1 AND deleted_at IS NULL
+			UNION
+			SELECT t.root, l.id FROM %[1]s l JOIN tree t ON l.parent_id = t.id
+			WHERE l.deleted_at IS NULL
+		)
+		SELECT t.root,
+			count(DISTINCT wi.id) FILTER (WHERE t.id = t.root),
+			count(DISTINCT wi.id)
+		FROM tree t
+		LEFT JOIN %[2]s wi ON wi.space_id = $1 AND wi.deleted_at IS NULL
+			AND wi.fields->'%[3]s' @> jsonb_build_array(t.id::text)
+		GROUP BY t.root`, label.LabelTableName, WorkItemStorage{}.TableName(), SystemLabels)
+	rows, err := r.db.Raw(query, spaceID).Rows()
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to count the label usage in space %s", spaceID))
+	}
+	defer closeable.Close(ctx, rows)
+	res := map[uuid.UUID]LabelUsage{}
+	for rows.Next() {
+		var id uuid.UUID
+		var usage LabelUsage
+		if err := rows.Scan(&id, &usage.Count, &usage.TotalCount); err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		res[id] = usage
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to count the label usage in space %s", spaceID))
+	}
+	return res, nil
+}
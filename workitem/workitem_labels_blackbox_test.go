@@ -0,0 +1,132 @@
+package workitem_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/label"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type workItemLabelsBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo *workitem.GormWorkItemRepository
+}
+
+func TestRunWorkItemLabelsBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &workItemLabelsBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *workItemLabelsBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.repo = workitem.NewWorkItemRepository(s.DB)
+}
+
+// createFixture creates the labels "bug", "Bug" and "bugs" and three work
+// items labeled with "bug" and "Bug", "bugs" and nothing at all.
+func (s *workItemLabelsBlackBoxTest) createFixture(t *testing.T, fns ...tf.CustomizeLabelFunc) *tf.TestFixture {
+	return tf.NewTestFixture(t, s.DB,
+		tf.Labels(3, append([]tf.CustomizeLabelFunc{tf.SetLabelNames("bug", "Bug", "bugs")}, fns...)...),
+		tf.WorkItems(3, tf.SetWorkItemTitles("first", "second", "third"), func(fxt *tf.TestFixture, idx int) error {
+			switch idx {
+			case 0:
+				fxt.WorkItems[idx].Fields[workitem.SystemLabels] = []string{fxt.Labels[0].ID.String(), fxt.Labels[1].ID.String()}
+			case 1:
+				fxt.WorkItems[idx].Fields[workitem.SystemLabels] = []string{fxt.Labels[2].ID.String()}
+			}
+			return nil
+		}),
+	)
+}
+
+func (s *workItemLabelsBlackBoxTest) TestReplaceLabels() {
+	s.T().Run("merge", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		bug, bugs := fxt.LabelByName("bug").ID, fxt.LabelByName("bugs").ID
+		// when
+		updated, err := s.repo.ReplaceLabels(s.Ctx, fxt.Spaces[0].ID, []uuid.UUID{fxt.LabelByName("Bug").ID, bugs}, &bug, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		assert.Len(t, updated, 2)
+		first, err := s.repo.LoadByID(s.Ctx, fxt.WorkItemByTitle("first").ID)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{bug.String()}, first.Fields[workitem.SystemLabels])
+		assert.Equal(t, fxt.WorkItemByTitle("first").Version+1, first.Version)
+		second, err := s.repo.LoadByID(s.Ctx, fxt.WorkItemByTitle("second").ID)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{bug.String()}, second.Fields[workitem.SystemLabels])
+		third, err := s.repo.LoadByID(s.Ctx, fxt.WorkItemByTitle("third").ID)
+		require.NoError(t, err)
+		assert.Equal(t, fxt.WorkItemByTitle("third").Version, third.Version)
+		// a revision was recorded
+		revisions, err := workitem.NewRevisionRepository(s.DB).List(s.Ctx, second.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, revisions)
+		last := revisions[len(revisions)-1]
+		assert.Equal(t, workitem.RevisionTypeUpdate, last.Type)
+		assert.Equal(t, fxt.Identities[0].ID, last.ModifierIdentity)
+	})
+
+	s.T().Run("remove", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		// when
+		updated, err := s.repo.ReplaceLabels(s.Ctx, fxt.Spaces[0].ID, []uuid.UUID{fxt.LabelByName("bugs").ID}, nil, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		assert.Len(t, updated, 1)
+		second, err := s.repo.LoadByID(s.Ctx, fxt.WorkItemByTitle("second").ID)
+		require.NoError(t, err)
+		assert.Empty(t, second.Fields[workitem.SystemLabels])
+		first, err := s.repo.LoadByID(s.Ctx, fxt.WorkItemByTitle("first").ID)
+		require.NoError(t, err)
+		assert.Len(t, first.Fields[workitem.SystemLabels], 2)
+	})
+
+	s.T().Run("other space is untouched", func(t *testing.T) {
+		// given
+		fxt := s.createFixture(t)
+		other := tf.NewTestFixture(t, s.DB, tf.Spaces(1))
+		// when
+		updated, err := s.repo.ReplaceLabels(s.Ctx, other.Spaces[0].ID, []uuid.UUID{fxt.LabelByName("bugs").ID}, nil, fxt.Identities[0].ID)
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, updated)
+	})
+}
+
+func (s *workItemLabelsBlackBoxTest) TestLabelUsage() {
+	// given "bug" as the parent of "Bug" and "bugs"
+	fxt := s.createFixture(s.T(), func(fxt *tf.TestFixture, idx int) error {
+		if idx > 0 {
+			fxt.Labels[idx].ParentID = &fxt.Labels[0].ID
+		}
+		return nil
+	})
+	// labels of other spaces are not counted
+	tf.NewTestFixture(s.T(), s.DB, tf.Labels(1))
+	// when
+	usage, err := s.repo.LabelUsage(s.Ctx, fxt.Spaces[0].ID)
+	// then
+	require.NoError(s.T(), err)
+	require.Len(s.T(), usage, 3)
+	assert.Equal(s.T(), workitem.LabelUsage{Count: 1, TotalCount: 2}, usage[fxt.LabelByName("bug").ID])
+	assert.Equal(s.T(), workitem.LabelUsage{Count: 1, TotalCount: 1}, usage[fxt.LabelByName("Bug").ID])
+	assert.Equal(s.T(), workitem.LabelUsage{Count: 1, TotalCount: 1}, usage[fxt.LabelByName("bugs").ID])
+
+	s.T().Run("deleted labels are not counted", func(t *testing.T) {
+		require.NoError(t, label.NewLabelRepository(s.DB).Delete(s.Ctx, fxt.LabelByName("bugs").ID))
+		usage, err := s.repo.LabelUsage(s.Ctx, fxt.Spaces[0].ID)
+		require.NoError(t, err)
+		require.Len(t, usage, 2)
+		assert.Equal(t, workitem.LabelUsage{Count: 1, TotalCount: 1}, usage[fxt.LabelByName("bug").ID])
+	})
+}
@@ -104,6 +104,16 @@ type WorkItemRepository interface {
 	GetCountsForIteration(ctx context.Context, itr *iteration.Iteration) (map[string]WICountsPerIteration, error)
 	Count(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression) (int, error)
 	ChangeWorkItemType(ctx context.Context, wiStorage *WorkItemStorage, oldWIType *WorkItemType, newWIType *WorkItemType, spaceID uuid.UUID) error
+	Clone(ctx context.Context, sourceID uuid.UUID, opts CopyOptions, creatorID uuid.UUID) (*WorkItem, *Revision, error)
+	Move(ctx context.Context, id uuid.UUID, opts CopyOptions, modifierID uuid.UUID) (*WorkItem, *Revision, error)
+	ListOrigins(ctx context.Context, id uuid.UUID) ([]Origin, error)
+	ComputeFields(ctx context.Context, wit WorkItemType, wi WorkItem) (map[string]interface{}, error)
+	ComputeFieldsList(ctx context.Context, wits []WorkItemType, wis []WorkItem) (map[uuid.UUID]map[string]interface{}, error)
+	ChildrenChangedAt(ctx context.Context, id uuid.UUID) (*time.Time, error)
+	Velocity(ctx context.Context, iterations []iteration.Iteration, field string) (*Velocity, error)
+	PlanIteration(ctx context.Context, itr iteration.Iteration, velocity Velocity) (*IterationPlan, error)
+	ReplaceLabels(ctx context.Context, spaceID uuid.UUID, labelIDs []uuid.UUID, replacementID *uuid.UUID, modifierID uuid.UUID) ([]Revision, error)
+	LabelUsage(ctx context.Context, spaceID uuid.UUID) (map[uuid.UUID]LabelUsage, error)
 }
 
 // NewWorkItemRepository creates a GormWorkItemRepository
@@ -364,6 +374,10 @@ func (r *GormWorkItemRepository) Delete(ctx context.Context, workitemID uuid.UUI
 	workItem.ID = workitemID
 	// retrieve the current version of the work item to delete
 	r.db.Select("id, version, type").Where("id = ?", workitemID).Find(&workItem)
+	// the computed fields of the parents depend on this work item
+	if err := r.invalidateComputedFieldsOfParents(ctx, workitemID); err != nil {
+		return errs.Wrapf(err, "failed to invalidate computed fields")
+	}
 	// delete the work item
 	tx := r.db.Delete(workItem)
 	if err := tx.Error; err != nil {
@@ -588,6 +602,8 @@ func (r *GormWorkItemRepository) Save(ctx context.Context, spaceID uuid.UUID, up
 		return nil, nil, errors.NewVersionConflictError("version conflict")
 	}
 	wiStorage.Version = wiStorage.Version + 1
+	oldState := wiStorage.Fields[SystemState]
+	oldColumns := wiStorage.Fields[SystemBoardcolumns]
 	wiStorage.Fields = Fields{}
 	for fieldName, fieldDef := range wiType.Fields {
 		if fieldDef.ReadOnly {
@@ -626,6 +642,20 @@ func (r *GormWorkItemRepository) Save(ctx context.Context, spaceID uuid.UUID, up
 		// This will be used by the ConvertWorkItemStorageToModel function
 		wiType = newWiType
 	}
+	if err := wiType.ValidateFieldValues(wiStorage.Fields); err != nil {
+		return nil, nil, err
+	}
+	if err := syncStateAndBoardColumns(ctx, r.db, spaceID, *wiType, oldState, oldColumns, wiStorage.Fields); err != nil {
+		return nil, nil, err
+	}
+	if err := wiType.CheckTransition(oldState, wiStorage.Fields); err != nil {
+		return nil, nil, err
+	}
+	if added := addedBoardColumns(oldColumns, wiStorage.Fields[SystemBoardcolumns]); len(added) > 0 {
+		if err := checkWIPLimits(ctx, r.db, spaceID, wiStorage.ID, added); err != nil {
+			return nil, nil, err
+		}
+	}
 	tx := r.db.Where("Version = ?", updatedWorkItem.Version).Save(&wiStorage)
 	if err := tx.Error; err != nil {
 		log.Error(ctx, map[string]interface{}{
@@ -644,6 +674,9 @@ func (r *GormWorkItemRepository) Save(ctx context.Context, spaceID uuid.UUID, up
 	if err != nil {
 		return nil, nil, errs.Wrapf(err, "error while saving work item")
 	}
+	if err := r.invalidateComputedFieldsOfParents(ctx, wiStorage.ID); err != nil {
+		return nil, nil, errs.Wrapf(err, "failed to invalidate computed fields")
+	}
 	log.Info(ctx, map[string]interface{}{
 		"wi_id":    updatedWorkItem.ID,
 		"space_id": spaceID,
@@ -745,6 +778,17 @@ func (r *GormWorkItemRepository) Create(ctx context.Context, spaceID uuid.UUID,
 			}
 		}
 	}
+	if err := syncStateAndBoardColumns(ctx, r.db, spaceID, *wiType, nil, nil, wi.Fields); err != nil {
+		return nil, nil, err
+	}
+	if err := wiType.ValidateFieldValues(wi.Fields); err != nil {
+		return nil, nil, err
+	}
+	if columns := addedBoardColumns(nil, wi.Fields[SystemBoardcolumns]); len(columns) > 0 {
+		if err := checkWIPLimits(ctx, r.db, spaceID, wi.ID, columns); err != nil {
+			return nil, nil, err
+		}
+	}
 	if err := r.db.Create(&wi).Error; err != nil {
 		return nil, nil, errs.Wrapf(err, "failed to create work item")
 	}
@@ -787,7 +831,11 @@ func ConvertWorkItemStorageToModel(wiType *WorkItemType, wi *WorkItemStorage) (*
 // extracted this function from List() in order to close the rows object with "defer" for more readability
 // workaround for https://github.com/lib/pq/issues/81
 func (r *GormWorkItemRepository) listItemsFromDB(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression, parentExists *bool, start *int, limit *int, sort SortWorkItemsBy) ([]WorkItemStorage, int, error) {
-	where, parameters, joins, compileErrors := Compile(criteria)
+	computed, err := r.witr.ListComputedFields(ctx, FieldNames(criteria)...)
+	if err != nil {
+		return nil, 0, errs.Wrap(err, "failed to load computed fields")
+	}
+	where, parameters, joins, compileErrors := CompileWithComputedFields(criteria, computed)
 	if compileErrors != nil {
 		log.Error(ctx, map[string]interface{}{"compile_errors": compileErrors, "expression": criteria}, "failed to compile expression")
 		return nil, 0, errors.NewBadParameterError("expression", criteria)
@@ -908,7 +956,11 @@ func (r *GormWorkItemRepository) List(ctx context.Context, spaceID uuid.UUID, cr
 func (r *GormWorkItemRepository) Count(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression) (int, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "workitem", "count"}, time.Now())
 
-	where, parameters, joins, compileError := Compile(criteria)
+	computed, err := r.witr.ListComputedFields(ctx, FieldNames(criteria)...)
+	if err != nil {
+		return 0, errs.Wrap(err, "failed to load computed fields")
+	}
+	where, parameters, joins, compileError := CompileWithComputedFields(criteria, computed)
 	if compileError != nil {
 		return 0, errors.NewBadParameterError("expression", criteria)
 	}
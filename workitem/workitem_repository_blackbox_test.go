@@ -216,6 +216,55 @@ func (s *workItemRepoBlackBoxTest) TestSave() {
 			})
 		}
 	})
+
+	s.T().Run("state transitions", func(t *testing.T) {
+		// given
+		fxt := tf.NewTestFixture(t, s.DB,
+			tf.CreateWorkItemEnvironment(),
+			tf.WorkItemTypes(1, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItemTypes[idx].Transitions = workitem.Transitions{
+					{From: workitem.SystemStateNew, To: workitem.SystemStateInProgress},
+					{From: workitem.SystemStateInProgress, To: workitem.SystemStateClosed, RequiredFields: []string{workitem.SystemDescription}},
+				}
+				return nil
+			}),
+			tf.WorkItems(1, func(fxt *tf.TestFixture, idx int) error {
+				fxt.WorkItems[idx].Fields[workitem.SystemState] = workitem.SystemStateNew
+				delete(fxt.WorkItems[idx].Fields, workitem.SystemDescription)
+				return nil
+			}),
+		)
+		save := func(t *testing.T, state string, fields map[string]interface{}) error {
+			wi, err := s.repo.LoadByID(s.Ctx, fxt.WorkItems[0].ID)
+			require.NoError(t, err)
+			wi.Fields[workitem.SystemState] = state
+			for k, v := range fields {
+				wi.Fields[k] = v
+			}
+			_, _, err = s.repo.Save(s.Ctx, wi.SpaceID, *wi, fxt.Identities[0].ID)
+			return err
+		}
+		t.Run("not allowed", func(t *testing.T) {
+			err := save(t, workitem.SystemStateClosed, nil)
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+			require.Contains(t, err.Error(), workitem.SystemState)
+		})
+		t.Run("allowed", func(t *testing.T) {
+			require.NoError(t, save(t, workitem.SystemStateInProgress, nil))
+		})
+		t.Run("missing required field", func(t *testing.T) {
+			err := save(t, workitem.SystemStateClosed, nil)
+			require.Error(t, err)
+			require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+			require.Contains(t, err.Error(), workitem.SystemDescription)
+		})
+		t.Run("with required field", func(t *testing.T) {
+			require.NoError(t, save(t, workitem.SystemStateClosed, map[string]interface{}{
+				workitem.SystemDescription: rendering.NewMarkupContentFromLegacy("done"),
+			}))
+		})
+	})
 }
 
 func (s *workItemRepoBlackBoxTest) TestLoadID() {
@@ -443,6 +492,68 @@ func (s *workItemRepoBlackBoxTest) TestCreate() {
 	})
 }
 
+func (s *workItemRepoBlackBoxTest) TestFieldRules() {
+	// given
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.CreateWorkItemEnvironment(),
+		tf.WorkItemTypes(1, func(fxt *tf.TestFixture, idx int) error {
+			fxt.WorkItemTypes[idx].Fields["effort"] = workitem.FieldDefinition{
+				Label: "Effort",
+				Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+				Rules: &workitem.FieldRules{Min: ptr.Float64(0), Max: ptr.Float64(100)},
+			}
+			fxt.WorkItemTypes[idx].Fields["resolution"] = workitem.FieldDefinition{
+				Label: "Resolution",
+				Type:  workitem.SimpleType{Kind: workitem.KindString},
+				Rules: &workitem.FieldRules{
+					RequiredWhen: []workitem.FieldCondition{{Field: workitem.SystemState, Values: []interface{}{workitem.SystemStateClosed}}},
+				},
+			}
+			return nil
+		}),
+		tf.WorkItems(1),
+	)
+	requirePointer := func(t *testing.T, err error, field string) {
+		require.Error(t, err)
+		ok, cause := errors.IsBadParameterError(err)
+		require.True(t, ok, "expected a bad parameter error but got %+v", err)
+		require.NotNil(t, cause.(errors.BadParameterError).SourcePointer())
+		require.Equal(t, "/data/attributes/"+field, *cause.(errors.BadParameterError).SourcePointer())
+	}
+
+	s.T().Run("create with value out of range", func(t *testing.T) {
+		_, _, err := s.repo.Create(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemTypes[0].ID, map[string]interface{}{
+			workitem.SystemTitle: "some title",
+			workitem.SystemState: workitem.SystemStateNew,
+			"effort":             101.0,
+		}, fxt.Identities[0].ID)
+		requirePointer(t, err, "effort")
+	})
+	s.T().Run("create with value in range", func(t *testing.T) {
+		_, _, err := s.repo.Create(s.Ctx, fxt.Spaces[0].ID, fxt.WorkItemTypes[0].ID, map[string]interface{}{
+			workitem.SystemTitle: "some title",
+			workitem.SystemState: workitem.SystemStateNew,
+			"effort":             100.0,
+		}, fxt.Identities[0].ID)
+		require.NoError(t, err)
+	})
+	s.T().Run("update to closed without resolution", func(t *testing.T) {
+		wi, err := s.repo.LoadByID(s.Ctx, fxt.WorkItems[0].ID)
+		require.NoError(t, err)
+		wi.Fields[workitem.SystemState] = workitem.SystemStateClosed
+		_, _, err = s.repo.Save(s.Ctx, wi.SpaceID, *wi, fxt.Identities[0].ID)
+		requirePointer(t, err, "resolution")
+	})
+	s.T().Run("update to closed with resolution", func(t *testing.T) {
+		wi, err := s.repo.LoadByID(s.Ctx, fxt.WorkItems[0].ID)
+		require.NoError(t, err)
+		wi.Fields[workitem.SystemState] = workitem.SystemStateClosed
+		wi.Fields["resolution"] = "fixed"
+		_, _, err = s.repo.Save(s.Ctx, wi.SpaceID, *wi, fxt.Identities[0].ID)
+		require.NoError(t, err)
+	})
+}
+
 func (s *workItemRepoBlackBoxTest) TestCheckExists() {
 	s.T().Run("work item exists", func(t *testing.T) {
 		// given
@@ -18,6 +18,12 @@ type RevisionRepository interface {
 	Create(ctx context.Context, modifierID uuid.UUID, revisionType RevisionType, workitem WorkItemStorage) (Revision, error)
 	// List retrieves all revisions for a given work item
 	List(ctx context.Context, workitemID uuid.UUID) ([]Revision, error)
+	// FlowMetrics computes the cumulative flow and the lead and cycle times
+	// of the work items in a space or iteration from their revisions.
+	FlowMetrics(ctx context.Context, spaceID uuid.UUID, iterationID *uuid.UUID, columns []BoardColumn, start, end time.Time) (*FlowMetrics, error)
+	// Burndown computes the day by day open and closed work of the given
+	// iterations from the revisions of their work items.
+	Burndown(ctx context.Context, iterationIDs []uuid.UUID, field string, start, end time.Time) (*Burndown, error)
 }
 
 // NewRevisionRepository creates a GormRevisionRepository
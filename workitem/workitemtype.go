@@ -18,6 +18,10 @@ const (
 	// called "path"
 	pathSep = "."
 
+	// SystemFieldPrefix is the prefix of the names of all system fields.
+	// Computed fields must not use it.
+	SystemFieldPrefix = "system."
+
 	SystemVersion = "version"
 
 	SystemRemoteItemID        = "system.remote_item_id"
@@ -109,6 +113,10 @@ type WorkItemType struct {
 	// type.
 	CanConstruct bool `gorm:"can_construct" json:"can_construct,omitempty"`
 
+	// Transitions contains the allowed changes of the "system.state" field. If
+	// no transitions are defined, every state can be set at any time.
+	Transitions Transitions `sql:"type:jsonb" json:"transitions,omitempty"`
+
 	// ChildTypeIDs is a list of work item type IDs that can be used as child
 	// type of this work item. This field is filled upon loading the work item
 	// type from the DB.
@@ -225,6 +233,9 @@ func (wit WorkItemType) Equal(u convert.Equaler) bool {
 	if wit.SpaceTemplateID != other.SpaceTemplateID {
 		return false
 	}
+	if !wit.Transitions.Equal(other.Transitions) {
+		return false
+	}
 	return true
 }
 
@@ -275,6 +286,18 @@ func (wit WorkItemType) IsTypeOrSubtypeOf(typeID uuid.UUID) bool {
 	return uuid.Equal(wit.ID, typeID) || strings.Contains(wit.Path, LtreeSafeID(typeID)+pathSep)
 }
 
+// ComputedFields returns the computed fields of the work item type keyed by
+// field name.
+func (wit WorkItemType) ComputedFields() map[string]ComputedType {
+	res := map[string]ComputedType{}
+	for name, field := range wit.Fields {
+		if t, ok := field.Type.(ComputedType); ok {
+			res[name] = t
+		}
+	}
+	return res
+}
+
 // GetETagData returns the field values to use to generate the ETag
 func (wit WorkItemType) GetETagData() []interface{} {
 	return []interface{}{wit.ID, wit.Version}
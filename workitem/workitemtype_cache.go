@@ -1,48 +1,93 @@
 package workitem
 
 import (
-	"sync"
+	"context"
 
+	"github.com/fabric8-services/fabric8-wit/cache"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/jinzhu/gorm"
 	uuid "github.com/satori/go.uuid"
 )
 
-type witCacheMap map[uuid.UUID]WorkItemType
+// WorkItemTypeCacheName is the name of the global work item type cache.
+const WorkItemTypeCacheName = "work_item_types"
 
 // WorkItemTypeCache represents WorkItemType cache
 type WorkItemTypeCache struct {
-	cache   witCacheMap
-	mapLock sync.RWMutex
+	cache cache.Cache
 }
 
 // NewWorkItemTypeCache constructs WorkItemTypeCache
 func NewWorkItemTypeCache() *WorkItemTypeCache {
-	witCache := WorkItemTypeCache{}
-	witCache.cache = make(witCacheMap)
-	return &witCache
+	return &WorkItemTypeCache{cache: cache.NewMemoryCache(WorkItemTypeCacheName, cache.DefaultTTL)}
 }
 
 // Get returns WorkItemType by ID.
 // The second value (ok) is a bool that is true if the WorkItemType exists in the cache, and false if not.
 func (c *WorkItemTypeCache) Get(id uuid.UUID) (WorkItemType, bool) {
-	c.mapLock.RLock()
-	defer c.mapLock.RUnlock()
-	w, ok := c.cache[id]
-	return w, ok
+	v, ok := c.cache.Get(id)
+	if !ok {
+		return WorkItemType{}, false
+	}
+	w, ok := v.(WorkItemType)
+	if !ok {
+		return WorkItemType{}, false
+	}
+	return copyWorkItemType(w), true
 }
 
 // Put puts a work item type to the cache
 func (c *WorkItemTypeCache) Put(wit WorkItemType) {
-	c.mapLock.Lock()
-	defer c.mapLock.Unlock()
-	c.cache[wit.ID] = wit
+	c.cache.Put(wit.ID, copyWorkItemType(wit))
+}
+
+// copyWorkItemType returns a copy of the given work item type that shares no
+// maps or slices with it, so that callers can't modify the cached value.
+func copyWorkItemType(wit WorkItemType) WorkItemType {
+	res := wit
+	if wit.Description != nil {
+		description := *wit.Description
+		res.Description = &description
+	}
+	if wit.Fields != nil {
+		res.Fields = make(FieldDefinitions, len(wit.Fields))
+		for name, fd := range wit.Fields {
+			res.Fields[name] = fd
+		}
+	}
+	if wit.Transitions != nil {
+		res.Transitions = make(Transitions, len(wit.Transitions))
+		for i, t := range wit.Transitions {
+			if t.RequiredFields != nil {
+				t.RequiredFields = append([]string{}, t.RequiredFields...)
+			}
+			if t.Guards != nil {
+				t.Guards = append([]FieldCondition{}, t.Guards...)
+			}
+			res.Transitions[i] = t
+		}
+	}
+	if wit.ChildTypeIDs != nil {
+		res.ChildTypeIDs = append([]uuid.UUID{}, wit.ChildTypeIDs...)
+	}
+	return res
 }
 
 // Clear clears the cache
 func (c *WorkItemTypeCache) Clear() {
-	c.mapLock.Lock()
-	defer c.mapLock.Unlock()
 	log.Info(nil, nil, "Clearing work item cache")
+	c.cache.Clear()
+}
+
+// globalWorkItemTypeCache is shared by all work item type repositories and is
+// invalidated across all instances of the service.
+var globalWorkItemTypeCache = &WorkItemTypeCache{
+	cache: cache.Register(cache.NewMemoryCache(WorkItemTypeCacheName, cache.DefaultTTL)),
+}
 
-	c.cache = make(witCacheMap)
+// InvalidateWorkItemTypeCache removes the work item type with the given ID
+// from the global cache of all instances of the service. If the ID is
+// uuid.Nil, all work item types are removed.
+func InvalidateWorkItemTypeCache(ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	return cache.Invalidate(ctx, db, WorkItemTypeCacheName, id)
 }
@@ -84,3 +84,26 @@ func TestNoFailuresWithConcurrentMapReadAndMapWrite(t *testing.T) {
 	}()
 	wg.Wait()
 }
+
+func TestCachedWITCannotBeModified(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	c := workitem.NewWorkItemTypeCache()
+	wit := workitem.WorkItemType{
+		ID:     uuid.FromStringOrNil("0ad2ff1c-6f0b-4b5e-8b8a-0d4e3e0d2c47"),
+		Name:   "testCopy",
+		Fields: workitem.FieldDefinitions{"foo": {Label: "Foo"}},
+	}
+	c.Put(wit)
+	wit.Fields["bar"] = workitem.FieldDefinition{Label: "Bar"}
+
+	cachedWit, ok := c.Get(wit.ID)
+	assert.True(t, ok)
+	assert.Len(t, cachedWit.Fields, 1)
+	cachedWit.Fields["baz"] = workitem.FieldDefinition{Label: "Baz"}
+
+	cachedWit, ok = c.Get(wit.ID)
+	assert.True(t, ok)
+	assert.Len(t, cachedWit.Fields, 1)
+}
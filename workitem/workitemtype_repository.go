@@ -17,8 +17,6 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-var cache = NewWorkItemTypeCache()
-
 // WorkItemTypeRepository encapsulates storage & retrieval of work item types
 type WorkItemTypeRepository interface {
 	repository.Exister
@@ -47,7 +45,7 @@ func (r *GormWorkItemTypeRepository) Load(ctx context.Context, id uuid.UUID) (*W
 	log.Debug(ctx, map[string]interface{}{
 		"wit_id": id,
 	}, "Loading work item type")
-	res, ok := cache.Get(id)
+	res, ok := globalWorkItemTypeCache.Get(id)
 	if !ok {
 		log.Info(ctx, map[string]interface{}{
 			"wit_id": id,
@@ -69,7 +67,7 @@ func (r *GormWorkItemTypeRepository) Load(ctx context.Context, id uuid.UUID) (*W
 			return nil, errs.Wrapf(err, `failed to load child types for WIT "%s" (%s)`, res.Name, res.ID)
 		}
 		res.ChildTypeIDs = childTypes
-		cache.Put(res)
+		globalWorkItemTypeCache.Put(res)
 	}
 	return &res, nil
 }
@@ -81,7 +79,7 @@ func (r *GormWorkItemTypeRepository) CheckExists(ctx context.Context, id uuid.UU
 		"wit_id": id,
 	}, "Checking if work item type exists")
 
-	_, exists := cache.Get(id)
+	_, exists := globalWorkItemTypeCache.Get(id)
 	if exists {
 		return nil
 	}
@@ -89,8 +87,10 @@ func (r *GormWorkItemTypeRepository) CheckExists(ctx context.Context, id uuid.UU
 }
 
 // ClearGlobalWorkItemTypeCache removes all work items from the global cache
+// of this instance. Use InvalidateWorkItemTypeCache to also clear the caches of
+// the other instances.
 func ClearGlobalWorkItemTypeCache() {
-	cache.Clear()
+	globalWorkItemTypeCache.Clear()
 }
 
 // Create creates a new work item type according to the given parameters.
@@ -138,6 +138,10 @@ func (r *GormWorkItemTypeRepository) CreateFromModel(ctx context.Context, model
 		for key, value := range extendedType.Fields {
 			allFields[key] = value
 		}
+		// inherit transitions unless the new type defines its own
+		if len(model.Transitions) == 0 {
+			model.Transitions = extendedType.Transitions
+		}
 		path = extendedType.Path + pathSep + path
 	}
 	// now process new fields, checking whether they are already there.
@@ -152,6 +156,9 @@ func (r *GormWorkItemTypeRepository) CreateFromModel(ctx context.Context, model
 	model.Version = 0
 	model.Path = path
 	model.Fields = allFields
+	if err := model.Transitions.Validate(model.Fields); err != nil {
+		return nil, errors.NewBadParameterError("transitions", model.Transitions).Expected(err.Error())
+	}
 
 	db := r.db.Create(&model)
 	if db.Error != nil {
@@ -249,9 +256,7 @@ func (r *GormWorkItemTypeRepository) AddChildTypes(ctx context.Context, parentTy
 			return errors.NewInternalError(ctx, db.Error)
 		}
 	}
-	ClearGlobalWorkItemTypeCache()
-	return nil
-
+	return InvalidateWorkItemTypeCache(ctx, r.db, parentTypeID)
 }
 
 // loadChildTypeList loads all child work item types associated with the given
@@ -0,0 +1,3 @@
+// Package worklog provides the time that identities spent on work items as
+// well as the aggregations of it used for timesheets.
+package worklog
@@ -0,0 +1,392 @@
+package worklog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-common/id"
+	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/closeable"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeWorklogs is the JSONAPI type of worklogs
+const APIStringTypeWorklogs = "worklogs"
+
+// Worklog describes the time an identity spent on a work item
+type Worklog struct {
+	gormsupport.Lifecycle
+	ID         uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	WorkItemID uuid.UUID `sql:"type:uuid"`
+	IdentityID uuid.UUID `sql:"type:uuid"`
+	StartedAt  time.Time
+	// Duration is the time spent in seconds
+	Duration int64
+	Comment  string
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (m Worklog) TableName() string {
+	return "worklogs"
+}
+
+// GetETagData returns the field values to use to generate the ETag
+func (m Worklog) GetETagData() []interface{} {
+	// using the 'ID' and 'UpdatedAt' (converted to number of seconds since epoch) fields
+	return []interface{}{m.ID, strconv.FormatInt(m.UpdatedAt.Unix(), 10)}
+}
+
+// GetLastModified returns the last modification time
+func (m Worklog) GetLastModified() time.Time {
+	return m.UpdatedAt.Truncate(time.Second)
+}
+
+// validate returns an error if the worklog lacks its work item, identity or
+// start time or if its duration is not positive.
+func (m Worklog) validate() error {
+	if m.WorkItemID == uuid.Nil {
+		return errors.NewBadParameterError("work item", m.WorkItemID).Expected("not empty")
+	}
+	if m.IdentityID == uuid.Nil {
+		return errors.NewBadParameterError("identity", m.IdentityID).Expected("not empty")
+	}
+	if m.StartedAt.IsZero() {
+		return errors.NewBadParameterError("started-at", m.StartedAt).Expected("not empty")
+	}
+	if m.Duration <= 0 {
+		return errors.NewBadParameterError("duration", m.Duration).Expected("positive number of seconds")
+	}
+	return nil
+}
+
+// TimeSpent holds the time spent on a work item in seconds
+type TimeSpent struct {
+	// Own is the time spent on the work item itself
+	Own int64
+	// Total is the time spent on the work item and on all of its descendants
+	// over tree links
+	Total int64
+}
+
+// Filter limits the worklogs of a space used for timesheets
+type Filter struct {
+	SpaceID uuid.UUID
+	// IdentityID limits the worklogs to the ones of an identity
+	IdentityID *uuid.UUID
+	// IterationID limits the worklogs to the ones on work items in an
+	// iteration
+	IterationID *uuid.UUID
+	// From and To limit the worklogs to the ones started in [From, To)
+	From *time.Time
+	To   *time.Time
+}
+
+// GroupBy is a dimension by which worklogs can be summarized
+type GroupBy string
+
+// The dimensions by which worklogs can be summarized
+const (
+	GroupByIdentity  GroupBy = "identity"
+	GroupByIteration GroupBy = "iteration"
+)
+
+// Summary is the time spent on the worklogs of a group
+type Summary struct {
+	// IdentityID is set when grouping by identity
+	IdentityID id.NullUUID
+	// IterationID is set when grouping by iteration and the work items of
+	// the group are in an iteration
+	IterationID id.NullUUID
+	// Duration is the time spent in seconds
+	Duration int64
+	// Count is the number of worklogs in the group
+	Count int
+}
+
+// Repository describes interactions with worklogs
+type Repository interface {
+	repository.Exister
+	Create(ctx context.Context, w *Worklog) error
+	Load(ctx context.Context, id uuid.UUID) (*Worklog, error)
+	// Save updates the start time, duration and comment of the given worklog
+	Save(ctx context.Context, w Worklog) (*Worklog, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListByWorkItem returns the worklogs of the given work item ordered by
+	// their start time
+	ListByWorkItem(ctx context.Context, workItemID uuid.UUID) ([]Worklog, error)
+	// List returns the worklogs matching the given filter ordered by their
+	// start time
+	List(ctx context.Context, filter Filter) ([]Worklog, error)
+	// Summarize returns the time spent on the worklogs matching the given
+	// filter grouped by the given dimensions. Without any dimension a single
+	// summary of all matching worklogs is returned.
+	Summarize(ctx context.Context, filter Filter, groupBy ...GroupBy) ([]Summary, error)
+	// TimeSpent returns the time spent on each of the given work items
+	TimeSpent(ctx context.Context, workItemIDs ...uuid.UUID) (map[uuid.UUID]TimeSpent, error)
+}
+
+// NewRepository creates a new worklog repository
+func NewRepository(db *gorm.DB) Repository {
+	return &GormRepository{db: db}
+}
+
+// GormRepository is the implementation of the storage interface for
+// worklogs.
+type GormRepository struct {
+	db *gorm.DB
+}
+
+// CheckExists returns nil if the given ID exists otherwise returns an error
+func (r *GormRepository) CheckExists(ctx context.Context, id uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "exists"}, time.Now())
+	return repository.CheckExists(ctx, r.db, Worklog{}.TableName(), id)
+}
+
+// Create stores a new worklog
+func (r *GormRepository) Create(ctx context.Context, w *Worklog) error {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "create"}, time.Now())
+	if err := w.validate(); err != nil {
+		return err
+	}
+	w.ID = uuid.NewV4()
+	if err := r.db.Create(w).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":          err,
+			"work_item_id": w.WorkItemID,
+		}, "failed to create worklog")
+		return errors.NewInternalError(ctx, errs.Wrap(err, "failed to create worklog"))
+	}
+	return nil
+}
+
+// Load returns the worklog with the given ID
+func (r *GormRepository) Load(ctx context.Context, id uuid.UUID) (*Worklog, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "load"}, time.Now())
+	var w Worklog
+	db := r.db.Where("id = ?", id).First(&w)
+	if db.RecordNotFound() {
+		return nil, errors.NewNotFoundError("worklog", id.String())
+	}
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to load worklog %s", id))
+	}
+	return &w, nil
+}
+
+// Save updates the start time, duration and comment of the given worklog
+func (r *GormRepository) Save(ctx context.Context, w Worklog) (*Worklog, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "save"}, time.Now())
+	existing, err := r.Load(ctx, w.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing.StartedAt = w.StartedAt
+	existing.Duration = w.Duration
+	existing.Comment = w.Comment
+	if err := existing.validate(); err != nil {
+		return nil, err
+	}
+	if err := r.db.Save(existing).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to save worklog %s", w.ID))
+	}
+	return existing, nil
+}
+
+// Delete removes the worklog with the given ID
+func (r *GormRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "delete"}, time.Now())
+	db := r.db.Delete(&Worklog{ID: id})
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, errs.Wrapf(db.Error, "failed to delete worklog %s", id))
+	}
+	if db.RowsAffected == 0 {
+		return errors.NewNotFoundError("worklog", id.String())
+	}
+	return nil
+}
+
+// ListByWorkItem returns the worklogs of the given work item ordered by their
+// start time
+func (r *GormRepository) ListByWorkItem(ctx context.Context, workItemID uuid.UUID) ([]Worklog, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "list"}, time.Now())
+	var res []Worklog
+	if err := r.db.Where("work_item_id = ?", workItemID).Order("started_at, created_at").Find(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to list worklogs of work item %s", workItemID))
+	}
+	return res, nil
+}
+
+// iterationColumn is the ID of the iteration of the work item of a worklog
+var iterationColumn = fmt.Sprintf(`wi.fields->>'%s'`, workitem.SystemIteration)
+
+// filtered returns a query for the worklogs (as "w") matching the given
+// filter joined with their work items (as "wi")
+func (r *GormRepository) filtered(filter Filter) *gorm.DB {
+	db := r.db.Table(fmt.Sprintf(`"%s" w`, Worklog{}.TableName())).
+		Joins(fmt.Sprintf(`JOIN "%s" wi ON wi.id = w.work_item_id AND wi.deleted_at IS NULL`, workitem.WorkItemStorage{}.TableName())).
+		Where("w.deleted_at IS NULL AND wi.space_id = ?", filter.SpaceID)
+	if filter.IdentityID != nil {
+		db = db.Where("w.identity_id = ?", *filter.IdentityID)
+	}
+	if filter.IterationID != nil {
+		db = db.Where(iterationColumn+" = ?", filter.IterationID.String())
+	}
+	if filter.From != nil {
+		db = db.Where("w.started_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		db = db.Where("w.started_at < ?", *filter.To)
+	}
+	return db
+}
+
+// List returns the worklogs matching the given filter ordered by their start
+// time
+func (r *GormRepository) List(ctx context.Context, filter Filter) ([]Worklog, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "list"}, time.Now())
+	var res []Worklog
+	if err := r.filtered(filter).Select("w.*").Order("w.started_at, w.created_at").Scan(&res).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to list worklogs of space %s", filter.SpaceID))
+	}
+	return res, nil
+}
+
+// Summarize returns the time spent on the worklogs matching the given filter
+// grouped by the given dimensions. Without any dimension a single summary of
+// all matching worklogs is returned.
+func (r *GormRepository) Summarize(ctx context.Context, filter Filter, groupBy ...GroupBy) ([]Summary, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "summarize"}, time.Now())
+	byIdentity, byIteration := false, false
+	for _, g := range groupBy {
+		switch g {
+		case GroupByIdentity:
+			byIdentity = true
+		case GroupByIteration:
+			byIteration = true
+		default:
+			return nil, errors.NewBadParameterError("group-by", g).Expected(string(GroupByIdentity) + "|" + string(GroupByIteration))
+		}
+	}
+	// selecting NULL for the dimensions not grouped by keeps the scanning
+	// of the rows independent from the grouping
+	columns := []string{"NULL", "NULL"}
+	groups := []string{}
+	if byIdentity {
+		columns[0] = "w.identity_id::text"
+		groups = append(groups, "w.identity_id")
+	}
+	if byIteration {
+		columns[1] = iterationColumn
+		groups = append(groups, iterationColumn)
+	}
+	db := r.filtered(filter).Select(strings.Join(columns, ", ") + ", COALESCE(SUM(w.duration), 0), COUNT(w.id)")
+	if len(groups) > 0 {
+		db = db.Group(strings.Join(groups, ", ")).Order(strings.Join(groups, ", "))
+	}
+	rows, err := db.Rows()
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrapf(err, "failed to summarize worklogs of space %s", filter.SpaceID))
+	}
+	defer closeable.Close(ctx, rows)
+	res := []Summary{}
+	for rows.Next() {
+		var identityID, iterationID sql.NullString
+		var s Summary
+		if err := rows.Scan(&identityID, &iterationID, &s.Duration, &s.Count); err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to scan worklog summary"))
+		}
+		if s.IdentityID, err = toNullUUID(identityID); err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "invalid identity of worklog summary"))
+		}
+		if s.IterationID, err = toNullUUID(iterationID); err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "invalid iteration of worklog summary"))
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to read worklog summaries"))
+	}
+	return res, nil
+}
+
+func toNullUUID(s sql.NullString) (id.NullUUID, error) {
+	if !s.Valid || s.String == "" {
+		return id.NullUUID{}, nil
+	}
+	u, err := uuid.FromString(s.String)
+	if err != nil {
+		return id.NullUUID{}, errs.WithStack(err)
+	}
+	return id.NullUUID{UUID: u, Valid: true}, nil
+}
+
+// TimeSpent returns the time spent on each of the given work items. The total
+// time spent includes the descendants of a work item over tree links.
+func (r *GormRepository) TimeSpent(ctx context.Context, workItemIDs ...uuid.UUID) (map[uuid.UUID]TimeSpent, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "worklog", "timespent"}, time.Now())
+	res := map[uuid.UUID]TimeSpent{}
+	if len(workItemIDs) == 0 {
+		return res, nil
+	}
+	for _, wiID := range workItemIDs {
+		res[wiID] = TimeSpent{}
+	}
+	// Postgres Common Table Expression (https://www.postgresql.org/docs/current/static/queries-with.html)
+	// that collects every given work item together with its descendants
+	query := fmt.Sprintf(`
+		WITH RECURSIVE tree(root, id, already_visited, cycle) AS (
+			SELECT wi.id, wi.id, ARRAY[]::uuid[], false
+			FROM %[1]s wi
+			WHERE wi.id IN (?)
+		UNION
+			SELECT t.root, l.target_id, t.already_visited || l.id, l.id = ANY(t.already_visited)
+			FROM tree t, %[2]s l
+			WHERE l.source_id = t.id
+				AND l.deleted_at IS NULL
+				AND l.link_type_id IN (SELECT id FROM %[3]s WHERE topology = '%[4]s')
+				AND NOT t.cycle
+		)
+		SELECT
+			t.root,
+			COALESCE(SUM(w.duration) FILTER (WHERE w.work_item_id = t.root), 0),
+			COALESCE(SUM(w.duration), 0)
+		FROM (SELECT DISTINCT root, id FROM tree) t
+		LEFT JOIN %[5]s w ON w.work_item_id = t.id AND w.deleted_at IS NULL
+		GROUP BY t.root`,
+		workitem.WorkItemStorage{}.TableName(),
+		link.WorkItemLink{}.TableName(),
+		link.WorkItemLinkType{}.TableName(),
+		link.TopologyTree,
+		Worklog{}.TableName(),
+	)
+	rows, err := r.db.Raw(query, workItemIDs).Rows()
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to compute the time spent on work items"))
+	}
+	defer closeable.Close(ctx, rows)
+	for rows.Next() {
+		var wiID uuid.UUID
+		var t TimeSpent
+		if err := rows.Scan(&wiID, &t.Own, &t.Total); err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to scan the time spent on work items"))
+		}
+		res[wiID] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to read the time spent on work items"))
+	}
+	return res, nil
+}
@@ -0,0 +1,224 @@
+package worklog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/ptr"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	tf "github.com/fabric8-services/fabric8-wit/test/testfixture"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/worklog"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type worklogRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo worklog.Repository
+}
+
+func TestRunWorklogRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &worklogRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite()})
+}
+
+func (s *worklogRepoBlackBoxTest) SetupTest() {
+	s.DBTestSuite.SetupTest()
+	s.repo = worklog.NewRepository(s.DB)
+}
+
+var started = time.Date(2018, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+func (s *worklogRepoBlackBoxTest) create(t *testing.T, wiID, identityID uuid.UUID, startedAt time.Time, duration int64) *worklog.Worklog {
+	w := &worklog.Worklog{
+		WorkItemID: wiID,
+		IdentityID: identityID,
+		StartedAt:  startedAt,
+		Duration:   duration,
+		Comment:    "some work",
+	}
+	require.NoError(t, s.repo.Create(s.Ctx, w))
+	return w
+}
+
+func (s *worklogRepoBlackBoxTest) TestCreate() {
+	s.T().Run("ok", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		w := s.create(t, fxt.WorkItems[0].ID, fxt.Identities[0].ID, started, 3600)
+		assert.NotEqual(t, uuid.Nil, w.ID)
+		loaded, err := s.repo.Load(s.Ctx, w.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3600), loaded.Duration)
+		assert.True(t, started.Equal(loaded.StartedAt))
+		assert.Equal(t, "some work", loaded.Comment)
+	})
+
+	s.T().Run("invalid", func(t *testing.T) {
+		fxt := tf.NewTestFixture(t, s.DB, tf.WorkItems(1))
+		for name, w := range map[string]worklog.Worklog{
+			"no duration":   {WorkItemID: fxt.WorkItems[0].ID, IdentityID: fxt.Identities[0].ID, StartedAt: started},
+			"no start time": {WorkItemID: fxt.WorkItems[0].ID, IdentityID: fxt.Identities[0].ID, Duration: 60},
+			"no identity":   {WorkItemID: fxt.WorkItems[0].ID, StartedAt: started, Duration: 60},
+			"no work item":  {IdentityID: fxt.Identities[0].ID, StartedAt: started, Duration: 60},
+		} {
+			t.Run(name, func(t *testing.T) {
+				err := s.repo.Create(s.Ctx, &w)
+				require.Error(t, err)
+				assert.IsType(t, errors.BadParameterError{}, err)
+			})
+		}
+	})
+}
+
+func (s *worklogRepoBlackBoxTest) TestSaveAndDelete() {
+	fxt := tf.NewTestFixture(s.T(), s.DB, tf.WorkItems(1))
+	w := s.create(s.T(), fxt.WorkItems[0].ID, fxt.Identities[0].ID, started, 3600)
+
+	s.T().Run("save", func(t *testing.T) {
+		w.Duration = 1800
+		w.Comment = "less work"
+		saved, err := s.repo.Save(s.Ctx, *w)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1800), saved.Duration)
+		assert.Equal(t, "less work", saved.Comment)
+		w.Duration = 0
+		_, err = s.repo.Save(s.Ctx, *w)
+		assert.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	s.T().Run("delete", func(t *testing.T) {
+		require.NoError(t, s.repo.Delete(s.Ctx, w.ID))
+		_, err := s.repo.Load(s.Ctx, w.ID)
+		assert.IsType(t, errors.NotFoundError{}, err)
+		err = s.repo.Delete(s.Ctx, w.ID)
+		assert.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
+func (s *worklogRepoBlackBoxTest) TestTimeSpent() {
+	// given a tree A -> B -> C and a work item D outside of the tree
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.WorkItemLinkTypes(1, tf.SetTopologies(link.TopologyTree)),
+		tf.WorkItems(4, tf.SetWorkItemTitles("A", "B", "C", "D")),
+		tf.WorkItemLinksCustom(2, tf.BuildLinks(tf.LinkChain("A", "B", "C")...)),
+	)
+	identityID := fxt.Identities[0].ID
+	s.create(s.T(), fxt.WorkItemByTitle("A").ID, identityID, started, 100)
+	s.create(s.T(), fxt.WorkItemByTitle("B").ID, identityID, started, 20)
+	s.create(s.T(), fxt.WorkItemByTitle("C").ID, identityID, started, 3)
+	s.create(s.T(), fxt.WorkItemByTitle("C").ID, identityID, started, 4)
+	s.create(s.T(), fxt.WorkItemByTitle("D").ID, identityID, started, 1000)
+	deleted := s.create(s.T(), fxt.WorkItemByTitle("C").ID, identityID, started, 5000)
+	require.NoError(s.T(), s.repo.Delete(s.Ctx, deleted.ID))
+	// when
+	res, err := s.repo.TimeSpent(s.Ctx, fxt.WorkItemByTitle("A").ID, fxt.WorkItemByTitle("B").ID, fxt.WorkItemByTitle("C").ID, fxt.WorkItemByTitle("D").ID)
+	// then
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), worklog.TimeSpent{Own: 100, Total: 127}, res[fxt.WorkItemByTitle("A").ID])
+	assert.Equal(s.T(), worklog.TimeSpent{Own: 20, Total: 27}, res[fxt.WorkItemByTitle("B").ID])
+	assert.Equal(s.T(), worklog.TimeSpent{Own: 7, Total: 7}, res[fxt.WorkItemByTitle("C").ID])
+	assert.Equal(s.T(), worklog.TimeSpent{Own: 1000, Total: 1000}, res[fxt.WorkItemByTitle("D").ID])
+
+	s.T().Run("unknown work item", func(t *testing.T) {
+		unknown := uuid.NewV4()
+		res, err := s.repo.TimeSpent(s.Ctx, unknown)
+		require.NoError(t, err)
+		assert.Equal(t, worklog.TimeSpent{}, res[unknown])
+	})
+}
+
+func (s *worklogRepoBlackBoxTest) TestListAndSummarize() {
+	// given two identities logging time on work items in two iterations
+	fxt := tf.NewTestFixture(s.T(), s.DB,
+		tf.Identities(2),
+		tf.Iterations(2),
+		tf.WorkItems(3, func(fxt *tf.TestFixture, idx int) error {
+			if idx < 2 {
+				fxt.WorkItems[idx].Fields[workitem.SystemIteration] = fxt.Iterations[idx].ID.String()
+			}
+			return nil
+		}),
+	)
+	alice, bob := fxt.Identities[0].ID, fxt.Identities[1].ID
+	s.create(s.T(), fxt.WorkItems[0].ID, alice, started, 3600)
+	s.create(s.T(), fxt.WorkItems[0].ID, bob, started.Add(time.Hour), 1800)
+	s.create(s.T(), fxt.WorkItems[1].ID, alice, started.Add(24*time.Hour), 900)
+	s.create(s.T(), fxt.WorkItems[2].ID, bob, started.Add(48*time.Hour), 60)
+	// worklogs in other spaces don't show up
+	other := tf.NewTestFixture(s.T(), s.DB, tf.WorkItems(1))
+	s.create(s.T(), other.WorkItems[0].ID, other.Identities[0].ID, started, 7)
+
+	s.T().Run("list all", func(t *testing.T) {
+		list, err := s.repo.List(s.Ctx, worklog.Filter{SpaceID: fxt.Spaces[0].ID})
+		require.NoError(t, err)
+		require.Len(t, list, 4)
+		assert.Equal(t, int64(3600), list[0].Duration)
+		assert.Equal(t, int64(60), list[3].Duration)
+	})
+
+	s.T().Run("list by identity and time", func(t *testing.T) {
+		list, err := s.repo.List(s.Ctx, worklog.Filter{
+			SpaceID:    fxt.Spaces[0].ID,
+			IdentityID: &bob,
+			From:       ptr.Time(started.Add(time.Hour)),
+			To:         ptr.Time(started.Add(48 * time.Hour)),
+		})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, int64(1800), list[0].Duration)
+	})
+
+	s.T().Run("list by iteration", func(t *testing.T) {
+		list, err := s.repo.List(s.Ctx, worklog.Filter{SpaceID: fxt.Spaces[0].ID, IterationID: &fxt.Iterations[1].ID})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, int64(900), list[0].Duration)
+	})
+
+	s.T().Run("summarize without grouping", func(t *testing.T) {
+		summaries, err := s.repo.Summarize(s.Ctx, worklog.Filter{SpaceID: fxt.Spaces[0].ID})
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		assert.Equal(t, int64(6360), summaries[0].Duration)
+		assert.Equal(t, 4, summaries[0].Count)
+	})
+
+	s.T().Run("summarize by identity", func(t *testing.T) {
+		summaries, err := s.repo.Summarize(s.Ctx, worklog.Filter{SpaceID: fxt.Spaces[0].ID}, worklog.GroupByIdentity)
+		require.NoError(t, err)
+		require.Len(t, summaries, 2)
+		byIdentity := map[uuid.UUID]worklog.Summary{}
+		for _, summary := range summaries {
+			assert.False(t, summary.IterationID.Valid)
+			byIdentity[summary.IdentityID.UUID] = summary
+		}
+		assert.Equal(t, int64(4500), byIdentity[alice].Duration)
+		assert.Equal(t, int64(1860), byIdentity[bob].Duration)
+	})
+
+	s.T().Run("summarize by identity and iteration", func(t *testing.T) {
+		summaries, err := s.repo.Summarize(s.Ctx, worklog.Filter{SpaceID: fxt.Spaces[0].ID}, worklog.GroupByIdentity, worklog.GroupByIteration)
+		require.NoError(t, err)
+		// alice in iteration 0 and 1, bob in iteration 0 and without iteration
+		require.Len(t, summaries, 4)
+		for _, summary := range summaries {
+			if summary.IdentityID.UUID == bob && !summary.IterationID.Valid {
+				assert.Equal(t, int64(60), summary.Duration)
+			}
+			if summary.IdentityID.UUID == alice && summary.IterationID.UUID == fxt.Iterations[1].ID {
+				assert.Equal(t, int64(900), summary.Duration)
+			}
+		}
+	})
+
+	s.T().Run("invalid grouping", func(t *testing.T) {
+		_, err := s.repo.Summarize(s.Ctx, worklog.Filter{SpaceID: fxt.Spaces[0].ID}, worklog.GroupBy("area"))
+		assert.IsType(t, errors.BadParameterError{}, err)
+	})
+}